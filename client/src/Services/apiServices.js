@@ -6,6 +6,7 @@ const api = axios.create({
     timeout: 10000,
     headers: {
         "Content-Type": "application/json",
+        "X-Client": "web", // Todos created here get the web source
     },
 });
 
@@ -75,11 +76,12 @@ export const fetchTodoListById = async (user, listId) => {
     }
 };
 
-// Update a todo list
+// Update a todo list, the version of the list goes in If-Match so a stale edit is rejected
 export const updateTodoList = async (user, listId, updatedData) => {
     try {
         const response = await api.put(`/lists/${listId}`, updatedData, {
-            user
+            user,
+            headers: { 'If-Match': `"${updatedData.version}"` }
         });
 
         return response.data;
@@ -5,6 +5,16 @@ import { useFetchLists } from '../Hooks/useFetchLists';
 
 const ListContext = createContext();
 
+// uniqueListTitle returns title, or title with the first free number when an open list already has it
+const uniqueListTitle = (title, lists) => {
+    const taken = new Set(lists.filter(list => !list.deleted).map(list => list.title));
+    if (!taken.has(title)) return title;
+
+    let n = 2;
+    while (taken.has(`${title} (${n})`)) n++;
+    return `${title} (${n})`;
+};
+
 export const ListProvider = ({ children }) => {
     const { user } = useAuth();
     const { lists, setLists, error } = useFetchLists();
@@ -90,7 +100,12 @@ export const ListProvider = ({ children }) => {
         try {
             setLoading(true);
 
-            const createdList = await createTodoList(user, listDetails);
+            // A user can't have two open lists with the same title, so a repeated one is numbered
+            const createdList = await createTodoList(user, {
+                ...listDetails,
+                title: uniqueListTitle(listDetails.title, lists),
+            });
+            if (!createdList) return;
 
             let createdItems = [];
             if (items && items.length > 0) {
@@ -116,9 +131,9 @@ export const ListProvider = ({ children }) => {
     const moveToBin = useCallback(async (listId) => {
         const listToUpdate = lists.find(list => list.id === listId);
         const updatedList = { ...listToUpdate, deleted: true };
-        await updateTodoList(user, listId, updatedList);
+        const updated = await updateTodoList(user, listId, updatedList);
         setLists(prevLists => prevLists.map(list =>
-            list.id === listId ? updatedList : list
+            list.id === listId ? { ...updatedList, version: updated?.version ?? updatedList.version } : list
         ));
     }, [user, lists, setLists]);
 
@@ -126,9 +141,9 @@ export const ListProvider = ({ children }) => {
         const listToMove = lists.find(l => l.id === listId);
         const updatedList = { ...listToMove, deleted: false };
 
-        await updateTodoList(user, listId, updatedList);
+        const updated = await updateTodoList(user, listId, updatedList);
         setLists(prevLists => prevLists.map(list =>
-            list.id === listId ? updatedList : list
+            list.id === listId ? { ...updatedList, version: updated?.version ?? updatedList.version } : list
         ));
     }, [user, lists, setLists]);
 
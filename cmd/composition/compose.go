@@ -1,37 +1,160 @@
 package composition
 
 import (
+	"fmt"
+	"strconv"
+	"time"
+
 	"github.com/jmoiron/sqlx"
-	"github.com/macesz/todo-go/dal/pgtodo"
-	"github.com/macesz/todo-go/dal/pgtodolist"
-	"github.com/macesz/todo-go/dal/pguser"
+	"github.com/macesz/todo-go/dal/cached"
+	"github.com/macesz/todo-go/dal/lrucache"
+	"github.com/macesz/todo-go/dal/rediscache"
 	"github.com/macesz/todo-go/delivery/web"
 	"github.com/macesz/todo-go/delivery/web/auth"
 	"github.com/macesz/todo-go/domain"
+	"github.com/macesz/todo-go/services/dashboard"
+	"github.com/macesz/todo-go/services/export"
 	"github.com/macesz/todo-go/services/todo"
 	"github.com/macesz/todo-go/services/todolist"
 	"github.com/macesz/todo-go/services/user"
 )
 
-func ComposeServices(cfg domain.Config, db *sqlx.DB) *web.ServerServices {
+func ComposeServices(cfg domain.Config, db *sqlx.DB) (*web.ServerServices, error) {
 	// Create DATA STORES
-	todoStore := pgtodo.CreateStore(db)
-	todolistStore := pgtodolist.CreateStore(db)
-	userStore := pguser.CreateStore(db)
+	stores, err := CreateStores(cfg.DBDriver, db)
+	if err != nil {
+		return nil, fmt.Errorf("DB_DRIVER: %w", err)
+	}
+
+	todoStore := stores.Todo
+	todolistStore := stores.TodoList
+
+	clock, err := createClock(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	// Cache todo and list reads, when a cache is configured
+	cache, cacheTTL, err := createCache(cfg, clock)
+	if err != nil {
+		return nil, err
+	}
+	if cache != nil {
+		todoStore = cached.CreateTodoStore(todoStore, cache, cacheTTL)
+		todolistStore = cached.CreateTodoListStore(todolistStore, cache, cacheTTL)
+	}
 
 	// Create SERVICES
 	// NEW: Create auth at application startup
-	tokenAuth := auth.CreateTokenAuth(cfg.JWTSecret)
-	todoService := todo.NewTodoService(todoStore) // Service with business logic
-	todoListService := todolist.NewTodoListService(todolistStore)
-	userService := user.NewUserService(userStore) // Service with business logic
+	tokenAuth, err := auth.CreateTokenAuth(cfg.JWTSecret)
+	if err != nil {
+		return nil, fmt.Errorf("JWT_SECRET: %w", err)
+	}
+
+	todoSort, err := domain.ParseSort(cfg.DefaultTodoSort)
+	if err != nil {
+		return nil, fmt.Errorf("DEFAULT_TODO_SORT: %w", err)
+	}
+
+	listSort, err := domain.ParseSort(cfg.DefaultListSort)
+	if err != nil {
+		return nil, fmt.Errorf("DEFAULT_LIST_SORT: %w", err)
+	}
+
+	undoWindow, err := createUndoWindow(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	todoService := todo.NewTodoService(todoStore, todo.Options{
+		DefaultSort:         todoSort,
+		WarnDuplicateTitles: cfg.Features.WarnDuplicateTodoTitles,
+		SoftDelete:          cfg.Features.SoftDeleteTodos,
+		UndoWindow:          undoWindow,
+		Clock:               clock,
+	}) // Service with business logic
+	todoListService := todolist.NewTodoListService(todolistStore, listSort, clock)
+	userService := user.NewUserService(stores.User) // Service with business logic
+	dashboardService := dashboard.NewDashboardService(stores.Dashboard)
+	exportService := export.NewExportService(stores.User, todolistStore, todoStore, stores.Import, clock)
 
 	services := &web.ServerServices{
 		TodoList:  todoListService,
 		Todo:      todoService,
 		User:      userService,
+		Dashboard: dashboardService,
+		Export:    exportService,
 		TokenAuth: tokenAuth, // ← Injected dependency
+		DB:        db,
+	}
+
+	return services, nil
+}
+
+// createClock truncates the system clock to the configured timestamp precision,
+// so the times the services create survive a round trip through the database unchanged
+func createClock(cfg domain.Config) (domain.Clock, error) {
+	value := cfg.TimestampPrecision
+	if value == "" {
+		value = domain.DefaultTimestampPrecision
+	}
+
+	precision, err := time.ParseDuration(value)
+	if err != nil {
+		return nil, fmt.Errorf("TIMESTAMP_PRECISION: %w", err)
+	}
+	if precision <= 0 {
+		return nil, fmt.Errorf("TIMESTAMP_PRECISION: %w: must be positive", domain.ErrInvalidInput)
+	}
+
+	return domain.TruncatingClock{Clock: domain.SystemClock{}, Precision: precision}, nil
+}
+
+// createUndoWindow parses how long a trashed todo can be restored, zero lets the service use its default
+func createUndoWindow(cfg domain.Config) (time.Duration, error) {
+	if cfg.UndoDeleteTTL == "" {
+		return 0, nil
+	}
+
+	window, err := time.ParseDuration(cfg.UndoDeleteTTL)
+	if err != nil {
+		return 0, fmt.Errorf("UNDO_DELETE_TTL: %w", err)
+	}
+	if window <= 0 {
+		return 0, fmt.Errorf("UNDO_DELETE_TTL: %w: must be positive", domain.ErrInvalidInput)
+	}
+
+	return window, nil
+}
+
+// createCache picks Redis when it is configured, else an in-process LRU cache when it has a size
+// A nil cache means reads are not cached
+func createCache(cfg domain.Config, clock domain.Clock) (cached.Cache, time.Duration, error) {
+	if cfg.RedisAddr == "" && cfg.CacheSize == "" {
+		return nil, 0, nil
+	}
+
+	ttl := cfg.CacheTTL
+	if ttl == "" {
+		ttl = domain.DefaultCacheTTL
+	}
+
+	cacheTTL, err := time.ParseDuration(ttl)
+	if err != nil {
+		return nil, 0, fmt.Errorf("CACHE_TTL: %w", err)
+	}
+
+	if cfg.RedisAddr != "" {
+		return rediscache.NewClient(cfg.RedisAddr), cacheTTL, nil
+	}
+
+	size, err := strconv.Atoi(cfg.CacheSize)
+	if err != nil || size < 0 {
+		return nil, 0, fmt.Errorf("CACHE_SIZE: %w: must be a non-negative integer", domain.ErrInvalidInput)
+	}
+	if size == 0 {
+		return nil, 0, nil
 	}
 
-	return services
+	return lrucache.New(size, clock), cacheTTL, nil
 }
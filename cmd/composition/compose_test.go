@@ -0,0 +1,63 @@
+package composition
+
+import (
+	"testing"
+	"time"
+
+	"github.com/macesz/todo-go/domain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateClock(t *testing.T) {
+	tests := []struct {
+		name          string
+		precision     string
+		wantPrecision time.Duration
+		wantErr       bool
+	}{
+		{name: "empty means microseconds", precision: "", wantPrecision: time.Microsecond},
+		{name: "milliseconds", precision: "1ms", wantPrecision: time.Millisecond},
+		{name: "not a duration", precision: "fast", wantErr: true},
+		{name: "zero", precision: "0s", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock, err := createClock(domain.Config{TimestampPrecision: tt.precision})
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			require.Equal(t, domain.TruncatingClock{Clock: domain.SystemClock{}, Precision: tt.wantPrecision}, clock)
+		})
+	}
+}
+
+func TestCreateUndoWindow(t *testing.T) {
+	tests := []struct {
+		name       string
+		ttl        string
+		wantWindow time.Duration
+		wantErr    bool
+	}{
+		{name: "empty leaves the service default", ttl: "", wantWindow: 0},
+		{name: "minutes", ttl: "10m", wantWindow: 10 * time.Minute},
+		{name: "not a duration", ttl: "soon", wantErr: true},
+		{name: "negative", ttl: "-1m", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			window, err := createUndoWindow(domain.Config{UndoDeleteTTL: tt.ttl})
+			if tt.wantErr {
+				require.ErrorContains(t, err, "UNDO_DELETE_TTL")
+				return
+			}
+			require.NoError(t, err)
+
+			require.Equal(t, tt.wantWindow, window)
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package composition
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/macesz/todo-go/dal/pgdashboard"
+	"github.com/macesz/todo-go/dal/pgimport"
+	"github.com/macesz/todo-go/dal/pgtodo"
+	"github.com/macesz/todo-go/dal/pgtodolist"
+	"github.com/macesz/todo-go/dal/pguser"
+	"github.com/macesz/todo-go/services/dashboard"
+	"github.com/macesz/todo-go/services/export"
+	"github.com/macesz/todo-go/services/todo"
+	"github.com/macesz/todo-go/services/todolist"
+	"github.com/macesz/todo-go/services/user"
+)
+
+// DefaultDBDriver is used when the config has no DBDriver.
+const DefaultDBDriver = "postgres"
+
+// ErrUnsupportedDBDriver is returned for a DBDriver without a store set.
+var ErrUnsupportedDBDriver = errors.New("unsupported database driver")
+
+// Stores is the set of data stores the services are built on.
+// Every backend fills all of them, so the services never know which one they got.
+type Stores struct {
+	Todo      todo.TodoStore
+	TodoList  todolist.TodoListStore
+	User      user.UserStore
+	Dashboard dashboard.DashboardStore
+	Import    export.ImportStore
+}
+
+// StoreFactory creates the store set of one backend.
+type StoreFactory func(db *sqlx.DB) *Stores
+
+// storeFactories maps a DBDriver to its backend.
+// Only Postgres has a full store set so far, the sqlite, file and memory drivers are rejected until they do.
+var storeFactories = map[string]StoreFactory{
+	"postgres": createPostgresStores,
+}
+
+// CreateStores returns the store set of the configured driver, Postgres when none is set.
+func CreateStores(driver string, db *sqlx.DB) (*Stores, error) {
+	if driver == "" {
+		driver = DefaultDBDriver
+	}
+
+	factory, ok := storeFactories[driver]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedDBDriver, driver)
+	}
+
+	return factory(db), nil
+}
+
+func createPostgresStores(db *sqlx.DB) *Stores {
+	return &Stores{
+		Todo:      pgtodo.CreateStore(db),
+		TodoList:  pgtodolist.CreateStore(db),
+		User:      pguser.CreateStore(db),
+		Dashboard: pgdashboard.CreateStore(db),
+		Import:    pgimport.CreateStore(db),
+	}
+}
@@ -0,0 +1,46 @@
+package composition
+
+import (
+	"testing"
+
+	"github.com/macesz/todo-go/dal/pgdashboard"
+	"github.com/macesz/todo-go/dal/pgimport"
+	"github.com/macesz/todo-go/dal/pgtodo"
+	"github.com/macesz/todo-go/dal/pgtodolist"
+	"github.com/macesz/todo-go/dal/pguser"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateStores(t *testing.T) {
+	tests := []struct {
+		name    string
+		driver  string
+		wantErr bool
+	}{
+		{name: "postgres", driver: "postgres"},
+		{name: "empty means postgres", driver: ""},
+		{name: "sqlite not available yet", driver: "sqlite", wantErr: true},
+		{name: "file not available yet", driver: "file", wantErr: true},
+		{name: "memory not available yet", driver: "memory", wantErr: true},
+		{name: "unknown", driver: "oracle", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The stores only keep the connection, they don't use it until a query runs
+			stores, err := CreateStores(tt.driver, nil)
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrUnsupportedDBDriver)
+				require.Nil(t, stores)
+				return
+			}
+			require.NoError(t, err)
+
+			require.IsType(t, &pgtodo.Store{}, stores.Todo)
+			require.IsType(t, &pgtodolist.Store{}, stores.TodoList)
+			require.IsType(t, &pguser.Store{}, stores.User)
+			require.IsType(t, &pgdashboard.Store{}, stores.Dashboard)
+			require.IsType(t, &pgimport.Store{}, stores.Import)
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"os"
 	"slices"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 
@@ -16,17 +17,21 @@ import (
 	infraPG "github.com/macesz/todo-go/infra/postgres"
 )
 
+// trashPurgeInterval is how often the trashed todos past their undo window are removed
+const trashPurgeInterval = time.Minute
+
+// trashPurger is the part of the todo service the background cleanup job needs
+type trashPurger interface {
+	RunTrashPurge(ctx context.Context, interval time.Duration)
+}
+
 func main() {
 	ctx := context.Background()
 
-	// Load CONFIG from ENV variables
-	cfg := domain.Config{
-		DBAddr:     os.Getenv("DB_ADDR"),
-		DBName:     os.Getenv("DB_NAME"),
-		DBUser:     os.Getenv("DB_USER"),
-		DBPassword: os.Getenv("DB_PASS"),
-		JWTSecret:  os.Getenv("JWT_SECRET"),
-		ServerPort: os.Getenv("SERVER_PORT"),
+	// Load CONFIG from the optional CONFIG_FILE, ENV variables win over the file
+	cfg, err := domain.LoadConfig(os.Getenv("CONFIG_FILE"))
+	if err != nil {
+		panic(err)
 	}
 
 	// Connect to POSTGRESQL
@@ -52,10 +57,18 @@ func main() {
 		panic(err)
 	}
 
-	services := composition.ComposeServices(cfg, db)
+	services, err := composition.ComposeServices(cfg, db)
+	if err != nil {
+		panic(err)
+	}
+
+	// Purge the trashed todos whose undo window has passed
+	if purger, ok := services.Todo.(trashPurger); ok {
+		go purger.RunTrashPurge(ctx, trashPurgeInterval)
+	}
 
 	// Create WEB HANDLERS
-	handlers, err := web.CreateHandlers(ctx, services)
+	handlers, err := web.CreateHandlers(ctx, cfg, services)
 	if err != nil {
 		panic(err)
 	}
@@ -0,0 +1,15 @@
+package cached
+
+import (
+	"context"
+	"time"
+)
+
+// Cache is a key/value store with expiring entries, like Redis
+// The cached stores only need these three calls, so any backend (or a fake in tests) fits
+type Cache interface {
+	// Get returns the value of key, found is false on a miss
+	Get(ctx context.Context, key string) (value []byte, found bool, err error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	Delete(ctx context.Context, keys ...string) error
+}
@@ -0,0 +1,372 @@
+package cached
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/macesz/todo-go/domain"
+	todomocks "github.com/macesz/todo-go/services/todo/mocks"
+	todolistmocks "github.com/macesz/todo-go/services/todolist/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeCache is an in-memory Cache, it ignores the ttl
+type fakeCache struct {
+	mu      sync.Mutex
+	data    map[string][]byte
+	failGet bool
+}
+
+func newFakeCache() *fakeCache {
+	return &fakeCache{data: make(map[string][]byte)}
+}
+
+func (c *fakeCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.failGet {
+		return nil, false, errors.New("cache down")
+	}
+
+	value, ok := c.data[key]
+	return value, ok, nil
+}
+
+func (c *fakeCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.data[key] = value
+	return nil
+}
+
+func (c *fakeCache) Delete(ctx context.Context, keys ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, key := range keys {
+		delete(c.data, key)
+	}
+	return nil
+}
+
+func (c *fakeCache) has(key string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	_, ok := c.data[key]
+	return ok
+}
+
+var fixedTime = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+func TestTodoStoreGet(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	todo := &domain.Todo{ID: 1, PublicID: "00000000-0000-0000-0000-000000000001", UserID: 1, TodoListID: 2, Title: "Buy milk", CreatedAt: fixedTime, UpdatedAt: fixedTime}
+
+	t.Run("cache hit skips the store", func(t *testing.T) {
+		t.Parallel()
+
+		inner := todomocks.NewTodoStore(t)
+		inner.On("Get", ctx, int64(1)).Return(todo, nil).Once()
+
+		s := CreateTodoStore(inner, newFakeCache(), time.Minute)
+
+		first, err := s.Get(ctx, 1)
+		require.NoError(t, err)
+		require.Equal(t, todo, first)
+
+		// The mock allows one call only, the second Get must come from the cache
+		second, err := s.Get(ctx, 1)
+		require.NoError(t, err)
+		require.Equal(t, todo, second)
+	})
+
+	t.Run("store errors are not cached", func(t *testing.T) {
+		t.Parallel()
+
+		inner := todomocks.NewTodoStore(t)
+		inner.On("Get", ctx, int64(1)).Return(nil, errors.New("db down")).Once()
+
+		cache := newFakeCache()
+		s := CreateTodoStore(inner, cache, time.Minute)
+
+		_, err := s.Get(ctx, 1)
+		require.Error(t, err)
+		require.False(t, cache.has(todoKey(1)))
+	})
+
+	t.Run("failing cache falls back to the store", func(t *testing.T) {
+		t.Parallel()
+
+		inner := todomocks.NewTodoStore(t)
+		inner.On("Get", ctx, int64(1)).Return(todo, nil).Twice()
+
+		cache := newFakeCache()
+		cache.failGet = true
+		s := CreateTodoStore(inner, cache, time.Minute)
+
+		for range 2 {
+			got, err := s.Get(ctx, 1)
+			require.NoError(t, err)
+			require.Equal(t, todo, got)
+		}
+	})
+}
+
+func TestTodoStoreInvalidation(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	todo := &domain.Todo{ID: 1, UserID: 1, TodoListID: 2, Title: "Buy milk", CreatedAt: fixedTime, UpdatedAt: fixedTime}
+
+	tests := []struct {
+		name  string
+		setup func(inner *todomocks.TodoStore)
+		write func(s *TodoStore) error
+	}{
+		{
+			name: "update",
+			setup: func(inner *todomocks.TodoStore) {
+				inner.On("Update", ctx, int64(1), "Buy bread", true, (*time.Time)(nil), (*string)(nil), fixedTime).Return(todo, nil).Once()
+			},
+			write: func(s *TodoStore) error {
+				_, err := s.Update(ctx, 1, "Buy bread", true, nil, nil, fixedTime)
+				return err
+			},
+		},
+		{
+			name: "set done",
+			setup: func(inner *todomocks.TodoStore) {
+				inner.On("SetDone", ctx, int64(1), int64(1), true, fixedTime).Return(todo, nil).Once()
+			},
+			write: func(s *TodoStore) error {
+				_, err := s.SetDone(ctx, 1, 1, true, fixedTime)
+				return err
+			},
+		},
+		{
+			name: "delete",
+			setup: func(inner *todomocks.TodoStore) {
+				inner.On("Delete", ctx, int64(1)).Return(nil).Once()
+			},
+			write: func(s *TodoStore) error {
+				return s.Delete(ctx, 1)
+			},
+		},
+		{
+			name: "soft delete",
+			setup: func(inner *todomocks.TodoStore) {
+				inner.On("SoftDelete", ctx, int64(1), fixedTime).Return(nil).Once()
+			},
+			write: func(s *TodoStore) error {
+				return s.SoftDelete(ctx, 1, fixedTime)
+			},
+		},
+		{
+			name: "restore",
+			setup: func(inner *todomocks.TodoStore) {
+				inner.On("Restore", ctx, int64(2), int64(1)).Return(nil).Once()
+			},
+			write: func(s *TodoStore) error {
+				return s.Restore(ctx, 2, 1)
+			},
+		},
+		{
+			name: "trash done",
+			setup: func(inner *todomocks.TodoStore) {
+				inner.On("List", ctx, int64(1), int64(2), mock.AnythingOfType("domain.ListOptions")).Return([]*domain.Todo{todo}, nil).Once()
+				inner.On("TrashDone", ctx, int64(1), int64(2), fixedTime).Return(int64(1), nil).Once()
+			},
+			write: func(s *TodoStore) error {
+				_, err := s.TrashDone(ctx, 1, 2, fixedTime)
+				return err
+			},
+		},
+		{
+			name: "delete done",
+			setup: func(inner *todomocks.TodoStore) {
+				inner.On("List", ctx, int64(1), int64(2), mock.AnythingOfType("domain.ListOptions")).Return([]*domain.Todo{todo}, nil).Once()
+				inner.On("DeleteDone", ctx, int64(1), int64(2)).Return(int64(1), nil).Once()
+			},
+			write: func(s *TodoStore) error {
+				_, err := s.DeleteDone(ctx, 1, 2)
+				return err
+			},
+		},
+		{
+			name: "set all done",
+			setup: func(inner *todomocks.TodoStore) {
+				inner.On("List", ctx, int64(1), int64(2), mock.AnythingOfType("domain.ListOptions")).Return([]*domain.Todo{todo}, nil).Once()
+				inner.On("SetAllDone", ctx, int64(1), int64(2), true, fixedTime).Return(int64(1), nil).Once()
+			},
+			write: func(s *TodoStore) error {
+				_, err := s.SetAllDone(ctx, 1, 2, true, fixedTime)
+				return err
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			inner := todomocks.NewTodoStore(t)
+			inner.On("Get", ctx, int64(1)).Return(todo, nil).Twice()
+			tc.setup(inner)
+
+			cache := newFakeCache()
+			s := CreateTodoStore(inner, cache, time.Minute)
+
+			_, err := s.Get(ctx, 1)
+			require.NoError(t, err)
+			require.True(t, cache.has(todoKey(1)))
+
+			require.NoError(t, tc.write(s))
+			require.False(t, cache.has(todoKey(1)))
+
+			// The entry is gone, so this reads the store again
+			_, err = s.Get(ctx, 1)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestTodoStoreList(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	done := true
+	todos := []*domain.Todo{
+		{ID: 1, UserID: 1, TodoListID: 2, Title: "Buy milk", CreatedAt: fixedTime, UpdatedAt: fixedTime},
+		{ID: 2, UserID: 1, TodoListID: 2, Title: "Buy bread", Done: true, CreatedAt: fixedTime, UpdatedAt: fixedTime},
+	}
+
+	t.Run("second identical request is served from the cache", func(t *testing.T) {
+		t.Parallel()
+
+		inner := todomocks.NewTodoStore(t)
+		inner.On("List", ctx, int64(1), int64(2), domain.ListOptions{Limit: 10}).Return(todos, nil).Once()
+
+		s := CreateTodoStore(inner, newFakeCache(), time.Minute)
+
+		for range 2 {
+			got, err := s.List(ctx, 1, 2, domain.ListOptions{Limit: 10})
+			require.NoError(t, err)
+			require.Equal(t, todos, got)
+		}
+	})
+
+	t.Run("different filters are cached apart", func(t *testing.T) {
+		t.Parallel()
+
+		inner := todomocks.NewTodoStore(t)
+		inner.On("List", ctx, int64(1), int64(2), domain.ListOptions{}).Return(todos, nil).Once()
+		inner.On("List", ctx, int64(1), int64(2), domain.ListOptions{Done: &done}).Return(todos[1:], nil).Once()
+		inner.On("List", ctx, int64(1), int64(2), domain.ListOptions{Query: "milk"}).Return(todos[:1], nil).Once()
+
+		s := CreateTodoStore(inner, newFakeCache(), time.Minute)
+
+		for range 2 {
+			all, err := s.List(ctx, 1, 2, domain.ListOptions{})
+			require.NoError(t, err)
+			require.Equal(t, todos, all)
+
+			onlyDone, err := s.List(ctx, 1, 2, domain.ListOptions{Done: &done})
+			require.NoError(t, err)
+			require.Equal(t, todos[1:], onlyDone)
+
+			search, err := s.List(ctx, 1, 2, domain.ListOptions{Query: "milk"})
+			require.NoError(t, err)
+			require.Equal(t, todos[:1], search)
+		}
+	})
+
+	t.Run("create invalidates the list", func(t *testing.T) {
+		t.Parallel()
+
+		created := &domain.Todo{UserID: 1, TodoListID: 2, Title: "Buy eggs"}
+
+		inner := todomocks.NewTodoStore(t)
+		inner.On("List", ctx, int64(1), int64(2), domain.ListOptions{}).Return(todos, nil).Once()
+		inner.On("Create", ctx, int64(2), created).Return(nil).Once()
+
+		s := CreateTodoStore(inner, newFakeCache(), time.Minute)
+
+		_, err := s.List(ctx, 1, 2, domain.ListOptions{})
+		require.NoError(t, err)
+
+		require.NoError(t, s.Create(ctx, 2, created))
+
+		withCreated := append(todos[:len(todos):len(todos)], created)
+		inner.On("List", ctx, int64(1), int64(2), domain.ListOptions{}).Return(withCreated, nil).Once()
+
+		got, err := s.List(ctx, 1, 2, domain.ListOptions{})
+		require.NoError(t, err)
+		require.Len(t, got, 3)
+	})
+
+	t.Run("update of a todo invalidates its list", func(t *testing.T) {
+		t.Parallel()
+
+		inner := todomocks.NewTodoStore(t)
+		inner.On("List", ctx, int64(1), int64(2), domain.ListOptions{}).Return(todos, nil).Twice()
+		inner.On("Get", ctx, int64(1)).Return(todos[0], nil).Once()
+		inner.On("Update", ctx, int64(1), "Buy oat milk", false, (*time.Time)(nil), (*string)(nil), fixedTime).Return(todos[0], nil).Once()
+
+		cache := newFakeCache()
+		s := CreateTodoStore(inner, cache, time.Minute)
+
+		_, err := s.List(ctx, 1, 2, domain.ListOptions{})
+		require.NoError(t, err)
+
+		_, err = s.Update(ctx, 1, "Buy oat milk", false, nil, nil, fixedTime)
+		require.NoError(t, err)
+		require.False(t, cache.has(listGenerationKey(2)))
+
+		_, err = s.List(ctx, 1, 2, domain.ListOptions{})
+		require.NoError(t, err)
+	})
+}
+
+func TestTodoListStoreCaching(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	list := &domain.TodoList{ID: 1, UserID: 1, Title: "Groceries", Color: "#FFFFFF", Labels: []string{"home"}, CreatedAt: fixedTime}
+	updated := &domain.TodoList{ID: 1, UserID: 1, Title: "Shopping", Color: "#FFFFFF", Labels: []string{"home"}, CreatedAt: fixedTime}
+
+	inner := todolistmocks.NewTodoListStore(t)
+	inner.On("GetListByID", ctx, int64(1)).Return(list, nil).Once()
+	inner.On("Update", ctx, int64(1), int64(1), "Shopping", "#FFFFFF", []string{"home"}, false, fixedTime).Return(updated, nil).Once()
+
+	cache := newFakeCache()
+	s := CreateTodoListStore(inner, cache, time.Minute)
+
+	for range 2 {
+		got, err := s.GetListByID(ctx, 1)
+		require.NoError(t, err)
+		require.Equal(t, list, got)
+	}
+
+	// The cached todos of the list carry the list color, an update drops them too
+	require.NoError(t, cache.Set(ctx, listGenerationKey(1), []byte("1"), time.Minute))
+
+	_, err := s.Update(ctx, 1, 1, "Shopping", "#FFFFFF", []string{"home"}, false, fixedTime)
+	require.NoError(t, err)
+	require.False(t, cache.has(todoListKey(1)))
+	require.False(t, cache.has(listGenerationKey(1)))
+
+	inner.On("GetListByID", ctx, int64(1)).Return(updated, nil).Once()
+
+	got, err := s.GetListByID(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, updated, got)
+}
@@ -0,0 +1,276 @@
+package cached
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/macesz/todo-go/domain"
+	"github.com/macesz/todo-go/pkg/logctx"
+	"github.com/macesz/todo-go/services/todo"
+)
+
+// TodoStore wraps a todo store and caches Get and List in front of it
+// Every other call goes straight to the wrapped store, the writes drop the cached entries they touch
+type TodoStore struct {
+	todo.TodoStore
+
+	cache Cache
+	ttl   time.Duration
+}
+
+// CreateTodoStore wraps store with a cache, entries live for ttl
+func CreateTodoStore(store todo.TodoStore, cache Cache, ttl time.Duration) *TodoStore {
+	return &TodoStore{
+		TodoStore: store,
+		cache:     cache,
+		ttl:       ttl,
+	}
+}
+
+func todoKey(id int64) string {
+	return "todo:" + strconv.FormatInt(id, 10)
+}
+
+// listGenerationKey holds the generation of a list's cached List results
+// The result keys contain the generation, so dropping it invalidates every filter and page at once
+func listGenerationKey(todolistID int64) string {
+	return "todos:" + strconv.FormatInt(todolistID, 10) + ":generation"
+}
+
+func listResultKey(generation string, userID int64, todolistID int64, opts domain.ListOptions) string {
+	done := ""
+	if opts.Done != nil {
+		done = strconv.FormatBool(*opts.Done)
+	}
+
+	updatedSince := ""
+	if opts.UpdatedSince != nil {
+		updatedSince = opts.UpdatedSince.UTC().Format(time.RFC3339Nano)
+	}
+
+	// The search text is free-form, so it goes last and quoted
+	return fmt.Sprintf("todos:%d:%s:%d:%d:%d:%s:%s:%s:%s:%q",
+		todolistID, generation, userID, opts.Limit, opts.Offset, opts.Sort, opts.Order, done, updatedSince, opts.Query)
+}
+
+// Get returns the cached todo, or loads it from the wrapped store and caches it
+// A failing cache is only logged, the todo is then read from the store
+func (s *TodoStore) Get(ctx context.Context, id int64) (*domain.Todo, error) {
+	key := todoKey(id)
+
+	if todo, ok := getJSON[domain.Todo](ctx, s.cache, key); ok {
+		return todo, nil
+	}
+
+	todo, err := s.TodoStore.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	setJSON(ctx, s.cache, key, todo, s.ttl)
+
+	return todo, nil
+}
+
+// List returns the cached todos of the list for these options, or loads and caches them
+// Without a usable generation the result is read from the store and not cached
+func (s *TodoStore) List(ctx context.Context, userID int64, todolistID int64, opts domain.ListOptions) ([]*domain.Todo, error) {
+	generation, ok := s.listGeneration(ctx, todolistID)
+	if !ok {
+		return s.TodoStore.List(ctx, userID, todolistID, opts)
+	}
+
+	key := listResultKey(generation, userID, todolistID, opts)
+
+	if todos, ok := getJSON[[]*domain.Todo](ctx, s.cache, key); ok {
+		return *todos, nil
+	}
+
+	todos, err := s.TodoStore.List(ctx, userID, todolistID, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	setJSON(ctx, s.cache, key, todos, s.ttl)
+
+	return todos, nil
+}
+
+// listGeneration returns the current generation of the list, starting a new one when there is none
+func (s *TodoStore) listGeneration(ctx context.Context, todolistID int64) (string, bool) {
+	key := listGenerationKey(todolistID)
+
+	generation, found, err := s.cache.Get(ctx, key)
+	if err != nil {
+		logctx.From(ctx).Warn("cache get failed", "key", key, "error", err)
+		return "", false
+	}
+	if found {
+		return string(generation), true
+	}
+
+	generation = []byte(strconv.FormatInt(time.Now().UnixNano(), 36))
+	if err := s.cache.Set(ctx, key, generation, s.ttl); err != nil {
+		logctx.From(ctx).Warn("cache set failed", "key", key, "error", err)
+		return "", false
+	}
+
+	return string(generation), true
+}
+
+func (s *TodoStore) Create(ctx context.Context, todolistID int64, todo *domain.Todo) error {
+	defer invalidate(ctx, s.cache, listGenerationKey(todolistID))
+
+	return s.TodoStore.Create(ctx, todolistID, todo)
+}
+
+func (s *TodoStore) Update(ctx context.Context, id int64, title string, done bool, dueDate *time.Time, color *string, updatedAt time.Time) (*domain.Todo, error) {
+	keys := s.touchedKeys(ctx, id)
+	defer invalidate(ctx, s.cache, keys...)
+
+	return s.TodoStore.Update(ctx, id, title, done, dueDate, color, updatedAt)
+}
+
+func (s *TodoStore) SetDone(ctx context.Context, userID int64, id int64, done bool, updatedAt time.Time) (*domain.Todo, error) {
+	keys := s.touchedKeys(ctx, id)
+	defer invalidate(ctx, s.cache, keys...)
+
+	return s.TodoStore.SetDone(ctx, userID, id, done, updatedAt)
+}
+
+func (s *TodoStore) Delete(ctx context.Context, id int64) error {
+	keys := s.touchedKeys(ctx, id)
+	defer invalidate(ctx, s.cache, keys...)
+
+	return s.TodoStore.Delete(ctx, id)
+}
+
+func (s *TodoStore) SoftDelete(ctx context.Context, id int64, deletedAt time.Time) error {
+	keys := s.touchedKeys(ctx, id)
+	defer invalidate(ctx, s.cache, keys...)
+
+	return s.TodoStore.SoftDelete(ctx, id, deletedAt)
+}
+
+// Restore makes the List results of the list stale, the todo shows up in them again
+func (s *TodoStore) Restore(ctx context.Context, todolistID int64, id int64) error {
+	defer invalidate(ctx, s.cache, todoKey(id), listGenerationKey(todolistID))
+
+	return s.TodoStore.Restore(ctx, todolistID, id)
+}
+
+// touchedKeys returns the keys a write to the todo makes stale: the todo and the List results of its list
+// A todo never moves between lists, so the (maybe cached) todo tells which list that is
+func (s *TodoStore) touchedKeys(ctx context.Context, id int64) []string {
+	keys := []string{todoKey(id)}
+
+	if todo, err := s.Get(ctx, id); err == nil {
+		keys = append(keys, listGenerationKey(todo.TodoListID))
+	}
+
+	return keys
+}
+
+// TrashDone looks up the done todos first, the store only reports how many it trashed
+func (s *TodoStore) TrashDone(ctx context.Context, userID int64, todolistID int64, deletedAt time.Time) (int64, error) {
+	keys, err := s.doneKeys(ctx, userID, todolistID)
+	if err != nil {
+		return 0, err
+	}
+	defer invalidate(ctx, s.cache, keys...)
+
+	return s.TodoStore.TrashDone(ctx, userID, todolistID, deletedAt)
+}
+
+// DeleteDone looks up the done todos first, like TrashDone
+func (s *TodoStore) DeleteDone(ctx context.Context, userID int64, todolistID int64) (int64, error) {
+	keys, err := s.doneKeys(ctx, userID, todolistID)
+	if err != nil {
+		return 0, err
+	}
+	defer invalidate(ctx, s.cache, keys...)
+
+	return s.TodoStore.DeleteDone(ctx, userID, todolistID)
+}
+
+// doneKeys are the cache keys of the list and of its done todos
+func (s *TodoStore) doneKeys(ctx context.Context, userID int64, todolistID int64) ([]string, error) {
+	done := true
+
+	todos, err := s.TodoStore.List(ctx, userID, todolistID, domain.ListOptions{Done: &done})
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(todos)+1)
+	keys = append(keys, listGenerationKey(todolistID))
+	for _, todo := range todos {
+		keys = append(keys, todoKey(todo.ID))
+	}
+
+	return keys, nil
+}
+
+func getJSON[T any](ctx context.Context, cache Cache, key string) (*T, bool) {
+	data, found, err := cache.Get(ctx, key)
+	if err != nil {
+		logctx.From(ctx).Warn("cache get failed", "key", key, "error", err)
+		return nil, false
+	}
+	if !found {
+		return nil, false
+	}
+
+	var value T
+	if err := json.Unmarshal(data, &value); err != nil {
+		logctx.From(ctx).Warn("cache entry is not valid", "key", key, "error", err)
+		return nil, false
+	}
+
+	return &value, true
+}
+
+func setJSON(ctx context.Context, cache Cache, key string, value any, ttl time.Duration) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		logctx.From(ctx).Warn("cache entry could not be encoded", "key", key, "error", err)
+		return
+	}
+
+	if err := cache.Set(ctx, key, data, ttl); err != nil {
+		logctx.From(ctx).Warn("cache set failed", "key", key, "error", err)
+	}
+}
+
+// invalidate drops the keys after a write, a stale entry expires with its ttl if this fails
+func invalidate(ctx context.Context, cache Cache, keys ...string) {
+	if len(keys) == 0 {
+		return
+	}
+
+	if err := cache.Delete(ctx, keys...); err != nil {
+		logctx.From(ctx).Warn("cache delete failed", "keys", keys, "error", err)
+	}
+}
+
+func (s *TodoStore) SetAllDone(ctx context.Context, userID int64, todolistID int64, done bool, updatedAt time.Time) (int64, error) {
+	// The todos in the other state are the ones that change
+	other := !done
+
+	todos, err := s.TodoStore.List(ctx, userID, todolistID, domain.ListOptions{Done: &other})
+	if err != nil {
+		return 0, err
+	}
+
+	keys := make([]string, 0, len(todos)+1)
+	keys = append(keys, listGenerationKey(todolistID))
+	for _, todo := range todos {
+		keys = append(keys, todoKey(todo.ID))
+	}
+	defer invalidate(ctx, s.cache, keys...)
+
+	return s.TodoStore.SetAllDone(ctx, userID, todolistID, done, updatedAt)
+}
@@ -0,0 +1,69 @@
+package cached
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/macesz/todo-go/domain"
+	"github.com/macesz/todo-go/services/todolist"
+)
+
+// TodoListStore wraps a todo list store and caches GetListByID in front of it
+type TodoListStore struct {
+	todolist.TodoListStore
+
+	cache Cache
+	ttl   time.Duration
+}
+
+// CreateTodoListStore wraps store with a cache, entries live for ttl
+func CreateTodoListStore(store todolist.TodoListStore, cache Cache, ttl time.Duration) *TodoListStore {
+	return &TodoListStore{
+		TodoListStore: store,
+		cache:         cache,
+		ttl:           ttl,
+	}
+}
+
+func todoListKey(id int64) string {
+	return "todolist:" + strconv.FormatInt(id, 10)
+}
+
+// GetListByID returns the cached list, or loads it from the wrapped store and caches it
+func (s *TodoListStore) GetListByID(ctx context.Context, id int64) (*domain.TodoList, error) {
+	key := todoListKey(id)
+
+	if list, ok := getJSON[domain.TodoList](ctx, s.cache, key); ok {
+		return list, nil
+	}
+
+	list, err := s.TodoListStore.GetListByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	setJSON(ctx, s.cache, key, list, s.ttl)
+
+	return list, nil
+}
+
+// Update also drops the cached todo lists of the list, their todos carry the list color.
+// A single cached todo keeps the old list color until it expires.
+func (s *TodoListStore) Update(ctx context.Context, id int64, version int64, title string, color string, labels []string, deleted bool, updatedAt time.Time) (*domain.TodoList, error) {
+	defer invalidate(ctx, s.cache, todoListKey(id), listGenerationKey(id))
+
+	return s.TodoListStore.Update(ctx, id, version, title, color, labels, deleted, updatedAt)
+}
+
+func (s *TodoListStore) SetPinned(ctx context.Context, id int64, pinned bool, updatedAt time.Time) (*domain.TodoList, error) {
+	defer invalidate(ctx, s.cache, todoListKey(id))
+
+	return s.TodoListStore.SetPinned(ctx, id, pinned, updatedAt)
+}
+
+func (s *TodoListStore) Delete(ctx context.Context, id int64) error {
+	defer invalidate(ctx, s.cache, todoListKey(id))
+
+	return s.TodoListStore.Delete(ctx, id)
+}
@@ -0,0 +1,119 @@
+// Package lrucache is a bounded in-process cache with expiring entries
+// It is the no-dependency alternative to Redis for the cached stores, each process keeps its own entries
+package lrucache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/macesz/todo-go/domain"
+)
+
+// Cache keeps at most capacity entries, the least recently used one is evicted first
+// Expired entries are dropped when they are read or pushed out by newer ones
+type Cache struct {
+	capacity int
+	clock    domain.Clock
+
+	mu    sync.Mutex
+	order *list.List // Front is the most recently used entry
+	items map[string]*list.Element
+}
+
+type entry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time // Zero means no expiry
+}
+
+// New creates a cache for capacity entries, a nil clock means the system clock
+func New(capacity int, clock domain.Clock) *Cache {
+	if clock == nil {
+		clock = domain.SystemClock{}
+	}
+
+	return &Cache{
+		capacity: capacity,
+		clock:    clock,
+		order:    list.New(),
+		items:    make(map[string]*list.Element, capacity),
+	}
+}
+
+// Get returns a copy of the value of key, found is false on a miss or an expired entry
+func (c *Cache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[key]
+	if !ok {
+		return nil, false, nil
+	}
+
+	e := el.Value.(*entry)
+	if !e.expiresAt.IsZero() && !c.clock.Now().Before(e.expiresAt) {
+		c.remove(el)
+		return nil, false, nil
+	}
+
+	c.order.MoveToFront(el)
+
+	return append([]byte(nil), e.value...), true, nil
+}
+
+// Set stores a copy of value under key, it expires after ttl, a ttl of 0 or less never expires
+func (c *Cache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = c.clock.Now().Add(ttl)
+	}
+	value = append([]byte(nil), value...)
+
+	if el, ok := c.items[key]; ok {
+		e := el.Value.(*entry)
+		e.value = value
+		e.expiresAt = expiresAt
+		c.order.MoveToFront(el)
+		return nil
+	}
+
+	c.items[key] = c.order.PushFront(&entry{key: key, value: value, expiresAt: expiresAt})
+
+	for c.order.Len() > c.capacity {
+		c.remove(c.order.Back())
+	}
+
+	return nil
+}
+
+// Delete removes the keys, missing keys are ignored
+func (c *Cache) Delete(ctx context.Context, keys ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, key := range keys {
+		if el, ok := c.items[key]; ok {
+			c.remove(el)
+		}
+	}
+
+	return nil
+}
+
+// Len returns the number of entries, expired ones that were not dropped yet included
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
+
+func (c *Cache) remove(el *list.Element) {
+	c.order.Remove(el)
+	delete(c.items, el.Value.(*entry).key)
+}
@@ -0,0 +1,99 @@
+package lrucache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/macesz/todo-go/domain"
+	"github.com/stretchr/testify/require"
+)
+
+var fixedTime = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+func TestEvictionAtCapacity(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	c := New(2, domain.FixedClock{Time: fixedTime})
+
+	require.NoError(t, c.Set(ctx, "a", []byte("1"), time.Minute))
+	require.NoError(t, c.Set(ctx, "b", []byte("2"), time.Minute))
+
+	// Reading a makes b the least recently used entry
+	_, found, err := c.Get(ctx, "a")
+	require.NoError(t, err)
+	require.True(t, found)
+
+	require.NoError(t, c.Set(ctx, "c", []byte("3"), time.Minute))
+	require.Equal(t, 2, c.Len())
+
+	_, found, err = c.Get(ctx, "b")
+	require.NoError(t, err)
+	require.False(t, found, "b should have been evicted")
+
+	for key, want := range map[string]string{"a": "1", "c": "3"} {
+		value, found, err := c.Get(ctx, key)
+		require.NoError(t, err)
+		require.True(t, found)
+		require.Equal(t, want, string(value))
+	}
+}
+
+func TestOverwriteKeepsCapacity(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	c := New(2, nil)
+
+	require.NoError(t, c.Set(ctx, "a", []byte("1"), time.Minute))
+	require.NoError(t, c.Set(ctx, "a", []byte("2"), time.Minute))
+	require.NoError(t, c.Set(ctx, "b", []byte("3"), time.Minute))
+	require.Equal(t, 2, c.Len())
+
+	value, found, err := c.Get(ctx, "a")
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, "2", string(value))
+}
+
+func TestTTLExpiry(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	clock := &domain.FixedClock{Time: fixedTime}
+	c := New(10, clock)
+
+	require.NoError(t, c.Set(ctx, "short", []byte("1"), time.Minute))
+	require.NoError(t, c.Set(ctx, "long", []byte("2"), time.Hour))
+	require.NoError(t, c.Set(ctx, "forever", []byte("3"), 0))
+
+	clock.Time = fixedTime.Add(time.Minute)
+
+	_, found, err := c.Get(ctx, "short")
+	require.NoError(t, err)
+	require.False(t, found, "short should have expired")
+	require.Equal(t, 2, c.Len())
+
+	for _, key := range []string{"long", "forever"} {
+		_, found, err := c.Get(ctx, key)
+		require.NoError(t, err)
+		require.True(t, found, key)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	c := New(10, nil)
+
+	require.NoError(t, c.Set(ctx, "a", []byte("1"), time.Minute))
+	require.NoError(t, c.Set(ctx, "b", []byte("2"), time.Minute))
+	require.NoError(t, c.Delete(ctx, "a", "missing"))
+
+	_, found, err := c.Get(ctx, "a")
+	require.NoError(t, err)
+	require.False(t, found)
+	require.Equal(t, 1, c.Len())
+}
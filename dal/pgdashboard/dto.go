@@ -0,0 +1,17 @@
+package pgdashboard
+
+import "github.com/macesz/todo-go/domain"
+
+type rowDTO struct {
+	TotalLists int64 `db:"total_lists"`
+	TotalTodos int64 `db:"total_todos"`
+	DoneCount  int64 `db:"done_count"`
+}
+
+func (r rowDTO) ToDomain() *domain.Dashboard {
+	return &domain.Dashboard{
+		TotalLists: r.TotalLists,
+		TotalTodos: r.TotalTodos,
+		DoneCount:  r.DoneCount,
+	}
+}
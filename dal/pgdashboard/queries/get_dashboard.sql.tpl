@@ -0,0 +1,9 @@
+SELECT
+    (SELECT COUNT(*) FROM todolists WHERE user_id = :user_id) AS total_lists,
+    COUNT(*) AS total_todos,
+    COUNT(*) FILTER (WHERE done) AS done_count
+FROM todos
+WHERE
+    user_id = :user_id
+    AND
+    deleted_at IS NULL
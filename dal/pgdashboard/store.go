@@ -0,0 +1,64 @@
+package pgdashboard
+
+import (
+	"context"
+	"errors"
+	"text/template"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/macesz/todo-go/domain"
+	"github.com/macesz/todo-go/pkg"
+)
+
+// Store reads aggregated numbers across lists and todos.
+type Store struct {
+	queryTemplates map[string]*template.Template
+	db             *sqlx.DB
+}
+
+// CreateStore creates a new Store instance.
+func CreateStore(db *sqlx.DB) *Store {
+	queryTemplates, err := pkg.BuildQueries(files, "queries")
+	if err != nil {
+		panic(err)
+	}
+
+	return &Store{
+		queryTemplates: queryTemplates,
+		db:             db,
+	}
+}
+
+// Get returns the dashboard counts of the user in a single query.
+func (s *Store) Get(ctx context.Context, userID int64) (*domain.Dashboard, error) {
+	templateParams := map[string]any{}
+
+	querystr, err := pkg.PrepareNamedQuery(s.queryTemplates, getDashboardQuery, templateParams)
+	if err != nil {
+		return nil, err
+	}
+
+	queryParams := map[string]any{
+		"user_id": userID,
+	}
+
+	rows, err := s.db.NamedQueryContext(ctx, querystr, queryParams)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var row rowDTO
+
+	// Aggregates always return exactly one row
+	if !rows.Next() {
+		return nil, errors.New("failed to retrieve dashboard")
+	}
+
+	if err := rows.StructScan(&row); err != nil {
+		return nil, err
+	}
+
+	return row.ToDomain(), nil
+}
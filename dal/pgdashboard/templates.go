@@ -0,0 +1,12 @@
+package pgdashboard
+
+import (
+	"embed"
+)
+
+//go:embed queries/*.sql.tpl
+var files embed.FS
+
+const (
+	getDashboardQuery = "get_dashboard"
+)
@@ -0,0 +1,3 @@
+DELETE FROM todolists
+WHERE
+    user_id = :user_id;
@@ -0,0 +1,3 @@
+DELETE FROM todos
+WHERE
+    user_id = :user_id;
@@ -0,0 +1,2 @@
+INSERT INTO todos (user_id, todolist_id, title, done, created_at, updated_at, completed_at, due_date, color, source)
+VALUES (:user_id, :todolist_id, :title, :done, :created_at, :updated_at, :completed_at, :due_date, :color, :source);
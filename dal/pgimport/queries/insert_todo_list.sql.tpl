@@ -0,0 +1,3 @@
+INSERT INTO todolists (user_id, title, color, labels, created_at, updated_at, deleted, pinned)
+VALUES (:user_id, :title, :color, :labels, :created_at, :updated_at, :deleted, :pinned)
+RETURNING id;
@@ -0,0 +1,134 @@
+package pgimport
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"text/template"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/macesz/todo-go/domain"
+	"github.com/macesz/todo-go/pkg"
+)
+
+// Store restores backups, it writes lists and todos together in one transaction.
+type Store struct {
+	queryTemplates map[string]*template.Template
+	db             *sqlx.DB
+}
+
+// CreateStore creates a new Store instance.
+func CreateStore(db *sqlx.DB) *Store {
+	queryTemplates, err := pkg.BuildQueries(files, "queries")
+	if err != nil {
+		panic(err)
+	}
+
+	return &Store{
+		queryTemplates: queryTemplates,
+		db:             db,
+	}
+}
+
+// Import creates the lists, with their Items as todos, for the user in one transaction.
+// Every row gets a new id and public id. With replace the user's existing lists and todos are deleted first.
+// Either everything is imported or nothing is, a list title the user already has returns domain.ErrDuplicate.
+func (s *Store) Import(ctx context.Context, userID int64, lists []*domain.TodoList, replace bool) error {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	// Rollback is a no-op after a successful Commit
+	defer tx.Rollback()
+
+	if replace {
+		for _, q := range []string{deleteUserTodosQuery, deleteUserTodoListsQuery} {
+			if err := s.exec(ctx, tx, q, map[string]any{"user_id": userID}); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, list := range lists {
+		listID, err := s.insertList(ctx, tx, userID, list)
+		if err != nil {
+			var pqErr *pq.Error
+			if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+				return domain.ErrDuplicate
+			}
+			return err
+		}
+
+		for _, todo := range list.Items {
+			queryParams := map[string]any{
+				"user_id":     userID,
+				"todolist_id": listID,
+				"title":       todo.Title,
+				"done":        todo.Done,
+				"created_at":  todo.CreatedAt,
+				"updated_at":  todo.UpdatedAt,
+
+				"completed_at": todo.CompletedAt,
+				"due_date":     todo.DueDate,
+				"color":        todo.Color,
+				"source":       domain.TodoSourceImport,
+			}
+
+			if err := s.exec(ctx, tx, insertTodoQuery, queryParams); err != nil {
+				return err
+			}
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (s *Store) insertList(ctx context.Context, tx *sqlx.Tx, userID int64, list *domain.TodoList) (int64, error) {
+	querystr, err := pkg.PrepareNamedQuery(s.queryTemplates, insertTodoListQuery, map[string]any{})
+	if err != nil {
+		return 0, err
+	}
+
+	queryParams := map[string]any{
+		"user_id":    userID,
+		"title":      list.Title,
+		"color":      list.Color,
+		"labels":     strings.Join(list.Labels, ","),
+		"created_at": list.CreatedAt,
+		"updated_at": list.UpdatedAt,
+		"deleted":    list.Deleted,
+		"pinned":     list.Pinned,
+	}
+
+	rows, err := sqlx.NamedQueryContext(ctx, tx, querystr, queryParams)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	var id int64
+	if rows.Next() {
+		if err := rows.Scan(&id); err != nil {
+			return 0, err
+		}
+	} else {
+		// A failed insert, like a unique violation, can surface here instead of at the query
+		if err := rows.Err(); err != nil {
+			return 0, err
+		}
+		return 0, errors.New("failed to retrieve inserted todo list ID")
+	}
+
+	return id, nil
+}
+
+func (s *Store) exec(ctx context.Context, tx *sqlx.Tx, query string, queryParams map[string]any) error {
+	querystr, err := pkg.PrepareNamedQuery(s.queryTemplates, query, map[string]any{})
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.NamedExecContext(ctx, querystr, queryParams)
+	return err
+}
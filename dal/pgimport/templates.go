@@ -0,0 +1,15 @@
+package pgimport
+
+import (
+	"embed"
+)
+
+//go:embed queries/*.sql.tpl
+var files embed.FS
+
+const (
+	deleteUserTodosQuery     = "delete_user_todos"
+	deleteUserTodoListsQuery = "delete_user_todo_lists"
+	insertTodoListQuery      = "insert_todo_list"
+	insertTodoQuery          = "insert_todo"
+)
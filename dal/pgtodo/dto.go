@@ -7,21 +7,64 @@ import (
 )
 
 type rowDTO struct {
-	ID        int64     `db:"id"`
-	UserID    int64     `db:"user_id"`
-	TodlistID int64     `db:"todolist_id"`
-	Title     string    `db:"title"`
-	Done      bool      `db:"done"`
-	CreatedAt time.Time `db:"created_at"`
+	ID          int64      `db:"id"`
+	PublicID    string     `db:"public_id"`
+	UserID      int64      `db:"user_id"`
+	TodlistID   int64      `db:"todolist_id"`
+	Title       string     `db:"title"`
+	Done        bool       `db:"done"`
+	CreatedAt   time.Time  `db:"created_at"`
+	UpdatedAt   time.Time  `db:"updated_at"`
+	DeletedAt   *time.Time `db:"deleted_at"`
+	CompletedAt *time.Time `db:"completed_at"`
+	DueDate     *time.Time `db:"due_date"`
+	Color       *string    `db:"color"`
+	Source      string     `db:"source"`
+
+	// ListColor is the color of the list, the queries that return it select it as todolist_color
+	ListColor *string `db:"todolist_color"`
+}
+
+// joinedRowDTO is a todo row joined with the public id of its list
+type joinedRowDTO struct {
+	rowDTO
+	TodoListPublicID string `db:"todolist_public_id"`
 }
 
 func (r rowDTO) ToDomain() *domain.Todo {
 	return &domain.Todo{
 		ID:         r.ID,
+		PublicID:   r.PublicID,
 		UserID:     r.UserID,
 		TodoListID: r.TodlistID,
 		Title:      r.Title,
 		Done:       r.Done,
-		CreatedAt:  r.CreatedAt,
+		CreatedAt:  r.CreatedAt.Truncate(time.Microsecond),
+		UpdatedAt:  r.UpdatedAt.Truncate(time.Microsecond),
+
+		CompletedAt: truncate(r.CompletedAt),
+		DeletedAt:   truncate(r.DeletedAt),
+		DueDate:     truncate(r.DueDate),
+		Color:       r.Color,
+		ListColor:   r.ListColor,
+		Source:      domain.TodoSource(r.Source),
+	}
+}
+
+func (r joinedRowDTO) ToDomain() *domain.Todo {
+	todo := r.rowDTO.ToDomain()
+	todo.TodoListPublicID = r.TodoListPublicID
+
+	return todo
+}
+
+// truncate cuts a scanned time down to microseconds, the precision Postgres keeps
+func truncate(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
 	}
+
+	truncated := t.Truncate(time.Microsecond)
+
+	return &truncated
 }
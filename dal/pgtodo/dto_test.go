@@ -0,0 +1,29 @@
+package pgtodo
+
+import (
+	"testing"
+	"time"
+)
+
+// TestToDomainTruncates checks that scanned times come out at microsecond precision
+func TestToDomainTruncates(t *testing.T) {
+	precise := time.Date(2024, 1, 2, 3, 4, 5, 123456789, time.UTC)
+	want := time.Date(2024, 1, 2, 3, 4, 5, 123456000, time.UTC)
+
+	todo := rowDTO{CreatedAt: precise, UpdatedAt: precise, DeletedAt: &precise, DueDate: &precise}.ToDomain()
+
+	for name, got := range map[string]time.Time{
+		"created_at": todo.CreatedAt,
+		"updated_at": todo.UpdatedAt,
+		"deleted_at": *todo.DeletedAt,
+		"due_date":   *todo.DueDate,
+	} {
+		if !got.Equal(want) {
+			t.Errorf("%s = %v, want %v", name, got, want)
+		}
+	}
+
+	if todo.CompletedAt != nil {
+		t.Errorf("completed_at = %v, want nil", todo.CompletedAt)
+	}
+}
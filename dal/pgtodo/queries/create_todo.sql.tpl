@@ -1,3 +1,3 @@
-INSERT INTO todos (user_id, todolist_id, title, done, created_at)
-VALUES (:user_id, :todolist_id, :title, :done, :created_at)
-RETURNING id;
+INSERT INTO todos (user_id, todolist_id, title, done, created_at, updated_at, completed_at, due_date, color, source)
+VALUES (:user_id, :todolist_id, :title, :done, :created_at, :created_at, :completed_at, :due_date, :color, :source)
+RETURNING id, public_id, (SELECT color FROM todolists WHERE todolists.id = todos.todolist_id) AS todolist_color;
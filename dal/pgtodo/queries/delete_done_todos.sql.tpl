@@ -0,0 +1,9 @@
+DELETE FROM todos
+WHERE
+    user_id = :user_id
+    AND
+    todolist_id = :todolist_id
+    AND
+    done = true
+    AND
+    deleted_at IS NULL;
@@ -1,4 +1,8 @@
-SELECT user_id, id, title, done, created_at
+SELECT todos.user_id, todos.id, todos.public_id, todos.todolist_id, todos.title, todos.done,
+    todos.created_at, todos.updated_at, todos.completed_at, todos.due_date, todos.color, todos.source,
+    todolists.public_id AS todolist_public_id, todolists.color AS todolist_color
 FROM todos
+JOIN todolists ON todolists.id = todos.todolist_id
 WHERE
- id = :id;
+ todos.id = :id
+ AND todos.deleted_at IS NULL;
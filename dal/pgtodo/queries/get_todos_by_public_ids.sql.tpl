@@ -0,0 +1,10 @@
+SELECT todos.*, todolists.public_id AS todolist_public_id, todolists.color AS todolist_color
+FROM todos
+JOIN todolists ON todolists.id = todos.todolist_id
+WHERE
+    todos.public_id = ANY(CAST(:public_ids AS UUID[]))
+    AND
+    todos.user_id = :user_id
+    AND
+    todos.deleted_at IS NULL
+ORDER BY todos.id
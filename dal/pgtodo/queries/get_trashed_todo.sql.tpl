@@ -0,0 +1,9 @@
+SELECT todos.user_id, todos.id, todos.public_id, todos.todolist_id, todos.title, todos.done,
+    todos.created_at, todos.updated_at, todos.completed_at, todos.due_date, todos.color, todos.source, todos.deleted_at,
+    todolists.public_id AS todolist_public_id, todolists.color AS todolist_color
+FROM todos
+JOIN todolists ON todolists.id = todos.todolist_id
+WHERE
+ todos.public_id = :public_id
+ AND todos.user_id = :user_id
+ AND todos.deleted_at IS NOT NULL;
@@ -0,0 +1,5 @@
+SELECT id FROM todolists
+WHERE
+    public_id = :public_id
+    AND
+    user_id = :user_id
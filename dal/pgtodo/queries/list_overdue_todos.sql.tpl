@@ -0,0 +1,14 @@
+SELECT todos.*, todolists.public_id AS todolist_public_id, todolists.color AS todolist_color
+FROM todos
+JOIN todolists ON todolists.id = todos.todolist_id
+WHERE
+    todos.user_id = :user_id
+    AND
+    todos.done = false
+    AND
+    todos.due_date < :now
+    AND
+    todos.deleted_at IS NULL
+    AND
+    NOT todolists.deleted
+ORDER BY todos.due_date, todos.id
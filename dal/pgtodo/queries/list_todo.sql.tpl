@@ -1,6 +1,27 @@
-SELECT * FROM todos
+SELECT todos.*, (SELECT color FROM todolists WHERE todolists.id = todos.todolist_id) AS todolist_color
+FROM todos
 WHERE
     user_id = :user_id
     AND
     todolist_id = :todolist_id
-ORDER BY created_at
+    AND
+    deleted_at IS NULL
+{{- if .filterDone }}
+    AND
+    done = :done
+{{- end }}
+{{- if .filterQuery }}
+    AND
+    title ILIKE :query ESCAPE '\'
+{{- end }}
+{{- if .filterUpdatedSince }}
+    AND
+    updated_at >= :updated_since
+{{- end }}
+ORDER BY {{ if .sort }}{{ .sort }} {{ .order }}{{ else }}created_at{{ end }}, id
+{{- if .limit }}
+LIMIT :limit
+{{- end }}
+{{- if .offset }}
+OFFSET :offset
+{{- end }}
@@ -0,0 +1,5 @@
+DELETE FROM todos
+WHERE
+    deleted_at IS NOT NULL
+    AND
+    deleted_at < :before;
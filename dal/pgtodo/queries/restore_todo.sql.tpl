@@ -0,0 +1,8 @@
+UPDATE todos
+SET deleted_at = NULL
+WHERE
+    id = :id
+    AND
+    todolist_id = :todolist_id
+    AND
+    deleted_at IS NOT NULL;
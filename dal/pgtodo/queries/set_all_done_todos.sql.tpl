@@ -0,0 +1,12 @@
+UPDATE todos
+SET done = :done, updated_at = :updated_at,
+    -- lib/pq sends the parameter untyped, without the cast the CASE would resolve to text
+    completed_at = CASE WHEN :done THEN CAST(:updated_at AS TIMESTAMP) END
+WHERE
+    user_id = :user_id
+    AND
+    todolist_id = :todolist_id
+    AND
+    deleted_at IS NULL
+    AND
+    done <> :done;
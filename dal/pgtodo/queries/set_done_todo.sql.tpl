@@ -0,0 +1,14 @@
+UPDATE todos
+SET done = :done, updated_at = :updated_at,
+    -- completed_at keeps the time the todo became done, and is cleared when it is undone
+    completed_at = CASE
+        WHEN NOT :done THEN NULL
+        WHEN done THEN completed_at
+        ELSE :updated_at
+    END
+WHERE
+    id = :id
+    AND
+    user_id = :user_id
+    AND
+    deleted_at IS NULL;
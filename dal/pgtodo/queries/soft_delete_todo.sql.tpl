@@ -0,0 +1,6 @@
+UPDATE todos
+SET deleted_at = :deleted_at
+WHERE
+    id = :id
+    AND
+    deleted_at IS NULL;
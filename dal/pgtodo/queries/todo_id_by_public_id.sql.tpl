@@ -0,0 +1,7 @@
+SELECT id FROM todos
+WHERE
+    public_id = :public_id
+    AND
+    user_id = :user_id
+    AND
+    deleted_at IS NULL
@@ -0,0 +1,9 @@
+SELECT EXISTS (
+    SELECT 1 FROM todos
+    WHERE
+        todolist_id = :todolist_id
+        AND
+        title = :title
+        AND
+        deleted_at IS NULL
+)
@@ -0,0 +1,10 @@
+UPDATE todos
+SET deleted_at = :deleted_at
+WHERE
+    user_id = :user_id
+    AND
+    todolist_id = :todolist_id
+    AND
+    done = true
+    AND
+    deleted_at IS NULL;
@@ -1,4 +1,10 @@
 UPDATE todos
-SET title = :title, done = :done
+SET title = :title, done = :done, due_date = :due_date, color = :color, updated_at = :updated_at,
+    -- completed_at keeps the time the todo became done, and is cleared when it is undone
+    completed_at = CASE
+        WHEN NOT :done THEN NULL
+        WHEN done THEN completed_at
+        ELSE :updated_at
+    END
 WHERE
     id = :id;
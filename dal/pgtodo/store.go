@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/macesz/todo-go/domain"
 	"github.com/macesz/todo-go/pkg"
 )
@@ -32,15 +33,24 @@ func CreateStore(db *sqlx.DB) *Store {
 }
 
 // List retrieves a list of todos from the database.
-func (s *Store) List(ctx context.Context, userID int64, todolistID int64) ([]*domain.Todo, error) {
+func (s *Store) List(ctx context.Context, userID int64, todolistID int64, opts domain.ListOptions) ([]*domain.Todo, error) {
 	todos := make([]*domain.Todo, 0)
 
 	// Template parameters are not safe to use directly in the query, because they can be used to inject SQL code.
 	// I can use anything that is not a user input, like Table Name, Column Name, etc.
-	templateParams := map[string]any{}
+	// The sort column and order come from a whitelist, so they are safe here.
+	templateParams := map[string]any{
+		"sort":               sortColumns[opts.Sort],
+		"order":              sortOrders[opts.Order],
+		"filterDone":         opts.Done != nil,
+		"filterQuery":        opts.Query != "",
+		"filterUpdatedSince": opts.UpdatedSince != nil,
+		"limit":              opts.Limit > 0,
+		"offset":             opts.Offset > 0,
+	}
 
 	// Prepare the query string, by using the template.
-	querystr, err := pkg.PrepareQuery(s.queryTemplates[listTodoQuery], templateParams)
+	querystr, err := pkg.PrepareNamedQuery(s.queryTemplates, listTodoQuery, templateParams)
 	if err != nil {
 		return nil, err
 	}
@@ -50,6 +60,20 @@ func (s *Store) List(ctx context.Context, userID int64, todolistID int64) ([]*do
 	queryParams := map[string]any{
 		"user_id":     userID,
 		"todolist_id": todolistID,
+		"limit":       opts.Limit,
+		"offset":      opts.Offset,
+	}
+
+	if opts.Done != nil {
+		queryParams["done"] = *opts.Done
+	}
+
+	if opts.Query != "" {
+		queryParams["query"] = "%" + escapeLike(opts.Query) + "%"
+	}
+
+	if opts.UpdatedSince != nil {
+		queryParams["updated_since"] = *opts.UpdatedSince
 	}
 
 	// Execute the query. You can add parameters to the query if needed instead of using nil.
@@ -78,7 +102,7 @@ func (s *Store) List(ctx context.Context, userID int64, todolistID int64) ([]*do
 func (s *Store) Create(ctx context.Context, todolistID int64, todo *domain.Todo) error {
 	templateParams := map[string]any{}
 
-	querystr, err := pkg.PrepareQuery(s.queryTemplates[createTodoQuery], templateParams)
+	querystr, err := pkg.PrepareNamedQuery(s.queryTemplates, createTodoQuery, templateParams)
 	if err != nil {
 		return err
 	}
@@ -88,9 +112,20 @@ func (s *Store) Create(ctx context.Context, todolistID int64, todo *domain.Todo)
 		"todolist_id": todolistID,
 		"title":       todo.Title,
 		"done":        todo.Done,
-		"created_at":  time.Now(),
+		"created_at":  todo.CreatedAt,
+		"due_date":    todo.DueDate,
+		"color":       todo.Color,
+		"source":      todo.Source,
 	}
 
+	// A todo created as done counts as completed when it was created.
+	if todo.Done && todo.CompletedAt == nil {
+		completedAt := todo.CreatedAt
+		todo.CompletedAt = &completedAt
+	}
+
+	queryParams["completed_at"] = todo.CompletedAt
+
 	// NamedQueryContext ✅ - Single row with RETURNING clause
 	result, err := s.db.NamedQueryContext(ctx, querystr, queryParams)
 	if err != nil {
@@ -99,12 +134,14 @@ func (s *Store) Create(ctx context.Context, todolistID int64, todo *domain.Todo)
 	defer result.Close()
 
 	var (
-		id int64
+		id        int64
+		publicID  string
+		listColor *string
 	)
 
 	// Scan the result into the variables
 	if result.Next() {
-		err = result.Scan(&id)
+		err = result.Scan(&id, &publicID, &listColor)
 		if err != nil {
 			return err
 		}
@@ -114,6 +151,8 @@ func (s *Store) Create(ctx context.Context, todolistID int64, todo *domain.Todo)
 
 	// Create a new Todo instance with the retrieved ID and other fields
 	todo.ID = id
+	todo.PublicID = publicID
+	todo.ListColor = listColor
 
 	return nil
 }
@@ -121,7 +160,7 @@ func (s *Store) Create(ctx context.Context, todolistID int64, todo *domain.Todo)
 func (s *Store) Get(ctx context.Context, id int64) (*domain.Todo, error) {
 	templateParams := map[string]any{}
 
-	querystr, err := pkg.PrepareQuery(s.queryTemplates[getTodoQuery], templateParams)
+	querystr, err := pkg.PrepareNamedQuery(s.queryTemplates, getTodoQuery, templateParams)
 	if err != nil {
 		return nil, err
 	}
@@ -130,7 +169,7 @@ func (s *Store) Get(ctx context.Context, id int64) (*domain.Todo, error) {
 		"id": id,
 	}
 
-	var row rowDTO
+	var row joinedRowDTO
 	//NamedQueryContext ✅ - Single row with named parameters (GetTodo, GetUser, etc.)
 	rows, err := s.db.NamedQueryContext(ctx, querystr, queryParams)
 	if err != nil {
@@ -154,18 +193,257 @@ func (s *Store) Get(ctx context.Context, id int64) (*domain.Todo, error) {
 	return row.ToDomain(), nil
 }
 
-func (s *Store) Update(ctx context.Context, id int64, title string, done bool) (*domain.Todo, error) {
+// GetByIDs retrieves the user's todos with the given IDs in one query.
+// IDs that don't exist or belong to another user are skipped.
+// The todos come back ordered by ID, not in the order of ids.
+func (s *Store) GetByIDs(ctx context.Context, userID int64, ids []int64) ([]*domain.Todo, error) {
+	todos := make([]*domain.Todo, 0, len(ids))
+
+	if len(ids) == 0 {
+		return todos, nil
+	}
+
+	templateParams := map[string]any{}
+
+	querystr, err := pkg.PrepareNamedQuery(s.queryTemplates, getByIDsQuery, templateParams)
+	if err != nil {
+		return nil, err
+	}
+
+	queryParams := map[string]any{
+		"user_id": userID,
+		"ids":     pq.Array(ids),
+	}
+
+	rows, err := s.db.NamedQueryContext(ctx, querystr, queryParams)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var row joinedRowDTO
+
+	for rows.Next() {
+		err := rows.StructScan(&row)
+		if err != nil {
+			return nil, err
+		}
+
+		todos = append(todos, row.ToDomain())
+	}
+
+	return todos, nil
+}
+
+// GetByPublicIDs retrieves the user's todos with the given public IDs in one query.
+// Public IDs that don't exist or belong to another user are skipped.
+// The todos come back ordered by ID, not in the order of publicIDs.
+func (s *Store) GetByPublicIDs(ctx context.Context, userID int64, publicIDs []string) ([]*domain.Todo, error) {
+	todos := make([]*domain.Todo, 0, len(publicIDs))
+
+	if len(publicIDs) == 0 {
+		return todos, nil
+	}
+
+	templateParams := map[string]any{}
+
+	querystr, err := pkg.PrepareNamedQuery(s.queryTemplates, getByPublicIDsQuery, templateParams)
+	if err != nil {
+		return nil, err
+	}
+
+	queryParams := map[string]any{
+		"user_id":    userID,
+		"public_ids": pq.Array(publicIDs),
+	}
+
+	rows, err := s.db.NamedQueryContext(ctx, querystr, queryParams)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var row joinedRowDTO
+
+	for rows.Next() {
+		err := rows.StructScan(&row)
+		if err != nil {
+			return nil, err
+		}
+
+		todos = append(todos, row.ToDomain())
+	}
+
+	return todos, nil
+}
+
+// RecentlyCompleted retrieves the user's most recently completed todos across all lists,
+// newest completion first. Todos in deleted lists are skipped.
+func (s *Store) RecentlyCompleted(ctx context.Context, userID int64, limit int) ([]*domain.Todo, error) {
+	todos := make([]*domain.Todo, 0, limit)
+
+	templateParams := map[string]any{}
+
+	querystr, err := pkg.PrepareNamedQuery(s.queryTemplates, recentlyCompletedQuery, templateParams)
+	if err != nil {
+		return nil, err
+	}
+
+	queryParams := map[string]any{
+		"user_id": userID,
+		"limit":   limit,
+	}
+
+	rows, err := s.db.NamedQueryContext(ctx, querystr, queryParams)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var row joinedRowDTO
+
+	for rows.Next() {
+		err := rows.StructScan(&row)
+		if err != nil {
+			return nil, err
+		}
+
+		todos = append(todos, row.ToDomain())
+	}
+
+	return todos, nil
+}
+
+// ListOverdue retrieves the user's not done todos whose due date is before now, earliest due first.
+// Todos of deleted lists are left out, like in RecentlyCompleted.
+func (s *Store) ListOverdue(ctx context.Context, userID int64, now time.Time) ([]*domain.Todo, error) {
+	todos := make([]*domain.Todo, 0)
+
 	templateParams := map[string]any{}
 
-	querystr, err := pkg.PrepareQuery(s.queryTemplates[updateTodoQuery], templateParams)
+	querystr, err := pkg.PrepareNamedQuery(s.queryTemplates, listOverdueQuery, templateParams)
 	if err != nil {
 		return nil, err
 	}
 
 	queryParams := map[string]any{
-		"id":    id,
-		"title": title,
-		"done":  done,
+		"user_id": userID,
+		"now":     now,
+	}
+
+	rows, err := s.db.NamedQueryContext(ctx, querystr, queryParams)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var row joinedRowDTO
+		if err := rows.StructScan(&row); err != nil {
+			return nil, err
+		}
+
+		todos = append(todos, row.ToDomain())
+	}
+
+	return todos, nil
+}
+
+// TitleExists reports whether the list already has a todo with the given title.
+func (s *Store) TitleExists(ctx context.Context, todolistID int64, title string) (bool, error) {
+	templateParams := map[string]any{}
+
+	querystr, err := pkg.PrepareNamedQuery(s.queryTemplates, titleExistsQuery, templateParams)
+	if err != nil {
+		return false, err
+	}
+
+	queryParams := map[string]any{
+		"todolist_id": todolistID,
+		"title":       title,
+	}
+
+	rows, err := s.db.NamedQueryContext(ctx, querystr, queryParams)
+	if err != nil {
+		return false, err
+	}
+
+	defer rows.Close()
+
+	var exists bool
+
+	if rows.Next() {
+		if err := rows.Scan(&exists); err != nil {
+			return false, err
+		}
+	}
+
+	return exists, nil
+}
+
+// IDByPublicID resolves the public id of one of the user's todos to its internal id.
+func (s *Store) IDByPublicID(ctx context.Context, userID int64, publicID string) (int64, error) {
+	return s.resolvePublicID(ctx, idByPublicIDQuery, userID, publicID)
+}
+
+// ListIDByPublicID resolves the public id of one of the user's lists to its internal id.
+func (s *Store) ListIDByPublicID(ctx context.Context, userID int64, publicID string) (int64, error) {
+	return s.resolvePublicID(ctx, listIDByPublicIDQuery, userID, publicID)
+}
+
+func (s *Store) resolvePublicID(ctx context.Context, query string, userID int64, publicID string) (int64, error) {
+	templateParams := map[string]any{}
+
+	querystr, err := pkg.PrepareNamedQuery(s.queryTemplates, query, templateParams)
+	if err != nil {
+		return 0, err
+	}
+
+	queryParams := map[string]any{
+		"user_id":   userID,
+		"public_id": publicID,
+	}
+
+	rows, err := s.db.NamedQueryContext(ctx, querystr, queryParams)
+	if err != nil {
+		return 0, err
+	}
+
+	defer rows.Close()
+
+	var id int64
+
+	if rows.Next() {
+		if err := rows.Scan(&id); err != nil {
+			return 0, err
+		}
+	} else {
+		// Return sql.ErrNoRows so the service layer can handle it properly
+		return 0, sql.ErrNoRows
+	}
+
+	return id, nil
+}
+
+func (s *Store) Update(ctx context.Context, id int64, title string, done bool, dueDate *time.Time, color *string, updatedAt time.Time) (*domain.Todo, error) {
+	templateParams := map[string]any{}
+
+	querystr, err := pkg.PrepareNamedQuery(s.queryTemplates, updateTodoQuery, templateParams)
+	if err != nil {
+		return nil, err
+	}
+
+	queryParams := map[string]any{
+		"id":         id,
+		"title":      title,
+		"done":       done,
+		"due_date":   dueDate,
+		"color":      color,
+		"updated_at": updatedAt,
 	}
 
 	result, err := s.db.NamedExecContext(ctx, querystr, queryParams)
@@ -187,10 +465,43 @@ func (s *Store) Update(ctx context.Context, id int64, title string, done bool) (
 	return s.Get(ctx, id)
 }
 
+// SetDone changes only the done flag (and completed_at) of the user's todo.
+func (s *Store) SetDone(ctx context.Context, userID int64, id int64, done bool, updatedAt time.Time) (*domain.Todo, error) {
+	templateParams := map[string]any{}
+
+	querystr, err := pkg.PrepareNamedQuery(s.queryTemplates, setDoneQuery, templateParams)
+	if err != nil {
+		return nil, err
+	}
+
+	queryParams := map[string]any{
+		"id":         id,
+		"user_id":    userID,
+		"done":       done,
+		"updated_at": updatedAt,
+	}
+
+	result, err := s.db.NamedExecContext(ctx, querystr, queryParams)
+	if err != nil {
+		return nil, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+
+	if rowsAffected == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	return s.Get(ctx, id)
+}
+
 func (s *Store) Delete(ctx context.Context, id int64) error {
 	templateParams := map[string]any{}
 
-	querystr, err := pkg.PrepareQuery(s.queryTemplates[deleteTodoQuery], templateParams)
+	querystr, err := pkg.PrepareNamedQuery(s.queryTemplates, deleteTodoQuery, templateParams)
 	if err != nil {
 		return err
 	}
@@ -217,3 +528,188 @@ func (s *Store) Delete(ctx context.Context, id int64) error {
 
 	return nil
 }
+
+// SoftDelete moves a todo to the trash by setting its deleted_at instead of removing the row.
+func (s *Store) SoftDelete(ctx context.Context, id int64, deletedAt time.Time) error {
+	templateParams := map[string]any{}
+
+	querystr, err := pkg.PrepareNamedQuery(s.queryTemplates, softDeleteQuery, templateParams)
+	if err != nil {
+		return err
+	}
+
+	queryParams := map[string]any{
+		"id":         id,
+		"deleted_at": deletedAt,
+	}
+
+	result, err := s.db.NamedExecContext(ctx, querystr, queryParams)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// TrashDone soft-deletes every done todo in the list and returns how many were trashed.
+func (s *Store) TrashDone(ctx context.Context, userID int64, todolistID int64, deletedAt time.Time) (int64, error) {
+	templateParams := map[string]any{}
+
+	querystr, err := pkg.PrepareNamedQuery(s.queryTemplates, trashDoneQuery, templateParams)
+	if err != nil {
+		return 0, err
+	}
+
+	queryParams := map[string]any{
+		"user_id":     userID,
+		"todolist_id": todolistID,
+		"deleted_at":  deletedAt,
+	}
+
+	result, err := s.db.NamedExecContext(ctx, querystr, queryParams)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+// DeleteDone deletes every done todo in the list for good and returns how many were deleted.
+func (s *Store) DeleteDone(ctx context.Context, userID int64, todolistID int64) (int64, error) {
+	templateParams := map[string]any{}
+
+	querystr, err := pkg.PrepareNamedQuery(s.queryTemplates, deleteDoneQuery, templateParams)
+	if err != nil {
+		return 0, err
+	}
+
+	queryParams := map[string]any{
+		"user_id":     userID,
+		"todolist_id": todolistID,
+	}
+
+	result, err := s.db.NamedExecContext(ctx, querystr, queryParams)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+// GetTrashed retrieves the user's trashed todo by its public id, with its deleted_at.
+func (s *Store) GetTrashed(ctx context.Context, userID int64, publicID string) (*domain.Todo, error) {
+	templateParams := map[string]any{}
+
+	querystr, err := pkg.PrepareNamedQuery(s.queryTemplates, getTrashedQuery, templateParams)
+	if err != nil {
+		return nil, err
+	}
+
+	queryParams := map[string]any{
+		"user_id":   userID,
+		"public_id": publicID,
+	}
+
+	rows, err := s.db.NamedQueryContext(ctx, querystr, queryParams)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var row joinedRowDTO
+	if !rows.Next() {
+		return nil, sql.ErrNoRows
+	}
+	if err := rows.StructScan(&row); err != nil {
+		return nil, err
+	}
+
+	return row.ToDomain(), nil
+}
+
+// Restore takes a todo of the list out of the trash by clearing its deleted_at.
+func (s *Store) Restore(ctx context.Context, todolistID int64, id int64) error {
+	templateParams := map[string]any{}
+
+	querystr, err := pkg.PrepareNamedQuery(s.queryTemplates, restoreQuery, templateParams)
+	if err != nil {
+		return err
+	}
+
+	queryParams := map[string]any{
+		"id":          id,
+		"todolist_id": todolistID,
+	}
+
+	result, err := s.db.NamedExecContext(ctx, querystr, queryParams)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// PurgeTrash removes the todos trashed before the given time and returns how many were removed.
+func (s *Store) PurgeTrash(ctx context.Context, before time.Time) (int64, error) {
+	templateParams := map[string]any{}
+
+	querystr, err := pkg.PrepareNamedQuery(s.queryTemplates, purgeTrashQuery, templateParams)
+	if err != nil {
+		return 0, err
+	}
+
+	queryParams := map[string]any{
+		"before": before,
+	}
+
+	result, err := s.db.NamedExecContext(ctx, querystr, queryParams)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+// SetAllDone sets the done state of every todo in the user's list in one statement.
+// Only todos in the other state change, their completed_at is set or cleared.
+// Returns the number of changed todos.
+func (s *Store) SetAllDone(ctx context.Context, userID int64, todolistID int64, done bool, updatedAt time.Time) (int64, error) {
+	templateParams := map[string]any{}
+
+	querystr, err := pkg.PrepareNamedQuery(s.queryTemplates, setAllDoneQuery, templateParams)
+	if err != nil {
+		return 0, err
+	}
+
+	queryParams := map[string]any{
+		"user_id":     userID,
+		"todolist_id": todolistID,
+		"done":        done,
+		"updated_at":  updatedAt,
+	}
+
+	result, err := s.db.NamedExecContext(ctx, querystr, queryParams)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
@@ -2,15 +2,55 @@ package pgtodo
 
 import (
 	"embed"
+	"strings"
+
+	"github.com/macesz/todo-go/domain"
 )
 
 //go:embed queries/*.sql.tpl
 var files embed.FS
 
 const (
-	listTodoQuery   = "list_todo"
-	createTodoQuery = "create_todo"
-	getTodoQuery    = "get_todo"
-	updateTodoQuery = "update_todo"
-	deleteTodoQuery = "delete_todo"
+	listTodoQuery          = "list_todo"
+	createTodoQuery        = "create_todo"
+	getTodoQuery           = "get_todo"
+	updateTodoQuery        = "update_todo"
+	deleteTodoQuery        = "delete_todo"
+	softDeleteQuery        = "soft_delete_todo"
+	trashDoneQuery         = "trash_done_todos"
+	deleteDoneQuery        = "delete_done_todos"
+	getTrashedQuery        = "get_trashed_todo"
+	restoreQuery           = "restore_todo"
+	purgeTrashQuery        = "purge_trash"
+	getByIDsQuery          = "get_todos_by_ids"
+	getByPublicIDsQuery    = "get_todos_by_public_ids"
+	titleExistsQuery       = "todo_title_exists"
+	idByPublicIDQuery      = "todo_id_by_public_id"
+	listIDByPublicIDQuery  = "list_id_by_public_id"
+	recentlyCompletedQuery = "recently_completed_todos"
+	listOverdueQuery       = "list_overdue_todos"
+	setAllDoneQuery        = "set_all_done_todos"
+	setDoneQuery           = "set_done_todo"
 )
+
+// sortColumns maps the sort field of domain.ListOptions to a column.
+// Only these values are ever put into the ORDER BY of a query template.
+var sortColumns = map[string]string{
+	"id":         "id",
+	"title":      "title",
+	"created_at": "created_at",
+}
+
+// likeEscaper escapes the wildcards of a LIKE pattern, the list query declares \ as its escape character.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// escapeLike makes user input match literally inside a LIKE pattern.
+func escapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
+
+// sortOrders maps the sort order of domain.ListOptions to SQL.
+var sortOrders = map[string]string{
+	domain.SortOrderAsc:  "ASC",
+	domain.SortOrderDesc: "DESC",
+}
@@ -1,77 +1,126 @@
 package pgtodo
 
 import (
+	"errors"
+	"strings"
 	"testing"
 
 	"github.com/macesz/todo-go/pkg"
 )
 
-func TestTemplate(t *testing.T) {
+// TestTemplates checks that every query renders and targets the expected table with the expected named params
+func TestTemplates(t *testing.T) {
 	queries, err := pkg.BuildQueries(files, "queries")
 	if err != nil {
-		t.Error(err)
+		t.Fatal(err)
 	}
 
-	query, err := pkg.PrepareQuery(queries["list_todo"], nil)
-	if err != nil {
-		t.Error(err)
+	tests := []struct {
+		name  string
+		query string
+		parts []string
+	}{
+		{name: "list", query: listTodoQuery, parts: []string{"FROM todos", ":user_id", ":todolist_id", "deleted_at IS NULL", "AS todolist_color"}},
+		{name: "create", query: createTodoQuery, parts: []string{"INSERT INTO todos", ":user_id", ":todolist_id", ":title", ":done", ":due_date", ":color", "RETURNING id, public_id", "AS todolist_color"}},
+		{name: "get", query: getTodoQuery, parts: []string{"FROM todos", ":id", "todos.due_date", "todos.color", "todolists.color AS todolist_color"}},
+		{name: "update", query: updateTodoQuery, parts: []string{"UPDATE todos", ":title", ":done", ":due_date", ":color", ":updated_at", ":id"}},
+		{name: "delete", query: deleteTodoQuery, parts: []string{"DELETE FROM todos", ":id"}},
+		{name: "list overdue", query: listOverdueQuery, parts: []string{"FROM todos", ":user_id", "todos.done = false", "todos.due_date < :now", "todos.deleted_at IS NULL"}},
+		{name: "set done", query: setDoneQuery, parts: []string{"UPDATE todos", ":done", ":updated_at", ":id", ":user_id", "deleted_at IS NULL"}},
+		{name: "trash done", query: trashDoneQuery, parts: []string{"UPDATE todos", ":deleted_at", ":user_id", ":todolist_id", "done = true", "deleted_at IS NULL"}},
+		{name: "delete done", query: deleteDoneQuery, parts: []string{"DELETE FROM todos", ":user_id", ":todolist_id", "done = true", "deleted_at IS NULL"}},
+		{name: "get trashed", query: getTrashedQuery, parts: []string{"FROM todos", ":user_id", ":public_id", "deleted_at IS NOT NULL"}},
+		{name: "restore", query: restoreQuery, parts: []string{"UPDATE todos", "deleted_at = NULL", ":id", ":todolist_id"}},
+		{name: "purge trash", query: purgeTrashQuery, parts: []string{"DELETE FROM todos", "deleted_at IS NOT NULL", ":before"}},
 	}
 
-	t.Log(query)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := pkg.PrepareQuery(queries[tt.query], nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, part := range tt.parts {
+				if !strings.Contains(query, part) {
+					t.Errorf("query is missing %q:\n%s", part, query)
+				}
+			}
+		})
+	}
 }
 
-func TestTemplateCreate(t *testing.T) {
+func TestTemplateListWithOptions(t *testing.T) {
 	queries, err := pkg.BuildQueries(files, "queries")
 	if err != nil {
 		t.Error(err)
 	}
 
-	query, err := pkg.PrepareQuery(queries["create_todo"], nil)
+	query, err := pkg.PrepareQuery(queries["list_todo"], map[string]any{
+		"sort":        sortColumns["title"],
+		"order":       sortOrders["desc"],
+		"filterDone":  true,
+		"filterQuery": true,
+		"limit":       true,
+		"offset":      true,
+	})
 	if err != nil {
 		t.Error(err)
 	}
 
+	for _, part := range []string{"done = :done", `title ILIKE :query ESCAPE '\'`, "ORDER BY title DESC", "LIMIT :limit", "OFFSET :offset"} {
+		if !strings.Contains(query, part) {
+			t.Errorf("query is missing %q:\n%s", part, query)
+		}
+	}
+
 	t.Log(query)
 }
 
-func TestTemplateGetTodo(t *testing.T) {
+func TestTemplateListWithoutOptions(t *testing.T) {
 	queries, err := pkg.BuildQueries(files, "queries")
 	if err != nil {
-		t.Error(err)
+		t.Fatal(err)
 	}
 
-	query, err := pkg.PrepareQuery(queries["get_todo"], nil)
+	query, err := pkg.PrepareQuery(queries[listTodoQuery], nil)
 	if err != nil {
-		t.Error(err)
+		t.Fatal(err)
 	}
 
-	t.Log(query)
+	for _, part := range []string{"done = :done", "ILIKE", "LIMIT", "OFFSET", "updated_at >="} {
+		if strings.Contains(query, part) {
+			t.Errorf("query has %q without the option:\n%s", part, query)
+		}
+	}
+	if !strings.Contains(query, "ORDER BY created_at, id") {
+		t.Errorf("query is missing the default order:\n%s", query)
+	}
 }
 
-func TestTemplateDeleteTodo(t *testing.T) {
+func TestTemplateUnknownName(t *testing.T) {
 	queries, err := pkg.BuildQueries(files, "queries")
 	if err != nil {
-		t.Error(err)
+		t.Fatal(err)
 	}
 
-	query, err := pkg.PrepareQuery(queries["delete_todo"], nil)
-	if err != nil {
-		t.Error(err)
+	_, err = pkg.PrepareQuery(queries["list_todos"], nil)
+	if !errors.Is(err, pkg.ErrNoQueryTemplate) {
+		t.Errorf("expected ErrNoQueryTemplate, got %v", err)
 	}
-
-	t.Log(query)
 }
 
-func TestTemplateUpdateTodo(t *testing.T) {
-	queries, err := pkg.BuildQueries(files, "queries")
-	if err != nil {
-		t.Error(err)
+func TestEscapeLike(t *testing.T) {
+	tests := map[string]string{
+		"milk":    "milk",
+		"100%":    `100\%`,
+		"to_do":   `to\_do`,
+		`back\up`: `back\\up`,
 	}
 
-	query, err := pkg.PrepareQuery(queries["update_todo"], nil)
-	if err != nil {
-		t.Error(err)
+	for input, want := range tests {
+		if got := escapeLike(input); got != want {
+			t.Errorf("escapeLike(%q) = %q, want %q", input, got, want)
+		}
 	}
-
-	t.Log(query)
 }
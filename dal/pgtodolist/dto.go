@@ -9,22 +9,44 @@ import (
 
 type rowDTO struct {
 	ID        int64     `db:"id"`
+	PublicID  string    `db:"public_id"`
 	UserID    int64     `db:"user_id"`
 	Title     string    `db:"title"`
 	Color     string    `db:"color"`
 	Labels    string    `db:"labels"`
 	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
 	Deleted   bool      `db:"deleted"`
+	Pinned    bool      `db:"pinned"`
+	Version   int64     `db:"version"`
+
+	NextDue *time.Time `db:"next_due"` // Only selected by the list query
 }
 
 func (r rowDTO) ToDomain() *domain.TodoList {
 	return &domain.TodoList{
 		ID:        r.ID,
+		PublicID:  r.PublicID,
 		UserID:    r.UserID,
 		Title:     r.Title,
 		Color:     r.Color,
 		Labels:    strings.Split(r.Labels, ","),
-		CreatedAt: r.CreatedAt,
+		CreatedAt: r.CreatedAt.Truncate(time.Microsecond),
+		UpdatedAt: r.UpdatedAt.Truncate(time.Microsecond),
 		Deleted:   r.Deleted,
+		Pinned:    r.Pinned,
+		Version:   r.Version,
+		NextDue:   truncate(r.NextDue),
+	}
+}
+
+// truncate cuts a scanned time down to microseconds, the precision Postgres keeps
+func truncate(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
 	}
+
+	truncated := t.Truncate(time.Microsecond)
+
+	return &truncated
 }
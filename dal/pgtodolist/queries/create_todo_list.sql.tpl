@@ -1,3 +1,3 @@
-INSERT INTO todolists (user_id, title, color, labels, created_at)
-VALUES (:user_id, :title, :color, :labels, :created_at)
-RETURNING id;
+INSERT INTO todolists (user_id, title, color, labels, created_at, updated_at)
+VALUES (:user_id, :title, :color, :labels, :created_at, :updated_at)
+RETURNING id, public_id, version;
@@ -0,0 +1,4 @@
+INSERT INTO todolists (user_id, title, color, labels, created_at, updated_at)
+VALUES (:user_id, :title, :color, :labels, :created_at, :updated_at)
+ON CONFLICT (user_id, title) WHERE NOT deleted DO NOTHING
+RETURNING id, public_id, version;
@@ -0,0 +1,7 @@
+SELECT * FROM todolists
+WHERE
+    user_id = :user_id
+    AND
+    title = :title
+    AND
+    NOT deleted
@@ -1,3 +1,21 @@
-SELECT * FROM todolists
+SELECT
+    todolists.*,
+    (
+        SELECT MIN(todos.due_date) FILTER (WHERE NOT todos.done AND todos.due_date >= :now)
+        FROM todos
+        WHERE todos.todolist_id = todolists.id AND todos.deleted_at IS NULL
+    ) AS next_due
+FROM todolists
 WHERE
     user_id = :user_id
+{{- if .filterUpdatedSince }}
+    AND
+    updated_at >= :updated_since
+{{- end }}
+ORDER BY pinned DESC, {{ if .sort }}{{ .sort }} {{ .order }}, {{ end }}id
+{{- if .limit }}
+LIMIT :limit
+{{- end }}
+{{- if .offset }}
+OFFSET :offset
+{{- end }}
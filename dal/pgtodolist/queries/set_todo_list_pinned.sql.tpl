@@ -0,0 +1,4 @@
+UPDATE todolists
+SET pinned = :pinned, updated_at = :updated_at, version = version + 1
+WHERE
+    id = :id;
@@ -1,4 +1,6 @@
 UPDATE todolists
-SET title = :title, color = :color, labels = :labels, deleted = :deleted
+SET title = :title, color = :color, labels = :labels, deleted = :deleted, updated_at = :updated_at, version = version + 1
 WHERE
-    id = :id;
+    id = :id
+    AND
+    version = :version;
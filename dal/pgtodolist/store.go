@@ -6,8 +6,10 @@ import (
 	"errors"
 	"strings"
 	"text/template"
+	"time"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/macesz/todo-go/domain"
 	"github.com/macesz/todo-go/pkg"
 )
@@ -31,15 +33,23 @@ func CreateStore(db *sqlx.DB) *Store {
 	}
 }
 
-func (s *Store) List(ctx context.Context, userID int64) ([]*domain.TodoList, error) {
+// List returns the user's lists, each with the earliest due date of its open todos due at or after now
+func (s *Store) List(ctx context.Context, userID int64, opts domain.ListOptions, now time.Time) ([]*domain.TodoList, error) {
 	todoLists := make([]*domain.TodoList, 0)
 
 	// Template parameters are not safe to use directly in the query, because they can be used to inject SQL code.
 	// I can use anything that is not a user input, like Table Name, Column Name, etc.
-	templateParams := map[string]any{}
+	// The sort column and order come from a whitelist, so they are safe here.
+	templateParams := map[string]any{
+		"sort":               sortColumns[opts.Sort],
+		"order":              sortOrders[opts.Order],
+		"filterUpdatedSince": opts.UpdatedSince != nil,
+		"limit":              opts.Limit > 0,
+		"offset":             opts.Offset > 0,
+	}
 
 	// Prepare the query string, by using the template.
-	querystr, err := pkg.PrepareQuery(s.queryTemplates[listTodoListQuery], templateParams)
+	querystr, err := pkg.PrepareNamedQuery(s.queryTemplates, listTodoListQuery, templateParams)
 	if err != nil {
 		return nil, err
 	}
@@ -48,6 +58,13 @@ func (s *Store) List(ctx context.Context, userID int64) ([]*domain.TodoList, err
 	// This is safe to use directly in the query, because it uses named parameters.
 	queryParams := map[string]any{
 		"user_id": userID,
+		"limit":   opts.Limit,
+		"offset":  opts.Offset,
+		"now":     now,
+	}
+
+	if opts.UpdatedSince != nil {
+		queryParams["updated_since"] = *opts.UpdatedSince
 	}
 
 	// Execute the query. You can add parameters to the query if needed instead of using nil.
@@ -76,7 +93,7 @@ func (s *Store) List(ctx context.Context, userID int64) ([]*domain.TodoList, err
 func (s *Store) GetListByID(ctx context.Context, id int64) (*domain.TodoList, error) {
 	templateParams := map[string]any{}
 
-	querystr, err := pkg.PrepareQuery(s.queryTemplates[getTodoListQuery], templateParams)
+	querystr, err := pkg.PrepareNamedQuery(s.queryTemplates, getTodoListQuery, templateParams)
 	if err != nil {
 		return nil, err
 	}
@@ -106,10 +123,45 @@ func (s *Store) GetListByID(ctx context.Context, id int64) (*domain.TodoList, er
 	return row.ToDomain(), nil
 }
 
+// IDByPublicID resolves the public id of one of the user's lists to its internal id.
+func (s *Store) IDByPublicID(ctx context.Context, userID int64, publicID string) (int64, error) {
+	templateParams := map[string]any{}
+
+	querystr, err := pkg.PrepareNamedQuery(s.queryTemplates, idByPublicIDQuery, templateParams)
+	if err != nil {
+		return 0, err
+	}
+
+	queryParams := map[string]any{
+		"user_id":   userID,
+		"public_id": publicID,
+	}
+
+	rows, err := s.db.NamedQueryContext(ctx, querystr, queryParams)
+	if err != nil {
+		return 0, err
+	}
+
+	defer rows.Close()
+
+	var id int64
+
+	if rows.Next() {
+		if err := rows.Scan(&id); err != nil {
+			return 0, err
+		}
+	} else {
+		// Return sql.ErrNoRows so the service layer can handle it properly
+		return 0, sql.ErrNoRows
+	}
+
+	return id, nil
+}
+
 func (s *Store) Create(ctx context.Context, todoList *domain.TodoList) error {
 	templateParams := map[string]any{}
 
-	querystr, err := pkg.PrepareQuery(s.queryTemplates[createTodoListQuery], templateParams)
+	querystr, err := pkg.PrepareNamedQuery(s.queryTemplates, createTodoListQuery, templateParams)
 	if err != nil {
 		return err
 	}
@@ -120,20 +172,26 @@ func (s *Store) Create(ctx context.Context, todoList *domain.TodoList) error {
 		"color":      todoList.Color,
 		"labels":     strings.Join(todoList.Labels, ","),
 		"created_at": todoList.CreatedAt,
+		"updated_at": todoList.UpdatedAt,
 	}
 
 	result, err := s.db.NamedQueryContext(ctx, querystr, queryParams)
 	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" { // "23505" = unique_violation
+			return domain.ErrDuplicate
+		}
 		return err
 	}
 	defer result.Close()
 
 	var (
-		id int64
+		id       int64
+		publicID string
+		version  int64
 	)
 
 	if result.Next() {
-		err = result.Scan(&id)
+		err = result.Scan(&id, &publicID, &version)
 		if err != nil {
 			return err
 		}
@@ -143,28 +201,37 @@ func (s *Store) Create(ctx context.Context, todoList *domain.TodoList) error {
 
 	// Create a new Todo instance with the retrieved ID and other fields
 	todoList.ID = id
+	todoList.PublicID = publicID
+	todoList.Version = version
 
 	return nil
 }
 
-func (s *Store) Update(ctx context.Context, id int64, title string, color string, labels []string, deleted bool) (*domain.TodoList, error) {
+// Update changes the list if it is still at the given version, and bumps the version.
+// It returns sql.ErrNoRows when the list is missing or at another version.
+func (s *Store) Update(ctx context.Context, id int64, version int64, title string, color string, labels []string, deleted bool, updatedAt time.Time) (*domain.TodoList, error) {
 	templateParams := map[string]any{}
 
-	querystr, err := pkg.PrepareQuery(s.queryTemplates[updateTodoListQuery], templateParams)
+	querystr, err := pkg.PrepareNamedQuery(s.queryTemplates, updateTodoListQuery, templateParams)
 	if err != nil {
 		return nil, err
 	}
 
 	queryParams := map[string]any{
-		"id":      id,
-		"title":   title,
-		"color":   color,
-		"labels":  strings.Join(labels, ","),
-		"deleted": deleted,
+		"id":         id,
+		"version":    version,
+		"title":      title,
+		"color":      color,
+		"labels":     strings.Join(labels, ","),
+		"deleted":    deleted,
+		"updated_at": updatedAt,
 	}
 
 	result, err := s.db.NamedExecContext(ctx, querystr, queryParams)
 	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" { // "23505" = unique_violation
+			return nil, domain.ErrDuplicate
+		}
 		return nil, err
 	}
 
@@ -182,10 +249,128 @@ func (s *Store) Update(ctx context.Context, id int64, title string, color string
 	return s.GetListByID(ctx, id)
 }
 
+// SetPinned pins or unpins a list, pinned lists are listed first.
+func (s *Store) SetPinned(ctx context.Context, id int64, pinned bool, updatedAt time.Time) (*domain.TodoList, error) {
+	templateParams := map[string]any{}
+
+	querystr, err := pkg.PrepareNamedQuery(s.queryTemplates, setPinnedQuery, templateParams)
+	if err != nil {
+		return nil, err
+	}
+
+	queryParams := map[string]any{
+		"id":         id,
+		"pinned":     pinned,
+		"updated_at": updatedAt,
+	}
+
+	result, err := s.db.NamedExecContext(ctx, querystr, queryParams)
+	if err != nil {
+		return nil, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+
+	if rowsAffected == 0 {
+		// Return sql.ErrNoRows so the service layer can handle it properly
+		return nil, sql.ErrNoRows
+	}
+
+	return s.GetListByID(ctx, id)
+}
+
+// GetOrCreate inserts the list unless the user already has one with the same title.
+// It returns the stored list and whether it was created by this call.
+func (s *Store) GetOrCreate(ctx context.Context, todoList *domain.TodoList) (*domain.TodoList, bool, error) {
+	templateParams := map[string]any{}
+
+	querystr, err := pkg.PrepareNamedQuery(s.queryTemplates, createIfAbsentQuery, templateParams)
+	if err != nil {
+		return nil, false, err
+	}
+
+	queryParams := map[string]any{
+		"user_id":    todoList.UserID,
+		"title":      todoList.Title,
+		"color":      todoList.Color,
+		"labels":     strings.Join(todoList.Labels, ","),
+		"created_at": todoList.CreatedAt,
+		"updated_at": todoList.UpdatedAt,
+	}
+
+	result, err := s.db.NamedQueryContext(ctx, querystr, queryParams)
+	if err != nil {
+		return nil, false, err
+	}
+	defer result.Close()
+
+	// ON CONFLICT DO NOTHING returns no row when the list already exists
+	if result.Next() {
+		var (
+			id       int64
+			publicID string
+			version  int64
+		)
+		if err := result.Scan(&id, &publicID, &version); err != nil {
+			return nil, false, err
+		}
+
+		todoList.ID = id
+		todoList.PublicID = publicID
+		todoList.Version = version
+
+		return todoList, true, nil
+	}
+
+	existing, err := s.getByTitle(ctx, todoList.UserID, todoList.Title)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return existing, false, nil
+}
+
+func (s *Store) getByTitle(ctx context.Context, userID int64, title string) (*domain.TodoList, error) {
+	templateParams := map[string]any{}
+
+	querystr, err := pkg.PrepareNamedQuery(s.queryTemplates, getByTitleQuery, templateParams)
+	if err != nil {
+		return nil, err
+	}
+
+	queryParams := map[string]any{
+		"user_id": userID,
+		"title":   title,
+	}
+
+	var row rowDTO
+	rows, err := s.db.NamedQueryContext(ctx, querystr, queryParams)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	if rows.Next() {
+		err = rows.StructScan(&row)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		// Return sql.ErrNoRows so the service layer can handle it properly
+		return nil, sql.ErrNoRows
+	}
+
+	return row.ToDomain(), nil
+}
+
 func (s *Store) Delete(ctx context.Context, id int64) error {
 	templateParams := map[string]any{}
 
-	querystr, err := pkg.PrepareQuery(s.queryTemplates[deleteTodoListQuery], templateParams)
+	querystr, err := pkg.PrepareNamedQuery(s.queryTemplates, deleteTodoListQuery, templateParams)
 	if err != nil {
 		return err
 	}
@@ -2,6 +2,8 @@ package pgtodolist
 
 import (
 	"embed"
+
+	"github.com/macesz/todo-go/domain"
 )
 
 //go:embed queries/*.sql.tpl
@@ -13,4 +15,22 @@ const (
 	getTodoListQuery    = "get_todo_list"
 	updateTodoListQuery = "update_todo_list"
 	deleteTodoListQuery = "delete_todo_list"
+	setPinnedQuery      = "set_todo_list_pinned"
+	createIfAbsentQuery = "create_todo_list_if_absent"
+	getByTitleQuery     = "get_todo_list_by_title"
+	idByPublicIDQuery   = "todo_list_id_by_public_id"
 )
+
+// sortColumns maps the sort field of domain.ListOptions to a column.
+// Only these values are ever put into the ORDER BY of a query template.
+var sortColumns = map[string]string{
+	"id":         "id",
+	"title":      "title",
+	"created_at": "created_at",
+}
+
+// sortOrders maps the sort order of domain.ListOptions to SQL.
+var sortOrders = map[string]string{
+	domain.SortOrderAsc:  "ASC",
+	domain.SortOrderDesc: "DESC",
+}
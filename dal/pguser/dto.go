@@ -7,12 +7,14 @@ type rowDTO struct {
 	Email    string `db:"email"`
 	Name     string `db:"name"`
 	Password string `db:"password"`
+	Timezone string `db:"timezone"`
 }
 
 func (r rowDTO) ToDomain() *domain.User {
 	return &domain.User{
-		ID:    r.ID,
-		Email: r.Email,
-		Name:  r.Name,
+		ID:       r.ID,
+		Email:    r.Email,
+		Name:     r.Name,
+		Timezone: r.Timezone,
 	}
 }
@@ -0,0 +1,4 @@
+UPDATE users
+SET name = :name, email = :email, timezone = COALESCE(NULLIF(:timezone, ''), timezone)
+WHERE id = :id
+RETURNING *;
@@ -33,7 +33,7 @@ func CreateStore(db *sqlx.DB) *Store {
 func (s *Store) CreateUser(ctx context.Context, user *domain.User) (*domain.User, error) {
 	templateParams := map[string]any{}
 
-	querystr, err := pkg.PrepareQuery(s.queryTemplates[createUserQuery], templateParams)
+	querystr, err := pkg.PrepareNamedQuery(s.queryTemplates, createUserQuery, templateParams)
 	if err != nil {
 		return nil, err
 	}
@@ -71,16 +71,17 @@ func (s *Store) CreateUser(ctx context.Context, user *domain.User) (*domain.User
 	}
 
 	createdUser := &domain.User{
-		ID:    id,
-		Name:  user.Name,
-		Email: user.Email,
+		ID:       id,
+		Name:     user.Name,
+		Email:    user.Email,
+		Timezone: domain.DefaultTimezone, // The column default
 	}
 	return createdUser, nil
 }
 
 func (s *Store) GetUser(ctx context.Context, id int64) (*domain.User, error) {
 
-	querystr, err := pkg.PrepareQuery(s.queryTemplates[getUserQuery], nil)
+	querystr, err := pkg.PrepareNamedQuery(s.queryTemplates, getUserQuery, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -112,7 +113,7 @@ func (s *Store) GetUser(ctx context.Context, id int64) (*domain.User, error) {
 
 // get user by email for duplicate check
 func (s *Store) GetUserByEmail(ctx context.Context, email string) (*domain.User, error) {
-	querystr, err := pkg.PrepareQuery(s.queryTemplates[getUserByEmailQuery], nil)
+	querystr, err := pkg.PrepareNamedQuery(s.queryTemplates, getUserByEmailQuery, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -144,7 +145,7 @@ func (s *Store) GetUserByEmail(ctx context.Context, email string) (*domain.User,
 // Login user
 func (s *Store) Login(ctx context.Context, email, password string) (*domain.User, error) {
 
-	querystr, err := pkg.PrepareQuery(s.queryTemplates[loginUserQuery], nil)
+	querystr, err := pkg.PrepareNamedQuery(s.queryTemplates, loginUserQuery, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -184,7 +185,7 @@ func (s *Store) Login(ctx context.Context, email, password string) (*domain.User
 // deleteUserQuery
 func (s *Store) DeleteUser(ctx context.Context, id int64) error {
 
-	querystr, err := pkg.PrepareQuery(s.queryTemplates[deleteUserQuery], nil)
+	querystr, err := pkg.PrepareNamedQuery(s.queryTemplates, deleteUserQuery, nil)
 	if err != nil {
 		return err
 	}
@@ -209,3 +210,42 @@ func (s *Store) DeleteUser(ctx context.Context, id int64) error {
 
 	return nil
 }
+
+// UpdateProfile changes the name, email and timezone of the user and returns the updated user.
+// An empty timezone keeps the current one. An email another user already has is ErrDuplicate.
+func (s *Store) UpdateProfile(ctx context.Context, id int64, name, email, timezone string) (*domain.User, error) {
+	querystr, err := pkg.PrepareNamedQuery(s.queryTemplates, updateProfileQuery, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	queryParams := map[string]any{
+		"id":       id,
+		"name":     name,
+		"email":    email,
+		"timezone": timezone,
+	}
+
+	result, err := s.db.NamedQueryContext(ctx, querystr, queryParams)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" { // "23505" = unique_violation
+			return nil, domain.ErrDuplicate
+		}
+		return nil, fmt.Errorf("db update profile: %w", err)
+	}
+
+	defer result.Close()
+
+	var row rowDTO
+
+	if !result.Next() {
+		return nil, domain.ErrUserNotFound
+	}
+
+	err = result.StructScan(&row)
+	if err != nil {
+		return nil, err
+	}
+
+	return row.ToDomain(), nil
+}
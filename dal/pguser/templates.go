@@ -13,4 +13,5 @@ const (
 	getUserByEmailQuery = "get_user_by_email"
 	deleteUserQuery     = "delete_user"
 	loginUserQuery      = "login_user"
+	updateProfileQuery  = "update_profile"
 )
@@ -0,0 +1,207 @@
+// Package rediscache is a small Redis client covering what the cached stores need: GET, SET with a ttl and DEL
+// It speaks RESP over one connection, which is plenty for a cache in front of Postgres
+package rediscache
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// DefaultTimeout bounds a command when the context has no deadline
+const DefaultTimeout = time.Second
+
+// Error is an error reply of the server, like "ERR unknown command"
+type Error string
+
+func (e Error) Error() string {
+	return "redis: " + string(e)
+}
+
+// Client is a Redis connection, safe for concurrent use
+// Commands are serialized on the connection, it is redialed after a network error
+type Client struct {
+	addr string
+
+	mu   sync.Mutex
+	conn net.Conn
+	rd   *bufio.Reader
+}
+
+// NewClient creates a client for the server at addr, like "localhost:6379"
+// The connection is opened on the first command
+func NewClient(addr string) *Client {
+	return &Client{addr: addr}
+}
+
+// Get returns the value of key, found is false when the key does not exist
+func (c *Client) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	reply, err := c.do(ctx, "GET", key)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if reply == nil {
+		return nil, false, nil
+	}
+
+	value, ok := reply.([]byte)
+	if !ok {
+		return nil, false, fmt.Errorf("redis: unexpected GET reply %T", reply)
+	}
+
+	return value, true, nil
+}
+
+// Set stores value under key, it expires after ttl
+func (c *Client) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	_, err := c.do(ctx, "SET", key, string(value), "PX", strconv.FormatInt(ttl.Milliseconds(), 10))
+	return err
+}
+
+// Delete removes the keys, missing keys are ignored
+func (c *Client) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	_, err := c.do(ctx, append([]string{"DEL"}, keys...)...)
+	return err
+}
+
+// Close closes the connection, the next command dials again
+func (c *Client) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.conn == nil {
+		return nil
+	}
+
+	err := c.conn.Close()
+	c.conn = nil
+	c.rd = nil
+
+	return err
+}
+
+func (c *Client) do(ctx context.Context, args ...string) (any, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.conn == nil {
+		var d net.Dialer
+		conn, err := d.DialContext(ctx, "tcp", c.addr)
+		if err != nil {
+			return nil, err
+		}
+
+		c.conn = conn
+		c.rd = bufio.NewReader(conn)
+	}
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(DefaultTimeout)
+	}
+	if err := c.conn.SetDeadline(deadline); err != nil {
+		return nil, c.drop(err)
+	}
+
+	if _, err := c.conn.Write(encodeCommand(args)); err != nil {
+		return nil, c.drop(err)
+	}
+
+	reply, err := readReply(c.rd)
+	if err != nil {
+		var replyErr Error
+		if errors.As(err, &replyErr) {
+			return nil, err
+		}
+		return nil, c.drop(err)
+	}
+
+	return reply, nil
+}
+
+// drop closes a connection that is out of sync after a network error
+func (c *Client) drop(err error) error {
+	c.conn.Close()
+	c.conn = nil
+	c.rd = nil
+
+	return err
+}
+
+// encodeCommand writes args as a RESP array of bulk strings
+func encodeCommand(args []string) []byte {
+	buf := fmt.Appendf(nil, "*%d\r\n", len(args))
+	for _, arg := range args {
+		buf = fmt.Appendf(buf, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+
+	return buf
+}
+
+// readReply reads one RESP reply
+// Simple strings come back as string, integers as int64, bulk strings as []byte and arrays as []any
+// A nil bulk string or array comes back as nil
+func readReply(rd *bufio.Reader) (any, error) {
+	line, err := rd.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	if len(line) < 3 || line[len(line)-2] != '\r' {
+		return nil, fmt.Errorf("redis: malformed reply %q", line)
+	}
+	line = line[:len(line)-2]
+
+	switch line[0] {
+	case '+':
+		return line[1:], nil
+	case '-':
+		return nil, Error(line[1:])
+	case ':':
+		return strconv.ParseInt(line[1:], 10, 64)
+	case '$':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, fmt.Errorf("redis: malformed bulk length %q", line)
+		}
+		if n < 0 {
+			return nil, nil
+		}
+
+		data := make([]byte, n+2)
+		if _, err := io.ReadFull(rd, data); err != nil {
+			return nil, err
+		}
+
+		return data[:n], nil
+	case '*':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, fmt.Errorf("redis: malformed array length %q", line)
+		}
+		if n < 0 {
+			return nil, nil
+		}
+
+		items := make([]any, n)
+		for i := range items {
+			if items[i], err = readReply(rd); err != nil {
+				return nil, err
+			}
+		}
+
+		return items, nil
+	default:
+		return nil, fmt.Errorf("redis: unknown reply type %q", line)
+	}
+}
@@ -0,0 +1,113 @@
+package rediscache
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeCommand(t *testing.T) {
+	got := encodeCommand([]string{"SET", "todo:1", "{}", "PX", "1000"})
+	require.Equal(t, "*5\r\n$3\r\nSET\r\n$6\r\ntodo:1\r\n$2\r\n{}\r\n$2\r\nPX\r\n$4\r\n1000\r\n", string(got))
+}
+
+func TestReadReply(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    any
+		wantErr bool
+	}{
+		{name: "simple string", input: "+OK\r\n", want: "OK"},
+		{name: "integer", input: ":2\r\n", want: int64(2)},
+		{name: "bulk string", input: "$5\r\nhello\r\n", want: []byte("hello")},
+		{name: "nil bulk string", input: "$-1\r\n", want: nil},
+		{name: "array", input: "*2\r\n:1\r\n$1\r\na\r\n", want: []any{int64(1), []byte("a")}},
+		{name: "error", input: "-ERR wrong\r\n", wantErr: true},
+		{name: "unknown type", input: "?x\r\n", wantErr: true},
+		{name: "truncated", input: "$5\r\nhel", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := readReply(bufio.NewReader(strings.NewReader(tc.input)))
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
+
+// TestClientRoundTrip runs the client against a fake server that answers a scripted conversation
+func TestClientRoundTrip(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("cannot listen: %v", err)
+	}
+	defer ln.Close()
+
+	script := []struct{ command, reply string }{
+		{command: string(encodeCommand([]string{"GET", "todo:1"})), reply: "$-1\r\n"},
+		{command: string(encodeCommand([]string{"SET", "todo:1", "x", "PX", "60000"})), reply: "+OK\r\n"},
+		{command: string(encodeCommand([]string{"GET", "todo:1"})), reply: "$1\r\nx\r\n"},
+		{command: string(encodeCommand([]string{"DEL", "todo:1", "todo:2"})), reply: ":1\r\n"},
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			done <- err
+			return
+		}
+		defer conn.Close()
+
+		rd := bufio.NewReader(conn)
+		for _, step := range script {
+			buf := make([]byte, len(step.command))
+			if _, err := io.ReadFull(rd, buf); err != nil {
+				done <- err
+				return
+			}
+			if string(buf) != step.command {
+				done <- fmt.Errorf("got command %q, want %q", buf, step.command)
+				return
+			}
+			if _, err := conn.Write([]byte(step.reply)); err != nil {
+				done <- err
+				return
+			}
+		}
+		done <- nil
+	}()
+
+	ctx := context.Background()
+	c := NewClient(ln.Addr().String())
+	defer c.Close()
+
+	_, found, err := c.Get(ctx, "todo:1")
+	require.NoError(t, err)
+	require.False(t, found)
+
+	require.NoError(t, c.Set(ctx, "todo:1", []byte("x"), time.Minute))
+
+	value, found, err := c.Get(ctx, "todo:1")
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, []byte("x"), value)
+
+	require.NoError(t, c.Delete(ctx, "todo:1", "todo:2"))
+
+	require.NoError(t, <-done)
+}
@@ -7,10 +7,19 @@ import (
 	"github.com/go-chi/jwtauth/v5"
 	"github.com/macesz/todo-go/domain"
 )
+
+// MinSecretLength is the shortest JWT secret CreateTokenAuth accepts
+const MinSecretLength = 16
+
 // CreateTokenAuth - Initialize JWT Auth with given secret, factory function
-func CreateTokenAuth(secret string) *jwtauth.JWTAuth {
+// An empty or too short secret is rejected, otherwise every token would be signed with a guessable key
+func CreateTokenAuth(secret string) (*jwtauth.JWTAuth, error) {
+	if len(secret) < MinSecretLength {
+		return nil, domain.ErrWeakJWTSecret
+	}
+
 	// JWT Auth setup with HS256 and secret from config
-	return jwtauth.New("HS256", []byte(secret), nil)
+	return jwtauth.New("HS256", []byte(secret), nil), nil
 }
 
 // JWT Claims struct, made private to the auth package -> encapsulation
@@ -0,0 +1,38 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/macesz/todo-go/domain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateTokenAuth(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		secret  string
+		wantErr error
+	}{
+		{name: "empty secret", secret: "", wantErr: domain.ErrWeakJWTSecret},
+		{name: "too short secret", secret: "short", wantErr: domain.ErrWeakJWTSecret},
+		{name: "valid secret", secret: "my-super-secret-test-key-12345"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tokenAuth, err := CreateTokenAuth(tc.secret)
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+				require.Nil(t, tokenAuth)
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, tokenAuth)
+		})
+	}
+}
@@ -0,0 +1,11 @@
+package dashboard
+
+type DashboardHandlers struct {
+	dashboardService DashboardService
+}
+
+func NewHandlers(dashboardService DashboardService) *DashboardHandlers {
+	return &DashboardHandlers{
+		dashboardService: dashboardService,
+	}
+}
@@ -0,0 +1,32 @@
+package dashboard
+
+import (
+	"net/http"
+
+	"github.com/macesz/todo-go/delivery/web/auth"
+	"github.com/macesz/todo-go/delivery/web/utils"
+	"github.com/macesz/todo-go/domain"
+)
+
+// GetDashboard handles GET /dashboard requests.
+func (h *DashboardHandlers) GetDashboard(w http.ResponseWriter, r *http.Request) {
+	user, ok := auth.UserFromContext(r.Context())
+	if !ok {
+		utils.WriteJSON(w, r, http.StatusForbidden, domain.ErrorResponse{Error: "missing user"})
+		return
+	}
+
+	dashboard, err := h.dashboardService.GetDashboard(r.Context(), user.ID)
+	if err != nil {
+		utils.WriteError(w, r, err)
+		return
+	}
+
+	respDashboard := domain.DashboardDTO{
+		TotalLists: dashboard.TotalLists,
+		TotalTodos: dashboard.TotalTodos,
+		DoneCount:  dashboard.DoneCount,
+	}
+
+	utils.WriteJSON(w, r, http.StatusOK, respDashboard)
+}
@@ -0,0 +1,77 @@
+package dashboard
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/macesz/todo-go/delivery/web/auth"
+	"github.com/macesz/todo-go/delivery/web/dashboard/mocks"
+	"github.com/macesz/todo-go/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetDashboard(t *testing.T) {
+	testUserID := int64(1)
+
+	tests := []struct {
+		name           string
+		withUser       bool
+		mockReturn     *domain.Dashboard
+		mockError      error
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "Success",
+			withUser:       true,
+			mockReturn:     &domain.Dashboard{TotalLists: 2, TotalTodos: 5, DoneCount: 3},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"total_lists":2,"total_todos":5,"done_count":3}`,
+		},
+		{
+			name:           "Service error",
+			withUser:       true,
+			mockError:      errors.New("database error"),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"error":"internal server error"}`,
+		},
+		{
+			name:           "Missing user",
+			withUser:       false,
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   `{"error":"missing user"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := mocks.NewDashboardService(t)
+
+			if tt.withUser {
+				mockService.On("GetDashboard", mock.Anything, testUserID).
+					Return(tt.mockReturn, tt.mockError).
+					Once()
+			}
+
+			handlers := NewHandlers(mockService)
+
+			req, err := http.NewRequest(http.MethodGet, "/dashboard", nil)
+			require.NoError(t, err)
+
+			if tt.withUser {
+				userCtx := &auth.UserContext{ID: testUserID, Email: "test@example.com", Name: "Test User"}
+				req = req.WithContext(userCtx.AddToContext(req.Context()))
+			}
+
+			rr := httptest.NewRecorder()
+			handlers.GetDashboard(rr, req)
+
+			require.Equal(t, tt.expectedStatus, rr.Code)
+			assert.JSONEq(t, tt.expectedBody, rr.Body.String())
+		})
+	}
+}
@@ -0,0 +1,11 @@
+package dashboard
+
+import (
+	"context"
+
+	"github.com/macesz/todo-go/domain"
+)
+
+type DashboardService interface {
+	GetDashboard(ctx context.Context, userID int64) (*domain.Dashboard, error)
+}
@@ -0,0 +1,11 @@
+package export
+
+type ExportHandlers struct {
+	exportService ExportService
+}
+
+func NewHandlers(exportService ExportService) *ExportHandlers {
+	return &ExportHandlers{
+		exportService: exportService,
+	}
+}
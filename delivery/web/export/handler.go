@@ -0,0 +1,98 @@
+package export
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/macesz/todo-go/delivery/web/auth"
+	"github.com/macesz/todo-go/delivery/web/utils"
+	"github.com/macesz/todo-go/domain"
+	"github.com/macesz/todo-go/pkg/logctx"
+)
+
+// ExportUser handles GET /me/export requests.
+// The document is streamed straight into the response, see domain.ExportDocument for its shape.
+func (h *ExportHandlers) ExportUser(w http.ResponseWriter, r *http.Request) {
+	user, ok := auth.UserFromContext(r.Context())
+	if !ok {
+		utils.WriteJSON(w, r, http.StatusForbidden, domain.ErrorResponse{Error: "missing user"})
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", `attachment; filename="todo-export.json"`)
+
+	cw := &countingWriter{w: w}
+	if err := h.exportService.ExportUser(r.Context(), user.ID, cw); err != nil {
+		if cw.n == 0 {
+			utils.WriteError(w, r, err)
+			return
+		}
+
+		// The status is already sent, the client can only notice the truncated document
+		logctx.From(r.Context()).Error("export aborted mid-stream", "user_id", user.ID, "error", err)
+	}
+}
+
+// ImportUser handles POST /me/import requests.
+// The body is a document from GET /me/export, ?replace=true deletes the user's lists and todos first.
+func (h *ExportHandlers) ImportUser(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	user, ok := auth.UserFromContext(r.Context())
+	if !ok {
+		utils.WriteJSON(w, r, http.StatusForbidden, domain.ErrorResponse{Error: "missing user"})
+		return
+	}
+
+	replace := false
+	if value := r.URL.Query().Get("replace"); value != "" {
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			utils.WriteJSON(w, r, http.StatusBadRequest, domain.ErrorResponse{Error: "replace must be true or false"})
+			return
+		}
+		replace = b
+	}
+
+	var doc domain.ExportDocument
+	if err := json.NewDecoder(r.Body).Decode(&doc); err != nil {
+		utils.WriteJSON(w, r, http.StatusBadRequest, domain.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	result, err := h.exportService.ImportUser(r.Context(), user.ID, &doc, replace)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidInput) {
+			utils.WriteValidationError(w, r, err.Error())
+			return
+		}
+		if errors.Is(err, domain.ErrDuplicate) {
+			utils.WriteJSON(w, r, http.StatusConflict, domain.ErrorResponse{Error: "a list with this title already exists, import with replace=true to overwrite"})
+			return
+		}
+		utils.WriteError(w, r, err)
+		return
+	}
+
+	utils.WriteJSON(w, r, http.StatusCreated, result)
+}
+
+// countingWriter tells whether anything reached the response, and with it the 200 status
+type countingWriter struct {
+	w http.ResponseWriter
+	n int
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	// An empty write would still commit the 200 status
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	n, err := cw.w.Write(p)
+	cw.n += n
+	return n, err
+}
@@ -0,0 +1,208 @@
+package export
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/macesz/todo-go/delivery/web/auth"
+	"github.com/macesz/todo-go/delivery/web/export/mocks"
+	"github.com/macesz/todo-go/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportUser(t *testing.T) {
+	testUserID := int64(1)
+
+	tests := []struct {
+		name           string
+		withUser       bool
+		written        string // What the service writes before returning
+		mockError      error
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "Success",
+			withUser:       true,
+			written:        `{"version":1,"lists":[]}`,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"version":1,"lists":[]}`,
+		},
+		{
+			name:           "Service error before writing",
+			withUser:       true,
+			mockError:      errors.New("database error"),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"error":"internal server error"}`,
+		},
+		{
+			name:           "Service error mid-stream keeps the status",
+			withUser:       true,
+			written:        `{"version":1,"lists":[`,
+			mockError:      errors.New("database error"),
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"version":1,"lists":[`,
+		},
+		{
+			name:           "Missing user",
+			withUser:       false,
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   `{"error":"missing user"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := mocks.NewExportService(t)
+
+			if tt.withUser {
+				mockService.On("ExportUser", mock.Anything, testUserID, mock.Anything).
+					Run(func(args mock.Arguments) {
+						io.WriteString(args.Get(2).(io.Writer), tt.written)
+					}).
+					Return(tt.mockError).
+					Once()
+			}
+
+			handlers := NewHandlers(mockService)
+
+			req, err := http.NewRequest(http.MethodGet, "/me/export", nil)
+			require.NoError(t, err)
+
+			if tt.withUser {
+				userCtx := &auth.UserContext{ID: testUserID, Email: "test@example.com", Name: "Test User"}
+				req = req.WithContext(userCtx.AddToContext(req.Context()))
+			}
+
+			rr := httptest.NewRecorder()
+			handlers.ExportUser(rr, req)
+
+			require.Equal(t, tt.expectedStatus, rr.Code)
+			assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+			assert.Equal(t, tt.expectedBody, strings.TrimSuffix(rr.Body.String(), "\n"))
+		})
+	}
+}
+
+func TestImportUser(t *testing.T) {
+	testUserID := int64(1)
+
+	tests := []struct {
+		name           string
+		withUser       bool
+		query          string
+		inputBody      string
+		expectCall     bool
+		wantReplace    bool
+		mockReturn     *domain.ImportResult
+		mockError      error
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "Success",
+			withUser:       true,
+			inputBody:      `{"version":1,"lists":[{"title":"Groceries","todos":[{"title":"Buy milk"}]}]}`,
+			expectCall:     true,
+			mockReturn:     &domain.ImportResult{Lists: 1, Todos: 1},
+			expectedStatus: http.StatusCreated,
+			expectedBody:   `{"lists":1,"todos":1}`,
+		},
+		{
+			name:           "Success - replace",
+			withUser:       true,
+			query:          "?replace=true",
+			inputBody:      `{"version":1,"lists":[]}`,
+			expectCall:     true,
+			wantReplace:    true,
+			mockReturn:     &domain.ImportResult{},
+			expectedStatus: http.StatusCreated,
+			expectedBody:   `{"lists":0,"todos":0}`,
+		},
+		{
+			name:           "Invalid replace",
+			withUser:       true,
+			query:          "?replace=maybe",
+			inputBody:      `{"version":1}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"replace must be true or false"}`,
+		},
+		{
+			name:           "Malformed JSON",
+			withUser:       true,
+			inputBody:      `{"version":`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"unexpected EOF"}`,
+		},
+		{
+			name:           "Invalid document",
+			withUser:       true,
+			inputBody:      `{"version":2}`,
+			expectCall:     true,
+			mockError:      fmt.Errorf("%w: version must be 1", domain.ErrInvalidInput),
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   `{"error":"invalid input: version must be 1"}`,
+		},
+		{
+			name:           "Title already exists",
+			withUser:       true,
+			inputBody:      `{"version":1,"lists":[{"title":"Groceries"}]}`,
+			expectCall:     true,
+			mockError:      domain.ErrDuplicate,
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `{"error":"a list with this title already exists, import with replace=true to overwrite"}`,
+		},
+		{
+			name:           "Service error",
+			withUser:       true,
+			inputBody:      `{"version":1}`,
+			expectCall:     true,
+			mockError:      errors.New("database error"),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"error":"internal server error"}`,
+		},
+		{
+			name:           "Missing user",
+			withUser:       false,
+			inputBody:      `{"version":1}`,
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   `{"error":"missing user"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := mocks.NewExportService(t)
+
+			if tt.expectCall {
+				mockService.On("ImportUser", mock.Anything, testUserID, mock.AnythingOfType("*domain.ExportDocument"), tt.wantReplace).
+					Return(tt.mockReturn, tt.mockError).
+					Once()
+			}
+
+			handlers := NewHandlers(mockService)
+
+			req, err := http.NewRequest(http.MethodPost, "/me/import"+tt.query, strings.NewReader(tt.inputBody))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+
+			if tt.withUser {
+				userCtx := &auth.UserContext{ID: testUserID, Email: "test@example.com", Name: "Test User"}
+				req = req.WithContext(userCtx.AddToContext(req.Context()))
+			}
+
+			rr := httptest.NewRecorder()
+			handlers.ImportUser(rr, req)
+
+			require.Equal(t, tt.expectedStatus, rr.Code)
+			assert.JSONEq(t, tt.expectedBody, rr.Body.String())
+		})
+	}
+}
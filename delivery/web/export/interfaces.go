@@ -0,0 +1,13 @@
+package export
+
+import (
+	"context"
+	"io"
+
+	"github.com/macesz/todo-go/domain"
+)
+
+type ExportService interface {
+	ExportUser(ctx context.Context, userID int64, w io.Writer) error
+	ImportUser(ctx context.Context, userID int64, doc *domain.ExportDocument, replace bool) (*domain.ImportResult, error)
+}
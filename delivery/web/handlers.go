@@ -2,47 +2,114 @@ package web
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/go-chi/jwtauth/v5"
+	"github.com/macesz/todo-go/delivery/web/dashboard"
+	"github.com/macesz/todo-go/delivery/web/export"
 	"github.com/macesz/todo-go/delivery/web/todo"
 	"github.com/macesz/todo-go/delivery/web/todolist"
 	"github.com/macesz/todo-go/delivery/web/user"
+	"github.com/macesz/todo-go/delivery/web/utils"
+	"github.com/macesz/todo-go/domain"
 )
 
 type ServerServices struct {
 	TodoList  todolist.TodoListService
 	Todo      todo.TodoService
 	User      user.UserService
+	Dashboard dashboard.DashboardService
+	Export    export.ExportService
 	TokenAuth *jwtauth.JWTAuth
+	DB        Pinger // For the database health check
 }
 
 func HealthCheckHandler(w http.ResponseWriter, r *http.Request) {
 	// A very simple health check.
 	w.WriteHeader(http.StatusOK)
 	w.Header().Set("Content-Type", "application/json")
-	// In the future we could report back on the status of our DB, or our cache
-	// (e.g. Redis) by performing a simple PING, and include them in the response.
+	// The database is checked by GET /health/db, see HealthHandlers.Check.
+	// In the future we could report back on the status of our cache (e.g. Redis) the same way.
 	io.WriteString(w, `{"alive": true}`)
 }
 
 type Handlers struct {
-	TodoList *todolist.TodoListHandlers
-	Todo     *todo.TodoHandlers
-	User     *user.UserHandlers
+	TodoList  *todolist.TodoListHandlers
+	Todo      *todo.TodoHandlers
+	User      *user.UserHandlers
+	Dashboard *dashboard.DashboardHandlers
+	Export    *export.ExportHandlers
+	Health    *HealthHandlers
 }
 
-func CreateHandlers(ctx context.Context, services *ServerServices) (*Handlers, error) {
-	todoListHandler := todolist.NewHandlers(services.TodoList, services.Todo, services.User)
-	todoHandler := todo.NewHandlers(services.Todo, services.User)      // Create handlers with the service
+func CreateHandlers(ctx context.Context, conf domain.Config, services *ServerServices) (*Handlers, error) {
+	pageSize, err := parsePageSize(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	degradedAfter, err := parseHealthDegradedLatency(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	todoListHandler := todolist.NewHandlers(services.TodoList, services.Todo, services.User, todolist.Options{
+		PageSize: pageSize,
+	})
+	todoHandler := todo.NewHandlers(services.Todo, services.User, todo.Options{
+		StrictListID: conf.Features.StrictTodoListID,
+		PageSize:     pageSize,
+	}) // Create handlers with the service
 	userHandler := user.NewHandlers(services.User, services.TokenAuth) // Create handlers with the service
+	dashboardHandler := dashboard.NewHandlers(services.Dashboard)
+	exportHandler := export.NewHandlers(services.Export)
+	healthHandler := NewHealthHandlers(services.DB, degradedAfter)
 
 	handlers := &Handlers{
-		TodoList: todoListHandler,
-		Todo:     todoHandler,
-		User:     userHandler,
+		TodoList:  todoListHandler,
+		Todo:      todoHandler,
+		User:      userHandler,
+		Dashboard: dashboardHandler,
+		Export:    exportHandler,
+		Health:    healthHandler,
 	}
 
 	return handlers, nil
 }
+
+// parsePageSize builds the page size policy from MAX_PAGE_SIZE and CLAMP_PAGE_SIZE.
+func parsePageSize(conf domain.Config) (utils.PageSize, error) {
+	pageSize := utils.PageSize{Clamp: conf.Features.ClampPageSize}
+
+	if conf.MaxPageSize != "" {
+		n, err := strconv.Atoi(conf.MaxPageSize)
+		if err != nil || n < 1 {
+			return utils.PageSize{}, fmt.Errorf("MAX_PAGE_SIZE: must be a positive integer, got %q", conf.MaxPageSize)
+		}
+		pageSize.Max = n
+	}
+
+	return pageSize, nil
+}
+
+// parseHealthDegradedLatency parses HEALTH_DEGRADED_LATENCY, the ping latency above which the database is degraded.
+func parseHealthDegradedLatency(conf domain.Config) (time.Duration, error) {
+	value := conf.HealthDegradedLatency
+	if value == "" {
+		value = domain.DefaultHealthDegradedLatency
+	}
+
+	latency, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("HEALTH_DEGRADED_LATENCY: %w", err)
+	}
+	if latency <= 0 {
+		return 0, fmt.Errorf("HEALTH_DEGRADED_LATENCY: %w: must be positive", domain.ErrInvalidInput)
+	}
+
+	return latency, nil
+}
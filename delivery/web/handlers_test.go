@@ -0,0 +1,80 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/jwtauth/v5"
+	"github.com/macesz/todo-go/delivery/web/auth"
+	usermocks "github.com/macesz/todo-go/delivery/web/user/mocks"
+	"github.com/macesz/todo-go/domain"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateHandlers(t *testing.T) {
+	t.Run("every handler is wired", func(t *testing.T) {
+		handlers, err := CreateHandlers(context.Background(), domain.Config{}, &ServerServices{})
+		require.NoError(t, err)
+
+		require.NotNil(t, handlers.TodoList)
+		require.NotNil(t, handlers.Todo)
+		require.NotNil(t, handlers.User)
+		require.NotNil(t, handlers.Dashboard)
+		require.NotNil(t, handlers.Export)
+		require.NotNil(t, handlers.Health)
+	})
+
+	t.Run("invalid page size", func(t *testing.T) {
+		_, err := CreateHandlers(context.Background(), domain.Config{MaxPageSize: "zero"}, &ServerServices{})
+		require.Error(t, err)
+	})
+
+	t.Run("invalid health degraded latency", func(t *testing.T) {
+		for _, latency := range []string{"fast", "0s", "-1ms"} {
+			_, err := CreateHandlers(context.Background(), domain.Config{HealthDegradedLatency: latency}, &ServerServices{})
+			require.Error(t, err, latency)
+		}
+	})
+}
+
+// TestCreateHandlersLoginUsesTokenAuth checks that the login handler signs tokens with the TokenAuth of
+// ServerServices, the one the router verifies them with
+func TestCreateHandlersLoginUsesTokenAuth(t *testing.T) {
+	tokenAuth, err := auth.CreateTokenAuth("my-super-secret-test-key-12345")
+	require.NoError(t, err)
+
+	userService := usermocks.NewUserService(t)
+	userService.On("Login", mock.Anything, "u1@example.com", "Password123").
+		Return(&domain.User{ID: 1, Name: "User One", Email: "u1@example.com"}, nil).
+		Once()
+
+	services := &ServerServices{User: userService, TokenAuth: tokenAuth}
+
+	handlers, err := CreateHandlers(context.Background(), domain.Config{}, services)
+	require.NoError(t, err)
+
+	router, err := CreateRouter(context.Background(), domain.Config{}, services, handlers)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/login", strings.NewReader(`{"email":"u1@example.com","password":"Password123"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+	var resp domain.LoginResponseDTO
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+	token, err := jwtauth.VerifyToken(tokenAuth, resp.Token)
+	require.NoError(t, err)
+
+	userID, ok := token.Get("user_id")
+	require.True(t, ok)
+	require.EqualValues(t, 1, userID)
+}
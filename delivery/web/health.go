@@ -0,0 +1,65 @@
+package web
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/macesz/todo-go/delivery/web/utils"
+)
+
+// Statuses of the database health check
+const (
+	HealthOK       = "ok"
+	HealthDegraded = "degraded" // The database answers, but slower than the threshold
+	HealthDown     = "down"
+)
+
+// Pinger checks the database connection, *sqlx.DB is one.
+type Pinger interface {
+	PingContext(ctx context.Context) error
+}
+
+type HealthResponse struct {
+	Status string `json:"status"`
+
+	// DBLatencyMS is how long the ping took, in milliseconds
+	DBLatencyMS float64 `json:"db_latency_ms"`
+}
+
+type HealthHandlers struct {
+	db            Pinger
+	degradedAfter time.Duration // A slower ping reports degraded
+}
+
+func NewHealthHandlers(db Pinger, degradedAfter time.Duration) *HealthHandlers {
+	return &HealthHandlers{
+		db:            db,
+		degradedAfter: degradedAfter,
+	}
+}
+
+// Check handles GET /health/db.
+// It pings the database and reports how long it took. A failed ping is down with 503,
+// a ping slower than the threshold is still 200, but degraded.
+func (h *HealthHandlers) Check(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	err := h.db.PingContext(r.Context())
+	latency := time.Since(start)
+
+	response := HealthResponse{
+		Status:      HealthOK,
+		DBLatencyMS: float64(latency.Microseconds()) / 1000,
+	}
+
+	switch {
+	case err != nil:
+		response.Status = HealthDown
+		utils.WriteJSON(w, r, http.StatusServiceUnavailable, response)
+		return
+	case latency > h.degradedAfter:
+		response.Status = HealthDegraded
+	}
+
+	utils.WriteJSON(w, r, http.StatusOK, response)
+}
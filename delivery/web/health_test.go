@@ -1,9 +1,15 @@
 package web
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
 )
 
 func TestHealthCheckHandler(t *testing.T) {
@@ -36,3 +42,45 @@ func TestHealthCheckHandler(t *testing.T) {
 			rr.Body.String(), expected)
 	}
 }
+
+// fakePinger answers a ping after a delay, with an error when it has one
+type fakePinger struct {
+	delay time.Duration
+	err   error
+}
+
+func (p fakePinger) PingContext(ctx context.Context) error {
+	time.Sleep(p.delay)
+	return p.err
+}
+
+func TestHealthCheckDB(t *testing.T) {
+	tests := []struct {
+		name       string
+		pinger     fakePinger
+		wantCode   int
+		wantStatus string
+		minLatency time.Duration
+	}{
+		{name: "fast ping", pinger: fakePinger{}, wantCode: http.StatusOK, wantStatus: HealthOK},
+		{name: "slow ping", pinger: fakePinger{delay: 30 * time.Millisecond}, wantCode: http.StatusOK, wantStatus: HealthDegraded, minLatency: 30 * time.Millisecond},
+		{name: "failed ping", pinger: fakePinger{err: errors.New("connection refused")}, wantCode: http.StatusServiceUnavailable, wantStatus: HealthDown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handlers := NewHealthHandlers(tt.pinger, 10*time.Millisecond)
+
+			req := httptest.NewRequest(http.MethodGet, "/health/db", nil)
+			rr := httptest.NewRecorder()
+			handlers.Check(rr, req)
+
+			require.Equal(t, tt.wantCode, rr.Code)
+
+			var got HealthResponse
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &got))
+			require.Equal(t, tt.wantStatus, got.Status)
+			require.GreaterOrEqual(t, got.DBLatencyMS, float64(tt.minLatency.Milliseconds()))
+		})
+	}
+}
@@ -1,13 +1,14 @@
 package middlewares
 
 import (
-	"errors"
 	"net/http"
+	"strings"
 
 	"github.com/go-chi/jwtauth/v5"
 	"github.com/lestrrat-go/jwx/v2/jwt"
 	"github.com/macesz/todo-go/delivery/web/auth"
 	"github.com/macesz/todo-go/delivery/web/utils"
+	"github.com/macesz/todo-go/domain"
 )
 
 func UnloggedInRedirector(next http.Handler) http.Handler {
@@ -22,31 +23,43 @@ func UnloggedInRedirector(next http.Handler) http.Handler {
 	})
 }
 
-func Authenticator(next http.Handler) http.Handler {
+// AuthorizationHeader rejects a request whose Authorization header is not "Bearer <token>".
+// jwtauth ignores a malformed header and the request then fails later with a jwx error, this answers
+// the same 401 as any other authentication failure instead. A request without the header is passed on.
+func AuthorizationHeader(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		token, _, err := jwtauth.FromContext(r.Context())
+		header := r.Header.Get("Authorization")
+		if header == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
 
-		if err != nil {
-			http.Error(w, utils.JsonError(err), http.StatusUnauthorized)
+		scheme, token, ok := strings.Cut(header, " ")
+		if !ok || !strings.EqualFold(scheme, "Bearer") || strings.TrimSpace(token) == "" {
+			unauthorized(w, r)
 			return
 		}
 
-		if token == nil {
-			http.Error(w, utils.JsonError(err), http.StatusUnauthorized)
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Authenticator answers 401 unless the request carries a valid token with a user id.
+// Every failure gets the same body, so clients can't tell an expired token from a forged one.
+func Authenticator(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, _, err := jwtauth.FromContext(r.Context())
+		if err != nil || token == nil {
+			unauthorized(w, r)
 			return
 		}
 
 		claim := token.PrivateClaims()
-		user_id, ok := claim["user_id"].(float64)
-		if !ok {
-			err := errors.New("invalid user id in token")
-			http.Error(w, utils.JsonError(err), http.StatusUnauthorized)
-		}
 
 		// CHECK USER ID IS VALID
-		if user_id <= 0 {
-			err := errors.New("invalid user id in token")
-			http.Error(w, utils.JsonError(err), http.StatusUnauthorized)
+		user_id, ok := claim["user_id"].(float64)
+		if !ok || user_id <= 0 {
+			unauthorized(w, r)
 			return
 		}
 
@@ -55,11 +68,16 @@ func Authenticator(next http.Handler) http.Handler {
 	})
 }
 
+// unauthorized writes the 401 of a failed authentication
+func unauthorized(w http.ResponseWriter, r *http.Request) {
+	utils.WriteJSON(w, r, http.StatusUnauthorized, domain.ErrorResponse{Error: domain.ErrUnauthorized.Error()})
+}
+
 func UserContext(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		token, _, err := jwtauth.FromContext(r.Context())
 		if err != nil {
-			http.Error(w, utils.JsonError(err), http.StatusUnauthorized)
+			unauthorized(w, r)
 			return
 		}
 
@@ -68,7 +86,7 @@ func UserContext(next http.Handler) http.Handler {
 		// Extract user information from token claims
 		claims, err := auth.ClaimsFromToken(privateClaims)
 		if err != nil {
-			http.Error(w, utils.JsonError(err), http.StatusUnauthorized)
+			unauthorized(w, r)
 			return
 		}
 
@@ -0,0 +1,126 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/jwtauth/v5"
+	"github.com/macesz/todo-go/delivery/web/auth"
+	"github.com/macesz/todo-go/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthentication(t *testing.T) {
+	tokenAuth, err := auth.CreateTokenAuth("my-super-secret-test-key-12345")
+	require.NoError(t, err)
+
+	otherAuth, err := auth.CreateTokenAuth("another-secret-test-key-67890")
+	require.NoError(t, err)
+
+	user := &domain.User{ID: 1, Name: "User One", Email: "u1@example.com"}
+
+	_, valid, err := tokenAuth.Encode(auth.NewUserClaims(user, time.Hour).ToMap())
+	require.NoError(t, err)
+	_, expired, err := tokenAuth.Encode(auth.NewUserClaims(user, -time.Hour).ToMap())
+	require.NoError(t, err)
+	_, forged, err := otherAuth.Encode(auth.NewUserClaims(user, time.Hour).ToMap())
+	require.NoError(t, err)
+
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	handler := AuthorizationHeader(jwtauth.Verifier(tokenAuth)(Authenticator(ok)))
+
+	tests := []struct {
+		name       string
+		header     string
+		wantStatus int
+	}{
+		{name: "valid token", header: "Bearer " + valid, wantStatus: http.StatusOK},
+		{name: "scheme is case insensitive", header: "bearer " + valid, wantStatus: http.StatusOK},
+		{name: "no header", header: "", wantStatus: http.StatusUnauthorized},
+		{name: "Bearer without token", header: "Bearer", wantStatus: http.StatusUnauthorized},
+		{name: "Bearer with a blank token", header: "Bearer  ", wantStatus: http.StatusUnauthorized},
+		{name: "token without scheme", header: valid, wantStatus: http.StatusUnauthorized},
+		{name: "non-Bearer scheme", header: "Basic dXNlcjpwYXNz", wantStatus: http.StatusUnauthorized},
+		{name: "garbage token", header: "Bearer not-a-jwt", wantStatus: http.StatusUnauthorized},
+		{name: "expired token", header: "Bearer " + expired, wantStatus: http.StatusUnauthorized},
+		{name: "token signed with another key", header: "Bearer " + forged, wantStatus: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/lists", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.wantStatus, rr.Code)
+			if tt.wantStatus == http.StatusUnauthorized {
+				assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+				assert.JSONEq(t, `{"error":"unauthorized"}`, rr.Body.String())
+			}
+		})
+	}
+}
+
+func TestUserContext(t *testing.T) {
+	tokenAuth, err := auth.CreateTokenAuth("my-super-secret-test-key-12345")
+	require.NoError(t, err)
+
+	user := &domain.User{ID: 1, Name: "User One", Email: "u1@example.com"}
+
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userCtx, found := auth.UserFromContext(r.Context())
+		require.True(t, found)
+		assert.Equal(t, user.Email, userCtx.Email)
+
+		w.WriteHeader(http.StatusOK)
+	})
+	handler := AuthorizationHeader(jwtauth.Verifier(tokenAuth)(Authenticator(UserContext(ok))))
+
+	// encode signs the claims of the user, leaving out the dropped ones
+	encode := func(drop ...string) string {
+		claims := auth.NewUserClaims(user, time.Hour).ToMap()
+		for _, claim := range drop {
+			delete(claims, claim)
+		}
+
+		_, token, err := tokenAuth.Encode(claims)
+		require.NoError(t, err)
+
+		return token
+	}
+
+	tests := []struct {
+		name       string
+		token      string
+		wantStatus int
+	}{
+		{name: "valid token", token: encode(), wantStatus: http.StatusOK},
+		{name: "token without name", token: encode("name"), wantStatus: http.StatusUnauthorized},
+		{name: "token without email", token: encode("email"), wantStatus: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/lists", nil)
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.wantStatus, rr.Code)
+			if tt.wantStatus == http.StatusUnauthorized {
+				assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+				assert.JSONEq(t, `{"error":"unauthorized"}`, rr.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package middlewares
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/macesz/todo-go/delivery/web/utils"
+	"github.com/macesz/todo-go/pkg/logctx"
+)
+
+// maxLoggedBody is the most of a body LogRequestBodies reads, a longer one is cut and so logged as redacted
+const maxLoggedBody = 64 << 10
+
+// LogRequestBodies logs the JSON body of the request with the password fields masked.
+// The handler still reads the whole body, other content types, like the csv uploads, are not logged.
+func LogRequestBodies(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Body == nil || !strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		body, err := io.ReadAll(io.LimitReader(r.Body, maxLoggedBody))
+		r.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(body), r.Body), r.Body}
+
+		logger := logctx.From(r.Context())
+		if err != nil {
+			logger.Warn("failed to read request body for logging", "error", err)
+		} else {
+			logger.Info("request body", "method", r.Method, "path", r.URL.Path, "body", string(utils.RedactJSON(body, utils.PasswordFields...)))
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
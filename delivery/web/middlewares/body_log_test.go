@@ -0,0 +1,64 @@
+package middlewares
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogRequestBodies(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		wantLogged  string
+	}{
+		{
+			name:        "login body",
+			contentType: "application/json",
+			body:        `{"email":"u1@example.com","password":"secret"}`,
+			wantLogged:  `{\"email\":\"u1@example.com\",\"password\":\"[REDACTED]\"}`,
+		},
+		{
+			name:        "not json",
+			contentType: "multipart/form-data; boundary=x",
+			body:        "password=secret",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var logs bytes.Buffer
+			previous := slog.Default()
+			slog.SetDefault(slog.New(slog.NewTextHandler(&logs, nil)))
+			t.Cleanup(func() { slog.SetDefault(previous) })
+
+			var got string
+			handler := LogRequestBodies(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				got = string(body)
+			}))
+
+			req := httptest.NewRequest(http.MethodPost, "/api/auth/login", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			// The handler still gets the body as it was sent
+			require.Equal(t, tt.body, got)
+			require.NotContains(t, logs.String(), "secret")
+			if tt.wantLogged != "" {
+				require.Contains(t, logs.String(), tt.wantLogged)
+			} else {
+				require.Empty(t, logs.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,15 @@
+package middlewares
+
+import (
+	"net/http"
+
+	"github.com/macesz/todo-go/delivery/web/utils"
+)
+
+// DebugErrors makes the 500 responses of the request carry the underlying error.
+// Only for development, the error can leak details of the database and the code.
+func DebugErrors(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r.WithContext(utils.WithDebugErrors(r.Context())))
+	})
+}
@@ -0,0 +1,23 @@
+package middlewares
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/macesz/todo-go/domain"
+)
+
+// ClientHeader names the client that sends the request, the web client sends "web"
+const ClientHeader = "X-Client"
+
+// TodoSource gives the todos created by a request of the web client the web source.
+// Any other request keeps the api source, the default of domain.TodoSourceFrom.
+func TodoSource(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.EqualFold(r.Header.Get(ClientHeader), string(domain.TodoSourceWeb)) {
+			r = r.WithContext(domain.WithTodoSource(r.Context(), domain.TodoSourceWeb))
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,42 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/macesz/todo-go/domain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTodoSource(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   domain.TodoSource
+	}{
+		{name: "web client", header: "web", want: domain.TodoSourceWeb},
+		{name: "case insensitive", header: "Web", want: domain.TodoSourceWeb},
+		{name: "no header", header: "", want: domain.TodoSourceAPI},
+		{name: "other client", header: "cli", want: domain.TodoSourceAPI},
+		{name: "a client can't claim import", header: "import", want: domain.TodoSourceAPI},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got domain.TodoSource
+			handler := TodoSource(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = domain.TodoSourceFrom(r.Context())
+			}))
+
+			req := httptest.NewRequest(http.MethodPost, "/api/lists", nil)
+			if tt.header != "" {
+				req.Header.Set(ClientHeader, tt.header)
+			}
+
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -20,6 +20,21 @@ func CreateRouter(ctx context.Context, conf domain.Config, services *ServerServi
 	r.Use(middleware.Logger)    // Logs the start and end of each request
 	r.Use(middleware.Recoverer) // Recovers from panics, returns 500 instead of crashing
 
+	// chi matches /api/lists/{id} and /api/lists/{id}/ as different routes, so the trailing slash is stripped
+	// before routing and both forms reach the same handler. StripSlashes rewrites the routing path in place;
+	// RedirectSlashes would answer with a redirect instead, which clients don't follow for PUT and POST.
+	r.Use(middleware.StripSlashes)
+
+	// DEBUG_ERRORS sends the cause of a 500 to the client, never turn it on in production
+	if conf.Features.DebugErrors {
+		r.Use(middlewares.DebugErrors)
+	}
+
+	// LOG_REQUEST_BODIES logs what clients send, passwords are masked
+	if conf.Features.LogRequestBodies {
+		r.Use(middlewares.LogRequestBodies)
+	}
+
 	// ============================================
 	// PUBLIC ROUTES (No authentication required)
 	// ============================================
@@ -28,42 +43,77 @@ func CreateRouter(ctx context.Context, conf domain.Config, services *ServerServi
 	// r.Get("/{AssetUrl}", GetAsset)
 	r.Post("/api/auth/register", handlers.User.CreateUser) // Create a new user
 	r.Post("/api/auth/login", handlers.User.Login)         // Login a user
+	r.Get("/health/db", handlers.Health.Check)             // Database health, for monitoring
 	// })
 
 	// ============================================
 	// PROTECTED ROUTES (JWT authentication required)
 	// ============================================
+	// Every protected route answers the same way when access fails:
+	//   - no token or an invalid one: 401, from the Authenticator middleware
+	//   - a resource of another user: 404, like a missing one, so ids of other users can't be probed
+	// Handlers resolve path ids with the caller's user id (todoIDFromPath, listIDFromPath, userIDFromPath)
+	// to get the 404. tests/access_policy_test.go checks the policy for todos, lists and users.
 	r.Group(func(r chi.Router) {
 		// r.Use(AuthMiddleware)
 
 		// Seek, verify and validate JWT tokens
 		// Using the injected TokenAuth from services
+		r.Use(middlewares.AuthorizationHeader)
 		r.Use(jwtauth.Verifier(services.TokenAuth))
 		r.Use(middlewares.Authenticator)
 		r.Use(middlewares.UserContext)
+		r.Use(middlewares.TodoSource)
 
-		r.Use(middleware.AllowContentType("application/json", "text/xml"))
+		// Uploads are multipart forms, every other protected route only takes JSON
+		// Creates todos from a CSV file and reports every row
+		r.With(middleware.AllowContentType("multipart/form-data")).Post("/api/todos/import.csv", handlers.Todo.ImportCSV)
 
-		r.Route("/api/lists", func(r chi.Router) {
-			r.Get("/", handlers.TodoList.List)
-			r.Get("/{id}", handlers.TodoList.GetListByID)
-			r.Post("/", handlers.TodoList.Create)
-			r.Put("/{id}", handlers.TodoList.Update)
-			r.Delete("/{id}", handlers.TodoList.Delete)
-		})
+		r.Group(func(r chi.Router) {
+			r.Use(middleware.AllowContentType("application/json"))
 
-		r.Route("/api/lists/{listID}/todos", func(r chi.Router) {
-			r.Get("/", handlers.Todo.ListTodos)         // List all todos
-			r.Get("/{id}", handlers.Todo.GetTodo)       // Get specific todo by ID
-			r.Post("/", handlers.Todo.CreateTodo)       // Create a new todo
-			r.Put("/{id}", handlers.Todo.UpdateTodo)    // Update a todo by ID
-			r.Delete("/{id}", handlers.Todo.DeleteTodo) // Delete a todo by ID
-		})
+			r.Route("/api/lists", func(r chi.Router) {
+				r.Get("/", handlers.TodoList.List)
+				r.Get("/{id}", handlers.TodoList.GetListByID)
+				r.Post("/", handlers.TodoList.Create) // 409 when an open list of the user has the title
+				r.Put("/{id}", handlers.TodoList.Update)
+				r.Delete("/{id}", handlers.TodoList.Delete)
+				r.Put("/{id}/pin", handlers.TodoList.Pin)
+				r.Delete("/{id}/pin", handlers.TodoList.Unpin)
+				r.Put("/by-title/{title}", handlers.TodoList.GetOrCreate)
+			})
+
+			r.Route("/api/lists/{listID}/todos", func(r chi.Router) {
+				r.Get("/", handlers.Todo.ListTodos)                   // List all todos
+				r.Get("/{id}", handlers.Todo.GetTodo)                 // Get specific todo by ID
+				r.Post("/", handlers.Todo.CreateTodo)                 // Create a new todo
+				r.Put("/{id}", handlers.Todo.UpdateTodo)              // Update a todo by ID
+				r.Delete("/{id}", handlers.Todo.DeleteTodo)           // Delete a todo by ID
+				r.Post("/{id}/undo-delete", handlers.Todo.UndoDelete) // Restore a soft-deleted todo within the undo window
+				r.Post("/empty-done", handlers.Todo.EmptyDone)        // Delete all done todos, to the trash with soft delete on
+				r.Post("/toggle-all", handlers.Todo.ToggleAll)        // Mark every todo done or not done
+			})
+
+			r.Get("/api/todos/{id}/list", handlers.TodoList.ListOfTodo) // The list that owns a todo
+			r.Post("/api/todos/{id}/clone", handlers.Todo.CloneTodo)    // Copy a todo into its list, not done
+			r.Post("/api/todos/{id}/done", handlers.Todo.MarkDone)      // Mark a todo done, the title stays as it is
+			r.Post("/api/todos/{id}/undone", handlers.Todo.MarkUndone)  // Mark a todo not done
+			r.Get("/api/todos/overdue", handlers.Todo.ListOverdue)      // Not done todos past their due date, across all lists
+			r.Post("/api/todos/batch-get", handlers.Todo.BatchGet)      // The caller's todos among the given ids
+
+			r.Get("/api/dashboard", handlers.Dashboard.GetDashboard)
+
+			r.Get("/api/me/export", handlers.Export.ExportUser)  // Full JSON backup of the user's data
+			r.Post("/api/me/import", handlers.Export.ImportUser) // Restore a backup from /api/me/export
+
+			r.Get("/api/me/todos/recently-completed", handlers.Todo.RecentlyCompleted) // Newest completions across all lists
 
-		// changed to /users from /user to follow REST conventions, as we need separation for private and protected routes
-		r.Route("/api/users", func(r chi.Router) {
-			r.Get("/{id}", handlers.User.GetUser)
-			r.Delete("/{id}", handlers.User.DeleteUser) // Delete a user by ID
+			// changed to /users from /user to follow REST conventions, as we need separation for private and protected routes
+			r.Route("/api/users", func(r chi.Router) {
+				r.Put("/me", handlers.User.UpdateProfile) // Change the caller's name, email and timezone
+				r.Get("/{id}", handlers.User.GetUser)
+				r.Delete("/{id}", handlers.User.DeleteUser) // Delete a user by ID
+			})
 		})
 	})
 
@@ -0,0 +1,272 @@
+package web
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	chi "github.com/go-chi/chi/v5"
+	"github.com/macesz/todo-go/delivery/web/auth"
+	"github.com/macesz/todo-go/delivery/web/todo"
+	todomocks "github.com/macesz/todo-go/delivery/web/todo/mocks"
+	"github.com/macesz/todo-go/delivery/web/todolist"
+	listmocks "github.com/macesz/todo-go/delivery/web/todolist/mocks"
+	"github.com/macesz/todo-go/domain"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRouterTrailingSlash checks that a path with and without a trailing slash reach the same handler
+func TestRouterTrailingSlash(t *testing.T) {
+	tokenAuth, err := auth.CreateTokenAuth("my-super-secret-test-key-12345")
+	require.NoError(t, err)
+
+	user := &domain.User{ID: 1, Name: "User One", Email: "u1@example.com"}
+	_, token, err := tokenAuth.Encode(auth.NewUserClaims(user, time.Hour).ToMap())
+	require.NoError(t, err)
+
+	listPublicID := "00000000-0000-0000-0000-000000000001"
+	list := &domain.TodoList{ID: 1, PublicID: listPublicID, UserID: 1, Title: "Groceries", Version: 1}
+
+	listService := listmocks.NewTodoListService(t)
+	listService.On("List", mock.Anything, int64(1), mock.Anything).Return([]*domain.TodoList{list}, nil)
+	listService.On("ResolveID", mock.Anything, int64(1), listPublicID).Return(int64(1), nil)
+	listService.On("GetListByID", mock.Anything, int64(1), int64(1)).Return(list, nil)
+
+	todoService := listmocks.NewTodoService(t)
+	todoService.On("ListTodos", mock.Anything, int64(1), int64(1), mock.Anything).Return([]*domain.Todo{}, nil)
+
+	services := &ServerServices{TodoList: listService, TokenAuth: tokenAuth}
+	handlers := &Handlers{TodoList: todolist.NewHandlers(listService, todoService, nil, todolist.Options{})}
+
+	router, err := CreateRouter(context.Background(), domain.Config{}, services, handlers)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		path string
+	}{
+		{name: "collection", path: "/api/lists"},
+		{name: "single list", path: "/api/lists/" + listPublicID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			get := func(path string) *httptest.ResponseRecorder {
+				req := httptest.NewRequest(http.MethodGet, path, nil)
+				req.Header.Set("Authorization", "Bearer "+token)
+
+				rr := httptest.NewRecorder()
+				router.ServeHTTP(rr, req)
+				return rr
+			}
+
+			without := get(tt.path)
+			with := get(tt.path + "/")
+
+			require.Equal(t, http.StatusOK, without.Code)
+			require.Equal(t, without.Code, with.Code)
+			require.JSONEq(t, without.Body.String(), with.Body.String())
+		})
+	}
+}
+
+// TestRouterContentTypes checks that the CSV upload takes a multipart form and the other routes keep taking JSON only
+func TestRouterContentTypes(t *testing.T) {
+	tokenAuth, err := auth.CreateTokenAuth("my-super-secret-test-key-12345")
+	require.NoError(t, err)
+
+	user := &domain.User{ID: 1, Name: "User One", Email: "u1@example.com"}
+	_, token, err := tokenAuth.Encode(auth.NewUserClaims(user, time.Hour).ToMap())
+	require.NoError(t, err)
+
+	todoService := todomocks.NewTodoService(t)
+	todoService.On("ImportCSV", mock.Anything, int64(1), mock.Anything).Return([]domain.CSVTodoResult{}, nil).Once()
+
+	// The list handlers are never reached, a strict mock fails if they are
+	listService := listmocks.NewTodoListService(t)
+
+	services := &ServerServices{TokenAuth: tokenAuth}
+	handlers := &Handlers{
+		Todo:     todo.NewHandlers(todoService, nil, todo.Options{}),
+		TodoList: todolist.NewHandlers(listService, nil, nil, todolist.Options{}),
+	}
+
+	router, err := CreateRouter(context.Background(), domain.Config{}, services, handlers)
+	require.NoError(t, err)
+
+	form := &bytes.Buffer{}
+	writer := multipart.NewWriter(form)
+	part, err := writer.CreateFormFile("file", "todos.csv")
+	require.NoError(t, err)
+	_, err = part.Write([]byte("list_id,title\n"))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	tests := []struct {
+		name        string
+		path        string
+		contentType string
+		body        []byte
+		wantStatus  int
+	}{
+		{name: "csv upload as multipart", path: "/api/todos/import.csv", contentType: writer.FormDataContentType(), body: form.Bytes(), wantStatus: http.StatusOK},
+		{name: "csv upload as json", path: "/api/todos/import.csv", contentType: "application/json", body: []byte(`{}`), wantStatus: http.StatusUnsupportedMediaType},
+		{name: "json route as multipart", path: "/api/lists", contentType: writer.FormDataContentType(), body: form.Bytes(), wantStatus: http.StatusUnsupportedMediaType},
+		{name: "json route as xml", path: "/api/lists", contentType: "text/xml", body: []byte(`<list><title>Groceries</title></list>`), wantStatus: http.StatusUnsupportedMediaType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, tt.path, bytes.NewReader(tt.body))
+			req.Header.Set("Authorization", "Bearer "+token)
+			req.Header.Set("Content-Type", tt.contentType)
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.wantStatus, rr.Code, rr.Body.String())
+		})
+	}
+}
+
+// TestRouterNestedTodoRoutes checks that the todo routes under /api/lists/{listID}/todos reach the todo
+// handlers with both the list id and the todo id of the path
+func TestRouterNestedTodoRoutes(t *testing.T) {
+	tokenAuth, err := auth.CreateTokenAuth("my-super-secret-test-key-12345")
+	require.NoError(t, err)
+
+	user := &domain.User{ID: 1, Name: "User One", Email: "u1@example.com"}
+	_, token, err := tokenAuth.Encode(auth.NewUserClaims(user, time.Hour).ToMap())
+	require.NoError(t, err)
+
+	listPublicID := "00000000-0000-0000-0000-000000000002"
+	todoPublicID := "00000000-0000-0000-0000-000000000005"
+	milk := &domain.Todo{ID: 5, PublicID: todoPublicID, UserID: 1, TodoListID: 2, Title: "Milk"}
+
+	todoService := todomocks.NewTodoService(t)
+	todoService.On("ResolveListID", mock.Anything, int64(1), listPublicID).Return(int64(2), nil).Twice()
+	todoService.On("ListTodos", mock.Anything, int64(1), int64(2), domain.ListOptions{}).Return([]*domain.Todo{milk}, nil).Once()
+	todoService.On("ResolveTodoID", mock.Anything, int64(1), todoPublicID).Return(int64(5), nil).Once()
+	todoService.On("GetTodoInList", mock.Anything, int64(1), int64(2), int64(5)).Return(milk, nil).Once()
+
+	services := &ServerServices{TokenAuth: tokenAuth}
+	handlers := &Handlers{Todo: todo.NewHandlers(todoService, nil, todo.Options{})}
+
+	router, err := CreateRouter(context.Background(), domain.Config{}, services, handlers)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		path string
+	}{
+		{name: "todos of a list", path: "/api/lists/" + listPublicID + "/todos"},
+		{name: "todo of a list", path: "/api/lists/" + listPublicID + "/todos/" + todoPublicID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+			require.Contains(t, rr.Body.String(), `"todolist_id":"`+listPublicID+`"`)
+		})
+	}
+}
+
+// TestRouterRequiresAuth walks every registered route and checks that all but the public ones answer 401 without a token.
+// A route added outside the protected group fails here.
+func TestRouterRequiresAuth(t *testing.T) {
+	tokenAuth, err := auth.CreateTokenAuth("my-super-secret-test-key-12345")
+	require.NoError(t, err)
+
+	// No handler is reached without a token, so they can all be nil
+	services := &ServerServices{TokenAuth: tokenAuth}
+	router, err := CreateRouter(context.Background(), domain.Config{}, services, &Handlers{})
+	require.NoError(t, err)
+
+	public := map[string]bool{
+		http.MethodPost + " /api/auth/register": true,
+		http.MethodPost + " /api/auth/login":    true,
+		http.MethodGet + " /health/db":          true, // Monitoring checks the database without a token
+	}
+
+	urlParam := regexp.MustCompile(`\{[^}]+\}`)
+
+	var protected, found int
+	err = chi.Walk(router, func(method string, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		if public[method+" "+route] {
+			found++
+			return nil
+		}
+		protected++
+
+		t.Run(method+" "+route, func(t *testing.T) {
+			path := urlParam.ReplaceAllString(route, "00000000-0000-0000-0000-000000000001")
+
+			req := httptest.NewRequest(method, path, nil)
+			req.Header.Set("Content-Type", "application/json")
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			require.Equal(t, http.StatusUnauthorized, rr.Code, rr.Body.String())
+		})
+
+		return nil
+	})
+	require.NoError(t, err)
+	require.NotZero(t, protected)
+	require.Equal(t, len(public), found, "a public route is missing from the router")
+}
+
+// TestRouterDebugErrors checks that a 500 only carries the underlying error with the DebugErrors config
+func TestRouterDebugErrors(t *testing.T) {
+	tokenAuth, err := auth.CreateTokenAuth("my-super-secret-test-key-12345")
+	require.NoError(t, err)
+
+	user := &domain.User{ID: 1, Name: "User One", Email: "u1@example.com"}
+	_, token, err := tokenAuth.Encode(auth.NewUserClaims(user, time.Hour).ToMap())
+	require.NoError(t, err)
+
+	listService := listmocks.NewTodoListService(t)
+	listService.On("List", mock.Anything, int64(1), mock.Anything).Return(nil, errors.New("failed to list todo lists: connection refused"))
+
+	services := &ServerServices{TodoList: listService, TokenAuth: tokenAuth}
+	handlers := &Handlers{TodoList: todolist.NewHandlers(listService, nil, nil, todolist.Options{})}
+
+	tests := []struct {
+		name  string
+		debug bool
+		want  string
+	}{
+		{name: "off", want: `{"error":"internal server error"}`},
+		{name: "on", debug: true, want: `{"error":"internal server error","detail":"failed to list todo lists: connection refused"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, err := CreateRouter(context.Background(), domain.Config{Features: domain.Features{DebugErrors: tt.debug}}, services, handlers)
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/lists", nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			require.Equal(t, http.StatusInternalServerError, rr.Code)
+			require.JSONEq(t, tt.want, rr.Body.String())
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package todo
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"strings"
+
+	"github.com/macesz/todo-go/domain"
+)
+
+// csvColumns are the columns of a todo CSV upload, the header row names them in any order.
+// Other columns are ignored.
+const (
+	csvColumnListID = "list_id" // Required, public id of the list
+	csvColumnTitle  = "title"   // Required
+	csvColumnDone   = "done"    // Optional, "true" or "false"
+)
+
+// csvPartTypes are the content types clients send a .csv file with.
+// Browsers disagree, and curl sends application/octet-stream for any extension it doesn't know.
+var csvPartTypes = map[string]bool{
+	"":                         true,
+	"text/csv":                 true,
+	"text/plain":               true,
+	"application/csv":          true,
+	"application/vnd.ms-excel": true,
+	"application/octet-stream": true,
+}
+
+// isCSVUpload tells whether an uploaded file looks like a CSV. The declared type of the part has to be
+// one used for CSV files and the content has to sniff as text, so a renamed image is rejected too.
+// The file is rewound for reading afterwards.
+func isCSVUpload(file multipart.File, declaredType string) (bool, error) {
+	mediaType, _, err := mime.ParseMediaType(declaredType)
+	if err != nil && declaredType != "" {
+		return false, nil
+	}
+	if !csvPartTypes[mediaType] {
+		return false, nil
+	}
+
+	// DetectContentType looks at no more than the first 512 bytes
+	head := make([]byte, 512)
+	n, err := io.ReadFull(file, head)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return false, err
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return false, err
+	}
+
+	return strings.HasPrefix(http.DetectContentType(head[:n]), "text/plain"), nil
+}
+
+// readCSVTodoRows reads the rows of a todo CSV upload.
+// Only the file itself can fail here, a malformed header or broken quoting. The values of each row
+// are validated by the service, so a bad row is reported without failing the whole upload.
+func readCSVTodoRows(file io.Reader) ([]domain.CSVTodoRow, error) {
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1 // A short row is reported by the service as a missing value
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("%w: the csv file is empty", domain.ErrInvalidInput)
+		}
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	for _, required := range []string{csvColumnListID, csvColumnTitle} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("%w: the csv header needs a %s column", domain.ErrInvalidInput, required)
+		}
+	}
+
+	field := func(record []string, column string) string {
+		i, ok := columns[column]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return record[i]
+	}
+
+	var rows []domain.CSVTodoRow
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err)
+		}
+
+		line, _ := reader.FieldPos(0)
+
+		rows = append(rows, domain.CSVTodoRow{
+			Line:   line,
+			ListID: field(record, csvColumnListID),
+			Title:  field(record, csvColumnTitle),
+			Done:   field(record, csvColumnDone),
+		})
+	}
+
+	return rows, nil
+}
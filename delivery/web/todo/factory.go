@@ -1,16 +1,44 @@
 package todo
 
+import "github.com/macesz/todo-go/delivery/web/utils"
+
+// DefaultRecentlyCompletedLimit is the number of todos GET /me/todos/recently-completed returns without a limit.
+const DefaultRecentlyCompletedLimit = 10
+
+// MaxBatchGetIDs is the most ids one POST /todos/batch-get request may ask for.
+const MaxBatchGetIDs = 100
+
+// MaxCSVUploadSize is the largest request body POST /todos/import.csv accepts, in bytes.
+const MaxCSVUploadSize = 1 << 20
+
 // TodoHandlers groups HTTP handler functions.
 // Like a Java controller class or JS route handler object.
 type TodoHandlers struct {
 	todoService TodoService
 	userService UserService
+
+	// strictListID rejects a create whose body list_id differs from the list in the path
+	strictListID bool
+
+	// pageSize limits the limit query param of the paginated endpoints
+	pageSize utils.PageSize
+}
+
+// Options holds the optional behaviour of the todo handlers.
+type Options struct {
+	// StrictListID rejects a create with a conflicting list_id in the body, otherwise the path wins
+	StrictListID bool
+
+	// PageSize is the max page size policy, the zero value uses utils.DefaultMaxPageSize
+	PageSize utils.PageSize
 }
 
 // NewHandlers creates a new Handlers instance.
-func NewHandlers(todoService TodoService, userService UserService) *TodoHandlers {
+func NewHandlers(todoService TodoService, userService UserService, opts Options) *TodoHandlers {
 	return &TodoHandlers{
-		todoService: todoService,
-		userService: userService,
+		todoService:  todoService,
+		userService:  userService,
+		strictListID: opts.StrictListID,
+		pageSize:     opts.PageSize,
 	}
 }
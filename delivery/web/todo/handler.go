@@ -4,14 +4,14 @@ import (
 	"encoding/json" // For JSON (like JSON.parse/stringify in JS)
 	"errors"
 	"fmt"
+	"io"
 	"net/http" // Standard HTTP library (like fetch in JS or HttpServlet in Java)
-	"strconv"
 	"strings"
 	"time"
 
-	chi "github.com/go-chi/chi/v5"
 	"github.com/go-playground/validator/v10"
 	validate "github.com/go-playground/validator/v10" // For struct validation (like Joi in JS or Hibernate Validator in Java)
+	"github.com/google/uuid"
 	"github.com/macesz/todo-go/delivery/web/auth"
 	"github.com/macesz/todo-go/delivery/web/utils"
 	"github.com/macesz/todo-go/domain"
@@ -20,47 +20,40 @@ import (
 )
 
 // ListTodos handles GET /todos requests.
+// The q, done and updated_since filters combine, the result is then sorted (created_at by default,
+// ties broken by id) and limit/offset pick the page. See utils.ParseListOptions for the params.
 func (h *TodoHandlers) ListTodos(w http.ResponseWriter, r *http.Request) {
 	user, ok := auth.UserFromContext(r.Context())
 	if !ok {
-		utils.WriteJSON(w, http.StatusForbidden, domain.ErrorResponse{Error: "missing user"})
+		utils.WriteJSON(w, r, http.StatusForbidden, domain.ErrorResponse{Error: "missing user"})
 		return
 	}
 
-	idr := chi.URLParam(r, "listID") // Get the "id" URL parameter
-
-	// Check if id parameter exists
-	if idr == "" {
-		utils.WriteJSON(w, http.StatusBadRequest, domain.ErrorResponse{Error: "id is required"})
+	// The path has the public id of the list, resolve it to the internal one
+	listID, listPublicID, ok := h.listIDFromPath(w, r, user.ID)
+	if !ok {
 		return
 	}
 
-	// Convert id string to int64
-	listID, err := strconv.ParseInt(idr, 10, 64) // Convert id string to int
+	opts, err := utils.ParseListOptions(r, h.pageSize)
 	if err != nil {
-		utils.WriteJSON(w, http.StatusBadRequest, domain.ErrorResponse{Error: "id must be an integer"})
+		utils.WriteJSON(w, r, http.StatusBadRequest, domain.ErrorResponse{Error: err.Error()})
 		return
 	}
 
-	todos, err := h.todoService.ListTodos(r.Context(), user.ID, listID)
+	todos, err := h.todoService.ListTodos(r.Context(), user.ID, listID, opts)
 	if err != nil {
-		utils.WriteJSON(w, http.StatusInternalServerError, domain.ErrorResponse{Error: "internal server error"})
+		utils.WriteError(w, r, err)
 		return
 	}
 
-	respTodos := make([]domain.TodoDTO, len(todos))
+	respTodos := make([]domain.TodoDTO, 0, len(todos))
 	for _, todo := range todos {
-		respTodo := domain.TodoDTO{
-			ID:         todo.ID,
-			UserID:     todo.UserID,
-			TodoListID: todo.TodoListID,
-			Title:      todo.Title,
-			Done:       todo.Done,
-			CreatedAt:  todo.CreatedAt.Format(time.RFC3339),
-		}
+		respTodo := domain.NewTodoDTO(todo)
+		respTodo.TodoListID = listPublicID
 		respTodos = append(respTodos, respTodo)
 	}
-	utils.WriteJSON(w, http.StatusOK, respTodos)
+	utils.WriteJSON(w, r, http.StatusOK, respTodos)
 }
 
 // CreateTodo handles POST /todos requests.
@@ -71,28 +64,18 @@ func (h *TodoHandlers) CreateTodo(w http.ResponseWriter, r *http.Request) {
 
 	userCtx, ok := auth.UserFromContext(ctx)
 	if !ok {
-		utils.WriteJSON(w, http.StatusForbidden, domain.ErrorResponse{Error: "missing user"})
+		utils.WriteJSON(w, r, http.StatusForbidden, domain.ErrorResponse{Error: "missing user"})
 		return
 	}
 
 	user, err := h.userService.GetUser(ctx, userCtx.ID)
 	if err != nil || user == nil {
-		utils.WriteJSON(w, http.StatusForbidden, domain.ErrorResponse{Error: "missing user"})
-		return
-	}
-
-	idr := chi.URLParam(r, "listID") // Get the "id" URL parameter
-
-	// Check if id parameter exists
-	if idr == "" {
-		utils.WriteJSON(w, http.StatusBadRequest, domain.ErrorResponse{Error: "id is required"})
+		utils.WriteJSON(w, r, http.StatusForbidden, domain.ErrorResponse{Error: "missing user"})
 		return
 	}
 
-	// Convert id string to int64
-	listID, err := strconv.ParseInt(idr, 10, 64) // Convert id string to int
-	if err != nil {
-		utils.WriteJSON(w, http.StatusBadRequest, domain.ErrorResponse{Error: "id must be an integer"})
+	listID, listPublicID, ok := h.listIDFromPath(w, r, user.ID)
+	if !ok {
 		return
 	}
 
@@ -103,7 +86,11 @@ func (h *TodoHandlers) CreateTodo(w http.ResponseWriter, r *http.Request) {
 	// r.Body is the request body (like req.body in Express)
 	// &todo is the address of the todo variable (like passing by reference in Java)
 	if err := json.NewDecoder(r.Body).Decode(&reqTodo); err != nil {
-		utils.WriteJSON(w, http.StatusBadRequest, domain.ErrorResponse{Error: err.Error()})
+		if isDueDateError(err) {
+			utils.WriteValidationError(w, r, dueDateMessage)
+			return
+		}
+		utils.WriteJSON(w, r, http.StatusBadRequest, domain.ErrorResponse{Error: err.Error()})
 		return
 	}
 
@@ -111,201 +98,576 @@ func (h *TodoHandlers) CreateTodo(w http.ResponseWriter, r *http.Request) {
 		useErr := translateValidationError(err)
 		// Dynamic message, e.g., "Title is required"
 		// Similar to Joi validation errors in JS or Bean Validation in Java
-		utils.WriteJSON(w, http.StatusBadRequest, domain.ErrorResponse{Error: useErr})
+		utils.WriteValidationError(w, r, useErr)
+		return
+	}
+
+	if h.strictListID && reqTodo.ListID != "" && !samePublicID(reqTodo.ListID, listPublicID) {
+		utils.WriteJSON(w, r, http.StatusBadRequest, domain.ErrorResponse{Error: "list_id does not match the list in the path"})
 		return
 	}
 
 	// Create the todo using the service
 	// If creation fails, return 400 Bad Request
-	todo, err := h.todoService.CreateTodo(r.Context(), user.ID, listID, reqTodo.Title)
+	todo, warnings, err := h.todoService.CreateTodo(r.Context(), user.ID, listID, reqTodo.Title, reqTodo.DueDate, reqTodo.Color)
 	if err != nil {
 		if errors.Is(err, domain.ErrInvalidTitle) {
-			utils.WriteJSON(w, http.StatusBadRequest, domain.ErrorResponse{Error: err.Error()})
+			utils.WriteValidationError(w, r, err.Error())
 			return
 		}
-		utils.WriteJSON(w, http.StatusInternalServerError, domain.ErrorResponse{Error: "internal server error"})
+		utils.WriteError(w, r, err)
 		return
 	}
 
-	respTodo := domain.TodoDTO{
-		ID:         todo.ID,
-		UserID:     todo.UserID,
-		TodoListID: todo.TodoListID,
-		Title:      todo.Title,
-		Done:       todo.Done,
-		CreatedAt:  todo.CreatedAt.Format(time.RFC3339), // Format time as ISO string
-	}
+	respTodo := domain.NewTodoDTO(todo)
+	respTodo.TodoListID = listPublicID
+	respTodo.Warnings = warnings
 
-	utils.WriteJSON(w, http.StatusCreated, respTodo)
+	utils.WriteJSON(w, r, http.StatusCreated, respTodo)
 }
 
 // GetTodo handles GET /lists/{listID}/todos/{id} requests.
 func (h *TodoHandlers) GetTodo(w http.ResponseWriter, r *http.Request) {
 	user, ok := auth.UserFromContext(r.Context())
 	if !ok {
-		utils.WriteJSON(w, http.StatusForbidden, domain.ErrorResponse{Error: "missing user"})
+		utils.WriteJSON(w, r, http.StatusForbidden, domain.ErrorResponse{Error: "missing user"})
 		return
 	}
 
 	// get listId parameter
-	idrl := chi.URLParam(r, "listID") // Get the "id" URL parameter
-	if idrl == "" {
-		utils.WriteJSON(w, http.StatusBadRequest, domain.ErrorResponse{Error: "id is required"})
+	todolistID, listPublicID, ok := h.listIDFromPath(w, r, user.ID)
+	if !ok {
 		return
 	}
 
-	todolistID, err := strconv.ParseInt(idrl, 10, 64) // Convert id string to int
+	id, ok := h.todoIDFromPath(w, r, user.ID)
+	if !ok {
+		return
+	}
+
+	// Get the todo from the service, it must be in the list of the path
+	todo, err := h.todoService.GetTodoInList(r.Context(), user.ID, todolistID, id)
 	if err != nil {
-		utils.WriteJSON(w, http.StatusBadRequest, domain.ErrorResponse{Error: "id must be an integer"})
+
+		if errors.Is(err, domain.ErrNotFound) {
+			utils.WriteJSON(w, r, http.StatusNotFound, domain.ErrorResponse{Error: err.Error()}) // e.g., {"error": "todo not found"}
+			return
+		}
+		utils.WriteError(w, r, err) // Generic for security
 		return
 	}
 
-	idr := chi.URLParam(r, "id") // Get the "id" URL parameter
-	if idr == "" {
-		utils.WriteJSON(w, http.StatusBadRequest, domain.ErrorResponse{Error: "id is required"})
+	// Map to response DTO
+	respTodo := domain.NewTodoDTO(todo)
+	respTodo.TodoListID = listPublicID
+
+	utils.WriteJSON(w, r, http.StatusOK, respTodo) // Return the todo as JSON
+}
+
+// UpdateTodo handles PUT /lists/{listID}/todos/{id} requests.
+func (h *TodoHandlers) UpdateTodo(w http.ResponseWriter, r *http.Request) {
+	user, ok := auth.UserFromContext(r.Context())
+	if !ok {
+		utils.WriteJSON(w, r, http.StatusForbidden, domain.ErrorResponse{Error: "missing user"})
 		return
 	}
 
-	// Convert id string to int64
-	id, err := strconv.ParseInt(idr, 10, 64) // Convert id string to int
+	// The todo must be in the list of the path, like GetTodo
+	id, listPublicID, ok := h.todoInListFromPath(w, r, user.ID)
+	if !ok {
+		return
+	}
+
+	var todoDTO domain.UpdateTodoDTO // Empty Todo struct to decode into
+
+	// Decode the JSON body into the todo struct
+	// If decoding fails, return 400 Bad Request
+	if err := json.NewDecoder(r.Body).Decode(&todoDTO); err != nil {
+		if isDueDateError(err) {
+			utils.WriteValidationError(w, r, dueDateMessage)
+			return
+		}
+		utils.WriteJSON(w, r, http.StatusBadRequest, domain.ErrorResponse{Error: err.Error()}) // Using struct for consistency
+		return
+	}
+
+	defer r.Body.Close() // Clean up - like closing a file; prevents leaks
+
+	// Validate using tags in UpdateTodoDTO (like Joi.validate in JS)
+	if err := validate.New().Struct(todoDTO); err != nil {
+		utils.WriteValidationError(w, r, translateValidationError(err)) // Dynamic message, e.g., "title is required"
+		return
+	}
+
+	// Call service to update (passes context for timeouts/cancellation)
+	updated, warnings, err := h.todoService.UpdateTodo(r.Context(), user.ID, id, todoDTO.Title, todoDTO.Done, todoDTO.DueDate, todoDTO.Color)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) { // Check custom error )
+			utils.WriteJSON(w, r, http.StatusNotFound, domain.ErrorResponse{Error: err.Error()}) // e.g., {"error": "todo not found"}
+			return
+		} else if errors.Is(err, domain.ErrInvalidTitle) { // Optional: If service returns this
+			utils.WriteValidationError(w, r, err.Error())
+			return
+		}
+		// TODO: Add logging here, e.g., log.Printf("Internal error updating todo %d: %v", id, err)
+		utils.WriteError(w, r, err) // Generic for security
+		return
+	}
+
+	respTodo := domain.NewTodoDTO(updated)
+	respTodo.TodoListID = listPublicID
+	respTodo.Warnings = warnings
+
+	utils.WriteJSON(w, r, http.StatusOK, respTodo) // Return the updated todo as JSON
+}
+
+// DeleteTodo handles DELETE /lists/{listID}/todos/{id} requests.
+// It answers 204, or with ?return=true 200 and the deleted todo.
+func (h *TodoHandlers) DeleteTodo(w http.ResponseWriter, r *http.Request) {
+	user, ok := auth.UserFromContext(r.Context())
+	if !ok {
+		utils.WriteJSON(w, r, http.StatusForbidden, domain.ErrorResponse{Error: "missing user"})
+		return
+	}
+
+	dryRun, err := utils.ParseDryRun(r)
 	if err != nil {
-		utils.WriteJSON(w, http.StatusBadRequest, domain.ErrorResponse{Error: "id must be an integer"})
+		utils.WriteJSON(w, r, http.StatusBadRequest, domain.ErrorResponse{Error: err.Error()})
 		return
 	}
 
-	// Get the todo from the service
-	todo, err := h.todoService.GetTodo(r.Context(), user.ID, id)
+	returnDeleted, err := utils.ParseReturnDeleted(r)
 	if err != nil {
+		utils.WriteJSON(w, r, http.StatusBadRequest, domain.ErrorResponse{Error: err.Error()})
+		return
+	}
 
+	id, _, ok := h.todoInListFromPath(w, r, user.ID)
+	if !ok {
+		return
+	}
+
+	// A dry run only reports the todo that would be deleted
+	if dryRun {
+		result, err := h.todoService.DeleteTodoDryRun(r.Context(), user.ID, id)
+		if err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				utils.WriteJSON(w, r, http.StatusNotFound, domain.ErrorResponse{Error: err.Error()})
+				return
+			}
+			utils.WriteError(w, r, err)
+			return
+		}
+
+		utils.WriteJSON(w, r, http.StatusOK, utils.DryRunResponse(result))
+		return
+	}
+
+	todo, err := h.todoService.DeleteTodo(r.Context(), user.ID, id)
+	if err != nil {
 		if errors.Is(err, domain.ErrNotFound) {
-			utils.WriteJSON(w, http.StatusNotFound, domain.ErrorResponse{Error: err.Error()}) // e.g., {"error": "todo not found"}
+			utils.WriteJSON(w, r, http.StatusNotFound, domain.ErrorResponse{Error: err.Error()}) // e.g., {"error": "todo not found"}
 			return
 		}
-		utils.WriteJSON(w, http.StatusInternalServerError, domain.ErrorResponse{Error: "internal server error"}) // Generic for security
+		utils.WriteError(w, r, err) // Generic for security
 		return
 	}
 
-	// Map to response DTO
-	respTodo := domain.TodoDTO{
-		ID:         todo.ID,
-		UserID:     todo.UserID,
-		TodoListID: todolistID,
-		Title:      todo.Title,
-		Done:       todo.Done,
-		CreatedAt:  todo.CreatedAt.Format(time.RFC3339), // Format time as ISO string
+	if !returnDeleted {
+		w.WriteHeader(http.StatusNoContent) // 204 No Content
+		return
 	}
 
-	utils.WriteJSON(w, http.StatusOK, respTodo) // Return the todo as JSON
+	// ?return=true echoes the todo as it was before the delete, so a client can offer undo
+	respTodo := domain.NewTodoDTO(todo)
+
+	utils.WriteJSON(w, r, http.StatusOK, respTodo)
 }
 
-// UpdateTodo handles PUT /lists/{listID}/todos/{id} requests.
-func (h *TodoHandlers) UpdateTodo(w http.ResponseWriter, r *http.Request) {
+// UndoDelete handles POST /todos/{id}/undo-delete requests.
+// It restores a soft-deleted todo within the undo window, 410 Gone once the window has passed.
+func (h *TodoHandlers) UndoDelete(w http.ResponseWriter, r *http.Request) {
 	user, ok := auth.UserFromContext(r.Context())
 	if !ok {
-		utils.WriteJSON(w, http.StatusForbidden, domain.ErrorResponse{Error: "missing user"})
+		utils.WriteJSON(w, r, http.StatusForbidden, domain.ErrorResponse{Error: "missing user"})
 		return
 	}
 
-	//get {listID} parameter
-	idrl := chi.URLParam(r, "listID") // Get the "id" URL parameter
+	listID, _, ok := h.listIDFromPath(w, r, user.ID)
+	if !ok {
+		return
+	}
 
-	if idrl == "" {
-		utils.WriteJSON(w, http.StatusBadRequest, domain.ErrorResponse{Error: "id is required"})
+	// The todo is in the trash, so todoIDFromPath would not find it
+	publicID, err := utils.ParsePublicID(r, "id")
+	if err != nil {
+		utils.WriteJSON(w, r, http.StatusBadRequest, domain.ErrorResponse{Error: err.Error()})
 		return
 	}
 
-	todolistID, err := strconv.ParseInt(idrl, 10, 64) // Convert id string to int
+	todo, err := h.todoService.UndoDelete(r.Context(), user.ID, listID, publicID)
 	if err != nil {
-		utils.WriteJSON(w, http.StatusBadRequest, domain.ErrorResponse{Error: "id must be an integer"})
+		if errors.Is(err, domain.ErrNotFound) {
+			utils.WriteJSON(w, r, http.StatusNotFound, domain.ErrorResponse{Error: err.Error()})
+			return
+		}
+		if errors.Is(err, domain.ErrUndoExpired) {
+			utils.WriteJSON(w, r, http.StatusGone, domain.ErrorResponse{Error: err.Error()})
+			return
+		}
+		utils.WriteError(w, r, err)
 		return
 	}
 
-	idr := chi.URLParam(r, "id") // Get the "id" URL parameter
+	respTodo := domain.NewTodoDTO(todo)
+
+	utils.WriteJSON(w, r, http.StatusOK, respTodo)
+}
 
-	if idr == "" {
-		utils.WriteJSON(w, http.StatusBadRequest, domain.ErrorResponse{Error: "id is required"})
+// EmptyDone handles POST /todos/empty-done requests.
+func (h *TodoHandlers) EmptyDone(w http.ResponseWriter, r *http.Request) {
+	user, ok := auth.UserFromContext(r.Context())
+	if !ok {
+		utils.WriteJSON(w, r, http.StatusForbidden, domain.ErrorResponse{Error: "missing user"})
 		return
 	}
 
-	id, err := strconv.ParseInt(idr, 10, 64) // Convert id string to int
+	dryRun, err := utils.ParseDryRun(r)
 	if err != nil {
-		utils.WriteJSON(w, http.StatusBadRequest, domain.ErrorResponse{Error: "id must be an integer"})
+		utils.WriteJSON(w, r, http.StatusBadRequest, domain.ErrorResponse{Error: err.Error()})
 		return
 	}
 
-	var todoDTO domain.UpdateTodoDTO // Empty Todo struct to decode into
+	listID, _, ok := h.listIDFromPath(w, r, user.ID)
+	if !ok {
+		return
+	}
 
-	// Decode the JSON body into the todo struct
-	// If decoding fails, return 400 Bad Request
-	if err := json.NewDecoder(r.Body).Decode(&todoDTO); err != nil {
-		utils.WriteJSON(w, http.StatusBadRequest, domain.ErrorResponse{Error: err.Error()}) // Using struct for consistency
+	// A dry run only reports the todos that would be deleted
+	if dryRun {
+		result, err := h.todoService.EmptyDoneDryRun(r.Context(), user.ID, listID)
+		if err != nil {
+			utils.WriteError(w, r, err)
+			return
+		}
+
+		utils.WriteJSON(w, r, http.StatusOK, utils.DryRunResponse(result))
 		return
 	}
 
-	defer r.Body.Close() // Clean up - like closing a file; prevents leaks
+	count, err := h.todoService.EmptyDone(r.Context(), user.ID, listID)
+	if err != nil {
+		utils.WriteError(w, r, err) // Generic for security
+		return
+	}
 
-	// Validate using tags in UpdateTodoDTO (like Joi.validate in JS)
-	if err := validate.New().Struct(todoDTO); err != nil {
-		utils.WriteJSON(w, http.StatusBadRequest, domain.ErrorResponse{Error: err.Error()}) // Dynamic message, e.g., "Title is required"
+	utils.WriteJSON(w, r, http.StatusOK, domain.EmptyDoneResponseDTO{Count: count})
+}
+
+// RecentlyCompleted handles GET /me/todos/recently-completed requests.
+// The optional limit query param defaults to DefaultRecentlyCompletedLimit.
+func (h *TodoHandlers) RecentlyCompleted(w http.ResponseWriter, r *http.Request) {
+	user, ok := auth.UserFromContext(r.Context())
+	if !ok {
+		utils.WriteJSON(w, r, http.StatusForbidden, domain.ErrorResponse{Error: "missing user"})
 		return
 	}
 
-	// Call service to update (passes context for timeouts/cancellation)
-	updated, err := h.todoService.UpdateTodo(r.Context(), user.ID, id, todoDTO.Title, todoDTO.Done)
+	limit, err := h.pageSize.ParseLimit(r.URL.Query().Get("limit"), DefaultRecentlyCompletedLimit)
 	if err != nil {
-		if errors.Is(err, domain.ErrNotFound) { // Check custom error )
-			utils.WriteJSON(w, http.StatusNotFound, domain.ErrorResponse{Error: err.Error()}) // e.g., {"error": "todo not found"}
+		utils.WriteJSON(w, r, http.StatusBadRequest, domain.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	todos, err := h.todoService.RecentlyCompleted(r.Context(), user.ID, limit)
+	if err != nil {
+		utils.WriteError(w, r, err)
+		return
+	}
+
+	respTodos := make([]domain.TodoDTO, 0, len(todos))
+	for _, todo := range todos {
+		respTodos = append(respTodos, domain.NewTodoDTO(todo))
+	}
+
+	utils.WriteJSON(w, r, http.StatusOK, respTodos)
+}
+
+// ListOverdue handles GET /todos/overdue requests.
+// It returns the caller's not done todos past their due date across all lists, earliest due first.
+func (h *TodoHandlers) ListOverdue(w http.ResponseWriter, r *http.Request) {
+	user, ok := auth.UserFromContext(r.Context())
+	if !ok {
+		utils.WriteJSON(w, r, http.StatusForbidden, domain.ErrorResponse{Error: "missing user"})
+		return
+	}
+
+	todos, err := h.todoService.ListOverdue(r.Context(), user.ID)
+	if err != nil {
+		utils.WriteError(w, r, err)
+		return
+	}
+
+	respTodos := make([]domain.TodoDTO, 0, len(todos))
+	for _, todo := range todos {
+		respTodos = append(respTodos, domain.NewTodoDTO(todo))
+	}
+
+	utils.WriteJSON(w, r, http.StatusOK, respTodos)
+}
+
+// ToggleAll handles POST /todos/toggle-all requests.
+// The body {"done": true} marks every todo of the list done, false marks them not done.
+func (h *TodoHandlers) ToggleAll(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	user, ok := auth.UserFromContext(r.Context())
+	if !ok {
+		utils.WriteJSON(w, r, http.StatusForbidden, domain.ErrorResponse{Error: "missing user"})
+		return
+	}
+
+	// Resolving the list checks that the caller owns it
+	listID, _, ok := h.listIDFromPath(w, r, user.ID)
+	if !ok {
+		return
+	}
+
+	var req domain.ToggleAllRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteJSON(w, r, http.StatusBadRequest, domain.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	if err := validate.New().Struct(req); err != nil {
+		utils.WriteValidationError(w, r, translateValidationError(err))
+		return
+	}
+
+	count, err := h.todoService.ToggleAll(r.Context(), user.ID, listID, *req.Done)
+	if err != nil {
+		utils.WriteError(w, r, err) // Generic for security
+		return
+	}
+
+	utils.WriteJSON(w, r, http.StatusOK, domain.ToggleAllResponseDTO{Count: count})
+}
+
+// listIDFromPath resolves the public id in the {listID} URL param to the internal list id.
+// It also returns the public id for the response. On failure the error response is already written.
+func (h *TodoHandlers) listIDFromPath(w http.ResponseWriter, r *http.Request, userID int64) (int64, string, bool) {
+	publicID, err := utils.ParsePublicID(r, "listID")
+	if err != nil {
+		utils.WriteJSON(w, r, http.StatusBadRequest, domain.ErrorResponse{Error: err.Error()})
+		return 0, "", false
+	}
+
+	id, err := h.todoService.ResolveListID(r.Context(), userID, publicID)
+	if err != nil {
+		if errors.Is(err, domain.ErrListNotFound) {
+			utils.WriteJSON(w, r, http.StatusNotFound, domain.ErrorResponse{Error: err.Error()})
+			return 0, "", false
+		}
+		utils.WriteError(w, r, err)
+		return 0, "", false
+	}
+
+	return id, publicID, true
+}
+
+// samePublicID reports whether the body id is the same UUID as the canonical path id.
+// A body id that is not a UUID never matches.
+func samePublicID(bodyID string, pathID string) bool {
+	id, err := uuid.Parse(bodyID)
+	if err != nil {
+		return false
+	}
+
+	return id.String() == pathID
+}
+
+// CloneTodo handles POST /todos/{id}/clone requests.
+// The copy goes into the same list and is not done, the optional body can give it another title.
+func (h *TodoHandlers) CloneTodo(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	user, ok := auth.UserFromContext(r.Context())
+	if !ok {
+		utils.WriteJSON(w, r, http.StatusForbidden, domain.ErrorResponse{Error: "missing user"})
+		return
+	}
+
+	id, ok := h.todoIDFromPath(w, r, user.ID)
+	if !ok {
+		return
+	}
+
+	// The body is optional, an empty one keeps the title
+	var reqClone domain.CloneTodoDTO
+	if err := json.NewDecoder(r.Body).Decode(&reqClone); err != nil && !errors.Is(err, io.EOF) {
+		utils.WriteJSON(w, r, http.StatusBadRequest, domain.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	if err := validate.New().Struct(reqClone); err != nil {
+		utils.WriteValidationError(w, r, translateValidationError(err))
+		return
+	}
+
+	clone, err := h.todoService.Clone(r.Context(), user.ID, id, strings.TrimSpace(reqClone.Title))
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			utils.WriteJSON(w, r, http.StatusNotFound, domain.ErrorResponse{Error: err.Error()})
 			return
-		} else if errors.Is(err, domain.ErrInvalidTitle) { // Optional: If service returns this
-			utils.WriteJSON(w, http.StatusBadRequest, domain.ErrorResponse{Error: err.Error()})
+		}
+		if errors.Is(err, domain.ErrInvalidTitle) {
+			utils.WriteValidationError(w, r, err.Error())
 			return
 		}
-		// TODO: Add logging here, e.g., log.Printf("Internal error updating todo %d: %v", id, err)
-		utils.WriteJSON(w, http.StatusInternalServerError, domain.ErrorResponse{Error: "internal server error"}) // Generic for security
+		utils.WriteError(w, r, err)
 		return
 	}
 
-	respTodo := domain.TodoDTO{
-		ID:         updated.ID,
-		UserID:     user.ID,
-		TodoListID: todolistID,
-		Title:      updated.Title,
-		Done:       updated.Done,
-	}
+	respTodo := domain.NewTodoDTO(clone)
 
-	utils.WriteJSON(w, http.StatusOK, respTodo) // Return the updated todo as JSON
+	utils.WriteJSON(w, r, http.StatusCreated, respTodo)
 }
 
-// DeleteTodo handles DELETE /todos/{id} requests.
-func (h *TodoHandlers) DeleteTodo(w http.ResponseWriter, r *http.Request) {
+// MarkDone handles POST /todos/{id}/done requests.
+func (h *TodoHandlers) MarkDone(w http.ResponseWriter, r *http.Request) {
+	h.setDone(w, r, true)
+}
+
+// MarkUndone handles POST /todos/{id}/undone requests.
+func (h *TodoHandlers) MarkUndone(w http.ResponseWriter, r *http.Request) {
+	h.setDone(w, r, false)
+}
+
+// setDone changes only the done flag, so a client can toggle a todo without sending its title
+func (h *TodoHandlers) setDone(w http.ResponseWriter, r *http.Request, done bool) {
 	user, ok := auth.UserFromContext(r.Context())
 	if !ok {
-		utils.WriteJSON(w, http.StatusForbidden, domain.ErrorResponse{Error: "missing user"})
+		utils.WriteJSON(w, r, http.StatusForbidden, domain.ErrorResponse{Error: "missing user"})
 		return
 	}
 
-	idr := chi.URLParam(r, "id") // Get the "id" URL parameter
-	if idr == "" {
-		utils.WriteJSON(w, http.StatusBadRequest, domain.ErrorResponse{Error: "id is required"})
+	id, ok := h.todoIDFromPath(w, r, user.ID)
+	if !ok {
 		return
 	}
 
-	id, err := strconv.ParseInt(idr, 10, 64) // Convert id string to int
+	todo, err := h.todoService.SetDone(r.Context(), user.ID, id, done)
 	if err != nil {
-		utils.WriteJSON(w, http.StatusBadRequest, domain.ErrorResponse{Error: "id must be an integer"})
+		if errors.Is(err, domain.ErrNotFound) {
+			utils.WriteJSON(w, r, http.StatusNotFound, domain.ErrorResponse{Error: err.Error()})
+			return
+		}
+		utils.WriteError(w, r, err)
 		return
 	}
 
-	if err := h.todoService.DeleteTodo(r.Context(), user.ID, id); err != nil {
-		if errors.Is(err, domain.ErrNotFound) {
-			utils.WriteJSON(w, http.StatusNotFound, domain.ErrorResponse{Error: err.Error()}) // e.g., {"error": "todo not found"}
+	respTodo := domain.NewTodoDTO(todo)
+
+	utils.WriteJSON(w, r, http.StatusOK, respTodo)
+}
+
+// BatchGet handles POST /todos/batch-get requests.
+// It returns the caller's todos among the requested public ids, ordered by id.
+// Ids that don't exist or belong to another user are left out, so a client can tell which of its todos are gone.
+func (h *TodoHandlers) BatchGet(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	user, ok := auth.UserFromContext(r.Context())
+	if !ok {
+		utils.WriteJSON(w, r, http.StatusForbidden, domain.ErrorResponse{Error: "missing user"})
+		return
+	}
+
+	var reqBatch domain.BatchGetTodosDTO
+	if err := json.NewDecoder(r.Body).Decode(&reqBatch); err != nil {
+		utils.WriteJSON(w, r, http.StatusBadRequest, domain.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	if len(reqBatch.IDs) == 0 {
+		utils.WriteJSON(w, r, http.StatusBadRequest, domain.ErrorResponse{Error: "ids is required"})
+		return
+	}
+	if len(reqBatch.IDs) > MaxBatchGetIDs {
+		utils.WriteJSON(w, r, http.StatusBadRequest, domain.ErrorResponse{Error: fmt.Sprintf("ids must have at most %d entries", MaxBatchGetIDs)})
+		return
+	}
+
+	publicIDs := make([]string, len(reqBatch.IDs))
+	for i, value := range reqBatch.IDs {
+		id, err := uuid.Parse(value)
+		if err != nil {
+			utils.WriteJSON(w, r, http.StatusBadRequest, domain.ErrorResponse{Error: utils.ErrIDNotUUID.Error()})
 			return
 		}
-		utils.WriteJSON(w, http.StatusInternalServerError, domain.ErrorResponse{Error: "internal server error"}) // Generic for security
+		publicIDs[i] = id.String()
+	}
+
+	todos, err := h.todoService.BatchGet(r.Context(), user.ID, publicIDs)
+	if err != nil {
+		utils.WriteError(w, r, err)
 		return
 	}
 
-	w.WriteHeader(http.StatusNoContent) // 204 No Content
+	respTodos := make([]domain.TodoDTO, len(todos))
+	for i, todo := range todos {
+		respTodos[i] = domain.NewTodoDTO(todo)
+	}
+
+	utils.WriteJSON(w, r, http.StatusOK, respTodos)
+}
+
+// todoIDFromPath resolves the public id in the {id} URL param to the internal todo id.
+// On failure the error response is already written.
+func (h *TodoHandlers) todoIDFromPath(w http.ResponseWriter, r *http.Request, userID int64) (int64, bool) {
+	publicID, err := utils.ParsePublicID(r, "id")
+	if err != nil {
+		utils.WriteJSON(w, r, http.StatusBadRequest, domain.ErrorResponse{Error: err.Error()})
+		return 0, false
+	}
+
+	id, err := h.todoService.ResolveTodoID(r.Context(), userID, publicID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			utils.WriteJSON(w, r, http.StatusNotFound, domain.ErrorResponse{Error: err.Error()})
+			return 0, false
+		}
+		utils.WriteError(w, r, err)
+		return 0, false
+	}
+
+	return id, true
+}
+
+// todoInListFromPath resolves the {listID} and {id} URL params and checks that the todo is in that list.
+// It returns the internal todo id and the public list id. On failure the error response is already written.
+func (h *TodoHandlers) todoInListFromPath(w http.ResponseWriter, r *http.Request, userID int64) (int64, string, bool) {
+	todolistID, listPublicID, ok := h.listIDFromPath(w, r, userID)
+	if !ok {
+		return 0, "", false
+	}
+
+	id, ok := h.todoIDFromPath(w, r, userID)
+	if !ok {
+		return 0, "", false
+	}
+
+	// A todo of another list is not found, even when it is the user's
+	if _, err := h.todoService.GetTodoInList(r.Context(), userID, todolistID, id); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			utils.WriteJSON(w, r, http.StatusNotFound, domain.ErrorResponse{Error: err.Error()})
+			return 0, "", false
+		}
+		utils.WriteError(w, r, err)
+		return 0, "", false
+	}
+
+	return id, listPublicID, true
 }
 
 // translateValidationError converts validator errors to user-friendly strings
@@ -327,6 +689,10 @@ func translateValidationError(err error) string {
 			default:
 				messages = append(messages, "title is invalid")
 			}
+		case "Done":
+			messages = append(messages, "done is required")
+		case "Color":
+			messages = append(messages, "color must be a hex color like #FF5733")
 		default:
 			messages = append(messages, fmt.Sprintf("%s is invalid", strings.ToLower(fieldErr.Field())))
 		}
@@ -339,3 +705,74 @@ func translateValidationError(err error) string {
 
 	return strings.Join(messages, "; ") // Combine if multiple errors
 }
+
+const dueDateMessage = "due_date must be an RFC3339 time like 2024-01-05T09:00:00Z"
+
+// isDueDateError reports whether decoding failed on a due_date that is not RFC3339.
+// The body is well-formed JSON then, so it is a validation error rather than a bad request.
+func isDueDateError(err error) bool {
+	var parseErr *time.ParseError
+	return errors.As(err, &parseErr)
+}
+
+// ImportCSV handles POST /todos/import.csv requests.
+// The multipart form has the CSV in its file field, with a header row naming the list_id, title and done columns.
+// Every data row gets a result, with the id of the created todo or why it was skipped.
+func (h *TodoHandlers) ImportCSV(w http.ResponseWriter, r *http.Request) {
+	user, ok := auth.UserFromContext(r.Context())
+	if !ok {
+		utils.WriteJSON(w, r, http.StatusForbidden, domain.ErrorResponse{Error: "missing user"})
+		return
+	}
+
+	// Reading past the limit fails the form parsing below, instead of buffering any size of upload
+	r.Body = http.MaxBytesReader(w, r.Body, MaxCSVUploadSize)
+
+	file, fileHeader, err := r.FormFile("file")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			utils.WriteJSON(w, r, http.StatusRequestEntityTooLarge, domain.ErrorResponse{Error: fmt.Sprintf("the upload is larger than %d bytes", MaxCSVUploadSize)})
+			return
+		}
+		utils.WriteJSON(w, r, http.StatusBadRequest, domain.ErrorResponse{Error: "a csv file is required in the file field"})
+		return
+	}
+	defer file.Close()
+
+	isCSV, err := isCSVUpload(file, fileHeader.Header.Get("Content-Type"))
+	if err != nil {
+		utils.WriteError(w, r, err)
+		return
+	}
+	if !isCSV {
+		utils.WriteJSON(w, r, http.StatusUnsupportedMediaType, domain.ErrorResponse{Error: "the upload must be a csv file"})
+		return
+	}
+
+	rows, err := readCSVTodoRows(file)
+	if err != nil {
+		utils.WriteJSON(w, r, http.StatusBadRequest, domain.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	results, err := h.todoService.ImportCSV(r.Context(), user.ID, rows)
+	if err != nil {
+		utils.WriteError(w, r, err)
+		return
+	}
+
+	resp := domain.CSVImportResponseDTO{Rows: make([]domain.CSVImportRowResult, len(results))}
+	for i, result := range results {
+		resp.Rows[i] = domain.CSVImportRowResult{Line: result.Line, ID: result.ID}
+
+		if result.Err != nil {
+			resp.Rows[i].Error = result.Err.Error()
+			resp.Failed++
+			continue
+		}
+		resp.Created++
+	}
+
+	utils.WriteJSON(w, r, http.StatusOK, resp)
+}
@@ -3,17 +3,20 @@
 package todo
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
-	"strconv"
+	"net/textproto"
 	"strings"
 	"testing"
 	"time"
 
-	"github.com/macesz/todo-go/tests/testutils"
+	"github.com/macesz/todo-go/delivery/web/auth"
 
 	chi "github.com/go-chi/chi/v5"
 	"github.com/macesz/todo-go/delivery/web/todo/mocks"
@@ -28,9 +31,13 @@ func TestListTodos(t *testing.T) {
 	fixedTime := time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC)
 	testUserID := int64(1)
 	testListID := int64(1)
+	done := true
 
 	tests := []struct {
 		name           string
+		query          string
+		skipMock       bool
+		mockOpts       domain.ListOptions
 		mockReturn     []*domain.Todo
 		mockError      error
 		expectedStatus int
@@ -46,11 +53,28 @@ func TestListTodos(t *testing.T) {
 		{
 			name: "One todo",
 			mockReturn: []*domain.Todo{
-				{ID: 1, UserID: testUserID, TodoListID: testListID, Title: "Test Todo 1", Done: false, CreatedAt: fixedTime},
+				{ID: 1, PublicID: publicID(1), UserID: testUserID, TodoListID: testListID, Title: "Test Todo 1", Done: false, CreatedAt: fixedTime, UpdatedAt: fixedTime, Source: domain.TodoSourceImport},
 			},
 			mockError:      nil,
 			expectedStatus: http.StatusOK,
-			expectedBody:   `[{"ID":1,"UserID": 1, "TodoListID": 1, "Title":"Test Todo 1","Done":false, "CreatedAt":"2024-01-01T12:00:00Z"}]`,
+			expectedBody:   `[{"id":"00000000-0000-0000-0000-000000000001","user_id":1,"todolist_id":"00000000-0000-0000-0000-000000000001","title":"Test Todo 1","done":false,"created_at":"2024-01-01T12:00:00Z","updated_at":"2024-01-01T12:00:00Z","source":"import"}]`,
+		},
+		{
+			name:     "With list options",
+			query:    "?limit=1&offset=1&sort=title&order=desc&done=true",
+			mockOpts: domain.ListOptions{Limit: 1, Offset: 1, Sort: "title", Order: "desc", Done: &done},
+			mockReturn: []*domain.Todo{
+				{ID: 2, PublicID: publicID(2), UserID: testUserID, TodoListID: testListID, Title: "Test Todo 2", Done: true, CreatedAt: fixedTime, UpdatedAt: fixedTime},
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `[{"id":"00000000-0000-0000-0000-000000000002","user_id":1,"todolist_id":"00000000-0000-0000-0000-000000000001","title":"Test Todo 2","done":true,"created_at":"2024-01-01T12:00:00Z","updated_at":"2024-01-01T12:00:00Z"}]`,
+		},
+		{
+			name:           "Invalid list options",
+			query:          "?sort=password",
+			skipMock:       true,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"invalid input: sort must be one of [id title created_at]"}`,
 		},
 		{
 			name:           "Service error",
@@ -65,21 +89,92 @@ func TestListTodos(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mockService := mocks.NewTodoService(t)
 
-			// Updated to match new signature with ListID
-			mockService.On("ListTodos", mock.Anything, testUserID, testListID).
-				Return(tt.mockReturn, tt.mockError).
-				Once()
+			expectResolveList(mockService, testUserID, testListID)
+
+			if !tt.skipMock {
+				mockService.On("ListTodos", mock.Anything, testUserID, testListID, tt.mockOpts).
+					Return(tt.mockReturn, tt.mockError).
+					Once()
+			}
 
 			handlers := &TodoHandlers{todoService: mockService}
 
-			req, err := http.NewRequest(http.MethodGet, "/lists/{listID}/todos/", nil)
+			req, err := http.NewRequest(http.MethodGet, "/lists/"+publicID(testListID)+"/todos/"+tt.query, nil)
 			require.NoError(t, err)
 
 			// Add user context to simulate authenticated request
-			req = testutils.WithUserContext(req, testUserID)
+			req = withUserContext(req, testUserID)
+
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("listID", publicID(testListID)) // Add the listID parameter
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+			rr := httptest.NewRecorder()
+			handlers.ListTodos(rr, req)
+
+			require.Equal(t, tt.expectedStatus, rr.Code)
+			assert.JSONEq(t, tt.expectedBody, rr.Body.String())
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+// TestListTodosListID tests how ListTodos handles the public list id of the path
+func TestListTodosListID(t *testing.T) {
+	testUserID := int64(1)
+
+	tests := []struct {
+		name           string
+		listID         string
+		resolveErr     error
+		shouldResolve  bool
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "Integer list ID",
+			listID:         "1",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"id must be a UUID"}`,
+		},
+		{
+			name:           "Unknown list ID",
+			listID:         publicID(42),
+			shouldResolve:  true,
+			resolveErr:     domain.ErrListNotFound,
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"error":"todo list not found"}`,
+		},
+		{
+			name:           "Resolve error",
+			listID:         publicID(42),
+			shouldResolve:  true,
+			resolveErr:     errors.New("database error"),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"error":"internal server error"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := mocks.NewTodoService(t)
+
+			if tt.shouldResolve {
+				mockService.On("ResolveListID", mock.Anything, testUserID, tt.listID).
+					Return(int64(0), tt.resolveErr).
+					Once()
+			}
+
+			handlers := &TodoHandlers{todoService: mockService}
+
+			req, err := http.NewRequest(http.MethodGet, "/lists/"+tt.listID+"/todos/", nil)
+			require.NoError(t, err)
+
+			req = withUserContext(req, testUserID)
 
 			rctx := chi.NewRouteContext()
-			rctx.URLParams.Add("listID", "1") // Add the listID parameter
+			rctx.URLParams.Add("listID", tt.listID)
 			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
 
 			rr := httptest.NewRecorder()
@@ -101,6 +196,7 @@ func TestCreateTodo(t *testing.T) {
 	tests := []struct {
 		name           string
 		inputBody      string
+		strictListID   bool
 		setupUserMock  func(*mocks.UserService)
 		setupTodoMock  func(*mocks.TodoService)
 		expectedStatus int
@@ -115,19 +211,71 @@ func TestCreateTodo(t *testing.T) {
 					Once()
 			},
 			setupTodoMock: func(m *mocks.TodoService) {
-				m.On("CreateTodo", mock.Anything, testUserID, testListID, "New Todo", int64(2)).
+				m.On("CreateTodo", mock.Anything, testUserID, testListID, "New Todo", (*time.Time)(nil), (*string)(nil)).
 					Return(&domain.Todo{
 						ID:         1,
+						PublicID:   publicID(1),
 						UserID:     testUserID,
 						TodoListID: testListID,
 						Title:      "New Todo",
 						Done:       false,
 						CreatedAt:  fixedTime,
-					}, nil).
+						UpdatedAt:  fixedTime,
+					}, []string(nil), nil).
+					Once()
+			},
+			expectedStatus: http.StatusCreated,
+			expectedBody:   `{"id":"00000000-0000-0000-0000-000000000001","user_id":1,"todolist_id":"00000000-0000-0000-0000-000000000001","title":"New Todo","done":false, "created_at":"2024-01-01T12:00:00Z","updated_at":"2024-01-01T12:00:00Z"}`,
+		},
+		{
+			name:      "With due date",
+			inputBody: `{"title": "New Todo", "due_date": "2024-01-05T09:00:00Z"}`,
+			setupUserMock: func(m *mocks.UserService) {
+				m.On("GetUser", mock.Anything, testUserID).
+					Return(&domain.User{ID: testUserID, Name: "Test User", Email: "test@example.com"}, nil).
+					Once()
+			},
+			setupTodoMock: func(m *mocks.TodoService) {
+				due := time.Date(2024, time.January, 5, 9, 0, 0, 0, time.UTC)
+				m.On("CreateTodo", mock.Anything, testUserID, testListID, "New Todo", &due, (*string)(nil)).
+					Return(&domain.Todo{
+						ID:         3,
+						PublicID:   publicID(3),
+						UserID:     testUserID,
+						TodoListID: testListID,
+						Title:      "New Todo",
+						CreatedAt:  fixedTime,
+						UpdatedAt:  fixedTime,
+						DueDate:    &due,
+					}, []string(nil), nil).
+					Once()
+			},
+			expectedStatus: http.StatusCreated,
+			expectedBody:   `{"id":"00000000-0000-0000-0000-000000000003","user_id":1,"todolist_id":"00000000-0000-0000-0000-000000000001","title":"New Todo","done":false,"created_at":"2024-01-01T12:00:00Z","updated_at":"2024-01-01T12:00:00Z","due_date":"2024-01-05T09:00:00Z"}`,
+		},
+		{
+			name:      "Duplicate title - created with warning",
+			inputBody: `{"title": "New Todo"}`,
+			setupUserMock: func(m *mocks.UserService) {
+				m.On("GetUser", mock.Anything, testUserID).
+					Return(&domain.User{ID: testUserID, Name: "Test User", Email: "test@example.com"}, nil).
+					Once()
+			},
+			setupTodoMock: func(m *mocks.TodoService) {
+				m.On("CreateTodo", mock.Anything, testUserID, testListID, "New Todo", (*time.Time)(nil), (*string)(nil)).
+					Return(&domain.Todo{
+						ID:         2,
+						PublicID:   publicID(2),
+						UserID:     testUserID,
+						TodoListID: testListID,
+						Title:      "New Todo",
+						CreatedAt:  fixedTime,
+						UpdatedAt:  fixedTime,
+					}, []string{domain.WarnDuplicateTitle}, nil).
 					Once()
 			},
 			expectedStatus: http.StatusCreated,
-			expectedBody:   `{"id":1,"user_id":1,"todolist_id":1,"title":"New Todo","done":false, "created_at":"2024-01-01T12:00:00Z"}`,
+			expectedBody:   `{"id":"00000000-0000-0000-0000-000000000002","user_id":1,"todolist_id":"00000000-0000-0000-0000-000000000001","title":"New Todo","done":false,"created_at":"2024-01-01T12:00:00Z","updated_at":"2024-01-01T12:00:00Z","warnings":["a todo with this title already exists in the list"]}`,
 		},
 		{
 			name:      "Missing title",
@@ -140,9 +288,129 @@ func TestCreateTodo(t *testing.T) {
 			setupTodoMock: func(m *mocks.TodoService) {
 				// Should not be called due to validation error
 			},
-			expectedStatus: http.StatusBadRequest,
+			expectedStatus: http.StatusUnprocessableEntity,
 			expectedBody:   `{"error":"title is required"}`,
 		},
+		{
+			name:      "Invalid color",
+			inputBody: `{"title": "New Todo", "color": "red"}`,
+			setupUserMock: func(m *mocks.UserService) {
+				m.On("GetUser", mock.Anything, testUserID).
+					Return(&domain.User{ID: testUserID, Name: "Test User", Email: "test@example.com"}, nil).
+					Once()
+			},
+			setupTodoMock: func(m *mocks.TodoService) {
+				// Should not be called due to validation error
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   `{"error":"color must be a hex color like #FF5733"}`,
+		},
+		{
+			name:      "Invalid due date",
+			inputBody: `{"title": "New Todo", "due_date": "tomorrow"}`,
+			setupUserMock: func(m *mocks.UserService) {
+				m.On("GetUser", mock.Anything, testUserID).
+					Return(&domain.User{ID: testUserID, Name: "Test User", Email: "test@example.com"}, nil).
+					Once()
+			},
+			setupTodoMock: func(m *mocks.TodoService) {
+				// Should not be called, the due date does not parse
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   `{"error":"due_date must be an RFC3339 time like 2024-01-05T09:00:00Z"}`,
+		},
+		{
+			name:      "With color",
+			inputBody: `{"title": "New Todo", "color": "#00AA00"}`,
+			setupUserMock: func(m *mocks.UserService) {
+				m.On("GetUser", mock.Anything, testUserID).
+					Return(&domain.User{ID: testUserID, Name: "Test User", Email: "test@example.com"}, nil).
+					Once()
+			},
+			setupTodoMock: func(m *mocks.TodoService) {
+				color := "#00AA00"
+				listColor := "#FF5733"
+				m.On("CreateTodo", mock.Anything, testUserID, testListID, "New Todo", (*time.Time)(nil), &color).
+					Return(&domain.Todo{
+						ID:         4,
+						PublicID:   publicID(4),
+						UserID:     testUserID,
+						TodoListID: testListID,
+						Title:      "New Todo",
+						CreatedAt:  fixedTime,
+						UpdatedAt:  fixedTime,
+						Color:      &color,
+						ListColor:  &listColor,
+					}, []string(nil), nil).
+					Once()
+			},
+			expectedStatus: http.StatusCreated,
+			expectedBody:   `{"id":"00000000-0000-0000-0000-000000000004","user_id":1,"todolist_id":"00000000-0000-0000-0000-000000000001","title":"New Todo","done":false,"created_at":"2024-01-01T12:00:00Z","updated_at":"2024-01-01T12:00:00Z","color":"#00AA00"}`,
+		},
+		{
+			name:         "Conflicting body list_id - strict",
+			inputBody:    `{"title": "New Todo", "list_id": "00000000-0000-0000-0000-000000000002"}`,
+			strictListID: true,
+			setupUserMock: func(m *mocks.UserService) {
+				m.On("GetUser", mock.Anything, testUserID).
+					Return(&domain.User{ID: testUserID, Name: "Test User", Email: "test@example.com"}, nil).
+					Once()
+			},
+			setupTodoMock: func(m *mocks.TodoService) {
+				// Should not be called, the body list_id conflicts with the path
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"list_id does not match the list in the path"}`,
+		},
+		{
+			name:      "Conflicting body list_id - not strict, path wins",
+			inputBody: `{"title": "New Todo", "list_id": "00000000-0000-0000-0000-000000000002"}`,
+			setupUserMock: func(m *mocks.UserService) {
+				m.On("GetUser", mock.Anything, testUserID).
+					Return(&domain.User{ID: testUserID, Name: "Test User", Email: "test@example.com"}, nil).
+					Once()
+			},
+			setupTodoMock: func(m *mocks.TodoService) {
+				m.On("CreateTodo", mock.Anything, testUserID, testListID, "New Todo", (*time.Time)(nil), (*string)(nil)).
+					Return(&domain.Todo{
+						ID:         1,
+						PublicID:   publicID(1),
+						UserID:     testUserID,
+						TodoListID: testListID,
+						Title:      "New Todo",
+						CreatedAt:  fixedTime,
+						UpdatedAt:  fixedTime,
+					}, []string(nil), nil).
+					Once()
+			},
+			expectedStatus: http.StatusCreated,
+			expectedBody:   `{"id":"00000000-0000-0000-0000-000000000001","user_id":1,"todolist_id":"00000000-0000-0000-0000-000000000001","title":"New Todo","done":false,"created_at":"2024-01-01T12:00:00Z","updated_at":"2024-01-01T12:00:00Z"}`,
+		},
+		{
+			name:         "Matching body list_id - strict",
+			inputBody:    `{"title": "New Todo", "list_id": "00000000-0000-0000-0000-000000000001"}`,
+			strictListID: true,
+			setupUserMock: func(m *mocks.UserService) {
+				m.On("GetUser", mock.Anything, testUserID).
+					Return(&domain.User{ID: testUserID, Name: "Test User", Email: "test@example.com"}, nil).
+					Once()
+			},
+			setupTodoMock: func(m *mocks.TodoService) {
+				m.On("CreateTodo", mock.Anything, testUserID, testListID, "New Todo", (*time.Time)(nil), (*string)(nil)).
+					Return(&domain.Todo{
+						ID:         1,
+						PublicID:   publicID(1),
+						UserID:     testUserID,
+						TodoListID: testListID,
+						Title:      "New Todo",
+						CreatedAt:  fixedTime,
+						UpdatedAt:  fixedTime,
+					}, []string(nil), nil).
+					Once()
+			},
+			expectedStatus: http.StatusCreated,
+			expectedBody:   `{"id":"00000000-0000-0000-0000-000000000001","user_id":1,"todolist_id":"00000000-0000-0000-0000-000000000001","title":"New Todo","done":false,"created_at":"2024-01-01T12:00:00Z","updated_at":"2024-01-01T12:00:00Z"}`,
+		},
 	}
 
 	for _, tt := range tests {
@@ -153,12 +421,14 @@ func TestCreateTodo(t *testing.T) {
 
 			// Setup mocks
 			tt.setupUserMock(mockUserService)
+			expectResolveList(mockTodoService, testUserID, testListID)
 			tt.setupTodoMock(mockTodoService)
 
 			// Create handlers with both services
 			handlers := &TodoHandlers{
-				userService: mockUserService,
-				todoService: mockTodoService,
+				userService:  mockUserService,
+				todoService:  mockTodoService,
+				strictListID: tt.strictListID,
 			}
 
 			// Create request
@@ -167,10 +437,10 @@ func TestCreateTodo(t *testing.T) {
 			req.Header.Set("Content-Type", "application/json")
 
 			// Add user context
-			req = testutils.WithUserContext(req, testUserID)
+			req = withUserContext(req, testUserID)
 
 			rctx := chi.NewRouteContext()
-			rctx.URLParams.Add("listID", "1") // Add the listID parameter
+			rctx.URLParams.Add("listID", publicID(testListID)) // Add the listID parameter
 			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
 
 			// Create response recorder
@@ -199,7 +469,9 @@ func TestGetTodo(t *testing.T) {
 
 	tests := []struct {
 		name           string
+		id             int64 // Internal id the public id resolves to, 0 when it can't be resolved
 		urlParam       string
+		resolveErr     error
 		shouldCallMock bool
 		mockReturn     *domain.Todo
 		mockError      error
@@ -208,47 +480,67 @@ func TestGetTodo(t *testing.T) {
 	}{
 		{
 			name:           "Valid ID",
-			urlParam:       "1",
+			id:             1,
+			urlParam:       publicID(1),
 			shouldCallMock: true,
-			mockReturn:     &domain.Todo{ID: 1, UserID: testUserID, TodoListID: testListID, Title: "Test Todo", Done: false, CreatedAt: fixedTime},
+			mockReturn:     &domain.Todo{ID: 1, PublicID: publicID(1), UserID: testUserID, TodoListID: testListID, Title: "Test Todo", Done: false, CreatedAt: fixedTime, UpdatedAt: fixedTime},
 			mockError:      nil,
 			expectedStatus: http.StatusOK,
-			expectedBody:   `{"id":1,"user_id":1,"todolist_id":1,"title":"Test Todo","done":false, "created_at":"2024-01-01T12:00:00Z"}`,
+			expectedBody:   `{"id":"00000000-0000-0000-0000-000000000001","user_id":1,"todolist_id":"00000000-0000-0000-0000-000000000001","title":"Test Todo","done":false, "created_at":"2024-01-01T12:00:00Z","updated_at":"2024-01-01T12:00:00Z"}`,
 		},
 		{
 			name:           "Todo not found",
-			urlParam:       "999",
+			urlParam:       publicID(999),
+			resolveErr:     domain.ErrNotFound,
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"error":"todo not found"}`,
+		},
+		{
+			name:           "Todo in another list",
+			id:             3,
+			urlParam:       publicID(3),
 			shouldCallMock: true,
 			mockReturn:     nil,
 			mockError:      domain.ErrNotFound,
 			expectedStatus: http.StatusNotFound,
 			expectedBody:   `{"error":"todo not found"}`,
 		},
+		{
+			name:           "Integer ID",
+			urlParam:       "1",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"id must be a UUID"}`,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockService := mocks.NewTodoService(t)
 
+			expectResolveList(mockService, testUserID, testListID)
+			if tt.id != 0 || tt.resolveErr != nil {
+				mockService.On("ResolveTodoID", mock.Anything, testUserID, tt.urlParam).
+					Return(tt.id, tt.resolveErr).
+					Once()
+			}
+
 			if tt.shouldCallMock {
-				expectedID, _ := strconv.ParseInt(tt.urlParam, 10, 64)
-				// Updated to match new signature: GetTodo(ctx, userID, todoID)
-				mockService.On("GetTodo", mock.Anything, testUserID, expectedID).
+				mockService.On("GetTodoInList", mock.Anything, testUserID, testListID, tt.id).
 					Return(tt.mockReturn, tt.mockError).
 					Once()
 			}
 
 			handler := &TodoHandlers{todoService: mockService}
 
-			req, err := http.NewRequest(http.MethodGet, "/lists/1/todos/"+tt.urlParam, nil)
+			req, err := http.NewRequest(http.MethodGet, "/lists/"+publicID(testListID)+"/todos/"+tt.urlParam, nil)
 			require.NoError(t, err)
 
 			// Add user context
-			req = testutils.WithUserContext(req, testUserID)
+			req = withUserContext(req, testUserID)
 
 			// Add chi URL params
 			rctx := chi.NewRouteContext()
-			rctx.URLParams.Add("listID", "1")
+			rctx.URLParams.Add("listID", publicID(testListID))
 			rctx.URLParams.Add("id", tt.urlParam)
 			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
 
@@ -270,45 +562,116 @@ func TestGetTodo(t *testing.T) {
 func TestUpdateTodo(t *testing.T) {
 	fixedTime := time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC)
 	testUserID := int64(1)
+	listColor := "#FF5733"
+	todoColor := "#00AA00"
 
 	tests := []struct {
 		name           string
 		urlParam       string
 		inputBody      string
+		inListErr      error // What the check that the todo is in the list of the path returns
 		shouldCallMock bool
+		wantColor      *string
 		mockReturn     *domain.Todo
+		mockWarnings   []string
 		mockError      error
 		expectedStatus int
 		expectedBody   string
 	}{
 		{
 			name:           "Valid input",
-			urlParam:       "1",
-			inputBody:      `{"title":"Updated Todo","done":true,}`,
+			urlParam:       publicID(1),
+			inputBody:      `{"title":"Updated Todo","done":true}`,
 			shouldCallMock: true,
-			mockReturn:     &domain.Todo{ID: 1, UserID: testUserID, TodoListID: 1, Title: "Updated Todo", Done: true, CreatedAt: fixedTime},
+			mockReturn:     &domain.Todo{ID: 1, PublicID: publicID(1), UserID: testUserID, TodoListID: 1, Title: "Updated Todo", Done: true, CreatedAt: fixedTime, UpdatedAt: fixedTime},
 			mockError:      nil,
 			expectedStatus: http.StatusOK,
-			expectedBody:   `{"id":1,"user_id":1,"todolist_id":1,"title":"Updated Todo","done":true,"created_at":""}`,
+			expectedBody:   `{"id":"00000000-0000-0000-0000-000000000001","user_id":1,"todolist_id":"00000000-0000-0000-0000-000000000001","title":"Updated Todo","done":true,"created_at":"2024-01-01T12:00:00Z","updated_at":"2024-01-01T12:00:00Z"}`,
+		},
+		{
+			name:           "Updated with warning",
+			urlParam:       publicID(1),
+			inputBody:      `{"title":"Updated Todo","done":true}`,
+			shouldCallMock: true,
+			mockReturn:     &domain.Todo{ID: 1, PublicID: publicID(1), UserID: testUserID, TodoListID: 1, Title: "Updated Todo", Done: true, CreatedAt: fixedTime, UpdatedAt: fixedTime},
+			mockWarnings:   []string{domain.WarnDuplicateTitle},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"id":"00000000-0000-0000-0000-000000000001","user_id":1,"todolist_id":"00000000-0000-0000-0000-000000000001","title":"Updated Todo","done":true,"created_at":"2024-01-01T12:00:00Z","updated_at":"2024-01-01T12:00:00Z","warnings":["a todo with this title already exists in the list"]}`,
+		},
+		{
+			name:           "With color",
+			urlParam:       publicID(1),
+			inputBody:      `{"title":"Updated Todo","done":true,"color":"#00AA00"}`,
+			shouldCallMock: true,
+			wantColor:      &todoColor,
+			mockReturn:     &domain.Todo{ID: 1, PublicID: publicID(1), UserID: testUserID, TodoListID: 1, Title: "Updated Todo", Done: true, CreatedAt: fixedTime, UpdatedAt: fixedTime, Color: &todoColor, ListColor: &listColor},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"id":"00000000-0000-0000-0000-000000000001","user_id":1,"todolist_id":"00000000-0000-0000-0000-000000000001","title":"Updated Todo","done":true,"created_at":"2024-01-01T12:00:00Z","updated_at":"2024-01-01T12:00:00Z","color":"#00AA00"}`,
+		},
+		{
+			name:           "Without color shows the list color",
+			urlParam:       publicID(1),
+			inputBody:      `{"title":"Updated Todo","done":true}`,
+			shouldCallMock: true,
+			mockReturn:     &domain.Todo{ID: 1, PublicID: publicID(1), UserID: testUserID, TodoListID: 1, Title: "Updated Todo", Done: true, CreatedAt: fixedTime, UpdatedAt: fixedTime, ListColor: &listColor},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"id":"00000000-0000-0000-0000-000000000001","user_id":1,"todolist_id":"00000000-0000-0000-0000-000000000001","title":"Updated Todo","done":true,"created_at":"2024-01-01T12:00:00Z","updated_at":"2024-01-01T12:00:00Z","color":"#FF5733"}`,
 		},
 		{
 			name:           "Todo not found",
-			urlParam:       "1",
-			inputBody:      `{"title":"Updated Todo","done":true,}`,
+			urlParam:       publicID(1),
+			inputBody:      `{"title":"Updated Todo","done":true}`,
 			shouldCallMock: true,
 			mockReturn:     nil,
 			mockError:      domain.ErrNotFound,
 			expectedStatus: http.StatusNotFound,
 			expectedBody:   `{"error":"todo not found"}`,
 		},
+		{
+			name:           "Todo in another list",
+			urlParam:       publicID(1),
+			inputBody:      `{"title":"Updated Todo","done":true}`,
+			inListErr:      domain.ErrNotFound,
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"error":"todo not found"}`,
+		},
+		{
+			name:           "Invalid JSON",
+			urlParam:       publicID(1),
+			inputBody:      `{"title":"Updated Todo",`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"unexpected EOF"}`,
+		},
+		{
+			name:           "Missing title",
+			urlParam:       publicID(1),
+			inputBody:      `{"done":true}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   `{"error":"title is required"}`,
+		},
+		{
+			name:           "Invalid due date",
+			urlParam:       publicID(1),
+			inputBody:      `{"title":"Updated Todo","done":true,"due_date":"2024-01-05"}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   `{"error":"due_date must be an RFC3339 time like 2024-01-05T09:00:00Z"}`,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockService := mocks.NewTodoService(t)
 
+			expectResolveList(mockService, testUserID, 1)
+
+			expectedID := int64(1)
+			mockService.On("ResolveTodoID", mock.Anything, testUserID, tt.urlParam).
+				Return(expectedID, nil).
+				Once()
+
+			expectTodoInList(mockService, testUserID, 1, expectedID, tt.inListErr)
+
 			if tt.shouldCallMock {
-				expectedID, _ := strconv.ParseInt(tt.urlParam, 10, 64)
 
 				// Parse input to get expected values
 				var input map[string]interface{}
@@ -316,24 +679,24 @@ func TestUpdateTodo(t *testing.T) {
 				expectedTitle := input["title"].(string)
 				expectedDone := input["done"].(bool)
 
-				// Updated to match new signature: UpdateTodo(ctx, userID, todoID, title, done, )
-				mockService.On("UpdateTodo", mock.Anything, testUserID, expectedID, expectedTitle, expectedDone).
-					Return(tt.mockReturn, tt.mockError).
+				// Updated to match new signature: UpdateTodo(ctx, userID, todoID, title, done, dueDate, color)
+				mockService.On("UpdateTodo", mock.Anything, testUserID, expectedID, expectedTitle, expectedDone, (*time.Time)(nil), tt.wantColor).
+					Return(tt.mockReturn, tt.mockWarnings, tt.mockError).
 					Once()
 			}
 
 			handlers := &TodoHandlers{todoService: mockService}
 
-			req, err := http.NewRequest(http.MethodPut, "/lists/1/todos/"+tt.urlParam, strings.NewReader(tt.inputBody))
+			req, err := http.NewRequest(http.MethodPut, "/lists/"+publicID(1)+"/todos/"+tt.urlParam, strings.NewReader(tt.inputBody))
 			require.NoError(t, err)
 			req.Header.Set("Content-Type", "application/json")
 
 			// Add user context
-			req = testutils.WithUserContext(req, testUserID)
+			req = withUserContext(req, testUserID)
 
 			// Add chi URL params
 			rctx := chi.NewRouteContext()
-			rctx.URLParams.Add("listID", "1")
+			rctx.URLParams.Add("listID", publicID(1))
 			rctx.URLParams.Add("id", tt.urlParam)
 			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
 
@@ -354,10 +717,17 @@ func TestUpdateTodo(t *testing.T) {
 // TestDeleteTodo tests the DeleteTodo handler with various scenarios
 func TestDeleteTodo(t *testing.T) {
 	testUserID := int64(1)
+	fixedTime := time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC)
+
+	deleted := &domain.Todo{ID: 1, PublicID: publicID(1), UserID: testUserID, TodoListID: 2, TodoListPublicID: publicID(2), Title: "Milk", Done: true, CreatedAt: fixedTime, UpdatedAt: fixedTime, CompletedAt: &fixedTime}
 
 	tests := []struct {
 		name           string
+		id             int64
 		urlParam       string
+		query          string
+		resolveErr     error
+		inListErr      error // What the check that the todo is in the list of the path returns
 		shouldCallMock bool
 		mockError      error
 		expectedStatus int
@@ -365,44 +735,103 @@ func TestDeleteTodo(t *testing.T) {
 	}{
 		{
 			name:           "Valid ID",
-			urlParam:       "1",
+			id:             1,
+			urlParam:       publicID(1),
 			shouldCallMock: true,
 			mockError:      nil,
 			expectedStatus: http.StatusNoContent,
 			expectedBody:   "",
 		},
+		{
+			name:           "Return the deleted todo",
+			id:             1,
+			urlParam:       publicID(1),
+			query:          "?return=true",
+			shouldCallMock: true,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"id":"` + publicID(1) + `","user_id":1,"todolist_id":"` + publicID(2) + `","title":"Milk","done":true,"created_at":"2024-01-01T12:00:00Z","updated_at":"2024-01-01T12:00:00Z","completed_at":"2024-01-01T12:00:00Z"}`,
+		},
+		{
+			name:           "Invalid return",
+			urlParam:       publicID(1),
+			query:          "?return=maybe",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"invalid input: return must be true or false"}`,
+		},
 		{
 			name:           "Todo not found",
-			urlParam:       "999",
+			urlParam:       publicID(999),
+			resolveErr:     domain.ErrNotFound,
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"error":"todo not found"}`,
+		},
+		{
+			name:           "Todo in another list",
+			id:             3,
+			urlParam:       publicID(3),
+			inListErr:      domain.ErrNotFound,
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"error":"todo not found"}`,
+		},
+		{
+			name:           "Deleted concurrently",
+			id:             2,
+			urlParam:       publicID(2),
 			shouldCallMock: true,
 			mockError:      domain.ErrNotFound,
 			expectedStatus: http.StatusNotFound,
 			expectedBody:   `{"error":"todo not found"}`,
 		},
+		{
+			name:           "Integer ID",
+			urlParam:       "1",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"id must be a UUID"}`,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockService := mocks.NewTodoService(t)
 
+			// A bad query is answered before the path is resolved
+			if tt.query != "?return=maybe" {
+				expectResolveList(mockService, testUserID, 2)
+			}
+
+			if tt.id != 0 || tt.resolveErr != nil {
+				mockService.On("ResolveTodoID", mock.Anything, testUserID, tt.urlParam).
+					Return(tt.id, tt.resolveErr).
+					Once()
+			}
+
+			if tt.id != 0 {
+				expectTodoInList(mockService, testUserID, 2, tt.id, tt.inListErr)
+			}
+
 			if tt.shouldCallMock {
-				expectedID, _ := strconv.ParseInt(tt.urlParam, 10, 64)
+				expectedID := tt.id
 				// Updated to match new signature: DeleteTodo(ctx, userID, todoID)
+				var mockReturn *domain.Todo
+				if tt.mockError == nil {
+					mockReturn = deleted
+				}
 				mockService.On("DeleteTodo", mock.Anything, testUserID, expectedID).
-					Return(tt.mockError).
+					Return(mockReturn, tt.mockError).
 					Once()
 			}
 
 			handlers := &TodoHandlers{todoService: mockService}
 
-			req, err := http.NewRequest(http.MethodDelete, "/lists/{listID}/todos/"+tt.urlParam, nil)
+			req, err := http.NewRequest(http.MethodDelete, "/lists/"+publicID(2)+"/todos/"+tt.urlParam+tt.query, nil)
 			require.NoError(t, err)
 
 			// Add user context
-			req = testutils.WithUserContext(req, testUserID)
+			req = withUserContext(req, testUserID)
 
 			// Add chi URL params
 			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("listID", publicID(2))
 			rctx.URLParams.Add("id", tt.urlParam)
 			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
 
@@ -411,6 +840,12 @@ func TestDeleteTodo(t *testing.T) {
 
 			require.Equal(t, tt.expectedStatus, rr.Code)
 
+			if tt.expectedStatus == http.StatusNoContent {
+				// 204 carries no body, not even an empty JSON object
+				assert.Zero(t, rr.Body.Len())
+				assert.Empty(t, rr.Header().Get("Content-Type"))
+			}
+
 			if tt.expectedBody != "" {
 				assert.JSONEq(t, tt.expectedBody, rr.Body.String())
 			}
@@ -419,3 +854,995 @@ func TestDeleteTodo(t *testing.T) {
 		})
 	}
 }
+
+func TestUndoDelete(t *testing.T) {
+	testUserID := int64(1)
+	fixedTime := time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC)
+
+	restored := &domain.Todo{ID: 3, PublicID: publicID(3), UserID: testUserID, TodoListID: 1, TodoListPublicID: publicID(1), Title: "Milk", CreatedAt: fixedTime, UpdatedAt: fixedTime}
+
+	tests := []struct {
+		name           string
+		todoParam      string
+		shouldCallMock bool
+		mockError      error
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "Restores within the window",
+			todoParam:      publicID(3),
+			shouldCallMock: true,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"id":"` + publicID(3) + `","user_id":1,"todolist_id":"` + publicID(1) + `","title":"Milk","done":false,"created_at":"2024-01-01T12:00:00Z","updated_at":"2024-01-01T12:00:00Z"}`,
+		},
+		{
+			name:           "Past the window",
+			todoParam:      publicID(3),
+			shouldCallMock: true,
+			mockError:      domain.ErrUndoExpired,
+			expectedStatus: http.StatusGone,
+			expectedBody:   `{"error":"the todo was deleted too long ago to restore"}`,
+		},
+		{
+			name:           "Not in the trash",
+			todoParam:      publicID(4),
+			shouldCallMock: true,
+			mockError:      domain.ErrNotFound,
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"error":"todo not found"}`,
+		},
+		{
+			name:           "Integer todo ID",
+			todoParam:      "3",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"id must be a UUID"}`,
+		},
+		{
+			name:           "Service error",
+			todoParam:      publicID(3),
+			shouldCallMock: true,
+			mockError:      errors.New("database error"),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"error":"internal server error"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := mocks.NewTodoService(t)
+
+			expectResolveList(mockService, testUserID, 1)
+			if tt.shouldCallMock {
+				var mockReturn *domain.Todo
+				if tt.mockError == nil {
+					mockReturn = restored
+				}
+				mockService.On("UndoDelete", mock.Anything, testUserID, int64(1), tt.todoParam).
+					Return(mockReturn, tt.mockError).
+					Once()
+			}
+
+			handlers := &TodoHandlers{todoService: mockService}
+
+			req, err := http.NewRequest(http.MethodPost, "/lists/"+publicID(1)+"/todos/"+tt.todoParam+"/undo-delete", nil)
+			require.NoError(t, err)
+
+			req = withUserContext(req, testUserID)
+
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("listID", publicID(1))
+			rctx.URLParams.Add("id", tt.todoParam)
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+			rr := httptest.NewRecorder()
+			handlers.UndoDelete(rr, req)
+
+			require.Equal(t, tt.expectedStatus, rr.Code)
+			assert.JSONEq(t, tt.expectedBody, rr.Body.String())
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestEmptyDone(t *testing.T) {
+	testUserID := int64(1)
+
+	tests := []struct {
+		name           string
+		urlParam       string
+		shouldCallMock bool
+		mockReturn     int64
+		mockError      error
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "Trashes done todos",
+			urlParam:       publicID(1),
+			shouldCallMock: true,
+			mockReturn:     2,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"count":2}`,
+		},
+		{
+			name:           "Invalid list ID",
+			urlParam:       "abc",
+			shouldCallMock: false,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"id must be a UUID"}`,
+		},
+		{
+			name:           "Service error",
+			urlParam:       publicID(1),
+			shouldCallMock: true,
+			mockError:      errors.New("database error"),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"error":"internal server error"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := mocks.NewTodoService(t)
+
+			if tt.shouldCallMock {
+				expectedID := int64(1)
+				expectResolveList(mockService, testUserID, expectedID)
+				mockService.On("EmptyDone", mock.Anything, testUserID, expectedID).
+					Return(tt.mockReturn, tt.mockError).
+					Once()
+			}
+
+			handlers := &TodoHandlers{todoService: mockService}
+
+			req, err := http.NewRequest(http.MethodPost, "/lists/"+tt.urlParam+"/todos/empty-done", nil)
+			require.NoError(t, err)
+
+			req = withUserContext(req, testUserID)
+
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("listID", tt.urlParam)
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+			rr := httptest.NewRecorder()
+			handlers.EmptyDone(rr, req)
+
+			require.Equal(t, tt.expectedStatus, rr.Code)
+			assert.JSONEq(t, tt.expectedBody, rr.Body.String())
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestToggleAll(t *testing.T) {
+	testUserID := int64(1)
+
+	tests := []struct {
+		name           string
+		inputBody      string
+		shouldCallMock bool
+		wantDone       bool
+		mockReturn     int64
+		mockError      error
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "Mark all done",
+			inputBody:      `{"done":true}`,
+			shouldCallMock: true,
+			wantDone:       true,
+			mockReturn:     3,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"count":3}`,
+		},
+		{
+			name:           "Mark all not done",
+			inputBody:      `{"done":false}`,
+			shouldCallMock: true,
+			wantDone:       false,
+			mockReturn:     2,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"count":2}`,
+		},
+		{
+			name:           "Missing done",
+			inputBody:      `{}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   `{"error":"done is required"}`,
+		},
+		{
+			name:           "Service error",
+			inputBody:      `{"done":true}`,
+			shouldCallMock: true,
+			wantDone:       true,
+			mockError:      errors.New("database error"),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"error":"internal server error"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := mocks.NewTodoService(t)
+
+			expectResolveList(mockService, testUserID, 1)
+			if tt.shouldCallMock {
+				mockService.On("ToggleAll", mock.Anything, testUserID, int64(1), tt.wantDone).
+					Return(tt.mockReturn, tt.mockError).
+					Once()
+			}
+
+			handlers := &TodoHandlers{todoService: mockService}
+
+			req, err := http.NewRequest(http.MethodPost, "/lists/"+publicID(1)+"/todos/toggle-all", strings.NewReader(tt.inputBody))
+			require.NoError(t, err)
+
+			req = withUserContext(req, testUserID)
+
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("listID", publicID(1))
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+			rr := httptest.NewRecorder()
+			handlers.ToggleAll(rr, req)
+
+			require.Equal(t, tt.expectedStatus, rr.Code)
+			assert.JSONEq(t, tt.expectedBody, rr.Body.String())
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+// TestDryRun tests ?dry_run=true on the destructive todo endpoints, the mock fails if DeleteTodo or EmptyDone is called
+func TestDryRun(t *testing.T) {
+	testUserID := int64(1)
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		urlParams      map[string]string
+		handler        func(h *TodoHandlers) http.HandlerFunc
+		initMocks      func(m *mocks.TodoService)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:      "Delete todo",
+			method:    http.MethodDelete,
+			path:      "/lists/" + publicID(1) + "/todos/" + publicID(5) + "?dry_run=true",
+			urlParams: map[string]string{"listID": publicID(1), "id": publicID(5)},
+			handler:   func(h *TodoHandlers) http.HandlerFunc { return h.DeleteTodo },
+			initMocks: func(m *mocks.TodoService) {
+				expectResolveList(m, testUserID, 1)
+				m.On("ResolveTodoID", mock.Anything, testUserID, publicID(5)).Return(int64(5), nil).Once()
+				expectTodoInList(m, testUserID, 1, 5, nil)
+				m.On("DeleteTodoDryRun", mock.Anything, testUserID, int64(5)).
+					Return(&domain.DryRunResult{Count: 1, IDs: []string{publicID(5)}}, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"dry_run":true,"count":1,"ids":["` + publicID(5) + `"]}`,
+		},
+		{
+			name:      "Delete todo not found",
+			method:    http.MethodDelete,
+			path:      "/lists/" + publicID(1) + "/todos/" + publicID(5) + "?dry_run=true",
+			urlParams: map[string]string{"listID": publicID(1), "id": publicID(5)},
+			handler:   func(h *TodoHandlers) http.HandlerFunc { return h.DeleteTodo },
+			initMocks: func(m *mocks.TodoService) {
+				expectResolveList(m, testUserID, 1)
+				m.On("ResolveTodoID", mock.Anything, testUserID, publicID(5)).Return(int64(5), nil).Once()
+				expectTodoInList(m, testUserID, 1, 5, nil)
+				m.On("DeleteTodoDryRun", mock.Anything, testUserID, int64(5)).Return(nil, domain.ErrNotFound).Once()
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"error":"todo not found"}`,
+		},
+		{
+			name:      "Empty done",
+			method:    http.MethodPost,
+			path:      "/lists/" + publicID(1) + "/todos/empty-done?dry_run=true",
+			urlParams: map[string]string{"listID": publicID(1)},
+			handler:   func(h *TodoHandlers) http.HandlerFunc { return h.EmptyDone },
+			initMocks: func(m *mocks.TodoService) {
+				expectResolveList(m, testUserID, 1)
+				m.On("EmptyDoneDryRun", mock.Anything, testUserID, int64(1)).
+					Return(&domain.DryRunResult{Count: 2, IDs: []string{publicID(2), publicID(3)}}, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"dry_run":true,"count":2,"ids":["` + publicID(2) + `","` + publicID(3) + `"]}`,
+		},
+		{
+			name:      "Empty done with nothing done",
+			method:    http.MethodPost,
+			path:      "/lists/" + publicID(1) + "/todos/empty-done?dry_run=true",
+			urlParams: map[string]string{"listID": publicID(1)},
+			handler:   func(h *TodoHandlers) http.HandlerFunc { return h.EmptyDone },
+			initMocks: func(m *mocks.TodoService) {
+				expectResolveList(m, testUserID, 1)
+				m.On("EmptyDoneDryRun", mock.Anything, testUserID, int64(1)).Return(&domain.DryRunResult{}, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"dry_run":true,"count":0,"ids":[]}`,
+		},
+		{
+			name:           "Invalid dry_run",
+			method:         http.MethodPost,
+			path:           "/lists/" + publicID(1) + "/todos/empty-done?dry_run=maybe",
+			urlParams:      map[string]string{"listID": publicID(1)},
+			handler:        func(h *TodoHandlers) http.HandlerFunc { return h.EmptyDone },
+			initMocks:      func(m *mocks.TodoService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"invalid input: dry_run must be true or false"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := mocks.NewTodoService(t)
+			tt.initMocks(mockService)
+
+			handlers := &TodoHandlers{todoService: mockService}
+
+			req, err := http.NewRequest(tt.method, tt.path, nil)
+			require.NoError(t, err)
+
+			req = withUserContext(req, testUserID)
+
+			rctx := chi.NewRouteContext()
+			for key, value := range tt.urlParams {
+				rctx.URLParams.Add(key, value)
+			}
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+			rr := httptest.NewRecorder()
+			tt.handler(handlers)(rr, req)
+
+			require.Equal(t, tt.expectedStatus, rr.Code)
+			assert.JSONEq(t, tt.expectedBody, rr.Body.String())
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRecentlyCompleted(t *testing.T) {
+	testUserID := int64(1)
+	completedAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		query          string
+		shouldCallMock bool
+		wantLimit      int
+		mockReturn     []*domain.Todo
+		mockError      error
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "Default limit",
+			shouldCallMock: true,
+			wantLimit:      DefaultRecentlyCompletedLimit,
+			mockReturn: []*domain.Todo{
+				{ID: 2, PublicID: publicID(2), UserID: testUserID, TodoListPublicID: publicID(1), Title: "Done", Done: true, CreatedAt: completedAt, UpdatedAt: completedAt, CompletedAt: &completedAt},
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: `[{"id":"` + publicID(2) + `","user_id":1,"todolist_id":"` + publicID(1) + `","title":"Done","done":true,` +
+				`"created_at":"2025-01-02T03:04:05Z","updated_at":"2025-01-02T03:04:05Z","completed_at":"2025-01-02T03:04:05Z"}]`,
+		},
+		{
+			name:           "Custom limit",
+			query:          "?limit=3",
+			shouldCallMock: true,
+			wantLimit:      3,
+			mockReturn:     []*domain.Todo{},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `[]`,
+		},
+		{
+			name:           "Invalid limit",
+			query:          "?limit=0",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"invalid input: limit must be an integer between 1 and 200"}`,
+		},
+		{
+			name:           "Service error",
+			shouldCallMock: true,
+			wantLimit:      DefaultRecentlyCompletedLimit,
+			mockError:      errors.New("database error"),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"error":"internal server error"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := mocks.NewTodoService(t)
+
+			if tt.shouldCallMock {
+				mockService.On("RecentlyCompleted", mock.Anything, testUserID, tt.wantLimit).
+					Return(tt.mockReturn, tt.mockError).
+					Once()
+			}
+
+			handlers := &TodoHandlers{todoService: mockService}
+
+			req, err := http.NewRequest(http.MethodGet, "/me/todos/recently-completed"+tt.query, nil)
+			require.NoError(t, err)
+
+			req = withUserContext(req, testUserID)
+
+			rr := httptest.NewRecorder()
+			handlers.RecentlyCompleted(rr, req)
+
+			require.Equal(t, tt.expectedStatus, rr.Code)
+			assert.JSONEq(t, tt.expectedBody, rr.Body.String())
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestListOverdue(t *testing.T) {
+	testUserID := int64(1)
+	createdAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	dueDate := time.Date(2025, 1, 3, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		mockReturn     []*domain.Todo
+		mockError      error
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "Overdue todos",
+			mockReturn: []*domain.Todo{
+				{ID: 2, PublicID: publicID(2), UserID: testUserID, TodoListPublicID: publicID(1), Title: "Pay rent", CreatedAt: createdAt, UpdatedAt: createdAt, DueDate: &dueDate},
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: `[{"id":"` + publicID(2) + `","user_id":1,"todolist_id":"` + publicID(1) + `","title":"Pay rent","done":false,` +
+				`"created_at":"2025-01-02T03:04:05Z","updated_at":"2025-01-02T03:04:05Z","due_date":"2025-01-03T09:00:00Z"}]`,
+		},
+		{
+			name:           "Nothing overdue",
+			mockReturn:     []*domain.Todo{},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `[]`,
+		},
+		{
+			name:           "Service error",
+			mockError:      errors.New("database error"),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"error":"internal server error"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := mocks.NewTodoService(t)
+			mockService.On("ListOverdue", mock.Anything, testUserID).
+				Return(tt.mockReturn, tt.mockError).
+				Once()
+
+			handlers := &TodoHandlers{todoService: mockService}
+
+			req, err := http.NewRequest(http.MethodGet, "/todos/overdue", nil)
+			require.NoError(t, err)
+
+			req = withUserContext(req, testUserID)
+
+			rr := httptest.NewRecorder()
+			handlers.ListOverdue(rr, req)
+
+			require.Equal(t, tt.expectedStatus, rr.Code)
+			assert.JSONEq(t, tt.expectedBody, rr.Body.String())
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestImportCSV(t *testing.T) {
+	testUserID := int64(1)
+
+	pngHeader := "\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR"
+
+	// multipartBody puts content in the file field of a multipart form, an empty field name leaves the form without a file.
+	// An empty partType sends the file as application/octet-stream, like curl does for a .csv file.
+	multipartBody := func(t *testing.T, field string, filename string, partType string, content string) (*bytes.Buffer, string) {
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		if field != "" {
+			if partType == "" {
+				partType = "application/octet-stream"
+			}
+
+			partHeader := textproto.MIMEHeader{}
+			partHeader.Set("Content-Disposition", `form-data; name="`+field+`"; filename="`+filename+`"`)
+			partHeader.Set("Content-Type", partType)
+
+			part, err := writer.CreatePart(partHeader)
+			require.NoError(t, err)
+			_, err = part.Write([]byte(content))
+			require.NoError(t, err)
+		}
+		require.NoError(t, writer.Close())
+		return body, writer.FormDataContentType()
+	}
+
+	tests := []struct {
+		name           string
+		field          string
+		filename       string
+		partType       string
+		content        string
+		wantRows       []domain.CSVTodoRow
+		mockReturn     []domain.CSVTodoResult
+		mockError      error
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:  "Mixed valid and invalid rows",
+			field: "file",
+			content: "Title,List_ID,Priority,Done\n" +
+				"Milk," + publicID(1) + ",high,false\n" +
+				"," + publicID(1) + ",low,false\n" +
+				"\"Bread, rye\"," + publicID(1) + "\n",
+			wantRows: []domain.CSVTodoRow{
+				{Line: 2, ListID: publicID(1), Title: "Milk", Done: "false"},
+				{Line: 3, ListID: publicID(1), Title: "", Done: "false"},
+				{Line: 4, ListID: publicID(1), Title: "Bread, rye"},
+			},
+			mockReturn: []domain.CSVTodoResult{
+				{Line: 2, ID: publicID(10)},
+				{Line: 3, Err: domain.ErrInvalidTitle},
+				{Line: 4, ID: publicID(11)},
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: `{"created":2,"failed":1,"rows":[` +
+				`{"line":2,"id":"` + publicID(10) + `"},` +
+				`{"line":3,"error":"title is required"},` +
+				`{"line":4,"id":"` + publicID(11) + `"}]}`,
+		},
+		{
+			name:           "Header only",
+			field:          "file",
+			content:        "list_id,title\n",
+			wantRows:       nil,
+			mockReturn:     []domain.CSVTodoResult{},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"created":0,"failed":0,"rows":[]}`,
+		},
+		{
+			name:           "Missing title column",
+			field:          "file",
+			content:        "list_id,done\n" + publicID(1) + ",true\n",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"invalid input: the csv header needs a title column"}`,
+		},
+		{
+			name:           "Empty file",
+			field:          "file",
+			content:        "",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"invalid input: the csv file is empty"}`,
+		},
+		{
+			name:           "No file",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"a csv file is required in the file field"}`,
+		},
+		{
+			name:     "Declared as text/csv",
+			field:    "file",
+			partType: "text/csv; charset=utf-8",
+			content:  "list_id,title\n" + publicID(1) + ",Milk\n",
+			wantRows: []domain.CSVTodoRow{{Line: 2, ListID: publicID(1), Title: "Milk"}},
+			mockReturn: []domain.CSVTodoResult{
+				{Line: 2, ID: publicID(10)},
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"created":1,"failed":0,"rows":[{"line":2,"id":"` + publicID(10) + `"}]}`,
+		},
+		{
+			name:           "Oversized file",
+			field:          "file",
+			content:        "list_id,title\n" + strings.Repeat(publicID(1)+",Milk\n", MaxCSVUploadSize/40),
+			expectedStatus: http.StatusRequestEntityTooLarge,
+			expectedBody:   `{"error":"the upload is larger than 1048576 bytes"}`,
+		},
+		{
+			name:           "PNG upload",
+			field:          "file",
+			filename:       "todos.png",
+			partType:       "image/png",
+			content:        pngHeader,
+			expectedStatus: http.StatusUnsupportedMediaType,
+			expectedBody:   `{"error":"the upload must be a csv file"}`,
+		},
+		{
+			name:           "PNG renamed to csv",
+			field:          "file",
+			content:        pngHeader,
+			expectedStatus: http.StatusUnsupportedMediaType,
+			expectedBody:   `{"error":"the upload must be a csv file"}`,
+		},
+		{
+			name:           "Service error",
+			field:          "file",
+			content:        "list_id,title\n" + publicID(1) + ",Milk\n",
+			wantRows:       []domain.CSVTodoRow{{Line: 2, ListID: publicID(1), Title: "Milk"}},
+			mockError:      errors.New("db error"),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"error":"internal server error"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := mocks.NewTodoService(t)
+
+			if tt.mockReturn != nil || tt.mockError != nil {
+				mockService.On("ImportCSV", mock.Anything, testUserID, tt.wantRows).
+					Return(tt.mockReturn, tt.mockError).
+					Once()
+			}
+
+			handlers := &TodoHandlers{todoService: mockService}
+
+			filename := tt.filename
+			if filename == "" {
+				filename = "todos.csv"
+			}
+
+			body, contentType := multipartBody(t, tt.field, filename, tt.partType, tt.content)
+
+			req, err := http.NewRequest(http.MethodPost, "/todos/import.csv", body)
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", contentType)
+
+			req = withUserContext(req, testUserID)
+
+			rr := httptest.NewRecorder()
+			handlers.ImportCSV(rr, req)
+
+			require.Equal(t, tt.expectedStatus, rr.Code)
+			assert.JSONEq(t, tt.expectedBody, rr.Body.String())
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCloneTodo(t *testing.T) {
+	fixedTime := time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC)
+	testUserID := int64(1)
+
+	clone := &domain.Todo{ID: 6, PublicID: publicID(6), UserID: testUserID, TodoListID: 2, TodoListPublicID: publicID(2), Title: "Milk", CreatedAt: fixedTime, UpdatedAt: fixedTime}
+
+	tests := []struct {
+		name           string
+		inputBody      string
+		shouldCallMock bool
+		wantTitle      string
+		mockReturn     *domain.Todo
+		mockError      error
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "Clone without a body",
+			inputBody:      "",
+			shouldCallMock: true,
+			wantTitle:      "",
+			mockReturn:     clone,
+			expectedStatus: http.StatusCreated,
+			expectedBody:   `{"id":"` + publicID(6) + `","user_id":1,"todolist_id":"` + publicID(2) + `","title":"Milk","done":false,"created_at":"2024-01-01T12:00:00Z","updated_at":"2024-01-01T12:00:00Z"}`,
+		},
+		{
+			name:           "Clone with a new title",
+			inputBody:      `{"title":" Oat milk "}`,
+			shouldCallMock: true,
+			wantTitle:      "Oat milk",
+			mockReturn:     &domain.Todo{ID: 6, PublicID: publicID(6), UserID: testUserID, TodoListID: 2, TodoListPublicID: publicID(2), Title: "Oat milk", CreatedAt: fixedTime, UpdatedAt: fixedTime},
+			expectedStatus: http.StatusCreated,
+			expectedBody:   `{"id":"` + publicID(6) + `","user_id":1,"todolist_id":"` + publicID(2) + `","title":"Oat milk","done":false,"created_at":"2024-01-01T12:00:00Z","updated_at":"2024-01-01T12:00:00Z"}`,
+		},
+		{
+			name:           "Someone else's todo",
+			inputBody:      `{}`,
+			shouldCallMock: true,
+			mockError:      domain.ErrNotFound,
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"error":"todo not found"}`,
+		},
+		{
+			name:           "Title too long",
+			inputBody:      `{"title":"` + strings.Repeat("a", 256) + `"}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   `{"error":"title must be at most 255 characters"}`,
+		},
+		{
+			name:           "Title with control characters",
+			inputBody:      `{"title":"Milk\u0000"}`,
+			shouldCallMock: true,
+			wantTitle:      "Milk\x00",
+			mockError:      domain.ErrInvalidTitle,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   `{"error":"` + domain.ErrInvalidTitle.Error() + `"}`,
+		},
+		{
+			name:           "Invalid JSON",
+			inputBody:      `{"title":`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"unexpected EOF"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := mocks.NewTodoService(t)
+
+			mockService.On("ResolveTodoID", mock.Anything, testUserID, publicID(5)).
+				Return(int64(5), nil).
+				Once()
+
+			if tt.shouldCallMock {
+				mockService.On("Clone", mock.Anything, testUserID, int64(5), tt.wantTitle).
+					Return(tt.mockReturn, tt.mockError).
+					Once()
+			}
+
+			handlers := &TodoHandlers{todoService: mockService}
+
+			req, err := http.NewRequest(http.MethodPost, "/todos/"+publicID(5)+"/clone", strings.NewReader(tt.inputBody))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+
+			req = withUserContext(req, testUserID)
+
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", publicID(5))
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+			rr := httptest.NewRecorder()
+			handlers.CloneTodo(rr, req)
+
+			require.Equal(t, tt.expectedStatus, rr.Code)
+			assert.JSONEq(t, tt.expectedBody, rr.Body.String())
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestMarkDone(t *testing.T) {
+	fixedTime := time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC)
+	testUserID := int64(1)
+
+	doneTodo := &domain.Todo{ID: 5, PublicID: publicID(5), UserID: testUserID, TodoListPublicID: publicID(2), Title: "Milk", Done: true, CreatedAt: fixedTime, UpdatedAt: fixedTime, CompletedAt: &fixedTime}
+	undoneTodo := &domain.Todo{ID: 5, PublicID: publicID(5), UserID: testUserID, TodoListPublicID: publicID(2), Title: "Milk", CreatedAt: fixedTime, UpdatedAt: fixedTime}
+
+	tests := []struct {
+		name           string
+		done           bool
+		resolveErr     error
+		shouldCallMock bool
+		mockReturn     *domain.Todo
+		mockError      error
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "Done",
+			done:           true,
+			shouldCallMock: true,
+			mockReturn:     doneTodo,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"id":"` + publicID(5) + `","user_id":1,"todolist_id":"` + publicID(2) + `","title":"Milk","done":true,"created_at":"2024-01-01T12:00:00Z","updated_at":"2024-01-01T12:00:00Z","completed_at":"2024-01-01T12:00:00Z"}`,
+		},
+		{
+			name:           "Undone",
+			done:           false,
+			shouldCallMock: true,
+			mockReturn:     undoneTodo,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"id":"` + publicID(5) + `","user_id":1,"todolist_id":"` + publicID(2) + `","title":"Milk","done":false,"created_at":"2024-01-01T12:00:00Z","updated_at":"2024-01-01T12:00:00Z"}`,
+		},
+		{
+			name:           "Another user's todo",
+			done:           true,
+			resolveErr:     domain.ErrNotFound,
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"error":"todo not found"}`,
+		},
+		{
+			name:           "Service error",
+			done:           true,
+			shouldCallMock: true,
+			mockError:      errors.New("database error"),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"error":"internal server error"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := mocks.NewTodoService(t)
+
+			mockService.On("ResolveTodoID", mock.Anything, testUserID, publicID(5)).
+				Return(int64(5), tt.resolveErr).
+				Once()
+
+			// Strict mock: the full UpdateTodo is never called
+			if tt.shouldCallMock {
+				mockService.On("SetDone", mock.Anything, testUserID, int64(5), tt.done).
+					Return(tt.mockReturn, tt.mockError).
+					Once()
+			}
+
+			handlers := &TodoHandlers{todoService: mockService}
+			handler, action := handlers.MarkDone, "done"
+			if !tt.done {
+				handler, action = handlers.MarkUndone, "undone"
+			}
+
+			req, err := http.NewRequest(http.MethodPost, "/todos/"+publicID(5)+"/"+action, nil)
+			require.NoError(t, err)
+
+			req = withUserContext(req, testUserID)
+
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", publicID(5))
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+			rr := httptest.NewRecorder()
+			handler(rr, req)
+
+			require.Equal(t, tt.expectedStatus, rr.Code)
+			assert.JSONEq(t, tt.expectedBody, rr.Body.String())
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestBatchGet(t *testing.T) {
+	fixedTime := time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC)
+	testUserID := int64(1)
+
+	owned := &domain.Todo{ID: 5, PublicID: publicID(5), UserID: testUserID, TodoListID: 2, TodoListPublicID: publicID(2), Title: "Milk", CreatedAt: fixedTime, UpdatedAt: fixedTime}
+
+	tests := []struct {
+		name           string
+		inputBody      string
+		shouldCallMock bool
+		wantIDs        []string
+		mockReturn     []*domain.Todo
+		mockError      error
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "Returns only owned todos",
+			inputBody:      `{"ids":["` + publicID(5) + `","` + publicID(9) + `"]}`,
+			shouldCallMock: true,
+			wantIDs:        []string{publicID(5), publicID(9)},
+			mockReturn:     []*domain.Todo{owned},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `[{"id":"` + publicID(5) + `","user_id":1,"todolist_id":"` + publicID(2) + `","title":"Milk","done":false,"created_at":"2024-01-01T12:00:00Z","updated_at":"2024-01-01T12:00:00Z"}]`,
+		},
+		{
+			name:           "Only foreign ids",
+			inputBody:      `{"ids":["` + publicID(9) + `"]}`,
+			shouldCallMock: true,
+			wantIDs:        []string{publicID(9)},
+			mockReturn:     []*domain.Todo{},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `[]`,
+		},
+		{
+			name:           "Service error",
+			inputBody:      `{"ids":["` + publicID(5) + `"]}`,
+			shouldCallMock: true,
+			wantIDs:        []string{publicID(5)},
+			mockError:      errors.New("db error"),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"error":"internal server error"}`,
+		},
+		{
+			name:           "No ids",
+			inputBody:      `{"ids":[]}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"ids is required"}`,
+		},
+		{
+			name:           "Too many ids",
+			inputBody:      `{"ids":["` + strings.Repeat(publicID(5)+`","`, MaxBatchGetIDs) + publicID(5) + `"]}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   fmt.Sprintf(`{"error":"ids must have at most %d entries"}`, MaxBatchGetIDs),
+		},
+		{
+			name:           "Id is not a UUID",
+			inputBody:      `{"ids":["5"]}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"id must be a UUID"}`,
+		},
+		{
+			name:           "Invalid JSON",
+			inputBody:      `{"ids":`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"unexpected EOF"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := mocks.NewTodoService(t)
+
+			if tt.shouldCallMock {
+				mockService.On("BatchGet", mock.Anything, testUserID, tt.wantIDs).
+					Return(tt.mockReturn, tt.mockError).
+					Once()
+			}
+
+			handlers := &TodoHandlers{todoService: mockService}
+
+			req, err := http.NewRequest(http.MethodPost, "/todos/batch-get", strings.NewReader(tt.inputBody))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+
+			req = withUserContext(req, testUserID)
+
+			rr := httptest.NewRecorder()
+			handlers.BatchGet(rr, req)
+
+			require.Equal(t, tt.expectedStatus, rr.Code)
+			assert.JSONEq(t, tt.expectedBody, rr.Body.String())
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+// publicID returns a fixed public id (UUID) for an internal id, so the tests can tell which one the handler used
+func publicID(id int64) string {
+	return fmt.Sprintf("00000000-0000-0000-0000-%012d", id)
+}
+
+// expectResolveList makes the mock resolve the public id of the list to its internal id
+func expectResolveList(m *mocks.TodoService, userID int64, listID int64) {
+	m.On("ResolveListID", mock.Anything, userID, publicID(listID)).
+		Return(listID, nil).
+		Once()
+}
+
+// expectTodoInList makes the mock answer the check that the todo is in the list of the path, with err when it is not
+func expectTodoInList(m *mocks.TodoService, userID int64, listID int64, id int64, err error) {
+	var todo *domain.Todo
+	if err == nil {
+		todo = &domain.Todo{ID: id, UserID: userID, TodoListID: listID}
+	}
+
+	m.On("GetTodoInList", mock.Anything, userID, listID, id).
+		Return(todo, err).
+		Once()
+}
+
+// withUserContext adds an authenticated user to the request context, like the UserContext middleware does.
+func withUserContext(req *http.Request, userID int64) *http.Request {
+	userCtx := &auth.UserContext{
+		ID:    userID,
+		Email: "test@example.com",
+		Name:  "Test User",
+	}
+	return req.WithContext(userCtx.AddToContext(req.Context()))
+}
@@ -2,16 +2,31 @@ package todo
 
 import (
 	"context"
+	"time"
 
 	"github.com/macesz/todo-go/domain"
 )
 
 type TodoService interface {
-	ListTodos(ctx context.Context, userID int64, todolistID int64) ([]*domain.Todo, error)
-	CreateTodo(ctx context.Context, userID int64, todolistID int64, title string) (*domain.Todo, error)
+	ListTodos(ctx context.Context, userID int64, todolistID int64, opts domain.ListOptions) ([]*domain.Todo, error)
+	CreateTodo(ctx context.Context, userID int64, todolistID int64, title string, dueDate *time.Time, color *string) (*domain.Todo, []string, error)
 	GetTodo(ctx context.Context, userID int64, id int64) (*domain.Todo, error)
-	UpdateTodo(ctx context.Context, userID int64, id int64, title string, done bool) (*domain.Todo, error)
-	DeleteTodo(ctx context.Context, userID int64, id int64) error
+	ResolveTodoID(ctx context.Context, userID int64, publicID string) (int64, error)
+	ResolveListID(ctx context.Context, userID int64, publicID string) (int64, error)
+	GetTodoInList(ctx context.Context, userID int64, todolistID int64, id int64) (*domain.Todo, error)
+	UpdateTodo(ctx context.Context, userID int64, id int64, title string, done bool, dueDate *time.Time, color *string) (*domain.Todo, []string, error)
+	SetDone(ctx context.Context, userID int64, id int64, done bool) (*domain.Todo, error)
+	DeleteTodo(ctx context.Context, userID int64, id int64) (*domain.Todo, error)
+	UndoDelete(ctx context.Context, userID int64, todolistID int64, publicID string) (*domain.Todo, error)
+	EmptyDone(ctx context.Context, userID int64, todolistID int64) (int64, error)
+	ToggleAll(ctx context.Context, userID int64, todolistID int64, done bool) (int64, error)
+	DeleteTodoDryRun(ctx context.Context, userID int64, id int64) (*domain.DryRunResult, error)
+	EmptyDoneDryRun(ctx context.Context, userID int64, todolistID int64) (*domain.DryRunResult, error)
+	RecentlyCompleted(ctx context.Context, userID int64, limit int) ([]*domain.Todo, error)
+	ListOverdue(ctx context.Context, userID int64) ([]*domain.Todo, error)
+	ImportCSV(ctx context.Context, userID int64, rows []domain.CSVTodoRow) ([]domain.CSVTodoResult, error)
+	Clone(ctx context.Context, userID int64, id int64, title string) (*domain.Todo, error)
+	BatchGet(ctx context.Context, userID int64, publicIDs []string) ([]*domain.Todo, error)
 }
 
 type UserService interface {
@@ -6,6 +6,7 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	"github.com/macesz/todo-go/domain"
 	mock "github.com/stretchr/testify/mock"
@@ -38,34 +39,196 @@ func (_m *TodoService) EXPECT() *TodoService_Expecter {
 	return &TodoService_Expecter{mock: &_m.Mock}
 }
 
-// CreateTodo provides a mock function for the type TodoService
-func (_mock *TodoService) CreateTodo(ctx context.Context, userID int64, todolistID int64, title string) (*domain.Todo, error) {
-	ret := _mock.Called(ctx, userID, todolistID, title)
+// BatchGet provides a mock function for the type TodoService
+func (_mock *TodoService) BatchGet(ctx context.Context, userID int64, publicIDs []string) ([]*domain.Todo, error) {
+	ret := _mock.Called(ctx, userID, publicIDs)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateTodo")
+		panic("no return value specified for BatchGet")
+	}
+
+	var r0 []*domain.Todo
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, []string) ([]*domain.Todo, error)); ok {
+		return returnFunc(ctx, userID, publicIDs)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, []string) []*domain.Todo); ok {
+		r0 = returnFunc(ctx, userID, publicIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Todo)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, []string) error); ok {
+		r1 = returnFunc(ctx, userID, publicIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// TodoService_BatchGet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BatchGet'
+type TodoService_BatchGet_Call struct {
+	*mock.Call
+}
+
+// BatchGet is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+//   - publicIDs []string
+func (_e *TodoService_Expecter) BatchGet(ctx interface{}, userID interface{}, publicIDs interface{}) *TodoService_BatchGet_Call {
+	return &TodoService_BatchGet_Call{Call: _e.mock.On("BatchGet", ctx, userID, publicIDs)}
+}
+
+func (_c *TodoService_BatchGet_Call) Run(run func(ctx context.Context, userID int64, publicIDs []string)) *TodoService_BatchGet_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 []string
+		if args[2] != nil {
+			arg2 = args[2].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *TodoService_BatchGet_Call) Return(todos []*domain.Todo, err error) *TodoService_BatchGet_Call {
+	_c.Call.Return(todos, err)
+	return _c
+}
+
+func (_c *TodoService_BatchGet_Call) RunAndReturn(run func(ctx context.Context, userID int64, publicIDs []string) ([]*domain.Todo, error)) *TodoService_BatchGet_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Clone provides a mock function for the type TodoService
+func (_mock *TodoService) Clone(ctx context.Context, userID int64, id int64, title string) (*domain.Todo, error) {
+	ret := _mock.Called(ctx, userID, id, title)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Clone")
 	}
 
 	var r0 *domain.Todo
 	var r1 error
 	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int64, string) (*domain.Todo, error)); ok {
-		return returnFunc(ctx, userID, todolistID, title)
+		return returnFunc(ctx, userID, id, title)
 	}
 	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int64, string) *domain.Todo); ok {
-		r0 = returnFunc(ctx, userID, todolistID, title)
+		r0 = returnFunc(ctx, userID, id, title)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.Todo)
 		}
 	}
 	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, int64, string) error); ok {
-		r1 = returnFunc(ctx, userID, todolistID, title)
+		r1 = returnFunc(ctx, userID, id, title)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
+// TodoService_Clone_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Clone'
+type TodoService_Clone_Call struct {
+	*mock.Call
+}
+
+// Clone is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+//   - id int64
+//   - title string
+func (_e *TodoService_Expecter) Clone(ctx interface{}, userID interface{}, id interface{}, title interface{}) *TodoService_Clone_Call {
+	return &TodoService_Clone_Call{Call: _e.mock.On("Clone", ctx, userID, id, title)}
+}
+
+func (_c *TodoService_Clone_Call) Run(run func(ctx context.Context, userID int64, id int64, title string)) *TodoService_Clone_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 int64
+		if args[2] != nil {
+			arg2 = args[2].(int64)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *TodoService_Clone_Call) Return(todo *domain.Todo, err error) *TodoService_Clone_Call {
+	_c.Call.Return(todo, err)
+	return _c
+}
+
+func (_c *TodoService_Clone_Call) RunAndReturn(run func(ctx context.Context, userID int64, id int64, title string) (*domain.Todo, error)) *TodoService_Clone_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateTodo provides a mock function for the type TodoService
+func (_mock *TodoService) CreateTodo(ctx context.Context, userID int64, todolistID int64, title string, dueDate *time.Time, color *string) (*domain.Todo, []string, error) {
+	ret := _mock.Called(ctx, userID, todolistID, title, dueDate, color)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateTodo")
+	}
+
+	var r0 *domain.Todo
+	var r1 []string
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int64, string, *time.Time, *string) (*domain.Todo, []string, error)); ok {
+		return returnFunc(ctx, userID, todolistID, title, dueDate, color)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int64, string, *time.Time, *string) *domain.Todo); ok {
+		r0 = returnFunc(ctx, userID, todolistID, title, dueDate, color)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Todo)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, int64, string, *time.Time, *string) []string); ok {
+		r1 = returnFunc(ctx, userID, todolistID, title, dueDate, color)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]string)
+		}
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, int64, int64, string, *time.Time, *string) error); ok {
+		r2 = returnFunc(ctx, userID, todolistID, title, dueDate, color)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
 // TodoService_CreateTodo_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateTodo'
 type TodoService_CreateTodo_Call struct {
 	*mock.Call
@@ -76,11 +239,13 @@ type TodoService_CreateTodo_Call struct {
 //   - userID int64
 //   - todolistID int64
 //   - title string
-func (_e *TodoService_Expecter) CreateTodo(ctx interface{}, userID interface{}, todolistID interface{}, title interface{}) *TodoService_CreateTodo_Call {
-	return &TodoService_CreateTodo_Call{Call: _e.mock.On("CreateTodo", ctx, userID, todolistID, title)}
+//   - dueDate *time.Time
+//   - color *string
+func (_e *TodoService_Expecter) CreateTodo(ctx interface{}, userID interface{}, todolistID interface{}, title interface{}, dueDate interface{}, color interface{}) *TodoService_CreateTodo_Call {
+	return &TodoService_CreateTodo_Call{Call: _e.mock.On("CreateTodo", ctx, userID, todolistID, title, dueDate, color)}
 }
 
-func (_c *TodoService_CreateTodo_Call) Run(run func(ctx context.Context, userID int64, todolistID int64, title string)) *TodoService_CreateTodo_Call {
+func (_c *TodoService_CreateTodo_Call) Run(run func(ctx context.Context, userID int64, todolistID int64, title string, dueDate *time.Time, color *string)) *TodoService_CreateTodo_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -98,41 +263,62 @@ func (_c *TodoService_CreateTodo_Call) Run(run func(ctx context.Context, userID
 		if args[3] != nil {
 			arg3 = args[3].(string)
 		}
+		var arg4 *time.Time
+		if args[4] != nil {
+			arg4 = args[4].(*time.Time)
+		}
+		var arg5 *string
+		if args[5] != nil {
+			arg5 = args[5].(*string)
+		}
 		run(
 			arg0,
 			arg1,
 			arg2,
 			arg3,
+			arg4,
+			arg5,
 		)
 	})
 	return _c
 }
 
-func (_c *TodoService_CreateTodo_Call) Return(todo *domain.Todo, err error) *TodoService_CreateTodo_Call {
-	_c.Call.Return(todo, err)
+func (_c *TodoService_CreateTodo_Call) Return(todo *domain.Todo, ss []string, err error) *TodoService_CreateTodo_Call {
+	_c.Call.Return(todo, ss, err)
 	return _c
 }
 
-func (_c *TodoService_CreateTodo_Call) RunAndReturn(run func(ctx context.Context, userID int64, todolistID int64, title string) (*domain.Todo, error)) *TodoService_CreateTodo_Call {
+func (_c *TodoService_CreateTodo_Call) RunAndReturn(run func(ctx context.Context, userID int64, todolistID int64, title string, dueDate *time.Time, color *string) (*domain.Todo, []string, error)) *TodoService_CreateTodo_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // DeleteTodo provides a mock function for the type TodoService
-func (_mock *TodoService) DeleteTodo(ctx context.Context, userID int64, id int64) error {
+func (_mock *TodoService) DeleteTodo(ctx context.Context, userID int64, id int64) (*domain.Todo, error) {
 	ret := _mock.Called(ctx, userID, id)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeleteTodo")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+	var r0 *domain.Todo
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int64) (*domain.Todo, error)); ok {
+		return returnFunc(ctx, userID, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int64) *domain.Todo); ok {
 		r0 = returnFunc(ctx, userID, id)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Todo)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = returnFunc(ctx, userID, id)
+	} else {
+		r1 = ret.Error(1)
 	}
-	return r0
+	return r0, r1
 }
 
 // TodoService_DeleteTodo_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteTodo'
@@ -171,34 +357,34 @@ func (_c *TodoService_DeleteTodo_Call) Run(run func(ctx context.Context, userID
 	return _c
 }
 
-func (_c *TodoService_DeleteTodo_Call) Return(err error) *TodoService_DeleteTodo_Call {
-	_c.Call.Return(err)
+func (_c *TodoService_DeleteTodo_Call) Return(todo *domain.Todo, err error) *TodoService_DeleteTodo_Call {
+	_c.Call.Return(todo, err)
 	return _c
 }
 
-func (_c *TodoService_DeleteTodo_Call) RunAndReturn(run func(ctx context.Context, userID int64, id int64) error) *TodoService_DeleteTodo_Call {
+func (_c *TodoService_DeleteTodo_Call) RunAndReturn(run func(ctx context.Context, userID int64, id int64) (*domain.Todo, error)) *TodoService_DeleteTodo_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetTodo provides a mock function for the type TodoService
-func (_mock *TodoService) GetTodo(ctx context.Context, userID int64, id int64) (*domain.Todo, error) {
+// DeleteTodoDryRun provides a mock function for the type TodoService
+func (_mock *TodoService) DeleteTodoDryRun(ctx context.Context, userID int64, id int64) (*domain.DryRunResult, error) {
 	ret := _mock.Called(ctx, userID, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetTodo")
+		panic("no return value specified for DeleteTodoDryRun")
 	}
 
-	var r0 *domain.Todo
+	var r0 *domain.DryRunResult
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int64) (*domain.Todo, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int64) (*domain.DryRunResult, error)); ok {
 		return returnFunc(ctx, userID, id)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int64) *domain.Todo); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int64) *domain.DryRunResult); ok {
 		r0 = returnFunc(ctx, userID, id)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*domain.Todo)
+			r0 = ret.Get(0).(*domain.DryRunResult)
 		}
 	}
 	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
@@ -209,20 +395,20 @@ func (_mock *TodoService) GetTodo(ctx context.Context, userID int64, id int64) (
 	return r0, r1
 }
 
-// TodoService_GetTodo_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTodo'
-type TodoService_GetTodo_Call struct {
+// TodoService_DeleteTodoDryRun_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteTodoDryRun'
+type TodoService_DeleteTodoDryRun_Call struct {
 	*mock.Call
 }
 
-// GetTodo is a helper method to define mock.On call
+// DeleteTodoDryRun is a helper method to define mock.On call
 //   - ctx context.Context
 //   - userID int64
 //   - id int64
-func (_e *TodoService_Expecter) GetTodo(ctx interface{}, userID interface{}, id interface{}) *TodoService_GetTodo_Call {
-	return &TodoService_GetTodo_Call{Call: _e.mock.On("GetTodo", ctx, userID, id)}
+func (_e *TodoService_Expecter) DeleteTodoDryRun(ctx interface{}, userID interface{}, id interface{}) *TodoService_DeleteTodoDryRun_Call {
+	return &TodoService_DeleteTodoDryRun_Call{Call: _e.mock.On("DeleteTodoDryRun", ctx, userID, id)}
 }
 
-func (_c *TodoService_GetTodo_Call) Run(run func(ctx context.Context, userID int64, id int64)) *TodoService_GetTodo_Call {
+func (_c *TodoService_DeleteTodoDryRun_Call) Run(run func(ctx context.Context, userID int64, id int64)) *TodoService_DeleteTodoDryRun_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -245,34 +431,106 @@ func (_c *TodoService_GetTodo_Call) Run(run func(ctx context.Context, userID int
 	return _c
 }
 
-func (_c *TodoService_GetTodo_Call) Return(todo *domain.Todo, err error) *TodoService_GetTodo_Call {
-	_c.Call.Return(todo, err)
+func (_c *TodoService_DeleteTodoDryRun_Call) Return(dryRunResult *domain.DryRunResult, err error) *TodoService_DeleteTodoDryRun_Call {
+	_c.Call.Return(dryRunResult, err)
 	return _c
 }
 
-func (_c *TodoService_GetTodo_Call) RunAndReturn(run func(ctx context.Context, userID int64, id int64) (*domain.Todo, error)) *TodoService_GetTodo_Call {
+func (_c *TodoService_DeleteTodoDryRun_Call) RunAndReturn(run func(ctx context.Context, userID int64, id int64) (*domain.DryRunResult, error)) *TodoService_DeleteTodoDryRun_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListTodos provides a mock function for the type TodoService
-func (_mock *TodoService) ListTodos(ctx context.Context, userID int64, todolistID int64) ([]*domain.Todo, error) {
+// EmptyDone provides a mock function for the type TodoService
+func (_mock *TodoService) EmptyDone(ctx context.Context, userID int64, todolistID int64) (int64, error) {
 	ret := _mock.Called(ctx, userID, todolistID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListTodos")
+		panic("no return value specified for EmptyDone")
 	}
 
-	var r0 []*domain.Todo
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int64) (int64, error)); ok {
+		return returnFunc(ctx, userID, todolistID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int64) int64); ok {
+		r0 = returnFunc(ctx, userID, todolistID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = returnFunc(ctx, userID, todolistID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// TodoService_EmptyDone_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EmptyDone'
+type TodoService_EmptyDone_Call struct {
+	*mock.Call
+}
+
+// EmptyDone is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+//   - todolistID int64
+func (_e *TodoService_Expecter) EmptyDone(ctx interface{}, userID interface{}, todolistID interface{}) *TodoService_EmptyDone_Call {
+	return &TodoService_EmptyDone_Call{Call: _e.mock.On("EmptyDone", ctx, userID, todolistID)}
+}
+
+func (_c *TodoService_EmptyDone_Call) Run(run func(ctx context.Context, userID int64, todolistID int64)) *TodoService_EmptyDone_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 int64
+		if args[2] != nil {
+			arg2 = args[2].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *TodoService_EmptyDone_Call) Return(n int64, err error) *TodoService_EmptyDone_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *TodoService_EmptyDone_Call) RunAndReturn(run func(ctx context.Context, userID int64, todolistID int64) (int64, error)) *TodoService_EmptyDone_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EmptyDoneDryRun provides a mock function for the type TodoService
+func (_mock *TodoService) EmptyDoneDryRun(ctx context.Context, userID int64, todolistID int64) (*domain.DryRunResult, error) {
+	ret := _mock.Called(ctx, userID, todolistID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EmptyDoneDryRun")
+	}
+
+	var r0 *domain.DryRunResult
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int64) ([]*domain.Todo, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int64) (*domain.DryRunResult, error)); ok {
 		return returnFunc(ctx, userID, todolistID)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int64) []*domain.Todo); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int64) *domain.DryRunResult); ok {
 		r0 = returnFunc(ctx, userID, todolistID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*domain.Todo)
+			r0 = ret.Get(0).(*domain.DryRunResult)
 		}
 	}
 	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
@@ -283,20 +541,20 @@ func (_mock *TodoService) ListTodos(ctx context.Context, userID int64, todolistI
 	return r0, r1
 }
 
-// TodoService_ListTodos_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTodos'
-type TodoService_ListTodos_Call struct {
+// TodoService_EmptyDoneDryRun_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EmptyDoneDryRun'
+type TodoService_EmptyDoneDryRun_Call struct {
 	*mock.Call
 }
 
-// ListTodos is a helper method to define mock.On call
+// EmptyDoneDryRun is a helper method to define mock.On call
 //   - ctx context.Context
 //   - userID int64
 //   - todolistID int64
-func (_e *TodoService_Expecter) ListTodos(ctx interface{}, userID interface{}, todolistID interface{}) *TodoService_ListTodos_Call {
-	return &TodoService_ListTodos_Call{Call: _e.mock.On("ListTodos", ctx, userID, todolistID)}
+func (_e *TodoService_Expecter) EmptyDoneDryRun(ctx interface{}, userID interface{}, todolistID interface{}) *TodoService_EmptyDoneDryRun_Call {
+	return &TodoService_EmptyDoneDryRun_Call{Call: _e.mock.On("EmptyDoneDryRun", ctx, userID, todolistID)}
 }
 
-func (_c *TodoService_ListTodos_Call) Run(run func(ctx context.Context, userID int64, todolistID int64)) *TodoService_ListTodos_Call {
+func (_c *TodoService_EmptyDoneDryRun_Call) Run(run func(ctx context.Context, userID int64, todolistID int64)) *TodoService_EmptyDoneDryRun_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -319,60 +577,58 @@ func (_c *TodoService_ListTodos_Call) Run(run func(ctx context.Context, userID i
 	return _c
 }
 
-func (_c *TodoService_ListTodos_Call) Return(todos []*domain.Todo, err error) *TodoService_ListTodos_Call {
-	_c.Call.Return(todos, err)
+func (_c *TodoService_EmptyDoneDryRun_Call) Return(dryRunResult *domain.DryRunResult, err error) *TodoService_EmptyDoneDryRun_Call {
+	_c.Call.Return(dryRunResult, err)
 	return _c
 }
 
-func (_c *TodoService_ListTodos_Call) RunAndReturn(run func(ctx context.Context, userID int64, todolistID int64) ([]*domain.Todo, error)) *TodoService_ListTodos_Call {
+func (_c *TodoService_EmptyDoneDryRun_Call) RunAndReturn(run func(ctx context.Context, userID int64, todolistID int64) (*domain.DryRunResult, error)) *TodoService_EmptyDoneDryRun_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateTodo provides a mock function for the type TodoService
-func (_mock *TodoService) UpdateTodo(ctx context.Context, userID int64, id int64, title string, done bool) (*domain.Todo, error) {
-	ret := _mock.Called(ctx, userID, id, title, done)
+// GetTodo provides a mock function for the type TodoService
+func (_mock *TodoService) GetTodo(ctx context.Context, userID int64, id int64) (*domain.Todo, error) {
+	ret := _mock.Called(ctx, userID, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateTodo")
+		panic("no return value specified for GetTodo")
 	}
 
 	var r0 *domain.Todo
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int64, string, bool) (*domain.Todo, error)); ok {
-		return returnFunc(ctx, userID, id, title, done)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int64) (*domain.Todo, error)); ok {
+		return returnFunc(ctx, userID, id)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int64, string, bool) *domain.Todo); ok {
-		r0 = returnFunc(ctx, userID, id, title, done)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int64) *domain.Todo); ok {
+		r0 = returnFunc(ctx, userID, id)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.Todo)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, int64, string, bool) error); ok {
-		r1 = returnFunc(ctx, userID, id, title, done)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = returnFunc(ctx, userID, id)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// TodoService_UpdateTodo_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateTodo'
-type TodoService_UpdateTodo_Call struct {
+// TodoService_GetTodo_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTodo'
+type TodoService_GetTodo_Call struct {
 	*mock.Call
 }
 
-// UpdateTodo is a helper method to define mock.On call
+// GetTodo is a helper method to define mock.On call
 //   - ctx context.Context
 //   - userID int64
 //   - id int64
-//   - title string
-//   - done bool
-func (_e *TodoService_Expecter) UpdateTodo(ctx interface{}, userID interface{}, id interface{}, title interface{}, done interface{}) *TodoService_UpdateTodo_Call {
-	return &TodoService_UpdateTodo_Call{Call: _e.mock.On("UpdateTodo", ctx, userID, id, title, done)}
+func (_e *TodoService_Expecter) GetTodo(ctx interface{}, userID interface{}, id interface{}) *TodoService_GetTodo_Call {
+	return &TodoService_GetTodo_Call{Call: _e.mock.On("GetTodo", ctx, userID, id)}
 }
 
-func (_c *TodoService_UpdateTodo_Call) Run(run func(ctx context.Context, userID int64, id int64, title string, done bool)) *TodoService_UpdateTodo_Call {
+func (_c *TodoService_GetTodo_Call) Run(run func(ctx context.Context, userID int64, id int64)) *TodoService_GetTodo_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -386,31 +642,885 @@ func (_c *TodoService_UpdateTodo_Call) Run(run func(ctx context.Context, userID
 		if args[2] != nil {
 			arg2 = args[2].(int64)
 		}
-		var arg3 string
-		if args[3] != nil {
-			arg3 = args[3].(string)
-		}
-		var arg4 bool
-		if args[4] != nil {
-			arg4 = args[4].(bool)
-		}
 		run(
 			arg0,
 			arg1,
 			arg2,
-			arg3,
-			arg4,
 		)
 	})
 	return _c
 }
 
-func (_c *TodoService_UpdateTodo_Call) Return(todo *domain.Todo, err error) *TodoService_UpdateTodo_Call {
+func (_c *TodoService_GetTodo_Call) Return(todo *domain.Todo, err error) *TodoService_GetTodo_Call {
 	_c.Call.Return(todo, err)
 	return _c
 }
 
-func (_c *TodoService_UpdateTodo_Call) RunAndReturn(run func(ctx context.Context, userID int64, id int64, title string, done bool) (*domain.Todo, error)) *TodoService_UpdateTodo_Call {
+func (_c *TodoService_GetTodo_Call) RunAndReturn(run func(ctx context.Context, userID int64, id int64) (*domain.Todo, error)) *TodoService_GetTodo_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTodoInList provides a mock function for the type TodoService
+func (_mock *TodoService) GetTodoInList(ctx context.Context, userID int64, todolistID int64, id int64) (*domain.Todo, error) {
+	ret := _mock.Called(ctx, userID, todolistID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTodoInList")
+	}
+
+	var r0 *domain.Todo
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int64, int64) (*domain.Todo, error)); ok {
+		return returnFunc(ctx, userID, todolistID, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int64, int64) *domain.Todo); ok {
+		r0 = returnFunc(ctx, userID, todolistID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Todo)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, int64, int64) error); ok {
+		r1 = returnFunc(ctx, userID, todolistID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// TodoService_GetTodoInList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTodoInList'
+type TodoService_GetTodoInList_Call struct {
+	*mock.Call
+}
+
+// GetTodoInList is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+//   - todolistID int64
+//   - id int64
+func (_e *TodoService_Expecter) GetTodoInList(ctx interface{}, userID interface{}, todolistID interface{}, id interface{}) *TodoService_GetTodoInList_Call {
+	return &TodoService_GetTodoInList_Call{Call: _e.mock.On("GetTodoInList", ctx, userID, todolistID, id)}
+}
+
+func (_c *TodoService_GetTodoInList_Call) Run(run func(ctx context.Context, userID int64, todolistID int64, id int64)) *TodoService_GetTodoInList_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 int64
+		if args[2] != nil {
+			arg2 = args[2].(int64)
+		}
+		var arg3 int64
+		if args[3] != nil {
+			arg3 = args[3].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *TodoService_GetTodoInList_Call) Return(todo *domain.Todo, err error) *TodoService_GetTodoInList_Call {
+	_c.Call.Return(todo, err)
+	return _c
+}
+
+func (_c *TodoService_GetTodoInList_Call) RunAndReturn(run func(ctx context.Context, userID int64, todolistID int64, id int64) (*domain.Todo, error)) *TodoService_GetTodoInList_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ImportCSV provides a mock function for the type TodoService
+func (_mock *TodoService) ImportCSV(ctx context.Context, userID int64, rows []domain.CSVTodoRow) ([]domain.CSVTodoResult, error) {
+	ret := _mock.Called(ctx, userID, rows)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImportCSV")
+	}
+
+	var r0 []domain.CSVTodoResult
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, []domain.CSVTodoRow) ([]domain.CSVTodoResult, error)); ok {
+		return returnFunc(ctx, userID, rows)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, []domain.CSVTodoRow) []domain.CSVTodoResult); ok {
+		r0 = returnFunc(ctx, userID, rows)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.CSVTodoResult)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, []domain.CSVTodoRow) error); ok {
+		r1 = returnFunc(ctx, userID, rows)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// TodoService_ImportCSV_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ImportCSV'
+type TodoService_ImportCSV_Call struct {
+	*mock.Call
+}
+
+// ImportCSV is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+//   - rows []domain.CSVTodoRow
+func (_e *TodoService_Expecter) ImportCSV(ctx interface{}, userID interface{}, rows interface{}) *TodoService_ImportCSV_Call {
+	return &TodoService_ImportCSV_Call{Call: _e.mock.On("ImportCSV", ctx, userID, rows)}
+}
+
+func (_c *TodoService_ImportCSV_Call) Run(run func(ctx context.Context, userID int64, rows []domain.CSVTodoRow)) *TodoService_ImportCSV_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 []domain.CSVTodoRow
+		if args[2] != nil {
+			arg2 = args[2].([]domain.CSVTodoRow)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *TodoService_ImportCSV_Call) Return(csvTodoResults []domain.CSVTodoResult, err error) *TodoService_ImportCSV_Call {
+	_c.Call.Return(csvTodoResults, err)
+	return _c
+}
+
+func (_c *TodoService_ImportCSV_Call) RunAndReturn(run func(ctx context.Context, userID int64, rows []domain.CSVTodoRow) ([]domain.CSVTodoResult, error)) *TodoService_ImportCSV_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListOverdue provides a mock function for the type TodoService
+func (_mock *TodoService) ListOverdue(ctx context.Context, userID int64) ([]*domain.Todo, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListOverdue")
+	}
+
+	var r0 []*domain.Todo
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) ([]*domain.Todo, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) []*domain.Todo); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Todo)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// TodoService_ListOverdue_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListOverdue'
+type TodoService_ListOverdue_Call struct {
+	*mock.Call
+}
+
+// ListOverdue is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+func (_e *TodoService_Expecter) ListOverdue(ctx interface{}, userID interface{}) *TodoService_ListOverdue_Call {
+	return &TodoService_ListOverdue_Call{Call: _e.mock.On("ListOverdue", ctx, userID)}
+}
+
+func (_c *TodoService_ListOverdue_Call) Run(run func(ctx context.Context, userID int64)) *TodoService_ListOverdue_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *TodoService_ListOverdue_Call) Return(todos []*domain.Todo, err error) *TodoService_ListOverdue_Call {
+	_c.Call.Return(todos, err)
+	return _c
+}
+
+func (_c *TodoService_ListOverdue_Call) RunAndReturn(run func(ctx context.Context, userID int64) ([]*domain.Todo, error)) *TodoService_ListOverdue_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListTodos provides a mock function for the type TodoService
+func (_mock *TodoService) ListTodos(ctx context.Context, userID int64, todolistID int64, opts domain.ListOptions) ([]*domain.Todo, error) {
+	ret := _mock.Called(ctx, userID, todolistID, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTodos")
+	}
+
+	var r0 []*domain.Todo
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int64, domain.ListOptions) ([]*domain.Todo, error)); ok {
+		return returnFunc(ctx, userID, todolistID, opts)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int64, domain.ListOptions) []*domain.Todo); ok {
+		r0 = returnFunc(ctx, userID, todolistID, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Todo)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, int64, domain.ListOptions) error); ok {
+		r1 = returnFunc(ctx, userID, todolistID, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// TodoService_ListTodos_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTodos'
+type TodoService_ListTodos_Call struct {
+	*mock.Call
+}
+
+// ListTodos is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+//   - todolistID int64
+//   - opts domain.ListOptions
+func (_e *TodoService_Expecter) ListTodos(ctx interface{}, userID interface{}, todolistID interface{}, opts interface{}) *TodoService_ListTodos_Call {
+	return &TodoService_ListTodos_Call{Call: _e.mock.On("ListTodos", ctx, userID, todolistID, opts)}
+}
+
+func (_c *TodoService_ListTodos_Call) Run(run func(ctx context.Context, userID int64, todolistID int64, opts domain.ListOptions)) *TodoService_ListTodos_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 int64
+		if args[2] != nil {
+			arg2 = args[2].(int64)
+		}
+		var arg3 domain.ListOptions
+		if args[3] != nil {
+			arg3 = args[3].(domain.ListOptions)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *TodoService_ListTodos_Call) Return(todos []*domain.Todo, err error) *TodoService_ListTodos_Call {
+	_c.Call.Return(todos, err)
+	return _c
+}
+
+func (_c *TodoService_ListTodos_Call) RunAndReturn(run func(ctx context.Context, userID int64, todolistID int64, opts domain.ListOptions) ([]*domain.Todo, error)) *TodoService_ListTodos_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecentlyCompleted provides a mock function for the type TodoService
+func (_mock *TodoService) RecentlyCompleted(ctx context.Context, userID int64, limit int) ([]*domain.Todo, error) {
+	ret := _mock.Called(ctx, userID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecentlyCompleted")
+	}
+
+	var r0 []*domain.Todo
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int) ([]*domain.Todo, error)); ok {
+		return returnFunc(ctx, userID, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int) []*domain.Todo); ok {
+		r0 = returnFunc(ctx, userID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Todo)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, int) error); ok {
+		r1 = returnFunc(ctx, userID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// TodoService_RecentlyCompleted_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecentlyCompleted'
+type TodoService_RecentlyCompleted_Call struct {
+	*mock.Call
+}
+
+// RecentlyCompleted is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+//   - limit int
+func (_e *TodoService_Expecter) RecentlyCompleted(ctx interface{}, userID interface{}, limit interface{}) *TodoService_RecentlyCompleted_Call {
+	return &TodoService_RecentlyCompleted_Call{Call: _e.mock.On("RecentlyCompleted", ctx, userID, limit)}
+}
+
+func (_c *TodoService_RecentlyCompleted_Call) Run(run func(ctx context.Context, userID int64, limit int)) *TodoService_RecentlyCompleted_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *TodoService_RecentlyCompleted_Call) Return(todos []*domain.Todo, err error) *TodoService_RecentlyCompleted_Call {
+	_c.Call.Return(todos, err)
+	return _c
+}
+
+func (_c *TodoService_RecentlyCompleted_Call) RunAndReturn(run func(ctx context.Context, userID int64, limit int) ([]*domain.Todo, error)) *TodoService_RecentlyCompleted_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResolveListID provides a mock function for the type TodoService
+func (_mock *TodoService) ResolveListID(ctx context.Context, userID int64, publicID string) (int64, error) {
+	ret := _mock.Called(ctx, userID, publicID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResolveListID")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, string) (int64, error)); ok {
+		return returnFunc(ctx, userID, publicID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, string) int64); ok {
+		r0 = returnFunc(ctx, userID, publicID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, string) error); ok {
+		r1 = returnFunc(ctx, userID, publicID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// TodoService_ResolveListID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResolveListID'
+type TodoService_ResolveListID_Call struct {
+	*mock.Call
+}
+
+// ResolveListID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+//   - publicID string
+func (_e *TodoService_Expecter) ResolveListID(ctx interface{}, userID interface{}, publicID interface{}) *TodoService_ResolveListID_Call {
+	return &TodoService_ResolveListID_Call{Call: _e.mock.On("ResolveListID", ctx, userID, publicID)}
+}
+
+func (_c *TodoService_ResolveListID_Call) Run(run func(ctx context.Context, userID int64, publicID string)) *TodoService_ResolveListID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *TodoService_ResolveListID_Call) Return(n int64, err error) *TodoService_ResolveListID_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *TodoService_ResolveListID_Call) RunAndReturn(run func(ctx context.Context, userID int64, publicID string) (int64, error)) *TodoService_ResolveListID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResolveTodoID provides a mock function for the type TodoService
+func (_mock *TodoService) ResolveTodoID(ctx context.Context, userID int64, publicID string) (int64, error) {
+	ret := _mock.Called(ctx, userID, publicID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResolveTodoID")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, string) (int64, error)); ok {
+		return returnFunc(ctx, userID, publicID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, string) int64); ok {
+		r0 = returnFunc(ctx, userID, publicID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, string) error); ok {
+		r1 = returnFunc(ctx, userID, publicID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// TodoService_ResolveTodoID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResolveTodoID'
+type TodoService_ResolveTodoID_Call struct {
+	*mock.Call
+}
+
+// ResolveTodoID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+//   - publicID string
+func (_e *TodoService_Expecter) ResolveTodoID(ctx interface{}, userID interface{}, publicID interface{}) *TodoService_ResolveTodoID_Call {
+	return &TodoService_ResolveTodoID_Call{Call: _e.mock.On("ResolveTodoID", ctx, userID, publicID)}
+}
+
+func (_c *TodoService_ResolveTodoID_Call) Run(run func(ctx context.Context, userID int64, publicID string)) *TodoService_ResolveTodoID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *TodoService_ResolveTodoID_Call) Return(n int64, err error) *TodoService_ResolveTodoID_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *TodoService_ResolveTodoID_Call) RunAndReturn(run func(ctx context.Context, userID int64, publicID string) (int64, error)) *TodoService_ResolveTodoID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetDone provides a mock function for the type TodoService
+func (_mock *TodoService) SetDone(ctx context.Context, userID int64, id int64, done bool) (*domain.Todo, error) {
+	ret := _mock.Called(ctx, userID, id, done)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetDone")
+	}
+
+	var r0 *domain.Todo
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int64, bool) (*domain.Todo, error)); ok {
+		return returnFunc(ctx, userID, id, done)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int64, bool) *domain.Todo); ok {
+		r0 = returnFunc(ctx, userID, id, done)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Todo)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, int64, bool) error); ok {
+		r1 = returnFunc(ctx, userID, id, done)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// TodoService_SetDone_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetDone'
+type TodoService_SetDone_Call struct {
+	*mock.Call
+}
+
+// SetDone is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+//   - id int64
+//   - done bool
+func (_e *TodoService_Expecter) SetDone(ctx interface{}, userID interface{}, id interface{}, done interface{}) *TodoService_SetDone_Call {
+	return &TodoService_SetDone_Call{Call: _e.mock.On("SetDone", ctx, userID, id, done)}
+}
+
+func (_c *TodoService_SetDone_Call) Run(run func(ctx context.Context, userID int64, id int64, done bool)) *TodoService_SetDone_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 int64
+		if args[2] != nil {
+			arg2 = args[2].(int64)
+		}
+		var arg3 bool
+		if args[3] != nil {
+			arg3 = args[3].(bool)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *TodoService_SetDone_Call) Return(todo *domain.Todo, err error) *TodoService_SetDone_Call {
+	_c.Call.Return(todo, err)
+	return _c
+}
+
+func (_c *TodoService_SetDone_Call) RunAndReturn(run func(ctx context.Context, userID int64, id int64, done bool) (*domain.Todo, error)) *TodoService_SetDone_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ToggleAll provides a mock function for the type TodoService
+func (_mock *TodoService) ToggleAll(ctx context.Context, userID int64, todolistID int64, done bool) (int64, error) {
+	ret := _mock.Called(ctx, userID, todolistID, done)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ToggleAll")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int64, bool) (int64, error)); ok {
+		return returnFunc(ctx, userID, todolistID, done)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int64, bool) int64); ok {
+		r0 = returnFunc(ctx, userID, todolistID, done)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, int64, bool) error); ok {
+		r1 = returnFunc(ctx, userID, todolistID, done)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// TodoService_ToggleAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ToggleAll'
+type TodoService_ToggleAll_Call struct {
+	*mock.Call
+}
+
+// ToggleAll is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+//   - todolistID int64
+//   - done bool
+func (_e *TodoService_Expecter) ToggleAll(ctx interface{}, userID interface{}, todolistID interface{}, done interface{}) *TodoService_ToggleAll_Call {
+	return &TodoService_ToggleAll_Call{Call: _e.mock.On("ToggleAll", ctx, userID, todolistID, done)}
+}
+
+func (_c *TodoService_ToggleAll_Call) Run(run func(ctx context.Context, userID int64, todolistID int64, done bool)) *TodoService_ToggleAll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 int64
+		if args[2] != nil {
+			arg2 = args[2].(int64)
+		}
+		var arg3 bool
+		if args[3] != nil {
+			arg3 = args[3].(bool)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *TodoService_ToggleAll_Call) Return(n int64, err error) *TodoService_ToggleAll_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *TodoService_ToggleAll_Call) RunAndReturn(run func(ctx context.Context, userID int64, todolistID int64, done bool) (int64, error)) *TodoService_ToggleAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UndoDelete provides a mock function for the type TodoService
+func (_mock *TodoService) UndoDelete(ctx context.Context, userID int64, todolistID int64, publicID string) (*domain.Todo, error) {
+	ret := _mock.Called(ctx, userID, todolistID, publicID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UndoDelete")
+	}
+
+	var r0 *domain.Todo
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int64, string) (*domain.Todo, error)); ok {
+		return returnFunc(ctx, userID, todolistID, publicID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int64, string) *domain.Todo); ok {
+		r0 = returnFunc(ctx, userID, todolistID, publicID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Todo)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, int64, string) error); ok {
+		r1 = returnFunc(ctx, userID, todolistID, publicID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// TodoService_UndoDelete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UndoDelete'
+type TodoService_UndoDelete_Call struct {
+	*mock.Call
+}
+
+// UndoDelete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+//   - todolistID int64
+//   - publicID string
+func (_e *TodoService_Expecter) UndoDelete(ctx interface{}, userID interface{}, todolistID interface{}, publicID interface{}) *TodoService_UndoDelete_Call {
+	return &TodoService_UndoDelete_Call{Call: _e.mock.On("UndoDelete", ctx, userID, todolistID, publicID)}
+}
+
+func (_c *TodoService_UndoDelete_Call) Run(run func(ctx context.Context, userID int64, todolistID int64, publicID string)) *TodoService_UndoDelete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 int64
+		if args[2] != nil {
+			arg2 = args[2].(int64)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *TodoService_UndoDelete_Call) Return(todo *domain.Todo, err error) *TodoService_UndoDelete_Call {
+	_c.Call.Return(todo, err)
+	return _c
+}
+
+func (_c *TodoService_UndoDelete_Call) RunAndReturn(run func(ctx context.Context, userID int64, todolistID int64, publicID string) (*domain.Todo, error)) *TodoService_UndoDelete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateTodo provides a mock function for the type TodoService
+func (_mock *TodoService) UpdateTodo(ctx context.Context, userID int64, id int64, title string, done bool, dueDate *time.Time, color *string) (*domain.Todo, []string, error) {
+	ret := _mock.Called(ctx, userID, id, title, done, dueDate, color)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateTodo")
+	}
+
+	var r0 *domain.Todo
+	var r1 []string
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int64, string, bool, *time.Time, *string) (*domain.Todo, []string, error)); ok {
+		return returnFunc(ctx, userID, id, title, done, dueDate, color)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int64, string, bool, *time.Time, *string) *domain.Todo); ok {
+		r0 = returnFunc(ctx, userID, id, title, done, dueDate, color)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Todo)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, int64, string, bool, *time.Time, *string) []string); ok {
+		r1 = returnFunc(ctx, userID, id, title, done, dueDate, color)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]string)
+		}
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, int64, int64, string, bool, *time.Time, *string) error); ok {
+		r2 = returnFunc(ctx, userID, id, title, done, dueDate, color)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// TodoService_UpdateTodo_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateTodo'
+type TodoService_UpdateTodo_Call struct {
+	*mock.Call
+}
+
+// UpdateTodo is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+//   - id int64
+//   - title string
+//   - done bool
+//   - dueDate *time.Time
+//   - color *string
+func (_e *TodoService_Expecter) UpdateTodo(ctx interface{}, userID interface{}, id interface{}, title interface{}, done interface{}, dueDate interface{}, color interface{}) *TodoService_UpdateTodo_Call {
+	return &TodoService_UpdateTodo_Call{Call: _e.mock.On("UpdateTodo", ctx, userID, id, title, done, dueDate, color)}
+}
+
+func (_c *TodoService_UpdateTodo_Call) Run(run func(ctx context.Context, userID int64, id int64, title string, done bool, dueDate *time.Time, color *string)) *TodoService_UpdateTodo_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 int64
+		if args[2] != nil {
+			arg2 = args[2].(int64)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 bool
+		if args[4] != nil {
+			arg4 = args[4].(bool)
+		}
+		var arg5 *time.Time
+		if args[5] != nil {
+			arg5 = args[5].(*time.Time)
+		}
+		var arg6 *string
+		if args[6] != nil {
+			arg6 = args[6].(*string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+			arg5,
+			arg6,
+		)
+	})
+	return _c
+}
+
+func (_c *TodoService_UpdateTodo_Call) Return(todo *domain.Todo, ss []string, err error) *TodoService_UpdateTodo_Call {
+	_c.Call.Return(todo, ss, err)
+	return _c
+}
+
+func (_c *TodoService_UpdateTodo_Call) RunAndReturn(run func(ctx context.Context, userID int64, id int64, title string, done bool, dueDate *time.Time, color *string) (*domain.Todo, []string, error)) *TodoService_UpdateTodo_Call {
 	_c.Call.Return(run)
 	return _c
 }
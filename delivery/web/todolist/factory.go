@@ -1,15 +1,27 @@
 package todolist
 
+import "github.com/macesz/todo-go/delivery/web/utils"
+
 type TodoListHandlers struct {
 	todoListService TodoListService
 	todoService     TodoService
 	userService     UserService
+
+	// pageSize limits the limit query param of List
+	pageSize utils.PageSize
+}
+
+// Options holds the optional behaviour of the todo list handlers.
+type Options struct {
+	// PageSize is the max page size policy, the zero value uses utils.DefaultMaxPageSize
+	PageSize utils.PageSize
 }
 
-func NewHandlers(todoListService TodoListService, todoService TodoService, userService UserService) *TodoListHandlers {
+func NewHandlers(todoListService TodoListService, todoService TodoService, userService UserService, opts Options) *TodoListHandlers {
 	return &TodoListHandlers{
 		todoListService: todoListService,
 		todoService:     todoService,
 		userService:     userService,
+		pageSize:        opts.PageSize,
 	}
 }
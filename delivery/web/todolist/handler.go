@@ -3,8 +3,9 @@ package todolist
 import (
 	"encoding/json"
 	"errors"
+	"io"
 	"net/http"
-	"strconv"
+	"net/url"
 	"time"
 
 	"github.com/go-chi/chi/v5"
@@ -16,13 +17,19 @@ import (
 func (h *TodoListHandlers) List(w http.ResponseWriter, r *http.Request) {
 	user, ok := auth.UserFromContext(r.Context())
 	if !ok {
-		utils.WriteJSON(w, http.StatusForbidden, domain.ErrorResponse{Error: "missing user"})
+		utils.WriteJSON(w, r, http.StatusForbidden, domain.ErrorResponse{Error: "missing user"})
 		return
 	}
 
-	todoLists, err := h.todoListService.List(r.Context(), user.ID)
+	opts, err := utils.ParseListOptions(r, h.pageSize)
 	if err != nil {
-		utils.WriteJSON(w, http.StatusInternalServerError, domain.ErrorResponse{Error: "internal server error"})
+		utils.WriteJSON(w, r, http.StatusBadRequest, domain.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	todoLists, err := h.todoListService.List(r.Context(), user.ID, opts)
+	if err != nil {
+		utils.WriteError(w, r, err)
 		return
 	}
 
@@ -31,41 +38,45 @@ func (h *TodoListHandlers) List(w http.ResponseWriter, r *http.Request) {
 	respTodoLists := make([]domain.TodoListDTO, 0, len(todoLists))
 	for _, todoList := range todoLists {
 		respTodoList := domain.TodoListDTO{
-			ID:        todoList.ID,
+			ID:        todoList.PublicID,
 			UserID:    todoList.UserID,
 			Title:     todoList.Title,
 			Color:     &todoList.Color,
 			Labels:    todoList.Labels,
 			CreatedAt: todoList.CreatedAt.Format(time.RFC3339),
+			UpdatedAt: todoList.UpdatedAt.Format(time.RFC3339),
 			Deleted:   todoList.Deleted,
+			Pinned:    todoList.Pinned,
+			Version:   todoList.Version,
+			NextDue:   utils.FormatNullableTime(todoList.NextDue),
 		}
 
 		if withItems {
 			//calling DB in a loop could be bad for performance (N+1 problem), think about it!
-			todos, err := h.todoService.ListTodos(r.Context(), user.ID, todoList.ID)
+			todos, err := h.todoService.ListTodos(r.Context(), user.ID, todoList.ID, domain.ListOptions{})
 			if err != nil {
 				todos = []*domain.Todo{}
 			}
 
 			itemDTOs := make([]domain.TodoDTO, len(todos))
 			for i, item := range todos {
-				itemDTOs[i] = domain.TodoDTO{
-					ID:         item.ID,
-					UserID:     item.UserID,
-					TodoListID: item.TodoListID,
-					Title:      item.Title,
-					Done:       item.Done,
-					CreatedAt:  item.CreatedAt.Format(time.RFC3339),
-				}
+				itemDTOs[i] = domain.NewTodoDTO(item)
+				itemDTOs[i].TodoListID = todoList.PublicID
+				itemDTOs[i].Color = todoColor(item.Color, todoList)
 			}
 			respTodoList.Items = itemDTOs
+
+			percentComplete := domain.PercentComplete(todos)
+			respTodoList.PercentComplete = &percentComplete
 		}
 		respTodoLists = append(respTodoLists, respTodoList)
 	}
 
-	utils.WriteJSON(w, http.StatusOK, respTodoLists)
+	utils.WriteJSON(w, r, http.StatusOK, respTodoLists)
 }
 
+// Create handles POST /lists requests.
+// A title the user already has on an open list answers 409 Conflict, PUT /lists/by-title/{title} returns that list instead.
 func (h *TodoListHandlers) Create(w http.ResponseWriter, r *http.Request) {
 	defer r.Body.Close()
 
@@ -73,20 +84,20 @@ func (h *TodoListHandlers) Create(w http.ResponseWriter, r *http.Request) {
 
 	userctx, ok := auth.UserFromContext(ctx)
 	if !ok {
-		utils.WriteJSON(w, http.StatusForbidden, domain.ErrorResponse{Error: "missing user"})
+		utils.WriteJSON(w, r, http.StatusForbidden, domain.ErrorResponse{Error: "missing user"})
 		return
 	}
 
 	user, err := h.userService.GetUser(ctx, userctx.ID)
 	if err != nil || user == nil {
-		utils.WriteJSON(w, http.StatusForbidden, domain.ErrorResponse{Error: "missing user"})
+		utils.WriteJSON(w, r, http.StatusForbidden, domain.ErrorResponse{Error: "missing user"})
 		return
 	}
 
 	var reqTodoList domain.CreateTodoListRequestDTO
 
 	if err := json.NewDecoder(r.Body).Decode(&reqTodoList); err != nil {
-		utils.WriteJSON(w, http.StatusBadRequest, domain.ErrorResponse{Error: err.Error()})
+		utils.WriteJSON(w, r, http.StatusBadRequest, domain.ErrorResponse{Error: err.Error()})
 		return
 	}
 	colorValue := "default"
@@ -96,85 +107,158 @@ func (h *TodoListHandlers) Create(w http.ResponseWriter, r *http.Request) {
 	todoList, err := h.todoListService.Create(ctx, user.ID, reqTodoList.Title, colorValue, reqTodoList.Labels)
 	if err != nil {
 		if errors.Is(err, domain.ErrInvalidTitle) {
-			utils.WriteJSON(w, http.StatusBadRequest, domain.ErrorResponse{Error: err.Error()})
+			utils.WriteValidationError(w, r, err.Error())
 			return
 		}
-		utils.WriteJSON(w, http.StatusInternalServerError, domain.ErrorResponse{Error: "internal server error"})
+		if errors.Is(err, domain.ErrDuplicate) {
+			utils.WriteJSON(w, r, http.StatusConflict, domain.ErrorResponse{Error: err.Error()})
+			return
+		}
+		utils.WriteError(w, r, err)
 		return
 	}
 
 	respTodoList := domain.TodoListDTO{
-		ID:        todoList.ID,
+		ID:        todoList.PublicID,
 		UserID:    todoList.UserID,
 		Title:     todoList.Title,
 		Color:     &todoList.Color,
 		Labels:    todoList.Labels,
 		CreatedAt: todoList.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: todoList.UpdatedAt.Format(time.RFC3339),
 		Deleted:   todoList.Deleted,
+		Pinned:    todoList.Pinned,
+		Version:   todoList.Version,
 	}
 
-	utils.WriteJSON(w, http.StatusCreated, respTodoList)
+	w.Header().Set("ETag", utils.ETag(respTodoList.Version))
+	utils.WriteJSON(w, r, http.StatusCreated, respTodoList)
 
 }
 
-func (h *TodoListHandlers) GetListByID(w http.ResponseWriter, r *http.Request) {
-	user, ok := auth.UserFromContext(r.Context())
+// GetOrCreate handles PUT /lists/by-title/{title} requests.
+// It returns the user's list with that title (200), or creates it (201).
+// The optional body sets color and labels, they are ignored when the list already exists.
+func (h *TodoListHandlers) GetOrCreate(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	ctx := r.Context()
+
+	user, ok := auth.UserFromContext(ctx)
 	if !ok {
-		utils.WriteJSON(w, http.StatusForbidden, domain.ErrorResponse{Error: "missing user"})
+		utils.WriteJSON(w, r, http.StatusForbidden, domain.ErrorResponse{Error: "missing user"})
 		return
 	}
 
-	idr := chi.URLParam(r, "id") // Get the "id" URL parameter
-	if idr == "" {
-		utils.WriteJSON(w, http.StatusBadRequest, domain.ErrorResponse{Error: "id is required"})
+	// chi matches on the raw path when it has escaped characters (like %2F), so the param is still escaped then
+	title := chi.URLParam(r, "title")
+	if r.URL.RawPath != "" {
+		unescaped, err := url.PathUnescape(title)
+		if err != nil {
+			utils.WriteJSON(w, r, http.StatusBadRequest, domain.ErrorResponse{Error: "title must be a valid path segment"})
+			return
+		}
+		title = unescaped
+	}
+
+	var reqTodoList domain.CreateTodoListRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&reqTodoList); err != nil && !errors.Is(err, io.EOF) {
+		utils.WriteJSON(w, r, http.StatusBadRequest, domain.ErrorResponse{Error: err.Error()})
 		return
 	}
 
-	id, err := strconv.ParseInt(idr, 10, 64) // Convert id string to int
+	colorValue := "default"
+	if reqTodoList.Color != nil {
+		colorValue = *reqTodoList.Color
+	}
+
+	todoList, created, err := h.todoListService.GetOrCreate(ctx, user.ID, title, colorValue, reqTodoList.Labels)
 	if err != nil {
-		utils.WriteJSON(w, http.StatusBadRequest, domain.ErrorResponse{Error: "id must be an integer"})
+		if errors.Is(err, domain.ErrInvalidTitle) {
+			utils.WriteValidationError(w, r, err.Error())
+			return
+		}
+		utils.WriteError(w, r, err)
+		return
+	}
+
+	respTodoList := domain.TodoListDTO{
+		ID:        todoList.PublicID,
+		UserID:    todoList.UserID,
+		Title:     todoList.Title,
+		Color:     &todoList.Color,
+		Labels:    todoList.Labels,
+		CreatedAt: todoList.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: todoList.UpdatedAt.Format(time.RFC3339),
+		Deleted:   todoList.Deleted,
+		Pinned:    todoList.Pinned,
+		Version:   todoList.Version,
+	}
+
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+	}
+
+	w.Header().Set("ETag", utils.ETag(respTodoList.Version))
+	utils.WriteJSON(w, r, status, respTodoList)
+}
+
+func (h *TodoListHandlers) GetListByID(w http.ResponseWriter, r *http.Request) {
+	user, ok := auth.UserFromContext(r.Context())
+	if !ok {
+		utils.WriteJSON(w, r, http.StatusForbidden, domain.ErrorResponse{Error: "missing user"})
+		return
+	}
+
+	// The path has the public id of the list, resolve it to the internal one
+	id, ok := h.idFromPath(w, r, user.ID)
+	if !ok {
 		return
 	}
 
 	todoList, err := h.todoListService.GetListByID(r.Context(), user.ID, id)
 	if err != nil {
 		if errors.Is(err, domain.ErrListNotFound) { // Check custom error
-			utils.WriteJSON(w, http.StatusNotFound, domain.ErrorResponse{Error: err.Error()}) // e.g., {"error": "todo not found"}
+			utils.WriteJSON(w, r, http.StatusNotFound, domain.ErrorResponse{Error: err.Error()}) // e.g., {"error": "todo not found"}
 			return
 		}
-		utils.WriteJSON(w, http.StatusInternalServerError, domain.ErrorResponse{Error: "internal server error"}) // Generic for security
+		utils.WriteError(w, r, err) // Generic for security
 		return
 	}
 
-	todos, err := h.todoService.ListTodos(r.Context(), user.ID, todoList.ID)
+	todos, err := h.todoService.ListTodos(r.Context(), user.ID, todoList.ID, domain.ListOptions{})
 	if err != nil {
 		todos = []*domain.Todo{}
 	}
 
 	itemDTOs := make([]domain.TodoDTO, len(todos))
 	for i, item := range todos {
-		itemDTOs[i] = domain.TodoDTO{
-			ID:         item.ID,
-			UserID:     item.UserID,
-			TodoListID: item.TodoListID,
-			Title:      item.Title,
-			Done:       item.Done,
-			CreatedAt:  item.CreatedAt.Format(time.RFC3339),
-		}
+		itemDTOs[i] = domain.NewTodoDTO(item)
+		itemDTOs[i].TodoListID = todoList.PublicID
+		itemDTOs[i].Color = todoColor(item.Color, todoList)
 	}
 
+	percentComplete := domain.PercentComplete(todos)
+
 	// Create response
 	respTodoList := domain.TodoListDTO{
-		ID:        todoList.ID,
+		ID:        todoList.PublicID,
 		UserID:    todoList.UserID,
 		Title:     todoList.Title,
 		Color:     &todoList.Color,
 		Labels:    todoList.Labels,
 		CreatedAt: todoList.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: todoList.UpdatedAt.Format(time.RFC3339),
 		Deleted:   todoList.Deleted,
+		Pinned:    todoList.Pinned,
+		Version:   todoList.Version,
 		Items:     itemDTOs,
+
+		PercentComplete: &percentComplete,
 	}
-	utils.WriteJSON(w, http.StatusOK, respTodoList)
+	w.Header().Set("ETag", utils.ETag(respTodoList.Version))
+	utils.WriteJSON(w, r, http.StatusOK, respTodoList)
 }
 
 func (h *TodoListHandlers) Update(w http.ResponseWriter, r *http.Request) {
@@ -184,83 +268,262 @@ func (h *TodoListHandlers) Update(w http.ResponseWriter, r *http.Request) {
 
 	user, ok := auth.UserFromContext(ctx)
 	if !ok {
-		utils.WriteJSON(w, http.StatusForbidden, domain.ErrorResponse{Error: "missing user"})
+		utils.WriteJSON(w, r, http.StatusForbidden, domain.ErrorResponse{Error: "missing user"})
 		return
 	}
 
-	idr := chi.URLParam(r, "id")
-	if idr == "" {
-		utils.WriteJSON(w, http.StatusBadRequest, domain.ErrorResponse{Error: "id is required"})
+	// The path has the public id of the list, resolve it to the internal one
+	id, ok := h.idFromPath(w, r, user.ID)
+	if !ok {
 		return
 	}
 
-	id, err := strconv.ParseInt(idr, 10, 64) // Convert id string to int
+	// The If-Match header has the version the client read, a stale one is a conflict
+	version, err := utils.ParseIfMatch(r)
 	if err != nil {
-		utils.WriteJSON(w, http.StatusBadRequest, domain.ErrorResponse{Error: "id must be an integer"})
+		if errors.Is(err, utils.ErrMissingIfMatch) {
+			utils.WriteJSON(w, r, http.StatusPreconditionRequired, domain.ErrorResponse{Error: err.Error()})
+			return
+		}
+		utils.WriteJSON(w, r, http.StatusBadRequest, domain.ErrorResponse{Error: err.Error()})
 		return
 	}
 
 	var todoListDtO domain.UpdateTodoListRequestDTO
 	if err := json.NewDecoder(r.Body).Decode(&todoListDtO); err != nil {
-		utils.WriteJSON(w, http.StatusBadRequest, domain.ErrorResponse{Error: err.Error()}) // Using struct for consistency
+		utils.WriteJSON(w, r, http.StatusBadRequest, domain.ErrorResponse{Error: err.Error()}) // Using struct for consistency
 		return
 	}
 
-	updated, err := h.todoListService.Update(ctx, user.ID, id, todoListDtO.Title, *todoListDtO.Color, todoListDtO.Labels, todoListDtO.Deleted)
+	updated, err := h.todoListService.Update(ctx, user.ID, id, version, todoListDtO.Title, todoListDtO.Color, todoListDtO.Labels, todoListDtO.Deleted)
 	if err != nil {
 		if errors.Is(err, domain.ErrListNotFound) { // Check custom error )
-			utils.WriteJSON(w, http.StatusNotFound, domain.ErrorResponse{Error: err.Error()}) // e.g., {"error": "todo not found"}
+			utils.WriteJSON(w, r, http.StatusNotFound, domain.ErrorResponse{Error: err.Error()}) // e.g., {"error": "todo not found"}
+			return
+		} else if errors.Is(err, domain.ErrVersionConflict) {
+			utils.WriteJSON(w, r, http.StatusConflict, domain.ErrorResponse{Error: err.Error()})
 			return
 		} else if errors.Is(err, domain.ErrInvalidTitle) { // Optional: If service returns this
-			utils.WriteJSON(w, http.StatusBadRequest, domain.ErrorResponse{Error: err.Error()})
+			utils.WriteValidationError(w, r, err.Error())
+			return
+		} else if errors.Is(err, domain.ErrDuplicate) {
+			utils.WriteJSON(w, r, http.StatusConflict, domain.ErrorResponse{Error: err.Error()})
 			return
 		}
-		utils.WriteJSON(w, http.StatusInternalServerError, domain.ErrorResponse{Error: "internal server error"}) // Generic for security
+		utils.WriteError(w, r, err) // Generic for security
 		return
 	}
 
 	respTodoList := domain.TodoListDTO{
-		ID:      updated.ID,
-		UserID:  user.ID,
-		Title:   updated.Title,
-		Color:   &updated.Color,
-		Labels:  updated.Labels,
-		Deleted: updated.Deleted,
+		ID:        updated.PublicID,
+		UserID:    user.ID,
+		Title:     updated.Title,
+		Color:     &updated.Color,
+		Labels:    updated.Labels,
+		CreatedAt: updated.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: updated.UpdatedAt.Format(time.RFC3339),
+		Deleted:   updated.Deleted,
+		Pinned:    updated.Pinned,
+		Version:   updated.Version,
 	}
 
-	utils.WriteJSON(w, http.StatusOK, respTodoList)
+	w.Header().Set("ETag", utils.ETag(respTodoList.Version))
+	utils.WriteJSON(w, r, http.StatusOK, respTodoList)
 }
 
-func (h *TodoListHandlers) Delete(w http.ResponseWriter, r *http.Request) {
+// Pin handles PUT /lists/{id}/pin requests.
+func (h *TodoListHandlers) Pin(w http.ResponseWriter, r *http.Request) {
+	h.setPinned(w, r, true)
+}
+
+// Unpin handles DELETE /lists/{id}/pin requests.
+func (h *TodoListHandlers) Unpin(w http.ResponseWriter, r *http.Request) {
+	h.setPinned(w, r, false)
+}
 
+func (h *TodoListHandlers) setPinned(w http.ResponseWriter, r *http.Request, pinned bool) {
 	ctx := r.Context()
 
 	user, ok := auth.UserFromContext(ctx)
 	if !ok {
-		utils.WriteJSON(w, http.StatusForbidden, domain.ErrorResponse{Error: "missing user"})
+		utils.WriteJSON(w, r, http.StatusForbidden, domain.ErrorResponse{Error: "missing user"})
 		return
 	}
 
-	idr := chi.URLParam(r, "id")
-	if idr == "" {
-		utils.WriteJSON(w, http.StatusBadRequest, domain.ErrorResponse{Error: "id is required"})
+	// The path has the public id of the list, resolve it to the internal one
+	id, ok := h.idFromPath(w, r, user.ID)
+	if !ok {
 		return
 	}
 
-	id, err := strconv.ParseInt(idr, 10, 64) // Convert id string to int
+	updated, err := h.todoListService.SetPinned(ctx, user.ID, id, pinned)
 	if err != nil {
-		utils.WriteJSON(w, http.StatusBadRequest, domain.ErrorResponse{Error: "id must be an integer"})
+		if errors.Is(err, domain.ErrListNotFound) {
+			utils.WriteJSON(w, r, http.StatusNotFound, domain.ErrorResponse{Error: err.Error()})
+			return
+		}
+		utils.WriteError(w, r, err) // Generic for security
+		return
+	}
+
+	respTodoList := domain.TodoListDTO{
+		ID:        updated.PublicID,
+		UserID:    updated.UserID,
+		Title:     updated.Title,
+		Color:     &updated.Color,
+		Labels:    updated.Labels,
+		CreatedAt: updated.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: updated.UpdatedAt.Format(time.RFC3339),
+		Deleted:   updated.Deleted,
+		Pinned:    updated.Pinned,
+		Version:   updated.Version,
+	}
+
+	w.Header().Set("ETag", utils.ETag(respTodoList.Version))
+	utils.WriteJSON(w, r, http.StatusOK, respTodoList)
+}
+
+func (h *TodoListHandlers) Delete(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	user, ok := auth.UserFromContext(ctx)
+	if !ok {
+		utils.WriteJSON(w, r, http.StatusForbidden, domain.ErrorResponse{Error: "missing user"})
+		return
+	}
+
+	dryRun, err := utils.ParseDryRun(r)
+	if err != nil {
+		utils.WriteJSON(w, r, http.StatusBadRequest, domain.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	// The path has the public id of the list, resolve it to the internal one
+	id, ok := h.idFromPath(w, r, user.ID)
+	if !ok {
+		return
+	}
+
+	// A dry run only reports the list that would be deleted
+	if dryRun {
+		result, err := h.todoListService.DeleteDryRun(ctx, user.ID, id)
+		if err != nil {
+			if errors.Is(err, domain.ErrListNotFound) {
+				utils.WriteJSON(w, r, http.StatusNotFound, domain.ErrorResponse{Error: err.Error()})
+				return
+			}
+			utils.WriteError(w, r, err)
+			return
+		}
+
+		utils.WriteJSON(w, r, http.StatusOK, utils.DryRunResponse(result))
 		return
 	}
 
 	if err := h.todoListService.Delete(ctx, user.ID, id); err != nil {
 		if errors.Is(err, domain.ErrListNotFound) {
-			utils.WriteJSON(w, http.StatusNotFound, domain.ErrorResponse{Error: err.Error()})
+			utils.WriteJSON(w, r, http.StatusNotFound, domain.ErrorResponse{Error: err.Error()})
 			return
 		}
-		utils.WriteJSON(w, http.StatusInternalServerError, domain.ErrorResponse{Error: "internal server error"}) // Generic for security
+		utils.WriteError(w, r, err) // Generic for security
 		return
 	}
 
 	w.WriteHeader(http.StatusNoContent) // 204 No Content
 }
+
+// ListOfTodo handles GET /todos/{id}/list requests.
+// It returns the list that owns the todo, without its items.
+func (h *TodoListHandlers) ListOfTodo(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	user, ok := auth.UserFromContext(ctx)
+	if !ok {
+		utils.WriteJSON(w, r, http.StatusForbidden, domain.ErrorResponse{Error: "missing user"})
+		return
+	}
+
+	publicID, err := utils.ParsePublicID(r, "id")
+	if err != nil {
+		utils.WriteJSON(w, r, http.StatusBadRequest, domain.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	// Every lookup checks the owner, someone else's todo is not found
+	todoID, err := h.todoService.ResolveTodoID(ctx, user.ID, publicID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			utils.WriteJSON(w, r, http.StatusNotFound, domain.ErrorResponse{Error: err.Error()})
+			return
+		}
+		utils.WriteError(w, r, err)
+		return
+	}
+
+	todo, err := h.todoService.GetTodo(ctx, user.ID, todoID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			utils.WriteJSON(w, r, http.StatusNotFound, domain.ErrorResponse{Error: err.Error()})
+			return
+		}
+		utils.WriteError(w, r, err)
+		return
+	}
+
+	todoList, err := h.todoListService.GetListByID(ctx, user.ID, todo.TodoListID)
+	if err != nil {
+		if errors.Is(err, domain.ErrListNotFound) {
+			utils.WriteJSON(w, r, http.StatusNotFound, domain.ErrorResponse{Error: err.Error()})
+			return
+		}
+		utils.WriteError(w, r, err)
+		return
+	}
+
+	respTodoList := domain.TodoListDTO{
+		ID:        todoList.PublicID,
+		UserID:    todoList.UserID,
+		Title:     todoList.Title,
+		Color:     &todoList.Color,
+		Labels:    todoList.Labels,
+		CreatedAt: todoList.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: todoList.UpdatedAt.Format(time.RFC3339),
+		Deleted:   todoList.Deleted,
+		Pinned:    todoList.Pinned,
+		Version:   todoList.Version,
+	}
+
+	w.Header().Set("ETag", utils.ETag(respTodoList.Version))
+	utils.WriteJSON(w, r, http.StatusOK, respTodoList)
+}
+
+// idFromPath resolves the public id in the {id} URL param to the internal list id.
+// On failure the error response is already written.
+func (h *TodoListHandlers) idFromPath(w http.ResponseWriter, r *http.Request, userID int64) (int64, bool) {
+	publicID, err := utils.ParsePublicID(r, "id")
+	if err != nil {
+		utils.WriteJSON(w, r, http.StatusBadRequest, domain.ErrorResponse{Error: err.Error()})
+		return 0, false
+	}
+
+	id, err := h.todoListService.ResolveID(r.Context(), userID, publicID)
+	if err != nil {
+		if errors.Is(err, domain.ErrListNotFound) {
+			utils.WriteJSON(w, r, http.StatusNotFound, domain.ErrorResponse{Error: err.Error()})
+			return 0, false
+		}
+		utils.WriteError(w, r, err)
+		return 0, false
+	}
+
+	return id, true
+}
+
+// todoColor is the color of a todo of the list, the list color when the todo has none.
+func todoColor(color *string, list *domain.TodoList) *string {
+	if color != nil {
+		return color
+	}
+	return &list.Color
+}
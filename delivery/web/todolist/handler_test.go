@@ -6,6 +6,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strconv"
@@ -14,9 +15,9 @@ import (
 	"time"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/macesz/todo-go/delivery/web/auth"
 	"github.com/macesz/todo-go/delivery/web/todolist/mocks"
 	"github.com/macesz/todo-go/domain"
-	"github.com/macesz/todo-go/tests/testutils"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -25,6 +26,7 @@ import (
 // TestList tests the List handler with various scenarios
 func TestList(t *testing.T) {
 	fixedTime := time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC)
+	nextDue := fixedTime.Add(21 * time.Hour) // Work Tasks has nothing due, so it sends no next_due
 	testUserID := int64(1)
 
 	tests := []struct {
@@ -39,26 +41,31 @@ func TestList(t *testing.T) {
 			mockReturn: []*domain.TodoList{
 				{
 					ID:        1,
+					PublicID:  publicID(1),
 					UserID:    testUserID,
 					Title:     "Shopping List",
 					Color:     "#FF5733",
 					Labels:    []string{"groceries", "urgent"},
 					CreatedAt: fixedTime,
+					UpdatedAt: fixedTime,
 					Items:     []domain.Todo{},
+					NextDue:   &nextDue,
 				},
 				{
 					ID:        2,
+					PublicID:  publicID(2),
 					UserID:    testUserID,
 					Title:     "Work Tasks",
 					Color:     "#3357FF",
 					Labels:    []string{"work"},
 					CreatedAt: fixedTime,
+					UpdatedAt: fixedTime,
 					Items:     []domain.Todo{},
 				},
 			},
 			mockError:      nil,
 			expectedStatus: http.StatusOK,
-			expectedBody:   `[{"ID":1,"UserID":1,"Title":"Shopping List","Color":"#FF5733","Labels":["groceries","urgent"],"CreatedAt":"2024-01-01T12:00:00Z","Items":[]},{"ID":2,"UserID":1,"Title":"Work Tasks","Color":"#3357FF","Labels":["work"],"CreatedAt":"2024-01-01T12:00:00Z","Items":[]}]`,
+			expectedBody:   `[{"id":"00000000-0000-0000-0000-000000000001","user_id":1,"title":"Shopping List","color":"#FF5733","labels":["groceries","urgent"],"created_at":"2024-01-01T12:00:00Z","updated_at":"2024-01-01T12:00:00Z","deleted":false,"pinned":false,"version":0,"next_due":"2024-01-02T09:00:00Z"},{"id":"00000000-0000-0000-0000-000000000002","user_id":1,"title":"Work Tasks","color":"#3357FF","labels":["work"],"created_at":"2024-01-01T12:00:00Z","updated_at":"2024-01-01T12:00:00Z","deleted":false,"pinned":false,"version":0}]`,
 		},
 		{
 			name:           "Service error",
@@ -73,7 +80,7 @@ func TestList(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mockService := mocks.NewTodoListService(t)
 
-			mockService.On("List", mock.Anything, testUserID).
+			mockService.On("List", mock.Anything, testUserID, domain.ListOptions{}).
 				Return(tt.mockReturn, tt.mockError).
 				Once()
 
@@ -83,7 +90,7 @@ func TestList(t *testing.T) {
 			require.NoError(t, err)
 
 			// Add user context to simulate authenticated request
-			req = testutils.WithUserContext(req, testUserID)
+			req = withUserContext(req, testUserID)
 
 			rr := httptest.NewRecorder()
 			handlers.List(rr, req)
@@ -99,6 +106,7 @@ func TestList(t *testing.T) {
 // TestGetListByID tests the GetListByID handler with various scenarios
 func TestGetListByID(t *testing.T) {
 	fixedTime := time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC)
+	dishesColor := "#00AA00" // A todo with its own color keeps it, the others show the list color
 	testUserID := int64(1)
 	testListID := int64(1)
 
@@ -117,18 +125,59 @@ func TestGetListByID(t *testing.T) {
 			shouldCallMock: true,
 			mockReturn: &domain.TodoList{
 				ID:        testListID,
+				PublicID:  publicID(testListID),
 				UserID:    testUserID,
 				Title:     "Shopping List",
 				Color:     "#FF5733",
 				Labels:    []string{"groceries"},
 				CreatedAt: fixedTime,
+				UpdatedAt: fixedTime,
 				Items: []domain.Todo{
-					{ID: 10, UserID: testUserID, TodoListID: testListID, Title: "Buy milk", Done: false, CreatedAt: fixedTime},
+					{ID: 10, PublicID: publicID(10), UserID: testUserID, TodoListID: testListID, Title: "Buy milk", Done: false, CreatedAt: fixedTime, UpdatedAt: fixedTime},
 				},
 			},
 			mockError:      nil,
 			expectedStatus: http.StatusOK,
-			expectedBody:   `{"id":1,"user_id":1,"title":"Shopping List","color":"#FF5733","labels":["groceries"],"created_at":"2024-01-01T12:00:00Z","items":[{"id":10,"user_id":1,"todolist_id":1,"title":"Buy milk","done":false,"created_at":"2024-01-01T12:00:00Z"}]}`,
+			expectedBody:   `{"id":"00000000-0000-0000-0000-000000000001","user_id":1,"title":"Shopping List","color":"#FF5733","labels":["groceries"],"created_at":"2024-01-01T12:00:00Z","updated_at":"2024-01-01T12:00:00Z","deleted":false,"pinned":false,"version":0,"percent_complete":0,"items":[{"id":"00000000-0000-0000-0000-000000000010","user_id":1,"todolist_id":"00000000-0000-0000-0000-000000000001","title":"Buy milk","done":false,"created_at":"2024-01-01T12:00:00Z","updated_at":"2024-01-01T12:00:00Z","color":"#FF5733"}]}`,
+		},
+		{
+			name:           "Half done list - 50 percent complete",
+			urlParam:       "3",
+			shouldCallMock: true,
+			mockReturn: &domain.TodoList{
+				ID:        3,
+				PublicID:  publicID(3),
+				UserID:    testUserID,
+				Title:     "Chores",
+				Color:     "#FF5733",
+				CreatedAt: fixedTime,
+				UpdatedAt: fixedTime,
+				Items: []domain.Todo{
+					{ID: 30, PublicID: publicID(30), UserID: testUserID, TodoListID: 3, Title: "Dishes", Done: true, CreatedAt: fixedTime, UpdatedAt: fixedTime, Color: &dishesColor},
+					{ID: 31, PublicID: publicID(31), UserID: testUserID, TodoListID: 3, Title: "Laundry", Done: false, CreatedAt: fixedTime, UpdatedAt: fixedTime},
+				},
+			},
+			mockError:      nil,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"id":"00000000-0000-0000-0000-000000000003","user_id":1,"title":"Chores","color":"#FF5733","created_at":"2024-01-01T12:00:00Z","updated_at":"2024-01-01T12:00:00Z","deleted":false,"pinned":false,"version":0,"percent_complete":50,"items":[{"id":"00000000-0000-0000-0000-000000000030","user_id":1,"todolist_id":"00000000-0000-0000-0000-000000000003","title":"Dishes","done":true,"created_at":"2024-01-01T12:00:00Z","updated_at":"2024-01-01T12:00:00Z","color":"#00AA00"},{"id":"00000000-0000-0000-0000-000000000031","user_id":1,"todolist_id":"00000000-0000-0000-0000-000000000003","title":"Laundry","done":false,"created_at":"2024-01-01T12:00:00Z","updated_at":"2024-01-01T12:00:00Z","color":"#FF5733"}]}`,
+		},
+		{
+			name:           "Empty list - 0 percent complete",
+			urlParam:       "4",
+			shouldCallMock: true,
+			mockReturn: &domain.TodoList{
+				ID:        4,
+				PublicID:  publicID(4),
+				UserID:    testUserID,
+				Title:     "Empty",
+				Color:     "#FF5733",
+				CreatedAt: fixedTime,
+				UpdatedAt: fixedTime,
+				Items:     []domain.Todo{},
+			},
+			mockError:      nil,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"id":"00000000-0000-0000-0000-000000000004","user_id":1,"title":"Empty","color":"#FF5733","created_at":"2024-01-01T12:00:00Z","updated_at":"2024-01-01T12:00:00Z","deleted":false,"pinned":false,"version":0,"percent_complete":0}`,
 		},
 		{
 			name:           "List not found",
@@ -144,25 +193,38 @@ func TestGetListByID(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockService := mocks.NewTodoListService(t)
+			mockTodoService := mocks.NewTodoService(t)
+
+			expectedID, _ := strconv.ParseInt(tt.urlParam, 10, 64)
+			expectResolve(mockService, testUserID, expectedID)
 
 			if tt.shouldCallMock {
-				expectedID, _ := strconv.ParseInt(tt.urlParam, 10, 64)
 				mockService.On("GetListByID", mock.Anything, testUserID, expectedID).
 					Return(tt.mockReturn, tt.mockError).
 					Once()
 			}
 
-			handler := &TodoListHandlers{todoListService: mockService}
+			if tt.mockReturn != nil {
+				items := make([]*domain.Todo, len(tt.mockReturn.Items))
+				for i := range tt.mockReturn.Items {
+					items[i] = &tt.mockReturn.Items[i]
+				}
+				mockTodoService.On("ListTodos", mock.Anything, testUserID, tt.mockReturn.ID, domain.ListOptions{}).
+					Return(items, nil).
+					Once()
+			}
 
-			req, err := http.NewRequest(http.MethodGet, "/lists/"+tt.urlParam, nil)
+			handler := &TodoListHandlers{todoListService: mockService, todoService: mockTodoService}
+
+			req, err := http.NewRequest(http.MethodGet, "/lists/"+publicID(expectedID), nil)
 			require.NoError(t, err)
 
 			// Add user context
-			req = testutils.WithUserContext(req, testUserID)
+			req = withUserContext(req, testUserID)
 
 			// Add chi URL params
 			rctx := chi.NewRouteContext()
-			rctx.URLParams.Add("id", tt.urlParam)
+			rctx.URLParams.Add("id", publicID(expectedID))
 			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
 
 			rr := httptest.NewRecorder()
@@ -204,16 +266,43 @@ func TestCreate(t *testing.T) {
 				m.On("Create", mock.Anything, testUserID, "Shopping List", "#FF5733", []string{"groceries", "urgent"}).
 					Return(&domain.TodoList{
 						ID:        1,
+						PublicID:  publicID(1),
 						UserID:    testUserID,
 						Title:     "Shopping List",
 						Color:     "#FF5733",
 						Labels:    []string{"groceries", "urgent"},
 						CreatedAt: fixedTime,
+						UpdatedAt: fixedTime,
 					}, nil).
 					Once()
 			},
 			expectedStatus: http.StatusCreated,
-			expectedBody:   `{"id":1,"user_id":1,"title":"Shopping List","color":"#FF5733","labels":["groceries","urgent"],"created_at":"2024-01-01T12:00:00Z"}`,
+			expectedBody:   `{"id":"00000000-0000-0000-0000-000000000001","user_id":1,"title":"Shopping List","color":"#FF5733","labels":["groceries","urgent"],"created_at":"2024-01-01T12:00:00Z","updated_at":"2024-01-01T12:00:00Z","deleted":false,"pinned":false,"version":0}`,
+		},
+		{
+			name:      "Without color uses the default color",
+			inputBody: `{"title":"Groceries","labels":["x"]}`,
+			setupUserMock: func(m *mocks.UserService) {
+				m.On("GetUser", mock.Anything, testUserID).
+					Return(&domain.User{ID: testUserID, Name: "Test User", Email: "test@example.com"}, nil).
+					Once()
+			},
+			setupListMock: func(m *mocks.TodoListService) {
+				m.On("Create", mock.Anything, testUserID, "Groceries", "default", []string{"x"}).
+					Return(&domain.TodoList{
+						ID:        2,
+						PublicID:  publicID(2),
+						UserID:    testUserID,
+						Title:     "Groceries",
+						Color:     "default",
+						Labels:    []string{"x"},
+						CreatedAt: fixedTime,
+						UpdatedAt: fixedTime,
+					}, nil).
+					Once()
+			},
+			expectedStatus: http.StatusCreated,
+			expectedBody:   `{"id":"00000000-0000-0000-0000-000000000002","user_id":1,"title":"Groceries","color":"default","labels":["x"],"created_at":"2024-01-01T12:00:00Z","updated_at":"2024-01-01T12:00:00Z","deleted":false,"pinned":false,"version":0}`,
 		},
 		{
 			name:      "Invalid JSON",
@@ -229,6 +318,22 @@ func TestCreate(t *testing.T) {
 			expectedStatus: http.StatusBadRequest,
 			expectedBody:   `{"error":"invalid character '}' looking for beginning of object key string"}`,
 		},
+		{
+			name:      "Missing title",
+			inputBody: `{"color":"#FF5733"}`, // Well-formed JSON, but the title is required
+			setupUserMock: func(m *mocks.UserService) {
+				m.On("GetUser", mock.Anything, testUserID).
+					Return(&domain.User{ID: testUserID, Name: "Test User", Email: "test@example.com"}, nil).
+					Once()
+			},
+			setupListMock: func(m *mocks.TodoListService) {
+				m.On("Create", mock.Anything, testUserID, "", "#FF5733", []string(nil)).
+					Return(nil, domain.ErrInvalidTitle).
+					Once()
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   `{"error":"` + domain.ErrInvalidTitle.Error() + `"}`,
+		},
 	}
 
 	for _, tt := range tests {
@@ -253,7 +358,7 @@ func TestCreate(t *testing.T) {
 			req.Header.Set("Content-Type", "application/json")
 
 			// Add user context
-			req = testutils.WithUserContext(req, testUserID)
+			req = withUserContext(req, testUserID)
 
 			// Create response recorder
 			rr := httptest.NewRecorder()
@@ -282,34 +387,108 @@ func TestUpdate(t *testing.T) {
 		name           string
 		urlParam       string
 		inputBody      string
+		ifMatch        string
 		shouldCallMock bool
 		mockReturn     *domain.TodoList
 		mockError      error
 		expectedStatus int
+		expectedETag   string
 		expectedBody   string
 	}{
 		{
 			name:           "Success - valid update",
 			urlParam:       "1",
 			inputBody:      `{"title":"Updated Shopping List","color":"#00FF00","labels":["groceries"]}`,
+			ifMatch:        `"1"`,
 			shouldCallMock: true,
 			mockReturn: &domain.TodoList{
 				ID:        1,
+				PublicID:  publicID(1),
 				UserID:    testUserID,
 				Title:     "Updated Shopping List",
 				Color:     "#00FF00",
 				Labels:    []string{"groceries"},
 				CreatedAt: fixedTime,
-				Deleted: false
+				UpdatedAt: fixedTime,
+				Deleted:   false,
+				Version:   2,
 			},
 			mockError:      nil,
 			expectedStatus: http.StatusOK,
-			expectedBody:   `{"id":1,"user_id":1,"title":"Updated Shopping List","color":"#00FF00","labels":["groceries"],"created_at":"","deleted": false}`,
+			expectedETag:   `"2"`,
+			expectedBody:   `{"id":"00000000-0000-0000-0000-000000000001","user_id":1,"title":"Updated Shopping List","color":"#00FF00","labels":["groceries"],"created_at":"2024-01-01T12:00:00Z","updated_at":"2024-01-01T12:00:00Z","deleted":false,"pinned":false,"version":2}`,
+		},
+		{
+			name:           "Keeps the original created_at",
+			urlParam:       "1",
+			inputBody:      `{"title":"Updated Shopping List","color":"#00FF00","labels":[]}`,
+			ifMatch:        `"1"`,
+			shouldCallMock: true,
+			mockReturn: &domain.TodoList{
+				ID:        1,
+				PublicID:  publicID(1),
+				UserID:    testUserID,
+				Title:     "Updated Shopping List",
+				Color:     "#00FF00",
+				CreatedAt: fixedTime.Add(-48 * time.Hour),
+				UpdatedAt: fixedTime,
+				Version:   2,
+			},
+			expectedStatus: http.StatusOK,
+			expectedETag:   `"2"`,
+			expectedBody:   `{"id":"00000000-0000-0000-0000-000000000001","user_id":1,"title":"Updated Shopping List","color":"#00FF00","created_at":"2023-12-30T12:00:00Z","updated_at":"2024-01-01T12:00:00Z","deleted":false,"pinned":false,"version":2}`,
+		},
+		{
+			name:           "Without color keeps the color",
+			urlParam:       "1",
+			inputBody:      `{"title":"Updated Shopping List","labels":["groceries"]}`,
+			ifMatch:        `"1"`,
+			shouldCallMock: true,
+			mockReturn: &domain.TodoList{
+				ID:        1,
+				PublicID:  publicID(1),
+				UserID:    testUserID,
+				Title:     "Updated Shopping List",
+				Color:     "#FF0000",
+				Labels:    []string{"groceries"},
+				CreatedAt: fixedTime,
+				UpdatedAt: fixedTime,
+				Version:   2,
+			},
+			expectedStatus: http.StatusOK,
+			expectedETag:   `"2"`,
+			expectedBody:   `{"id":"00000000-0000-0000-0000-000000000001","user_id":1,"title":"Updated Shopping List","color":"#FF0000","labels":["groceries"],"created_at":"2024-01-01T12:00:00Z","updated_at":"2024-01-01T12:00:00Z","deleted":false,"pinned":false,"version":2}`,
+		},
+		{
+			name:           "Stale version",
+			urlParam:       "1",
+			inputBody:      `{"title":"Updated Shopping List","color":"#00FF00","labels":["groceries"]}`,
+			ifMatch:        `"1"`,
+			shouldCallMock: true,
+			mockError:      domain.ErrVersionConflict,
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `{"error":"the list was changed since it was read, reload it and try again"}`,
+		},
+		{
+			name:           "Missing If-Match",
+			urlParam:       "1",
+			inputBody:      `{"title":"Updated Shopping List","color":"#00FF00","labels":["groceries"]}`,
+			expectedStatus: http.StatusPreconditionRequired,
+			expectedBody:   `{"error":"the If-Match header is required, send the ETag of the list you read"}`,
+		},
+		{
+			name:           "Invalid If-Match",
+			urlParam:       "1",
+			inputBody:      `{"title":"Updated Shopping List","color":"#00FF00","labels":["groceries"]}`,
+			ifMatch:        "latest",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"invalid input: If-Match must be an ETag like \"1\""}`,
 		},
 		{
 			name:           "List not found",
 			urlParam:       "999",
 			inputBody:      `{"title":"Updated List","color":"#00FF00","labels":[]}`,
+			ifMatch:        `"1"`,
 			shouldCallMock: true,
 			mockReturn:     nil,
 			mockError:      domain.ErrListNotFound,
@@ -322,14 +501,18 @@ func TestUpdate(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mockService := mocks.NewTodoListService(t)
 
-			if tt.shouldCallMock {
-				expectedID, _ := strconv.ParseInt(tt.urlParam, 10, 64)
+			expectedID, _ := strconv.ParseInt(tt.urlParam, 10, 64)
+			expectResolve(mockService, testUserID, expectedID)
 
+			if tt.shouldCallMock {
 				// Parse input to get expected values
 				var input map[string]interface{}
 				json.Unmarshal([]byte(tt.inputBody), &input)
 				expectedTitle := input["title"].(string)
-				expectedColor := input["color"].(string)
+				var expectedColor *string
+				if color, ok := input["color"].(string); ok {
+					expectedColor = &color
+				}
 				expectedLabels := []string{}
 				if labels, ok := input["labels"].([]interface{}); ok {
 					for _, label := range labels {
@@ -337,29 +520,33 @@ func TestUpdate(t *testing.T) {
 					}
 				}
 
-				mockService.On("Update", mock.Anything, testUserID, expectedID, expectedTitle, expectedColor, expectedLabels).
+				mockService.On("Update", mock.Anything, testUserID, expectedID, int64(1), expectedTitle, expectedColor, expectedLabels, false).
 					Return(tt.mockReturn, tt.mockError).
 					Once()
 			}
 
 			handlers := &TodoListHandlers{todoListService: mockService}
 
-			req, err := http.NewRequest(http.MethodPut, "/lists/"+tt.urlParam, strings.NewReader(tt.inputBody))
+			req, err := http.NewRequest(http.MethodPut, "/lists/"+publicID(expectedID), strings.NewReader(tt.inputBody))
 			require.NoError(t, err)
 			req.Header.Set("Content-Type", "application/json")
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
 
 			// Add user context
-			req = testutils.WithUserContext(req, testUserID)
+			req = withUserContext(req, testUserID)
 
 			// Add chi URL params
 			rctx := chi.NewRouteContext()
-			rctx.URLParams.Add("id", tt.urlParam)
+			rctx.URLParams.Add("id", publicID(expectedID))
 			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
 
 			rr := httptest.NewRecorder()
 			handlers.Update(rr, req)
 
 			require.Equal(t, tt.expectedStatus, rr.Code)
+			require.Equal(t, tt.expectedETag, rr.Header().Get("ETag"))
 
 			if tt.expectedBody != "" {
 				assert.JSONEq(t, tt.expectedBody, rr.Body.String())
@@ -370,6 +557,155 @@ func TestUpdate(t *testing.T) {
 	}
 }
 
+// TestGetOrCreate tests the GetOrCreate handler
+func TestGetOrCreate(t *testing.T) {
+	fixedTime := time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC)
+	testUserID := int64(1)
+
+	tests := []struct {
+		name           string
+		title          string
+		inputBody      string
+		mockColor      string
+		mockCreated    bool
+		mockErr        error
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "First call creates",
+			title:          "Groceries",
+			inputBody:      `{"color":"#FF5733"}`,
+			mockColor:      "#FF5733",
+			mockCreated:    true,
+			expectedStatus: http.StatusCreated,
+			expectedBody:   `{"id":"00000000-0000-0000-0000-000000000001","user_id":1,"title":"Groceries","color":"#FF5733","created_at":"2024-01-01T12:00:00Z","updated_at":"2024-01-01T12:00:00Z","deleted":false,"pinned":false,"version":0}`,
+		},
+		{
+			name:           "Second call returns existing, empty body",
+			title:          "Groceries",
+			inputBody:      "",
+			mockColor:      "default",
+			mockCreated:    false,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"id":"00000000-0000-0000-0000-000000000001","user_id":1,"title":"Groceries","color":"#FF5733","created_at":"2024-01-01T12:00:00Z","updated_at":"2024-01-01T12:00:00Z","deleted":false,"pinned":false,"version":0}`,
+		},
+		{
+			name:           "Invalid title",
+			title:          "Groceries",
+			inputBody:      "",
+			mockColor:      "default",
+			mockErr:        domain.ErrInvalidTitle,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   `{"error":"` + domain.ErrInvalidTitle.Error() + `"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var list *domain.TodoList
+			if tt.mockErr == nil {
+				list = &domain.TodoList{ID: 1, PublicID: publicID(1), UserID: testUserID, Title: tt.title, Color: "#FF5733", CreatedAt: fixedTime, UpdatedAt: fixedTime}
+			}
+
+			mockListService := mocks.NewTodoListService(t)
+			mockListService.On("GetOrCreate", mock.Anything, testUserID, tt.title, tt.mockColor, []string(nil)).
+				Return(list, tt.mockCreated, tt.mockErr).
+				Once()
+
+			handlers := &TodoListHandlers{todoListService: mockListService}
+
+			req, err := http.NewRequest(http.MethodPut, "/lists/by-title/"+tt.title, strings.NewReader(tt.inputBody))
+			require.NoError(t, err)
+
+			req = withUserContext(req, testUserID)
+
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("title", tt.title)
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+			rr := httptest.NewRecorder()
+			handlers.GetOrCreate(rr, req)
+
+			require.Equal(t, tt.expectedStatus, rr.Code)
+			assert.JSONEq(t, tt.expectedBody, rr.Body.String())
+		})
+	}
+}
+
+// TestPin tests the Pin and Unpin handlers
+func TestPin(t *testing.T) {
+	fixedTime := time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC)
+	testUserID := int64(1)
+
+	tests := []struct {
+		name           string
+		pinned         bool
+		mockReturn     *domain.TodoList
+		mockError      error
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "Pin",
+			pinned:         true,
+			mockReturn:     &domain.TodoList{ID: 1, PublicID: publicID(1), UserID: testUserID, Title: "Work", Color: "#3357FF", CreatedAt: fixedTime, UpdatedAt: fixedTime, Pinned: true},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"id":"00000000-0000-0000-0000-000000000001","user_id":1,"title":"Work","color":"#3357FF","created_at":"2024-01-01T12:00:00Z","updated_at":"2024-01-01T12:00:00Z","deleted":false,"pinned":true,"version":0}`,
+		},
+		{
+			name:           "Unpin",
+			pinned:         false,
+			mockReturn:     &domain.TodoList{ID: 1, PublicID: publicID(1), UserID: testUserID, Title: "Work", Color: "#3357FF", CreatedAt: fixedTime, UpdatedAt: fixedTime, Pinned: false},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"id":"00000000-0000-0000-0000-000000000001","user_id":1,"title":"Work","color":"#3357FF","created_at":"2024-01-01T12:00:00Z","updated_at":"2024-01-01T12:00:00Z","deleted":false,"pinned":false,"version":0}`,
+		},
+		{
+			name:           "List not found",
+			pinned:         true,
+			mockError:      domain.ErrListNotFound,
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"error":"todo list not found"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockListService := mocks.NewTodoListService(t)
+			expectResolve(mockListService, testUserID, 1)
+			mockListService.On("SetPinned", mock.Anything, testUserID, int64(1), tt.pinned).
+				Return(tt.mockReturn, tt.mockError).
+				Once()
+
+			handlers := &TodoListHandlers{todoListService: mockListService}
+
+			method := http.MethodPut
+			if !tt.pinned {
+				method = http.MethodDelete
+			}
+
+			req, err := http.NewRequest(method, "/lists/"+publicID(1)+"/pin", nil)
+			require.NoError(t, err)
+
+			req = withUserContext(req, testUserID)
+
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", publicID(1))
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+			rr := httptest.NewRecorder()
+			if tt.pinned {
+				handlers.Pin(rr, req)
+			} else {
+				handlers.Unpin(rr, req)
+			}
+
+			require.Equal(t, tt.expectedStatus, rr.Code)
+			assert.JSONEq(t, tt.expectedBody, rr.Body.String())
+		})
+	}
+}
+
 // TestDelete tests the Delete handler with various scenarios
 func TestDelete(t *testing.T) {
 	testUserID := int64(1)
@@ -404,8 +740,10 @@ func TestDelete(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mockListService := mocks.NewTodoListService(t)
 
+			expectedID, _ := strconv.ParseInt(tt.urlParam, 10, 64)
+			expectResolve(mockListService, testUserID, expectedID)
+
 			if tt.shouldCallMock {
-				expectedID, _ := strconv.ParseInt(tt.urlParam, 10, 64)
 				mockListService.On("Delete", mock.Anything, testUserID, expectedID).
 					Return(tt.mockError).
 					Once()
@@ -415,15 +753,15 @@ func TestDelete(t *testing.T) {
 				todoListService: mockListService,
 			}
 
-			req, err := http.NewRequest(http.MethodDelete, "/lists/"+tt.urlParam, nil)
+			req, err := http.NewRequest(http.MethodDelete, "/lists/"+publicID(expectedID), nil)
 			require.NoError(t, err)
 
 			// Add user context
-			req = testutils.WithUserContext(req, testUserID)
+			req = withUserContext(req, testUserID)
 
 			// Add chi URL params
 			rctx := chi.NewRouteContext()
-			rctx.URLParams.Add("id", tt.urlParam)
+			rctx.URLParams.Add("id", publicID(expectedID))
 			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
 
 			rr := httptest.NewRecorder()
@@ -431,6 +769,12 @@ func TestDelete(t *testing.T) {
 
 			assert.Equal(t, tt.expectedStatus, rr.Code)
 
+			if tt.expectedStatus == http.StatusNoContent {
+				// 204 carries no body, not even an empty JSON object
+				assert.Zero(t, rr.Body.Len())
+				assert.Empty(t, rr.Header().Get("Content-Type"))
+			}
+
 			if tt.expectedBody != "" {
 				assert.JSONEq(t, tt.expectedBody, rr.Body.String())
 			}
@@ -439,3 +783,264 @@ func TestDelete(t *testing.T) {
 		})
 	}
 }
+
+// TestDeleteDryRun tests ?dry_run=true on DELETE /lists/{id}, the mock fails if Delete is called
+func TestDeleteDryRun(t *testing.T) {
+	testUserID := int64(1)
+
+	tests := []struct {
+		name           string
+		query          string
+		shouldCallMock bool
+		mockReturn     *domain.DryRunResult
+		mockError      error
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "Reports the list",
+			query:          "?dry_run=true",
+			shouldCallMock: true,
+			mockReturn:     &domain.DryRunResult{Count: 1, IDs: []string{publicID(1)}},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"dry_run":true,"count":1,"ids":["` + publicID(1) + `"]}`,
+		},
+		{
+			name:           "List not found",
+			query:          "?dry_run=true",
+			shouldCallMock: true,
+			mockError:      domain.ErrListNotFound,
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"error":"todo list not found"}`,
+		},
+		{
+			name:           "Invalid dry_run",
+			query:          "?dry_run=maybe",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"invalid input: dry_run must be true or false"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockListService := mocks.NewTodoListService(t)
+
+			if tt.shouldCallMock {
+				expectResolve(mockListService, testUserID, 1)
+				mockListService.On("DeleteDryRun", mock.Anything, testUserID, int64(1)).
+					Return(tt.mockReturn, tt.mockError).
+					Once()
+			}
+
+			handlers := &TodoListHandlers{
+				todoListService: mockListService,
+			}
+
+			req, err := http.NewRequest(http.MethodDelete, "/lists/"+publicID(1)+tt.query, nil)
+			require.NoError(t, err)
+
+			req = withUserContext(req, testUserID)
+
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", publicID(1))
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+			rr := httptest.NewRecorder()
+			handlers.Delete(rr, req)
+
+			require.Equal(t, tt.expectedStatus, rr.Code)
+			assert.JSONEq(t, tt.expectedBody, rr.Body.String())
+
+			mockListService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestListOfTodo(t *testing.T) {
+	fixedTime := time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC)
+	testUserID := int64(1)
+
+	tests := []struct {
+		name           string
+		urlParam       string
+		setupMocks     func(todoService *mocks.TodoService, listService *mocks.TodoListService)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:     "Returns the owning list",
+			urlParam: publicID(5),
+			setupMocks: func(todoService *mocks.TodoService, listService *mocks.TodoListService) {
+				todoService.On("ResolveTodoID", mock.Anything, testUserID, publicID(5)).Return(int64(5), nil).Once()
+				todoService.On("GetTodo", mock.Anything, testUserID, int64(5)).
+					Return(&domain.Todo{ID: 5, PublicID: publicID(5), UserID: testUserID, TodoListID: 2, Title: "Milk"}, nil).
+					Once()
+				listService.On("GetListByID", mock.Anything, testUserID, int64(2)).
+					Return(&domain.TodoList{ID: 2, PublicID: publicID(2), UserID: testUserID, Title: "Groceries", Color: "default", CreatedAt: fixedTime, UpdatedAt: fixedTime, Version: 3}, nil).
+					Once()
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"id":"` + publicID(2) + `","user_id":1,"title":"Groceries","color":"default","created_at":"2024-01-01T12:00:00Z","updated_at":"2024-01-01T12:00:00Z","deleted":false,"pinned":false,"version":3}`,
+		},
+		{
+			name:     "Someone else's todo",
+			urlParam: publicID(6),
+			setupMocks: func(todoService *mocks.TodoService, listService *mocks.TodoListService) {
+				todoService.On("ResolveTodoID", mock.Anything, testUserID, publicID(6)).Return(int64(0), domain.ErrNotFound).Once()
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"error":"todo not found"}`,
+		},
+		{
+			name:     "List not found",
+			urlParam: publicID(5),
+			setupMocks: func(todoService *mocks.TodoService, listService *mocks.TodoListService) {
+				todoService.On("ResolveTodoID", mock.Anything, testUserID, publicID(5)).Return(int64(5), nil).Once()
+				todoService.On("GetTodo", mock.Anything, testUserID, int64(5)).
+					Return(&domain.Todo{ID: 5, UserID: testUserID, TodoListID: 2}, nil).
+					Once()
+				listService.On("GetListByID", mock.Anything, testUserID, int64(2)).Return(nil, domain.ErrListNotFound).Once()
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"error":"todo list not found"}`,
+		},
+		{
+			name:     "Service error",
+			urlParam: publicID(5),
+			setupMocks: func(todoService *mocks.TodoService, listService *mocks.TodoListService) {
+				todoService.On("ResolveTodoID", mock.Anything, testUserID, publicID(5)).Return(int64(5), nil).Once()
+				todoService.On("GetTodo", mock.Anything, testUserID, int64(5)).Return(nil, errors.New("db error")).Once()
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"error":"internal server error"}`,
+		},
+		{
+			name:           "Invalid id",
+			urlParam:       "5",
+			setupMocks:     func(todoService *mocks.TodoService, listService *mocks.TodoListService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"id must be a UUID"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTodoService := mocks.NewTodoService(t)
+			mockListService := mocks.NewTodoListService(t)
+			tt.setupMocks(mockTodoService, mockListService)
+
+			handlers := &TodoListHandlers{
+				todoListService: mockListService,
+				todoService:     mockTodoService,
+			}
+
+			req, err := http.NewRequest(http.MethodGet, "/todos/"+tt.urlParam+"/list", nil)
+			require.NoError(t, err)
+
+			req = withUserContext(req, testUserID)
+
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", tt.urlParam)
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+			rr := httptest.NewRecorder()
+			handlers.ListOfTodo(rr, req)
+
+			require.Equal(t, tt.expectedStatus, rr.Code)
+			assert.JSONEq(t, tt.expectedBody, rr.Body.String())
+			if tt.expectedStatus == http.StatusOK {
+				assert.Equal(t, `"3"`, rr.Header().Get("ETag"))
+			}
+		})
+	}
+}
+
+// TestListIDFromPath tests how the handlers treat the public list id of the path
+func TestListIDFromPath(t *testing.T) {
+	testUserID := int64(1)
+
+	tests := []struct {
+		name           string
+		urlParam       string
+		shouldResolve  bool
+		resolveErr     error
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "Integer ID",
+			urlParam:       "1",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"id must be a UUID"}`,
+		},
+		{
+			name:           "Unknown or foreign ID",
+			urlParam:       publicID(42),
+			shouldResolve:  true,
+			resolveErr:     domain.ErrListNotFound,
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"error":"todo list not found"}`,
+		},
+		{
+			name:           "Resolve error",
+			urlParam:       publicID(42),
+			shouldResolve:  true,
+			resolveErr:     errors.New("database error"),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"error":"internal server error"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := mocks.NewTodoListService(t)
+
+			if tt.shouldResolve {
+				mockService.On("ResolveID", mock.Anything, testUserID, tt.urlParam).
+					Return(int64(0), tt.resolveErr).
+					Once()
+			}
+
+			handler := &TodoListHandlers{todoListService: mockService}
+
+			req, err := http.NewRequest(http.MethodGet, "/lists/"+tt.urlParam, nil)
+			require.NoError(t, err)
+
+			req = withUserContext(req, testUserID)
+
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", tt.urlParam)
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+			rr := httptest.NewRecorder()
+			handler.GetListByID(rr, req)
+
+			require.Equal(t, tt.expectedStatus, rr.Code)
+			assert.JSONEq(t, tt.expectedBody, rr.Body.String())
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+// publicID returns a fixed public id (UUID) for an internal id, so the tests can tell which one the handler used
+func publicID(id int64) string {
+	return fmt.Sprintf("00000000-0000-0000-0000-%012d", id)
+}
+
+// expectResolve makes the mock resolve the public id of the list to its internal id
+func expectResolve(m *mocks.TodoListService, userID int64, id int64) {
+	m.On("ResolveID", mock.Anything, userID, publicID(id)).
+		Return(id, nil).
+		Once()
+}
+
+// withUserContext adds an authenticated user to the request context, like the UserContext middleware does.
+func withUserContext(req *http.Request, userID int64) *http.Request {
+	userCtx := &auth.UserContext{
+		ID:    userID,
+		Email: "test@example.com",
+		Name:  "Test User",
+	}
+	return req.WithContext(userCtx.AddToContext(req.Context()))
+}
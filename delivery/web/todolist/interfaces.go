@@ -7,11 +7,15 @@ import (
 )
 
 type TodoListService interface {
-	List(ctx context.Context, userID int64) ([]*domain.TodoList, error)
+	List(ctx context.Context, userID int64, opts domain.ListOptions) ([]*domain.TodoList, error)
 	GetListByID(ctx context.Context, userID int64, id int64) (*domain.TodoList, error)
+	ResolveID(ctx context.Context, userID int64, publicID string) (int64, error)
 	Create(ctx context.Context, userID int64, title string, color string, labels []string) (*domain.TodoList, error)
-	Update(ctx context.Context, userID int64, id int64, title string, color string, labels []string, deleted bool) (*domain.TodoList, error)
+	GetOrCreate(ctx context.Context, userID int64, title string, color string, labels []string) (*domain.TodoList, bool, error)
+	Update(ctx context.Context, userID int64, id int64, version int64, title string, color *string, labels []string, deleted bool) (*domain.TodoList, error)
+	SetPinned(ctx context.Context, userID int64, id int64, pinned bool) (*domain.TodoList, error)
 	Delete(ctx context.Context, userID int64, id int64) error
+	DeleteDryRun(ctx context.Context, userID int64, id int64) (*domain.DryRunResult, error)
 }
 
 type UserService interface {
@@ -19,5 +23,7 @@ type UserService interface {
 }
 
 type TodoService interface {
-	ListTodos(ctx context.Context, userID int64, todolistID int64) ([]*domain.Todo, error)
+	ListTodos(ctx context.Context, userID int64, todolistID int64, opts domain.ListOptions) ([]*domain.Todo, error)
+	ResolveTodoID(ctx context.Context, userID int64, publicID string) (int64, error)
+	GetTodo(ctx context.Context, userID int64, id int64) (*domain.Todo, error)
 }
@@ -187,6 +187,80 @@ func (_c *TodoListService_Delete_Call) RunAndReturn(run func(ctx context.Context
 	return _c
 }
 
+// DeleteDryRun provides a mock function for the type TodoListService
+func (_mock *TodoListService) DeleteDryRun(ctx context.Context, userID int64, id int64) (*domain.DryRunResult, error) {
+	ret := _mock.Called(ctx, userID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteDryRun")
+	}
+
+	var r0 *domain.DryRunResult
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int64) (*domain.DryRunResult, error)); ok {
+		return returnFunc(ctx, userID, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int64) *domain.DryRunResult); ok {
+		r0 = returnFunc(ctx, userID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.DryRunResult)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = returnFunc(ctx, userID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// TodoListService_DeleteDryRun_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteDryRun'
+type TodoListService_DeleteDryRun_Call struct {
+	*mock.Call
+}
+
+// DeleteDryRun is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+//   - id int64
+func (_e *TodoListService_Expecter) DeleteDryRun(ctx interface{}, userID interface{}, id interface{}) *TodoListService_DeleteDryRun_Call {
+	return &TodoListService_DeleteDryRun_Call{Call: _e.mock.On("DeleteDryRun", ctx, userID, id)}
+}
+
+func (_c *TodoListService_DeleteDryRun_Call) Run(run func(ctx context.Context, userID int64, id int64)) *TodoListService_DeleteDryRun_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 int64
+		if args[2] != nil {
+			arg2 = args[2].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *TodoListService_DeleteDryRun_Call) Return(dryRunResult *domain.DryRunResult, err error) *TodoListService_DeleteDryRun_Call {
+	_c.Call.Return(dryRunResult, err)
+	return _c
+}
+
+func (_c *TodoListService_DeleteDryRun_Call) RunAndReturn(run func(ctx context.Context, userID int64, id int64) (*domain.DryRunResult, error)) *TodoListService_DeleteDryRun_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetListByID provides a mock function for the type TodoListService
 func (_mock *TodoListService) GetListByID(ctx context.Context, userID int64, id int64) (*domain.TodoList, error) {
 	ret := _mock.Called(ctx, userID, id)
@@ -261,9 +335,101 @@ func (_c *TodoListService_GetListByID_Call) RunAndReturn(run func(ctx context.Co
 	return _c
 }
 
+// GetOrCreate provides a mock function for the type TodoListService
+func (_mock *TodoListService) GetOrCreate(ctx context.Context, userID int64, title string, color string, labels []string) (*domain.TodoList, bool, error) {
+	ret := _mock.Called(ctx, userID, title, color, labels)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOrCreate")
+	}
+
+	var r0 *domain.TodoList
+	var r1 bool
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, string, string, []string) (*domain.TodoList, bool, error)); ok {
+		return returnFunc(ctx, userID, title, color, labels)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, string, string, []string) *domain.TodoList); ok {
+		r0 = returnFunc(ctx, userID, title, color, labels)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.TodoList)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, string, string, []string) bool); ok {
+		r1 = returnFunc(ctx, userID, title, color, labels)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, int64, string, string, []string) error); ok {
+		r2 = returnFunc(ctx, userID, title, color, labels)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// TodoListService_GetOrCreate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOrCreate'
+type TodoListService_GetOrCreate_Call struct {
+	*mock.Call
+}
+
+// GetOrCreate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+//   - title string
+//   - color string
+//   - labels []string
+func (_e *TodoListService_Expecter) GetOrCreate(ctx interface{}, userID interface{}, title interface{}, color interface{}, labels interface{}) *TodoListService_GetOrCreate_Call {
+	return &TodoListService_GetOrCreate_Call{Call: _e.mock.On("GetOrCreate", ctx, userID, title, color, labels)}
+}
+
+func (_c *TodoListService_GetOrCreate_Call) Run(run func(ctx context.Context, userID int64, title string, color string, labels []string)) *TodoListService_GetOrCreate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 []string
+		if args[4] != nil {
+			arg4 = args[4].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *TodoListService_GetOrCreate_Call) Return(todoList *domain.TodoList, b bool, err error) *TodoListService_GetOrCreate_Call {
+	_c.Call.Return(todoList, b, err)
+	return _c
+}
+
+func (_c *TodoListService_GetOrCreate_Call) RunAndReturn(run func(ctx context.Context, userID int64, title string, color string, labels []string) (*domain.TodoList, bool, error)) *TodoListService_GetOrCreate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // List provides a mock function for the type TodoListService
-func (_mock *TodoListService) List(ctx context.Context, userID int64) ([]*domain.TodoList, error) {
-	ret := _mock.Called(ctx, userID)
+func (_mock *TodoListService) List(ctx context.Context, userID int64, opts domain.ListOptions) ([]*domain.TodoList, error) {
+	ret := _mock.Called(ctx, userID, opts)
 
 	if len(ret) == 0 {
 		panic("no return value specified for List")
@@ -271,18 +437,18 @@ func (_mock *TodoListService) List(ctx context.Context, userID int64) ([]*domain
 
 	var r0 []*domain.TodoList
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) ([]*domain.TodoList, error)); ok {
-		return returnFunc(ctx, userID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, domain.ListOptions) ([]*domain.TodoList, error)); ok {
+		return returnFunc(ctx, userID, opts)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) []*domain.TodoList); ok {
-		r0 = returnFunc(ctx, userID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, domain.ListOptions) []*domain.TodoList); ok {
+		r0 = returnFunc(ctx, userID, opts)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*domain.TodoList)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, int64) error); ok {
-		r1 = returnFunc(ctx, userID)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, domain.ListOptions) error); ok {
+		r1 = returnFunc(ctx, userID, opts)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -297,11 +463,12 @@ type TodoListService_List_Call struct {
 // List is a helper method to define mock.On call
 //   - ctx context.Context
 //   - userID int64
-func (_e *TodoListService_Expecter) List(ctx interface{}, userID interface{}) *TodoListService_List_Call {
-	return &TodoListService_List_Call{Call: _e.mock.On("List", ctx, userID)}
+//   - opts domain.ListOptions
+func (_e *TodoListService_Expecter) List(ctx interface{}, userID interface{}, opts interface{}) *TodoListService_List_Call {
+	return &TodoListService_List_Call{Call: _e.mock.On("List", ctx, userID, opts)}
 }
 
-func (_c *TodoListService_List_Call) Run(run func(ctx context.Context, userID int64)) *TodoListService_List_Call {
+func (_c *TodoListService_List_Call) Run(run func(ctx context.Context, userID int64, opts domain.ListOptions)) *TodoListService_List_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -311,9 +478,14 @@ func (_c *TodoListService_List_Call) Run(run func(ctx context.Context, userID in
 		if args[1] != nil {
 			arg1 = args[1].(int64)
 		}
+		var arg2 domain.ListOptions
+		if args[2] != nil {
+			arg2 = args[2].(domain.ListOptions)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
@@ -324,14 +496,166 @@ func (_c *TodoListService_List_Call) Return(todoLists []*domain.TodoList, err er
 	return _c
 }
 
-func (_c *TodoListService_List_Call) RunAndReturn(run func(ctx context.Context, userID int64) ([]*domain.TodoList, error)) *TodoListService_List_Call {
+func (_c *TodoListService_List_Call) RunAndReturn(run func(ctx context.Context, userID int64, opts domain.ListOptions) ([]*domain.TodoList, error)) *TodoListService_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResolveID provides a mock function for the type TodoListService
+func (_mock *TodoListService) ResolveID(ctx context.Context, userID int64, publicID string) (int64, error) {
+	ret := _mock.Called(ctx, userID, publicID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResolveID")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, string) (int64, error)); ok {
+		return returnFunc(ctx, userID, publicID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, string) int64); ok {
+		r0 = returnFunc(ctx, userID, publicID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, string) error); ok {
+		r1 = returnFunc(ctx, userID, publicID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// TodoListService_ResolveID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResolveID'
+type TodoListService_ResolveID_Call struct {
+	*mock.Call
+}
+
+// ResolveID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+//   - publicID string
+func (_e *TodoListService_Expecter) ResolveID(ctx interface{}, userID interface{}, publicID interface{}) *TodoListService_ResolveID_Call {
+	return &TodoListService_ResolveID_Call{Call: _e.mock.On("ResolveID", ctx, userID, publicID)}
+}
+
+func (_c *TodoListService_ResolveID_Call) Run(run func(ctx context.Context, userID int64, publicID string)) *TodoListService_ResolveID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *TodoListService_ResolveID_Call) Return(n int64, err error) *TodoListService_ResolveID_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *TodoListService_ResolveID_Call) RunAndReturn(run func(ctx context.Context, userID int64, publicID string) (int64, error)) *TodoListService_ResolveID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetPinned provides a mock function for the type TodoListService
+func (_mock *TodoListService) SetPinned(ctx context.Context, userID int64, id int64, pinned bool) (*domain.TodoList, error) {
+	ret := _mock.Called(ctx, userID, id, pinned)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetPinned")
+	}
+
+	var r0 *domain.TodoList
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int64, bool) (*domain.TodoList, error)); ok {
+		return returnFunc(ctx, userID, id, pinned)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int64, bool) *domain.TodoList); ok {
+		r0 = returnFunc(ctx, userID, id, pinned)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.TodoList)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, int64, bool) error); ok {
+		r1 = returnFunc(ctx, userID, id, pinned)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// TodoListService_SetPinned_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetPinned'
+type TodoListService_SetPinned_Call struct {
+	*mock.Call
+}
+
+// SetPinned is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+//   - id int64
+//   - pinned bool
+func (_e *TodoListService_Expecter) SetPinned(ctx interface{}, userID interface{}, id interface{}, pinned interface{}) *TodoListService_SetPinned_Call {
+	return &TodoListService_SetPinned_Call{Call: _e.mock.On("SetPinned", ctx, userID, id, pinned)}
+}
+
+func (_c *TodoListService_SetPinned_Call) Run(run func(ctx context.Context, userID int64, id int64, pinned bool)) *TodoListService_SetPinned_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 int64
+		if args[2] != nil {
+			arg2 = args[2].(int64)
+		}
+		var arg3 bool
+		if args[3] != nil {
+			arg3 = args[3].(bool)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *TodoListService_SetPinned_Call) Return(todoList *domain.TodoList, err error) *TodoListService_SetPinned_Call {
+	_c.Call.Return(todoList, err)
+	return _c
+}
+
+func (_c *TodoListService_SetPinned_Call) RunAndReturn(run func(ctx context.Context, userID int64, id int64, pinned bool) (*domain.TodoList, error)) *TodoListService_SetPinned_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // Update provides a mock function for the type TodoListService
-func (_mock *TodoListService) Update(ctx context.Context, userID int64, id int64, title string, color string, labels []string, deleted bool) (*domain.TodoList, error) {
-	ret := _mock.Called(ctx, userID, id, title, color, labels, deleted)
+func (_mock *TodoListService) Update(ctx context.Context, userID int64, id int64, version int64, title string, color *string, labels []string, deleted bool) (*domain.TodoList, error) {
+	ret := _mock.Called(ctx, userID, id, version, title, color, labels, deleted)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Update")
@@ -339,18 +663,18 @@ func (_mock *TodoListService) Update(ctx context.Context, userID int64, id int64
 
 	var r0 *domain.TodoList
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int64, string, string, []string, bool) (*domain.TodoList, error)); ok {
-		return returnFunc(ctx, userID, id, title, color, labels, deleted)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int64, int64, string, *string, []string, bool) (*domain.TodoList, error)); ok {
+		return returnFunc(ctx, userID, id, version, title, color, labels, deleted)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int64, string, string, []string, bool) *domain.TodoList); ok {
-		r0 = returnFunc(ctx, userID, id, title, color, labels, deleted)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int64, int64, string, *string, []string, bool) *domain.TodoList); ok {
+		r0 = returnFunc(ctx, userID, id, version, title, color, labels, deleted)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.TodoList)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, int64, string, string, []string, bool) error); ok {
-		r1 = returnFunc(ctx, userID, id, title, color, labels, deleted)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, int64, int64, string, *string, []string, bool) error); ok {
+		r1 = returnFunc(ctx, userID, id, version, title, color, labels, deleted)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -366,15 +690,16 @@ type TodoListService_Update_Call struct {
 //   - ctx context.Context
 //   - userID int64
 //   - id int64
+//   - version int64
 //   - title string
-//   - color string
+//   - color *string
 //   - labels []string
 //   - deleted bool
-func (_e *TodoListService_Expecter) Update(ctx interface{}, userID interface{}, id interface{}, title interface{}, color interface{}, labels interface{}, deleted interface{}) *TodoListService_Update_Call {
-	return &TodoListService_Update_Call{Call: _e.mock.On("Update", ctx, userID, id, title, color, labels, deleted)}
+func (_e *TodoListService_Expecter) Update(ctx interface{}, userID interface{}, id interface{}, version interface{}, title interface{}, color interface{}, labels interface{}, deleted interface{}) *TodoListService_Update_Call {
+	return &TodoListService_Update_Call{Call: _e.mock.On("Update", ctx, userID, id, version, title, color, labels, deleted)}
 }
 
-func (_c *TodoListService_Update_Call) Run(run func(ctx context.Context, userID int64, id int64, title string, color string, labels []string, deleted bool)) *TodoListService_Update_Call {
+func (_c *TodoListService_Update_Call) Run(run func(ctx context.Context, userID int64, id int64, version int64, title string, color *string, labels []string, deleted bool)) *TodoListService_Update_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -388,21 +713,25 @@ func (_c *TodoListService_Update_Call) Run(run func(ctx context.Context, userID
 		if args[2] != nil {
 			arg2 = args[2].(int64)
 		}
-		var arg3 string
+		var arg3 int64
 		if args[3] != nil {
-			arg3 = args[3].(string)
+			arg3 = args[3].(int64)
 		}
 		var arg4 string
 		if args[4] != nil {
 			arg4 = args[4].(string)
 		}
-		var arg5 []string
+		var arg5 *string
 		if args[5] != nil {
-			arg5 = args[5].([]string)
+			arg5 = args[5].(*string)
 		}
-		var arg6 bool
+		var arg6 []string
 		if args[6] != nil {
-			arg6 = args[6].(bool)
+			arg6 = args[6].([]string)
+		}
+		var arg7 bool
+		if args[7] != nil {
+			arg7 = args[7].(bool)
 		}
 		run(
 			arg0,
@@ -412,6 +741,7 @@ func (_c *TodoListService_Update_Call) Run(run func(ctx context.Context, userID
 			arg4,
 			arg5,
 			arg6,
+			arg7,
 		)
 	})
 	return _c
@@ -422,7 +752,7 @@ func (_c *TodoListService_Update_Call) Return(todoList *domain.TodoList, err err
 	return _c
 }
 
-func (_c *TodoListService_Update_Call) RunAndReturn(run func(ctx context.Context, userID int64, id int64, title string, color string, labels []string, deleted bool) (*domain.TodoList, error)) *TodoListService_Update_Call {
+func (_c *TodoListService_Update_Call) RunAndReturn(run func(ctx context.Context, userID int64, id int64, version int64, title string, color *string, labels []string, deleted bool) (*domain.TodoList, error)) *TodoListService_Update_Call {
 	_c.Call.Return(run)
 	return _c
 }
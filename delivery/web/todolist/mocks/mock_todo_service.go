@@ -38,9 +38,83 @@ func (_m *TodoService) EXPECT() *TodoService_Expecter {
 	return &TodoService_Expecter{mock: &_m.Mock}
 }
 
+// GetTodo provides a mock function for the type TodoService
+func (_mock *TodoService) GetTodo(ctx context.Context, userID int64, id int64) (*domain.Todo, error) {
+	ret := _mock.Called(ctx, userID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTodo")
+	}
+
+	var r0 *domain.Todo
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int64) (*domain.Todo, error)); ok {
+		return returnFunc(ctx, userID, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int64) *domain.Todo); ok {
+		r0 = returnFunc(ctx, userID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Todo)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = returnFunc(ctx, userID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// TodoService_GetTodo_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTodo'
+type TodoService_GetTodo_Call struct {
+	*mock.Call
+}
+
+// GetTodo is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+//   - id int64
+func (_e *TodoService_Expecter) GetTodo(ctx interface{}, userID interface{}, id interface{}) *TodoService_GetTodo_Call {
+	return &TodoService_GetTodo_Call{Call: _e.mock.On("GetTodo", ctx, userID, id)}
+}
+
+func (_c *TodoService_GetTodo_Call) Run(run func(ctx context.Context, userID int64, id int64)) *TodoService_GetTodo_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 int64
+		if args[2] != nil {
+			arg2 = args[2].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *TodoService_GetTodo_Call) Return(todo *domain.Todo, err error) *TodoService_GetTodo_Call {
+	_c.Call.Return(todo, err)
+	return _c
+}
+
+func (_c *TodoService_GetTodo_Call) RunAndReturn(run func(ctx context.Context, userID int64, id int64) (*domain.Todo, error)) *TodoService_GetTodo_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ListTodos provides a mock function for the type TodoService
-func (_mock *TodoService) ListTodos(ctx context.Context, userID int64, todolistID int64) ([]*domain.Todo, error) {
-	ret := _mock.Called(ctx, userID, todolistID)
+func (_mock *TodoService) ListTodos(ctx context.Context, userID int64, todolistID int64, opts domain.ListOptions) ([]*domain.Todo, error) {
+	ret := _mock.Called(ctx, userID, todolistID, opts)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ListTodos")
@@ -48,18 +122,18 @@ func (_mock *TodoService) ListTodos(ctx context.Context, userID int64, todolistI
 
 	var r0 []*domain.Todo
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int64) ([]*domain.Todo, error)); ok {
-		return returnFunc(ctx, userID, todolistID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int64, domain.ListOptions) ([]*domain.Todo, error)); ok {
+		return returnFunc(ctx, userID, todolistID, opts)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int64) []*domain.Todo); ok {
-		r0 = returnFunc(ctx, userID, todolistID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int64, domain.ListOptions) []*domain.Todo); ok {
+		r0 = returnFunc(ctx, userID, todolistID, opts)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*domain.Todo)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
-		r1 = returnFunc(ctx, userID, todolistID)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, int64, domain.ListOptions) error); ok {
+		r1 = returnFunc(ctx, userID, todolistID, opts)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -75,11 +149,12 @@ type TodoService_ListTodos_Call struct {
 //   - ctx context.Context
 //   - userID int64
 //   - todolistID int64
-func (_e *TodoService_Expecter) ListTodos(ctx interface{}, userID interface{}, todolistID interface{}) *TodoService_ListTodos_Call {
-	return &TodoService_ListTodos_Call{Call: _e.mock.On("ListTodos", ctx, userID, todolistID)}
+//   - opts domain.ListOptions
+func (_e *TodoService_Expecter) ListTodos(ctx interface{}, userID interface{}, todolistID interface{}, opts interface{}) *TodoService_ListTodos_Call {
+	return &TodoService_ListTodos_Call{Call: _e.mock.On("ListTodos", ctx, userID, todolistID, opts)}
 }
 
-func (_c *TodoService_ListTodos_Call) Run(run func(ctx context.Context, userID int64, todolistID int64)) *TodoService_ListTodos_Call {
+func (_c *TodoService_ListTodos_Call) Run(run func(ctx context.Context, userID int64, todolistID int64, opts domain.ListOptions)) *TodoService_ListTodos_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -93,10 +168,15 @@ func (_c *TodoService_ListTodos_Call) Run(run func(ctx context.Context, userID i
 		if args[2] != nil {
 			arg2 = args[2].(int64)
 		}
+		var arg3 domain.ListOptions
+		if args[3] != nil {
+			arg3 = args[3].(domain.ListOptions)
+		}
 		run(
 			arg0,
 			arg1,
 			arg2,
+			arg3,
 		)
 	})
 	return _c
@@ -107,7 +187,79 @@ func (_c *TodoService_ListTodos_Call) Return(todos []*domain.Todo, err error) *T
 	return _c
 }
 
-func (_c *TodoService_ListTodos_Call) RunAndReturn(run func(ctx context.Context, userID int64, todolistID int64) ([]*domain.Todo, error)) *TodoService_ListTodos_Call {
+func (_c *TodoService_ListTodos_Call) RunAndReturn(run func(ctx context.Context, userID int64, todolistID int64, opts domain.ListOptions) ([]*domain.Todo, error)) *TodoService_ListTodos_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResolveTodoID provides a mock function for the type TodoService
+func (_mock *TodoService) ResolveTodoID(ctx context.Context, userID int64, publicID string) (int64, error) {
+	ret := _mock.Called(ctx, userID, publicID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResolveTodoID")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, string) (int64, error)); ok {
+		return returnFunc(ctx, userID, publicID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, string) int64); ok {
+		r0 = returnFunc(ctx, userID, publicID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, string) error); ok {
+		r1 = returnFunc(ctx, userID, publicID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// TodoService_ResolveTodoID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResolveTodoID'
+type TodoService_ResolveTodoID_Call struct {
+	*mock.Call
+}
+
+// ResolveTodoID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+//   - publicID string
+func (_e *TodoService_Expecter) ResolveTodoID(ctx interface{}, userID interface{}, publicID interface{}) *TodoService_ResolveTodoID_Call {
+	return &TodoService_ResolveTodoID_Call{Call: _e.mock.On("ResolveTodoID", ctx, userID, publicID)}
+}
+
+func (_c *TodoService_ResolveTodoID_Call) Run(run func(ctx context.Context, userID int64, publicID string)) *TodoService_ResolveTodoID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *TodoService_ResolveTodoID_Call) Return(n int64, err error) *TodoService_ResolveTodoID_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *TodoService_ResolveTodoID_Call) RunAndReturn(run func(ctx context.Context, userID int64, publicID string) (int64, error)) *TodoService_ResolveTodoID_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -2,6 +2,9 @@ package user
 
 import "github.com/go-chi/jwtauth/v5"
 
+// TokenHeader carries the re-issued token when PUT /users/me changes the claims of the caller.
+const TokenHeader = "X-Auth-Token"
+
 // UserHandlers groups HTTP handler functions.
 // Like a Java controller class or JS route handler object.
 type UserHandlers struct {
@@ -26,14 +26,14 @@ func (h *UserHandlers) CreateUser(w http.ResponseWriter, r *http.Request) {
 	// Decode the JSON body into the user struct
 	if err := json.NewDecoder(r.Body).Decode(&reqUser); err != nil {
 		// domain.ErrorResponse{Error: err.Error() for dynamic error message
-		utils.WriteJSON(w, http.StatusBadRequest, domain.ErrorResponse{Error: err.Error()})
+		utils.WriteJSON(w, r, http.StatusBadRequest, domain.ErrorResponse{Error: err.Error()})
 		return
 	}
 
 	if err := validate.New().Struct(reqUser); err != nil {
 		useErr := translateValidationError(err)
 		// Dynamic message, e.g., "Name is required; Email is required"
-		utils.WriteJSON(w, http.StatusBadRequest, domain.ErrorResponse{Error: useErr})
+		utils.WriteValidationError(w, r, useErr)
 		return
 	}
 
@@ -42,61 +42,55 @@ func (h *UserHandlers) CreateUser(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		switch {
 		case errors.Is(err, domain.ErrInvalidEmail):
-			utils.WriteJSON(w, http.StatusBadRequest, domain.ErrorResponse{Error: err.Error()})
+			utils.WriteValidationError(w, r, err.Error())
 			return
 		case errors.Is(err, domain.ErrInvalidPassword):
-			utils.WriteJSON(w, http.StatusBadRequest, domain.ErrorResponse{Error: err.Error()})
+			utils.WriteValidationError(w, r, err.Error())
 			return
 		case errors.Is(err, domain.ErrDuplicate):
-			utils.WriteJSON(w, http.StatusConflict, domain.ErrorResponse{Error: err.Error()})
+			utils.WriteJSON(w, r, http.StatusConflict, domain.ErrorResponse{Error: err.Error()})
 			return
 		default:
-			utils.WriteJSON(w, http.StatusInternalServerError, domain.ErrorResponse{Error: "internal server error"})
+			utils.WriteError(w, r, err)
 			return
 		}
 	}
 
 	respUser := domain.UserDTO{
-		ID:    user.ID,
-		Name:  user.Name,
-		Email: user.Email,
+		ID:       user.ID,
+		Name:     user.Name,
+		Email:    user.Email,
+		Timezone: user.Timezone,
 	}
 
-	utils.WriteJSON(w, http.StatusCreated, respUser)
+	utils.WriteJSON(w, r, http.StatusCreated, respUser)
 }
 
 // GetUser creates a new HTTP handler for getting a user by ID.
 func (h *UserHandlers) GetUser(w http.ResponseWriter, r *http.Request) {
-	idr := chi.URLParam(r, "id") // Get the "id" URL parameter
-
-	if idr == "" {
-		utils.WriteJSON(w, http.StatusBadRequest, domain.ErrorResponse{Error: "id is required"})
-		return
-	}
-
-	id, err := strconv.ParseInt(idr, 10, 64) // Convert id string to int
-	if err != nil {
-		utils.WriteJSON(w, http.StatusBadRequest, domain.ErrorResponse{Error: "id must be an integer"})
+	id, ok := userIDFromPath(w, r)
+	if !ok {
 		return
 	}
 
 	user, err := h.Service.GetUser(r.Context(), id)
 	if err != nil {
 		if errors.Is(err, domain.ErrUserNotFound) {
-			utils.WriteJSON(w, http.StatusNotFound, domain.ErrorResponse{Error: err.Error()})
+			utils.WriteJSON(w, r, http.StatusNotFound, domain.ErrorResponse{Error: err.Error()})
 			return
 		}
-		utils.WriteJSON(w, http.StatusInternalServerError, domain.ErrorResponse{Error: "internal server error"})
+		utils.WriteError(w, r, err)
 		return
 	}
 
 	respUser := domain.UserDTO{
-		ID:    user.ID,
-		Name:  user.Name,
-		Email: user.Email,
+		ID:       user.ID,
+		Name:     user.Name,
+		Email:    user.Email,
+		Timezone: user.Timezone,
 	}
 
-	utils.WriteJSON(w, http.StatusOK, respUser)
+	utils.WriteJSON(w, r, http.StatusOK, respUser)
 }
 
 // Get user by email for authentication
@@ -105,24 +99,25 @@ func (h *UserHandlers) Login(w http.ResponseWriter, r *http.Request) {
 	var reqLogin domain.LoginRequest
 
 	if err := json.NewDecoder(r.Body).Decode(&reqLogin); err != nil {
-		utils.WriteJSON(w, http.StatusBadRequest, domain.ErrorResponse{Error: "invalid request body"})
+		utils.WriteJSON(w, r, http.StatusBadRequest, domain.ErrorResponse{Error: "invalid request body"})
 		return
 	}
 	defer r.Body.Close()
 
 	if reqLogin.Email == "" || reqLogin.Password == "" {
-		utils.WriteJSON(w, http.StatusBadRequest, domain.ErrorResponse{Error: "email and password are required"})
+		utils.WriteJSON(w, r, http.StatusBadRequest, domain.ErrorResponse{Error: "email and password are required"})
 		return
 	}
 
 	user, err := h.Service.Login(r.Context(), reqLogin.Email, reqLogin.Password)
 	if err != nil {
-		if errors.Is(err, domain.ErrInvalidCredentials) || errors.Is(err, domain.ErrUserNotFound) {
-			utils.WriteJSON(w, http.StatusUnauthorized, domain.ErrorResponse{Error: err.Error()})
+		// The service answers an unknown email like a wrong password, so login can't be used to probe for accounts
+		if errors.Is(err, domain.ErrInvalidCredentials) {
+			utils.WriteJSON(w, r, http.StatusUnauthorized, domain.ErrorResponse{Error: err.Error()})
 			return
 		}
 
-		utils.WriteJSON(w, http.StatusInternalServerError, domain.ErrorResponse{Error: "internal server error"})
+		utils.WriteError(w, r, err)
 		return
 	}
 
@@ -135,7 +130,7 @@ func (h *UserHandlers) Login(w http.ResponseWriter, r *http.Request) {
 
 	_, tokenString, err := h.TokenAuth.Encode(claims.ToMap())
 	if err != nil {
-		utils.WriteJSON(w, http.StatusInternalServerError, domain.ErrorResponse{Error: "failed to generate token"})
+		utils.WriteJSON(w, r, http.StatusInternalServerError, domain.ErrorResponse{Error: "failed to generate token"})
 		return
 	}
 
@@ -143,89 +138,166 @@ func (h *UserHandlers) Login(w http.ResponseWriter, r *http.Request) {
 	respLogin := domain.LoginResponseDTO{
 		Token: tokenString,
 		User: domain.UserDTO{
-			ID:    user.ID,
-			Name:  user.Name,
-			Email: user.Email,
+			ID:       user.ID,
+			Name:     user.Name,
+			Email:    user.Email,
+			Timezone: user.Timezone,
 		},
 	}
 
 	// Send response
-	utils.WriteJSON(w, http.StatusOK, respLogin)
+	utils.WriteJSON(w, r, http.StatusOK, respLogin)
 }
 
 // DeleteUser creates a new HTTP handler for deleting a user.
 func (h *UserHandlers) DeleteUser(w http.ResponseWriter, r *http.Request) {
-	idr := chi.URLParam(r, "id") // Get the "id" URL parameter
-
-	if idr == "" {
-		utils.WriteJSON(w, http.StatusBadRequest, domain.ErrorResponse{Error: "id is required"})
+	id, ok := userIDFromPath(w, r)
+	if !ok {
 		return
 	}
 
-	id, err := strconv.ParseInt(idr, 10, 64) // Convert id string to int
+	err := h.Service.DeleteUser(r.Context(), id)
 	if err != nil {
-		utils.WriteJSON(w, http.StatusBadRequest, domain.ErrorResponse{Error: "id must be an integer"})
+		if errors.Is(err, domain.ErrUserNotFound) {
+			utils.WriteJSON(w, r, http.StatusNotFound, domain.ErrorResponse{Error: err.Error()})
+			return
+		}
+		utils.WriteError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent) // 204 No Content on successful deletion
+}
+
+// UpdateProfile handles PUT /users/me requests, it changes the name, email and timezone of the caller.
+// Tokens identify the user by id, so tokens issued before the change stay valid until they expire.
+// Their name and email claims are stale though, so when either changes a fresh token is sent in the
+// X-Auth-Token response header and the client should use it from then on.
+func (h *UserHandlers) UpdateProfile(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	caller, ok := auth.UserFromContext(r.Context())
+	if !ok {
+		utils.WriteJSON(w, r, http.StatusForbidden, domain.ErrorResponse{Error: "missing user"})
+		return
+	}
+
+	var reqProfile domain.UpdateProfileRequestDTO
+
+	if err := json.NewDecoder(r.Body).Decode(&reqProfile); err != nil {
+		utils.WriteJSON(w, r, http.StatusBadRequest, domain.ErrorResponse{Error: err.Error()})
 		return
 	}
 
-	err = h.Service.DeleteUser(r.Context(), id)
+	if err := validate.New().Struct(reqProfile); err != nil {
+		utils.WriteValidationError(w, r, translateValidationError(err))
+		return
+	}
+
+	user, err := h.Service.UpdateProfile(r.Context(), caller.ID, reqProfile.Name, reqProfile.Email, reqProfile.Timezone)
 	if err != nil {
-		if errors.Is(err, domain.ErrUserNotFound) {
-			utils.WriteJSON(w, http.StatusNotFound, domain.ErrorResponse{Error: err.Error()})
-			return
+		switch {
+		case errors.Is(err, domain.ErrInvalidInput), errors.Is(err, domain.ErrInvalidTimezone):
+			utils.WriteValidationError(w, r, err.Error())
+		case errors.Is(err, domain.ErrDuplicate):
+			utils.WriteJSON(w, r, http.StatusConflict, domain.ErrorResponse{Error: err.Error()})
+		case errors.Is(err, domain.ErrUserNotFound):
+			utils.WriteJSON(w, r, http.StatusNotFound, domain.ErrorResponse{Error: err.Error()})
+		default:
+			utils.WriteError(w, r, err)
 		}
-		utils.WriteJSON(w, http.StatusInternalServerError, domain.ErrorResponse{Error: "internal server error"})
 		return
 	}
 
-	w.WriteHeader(http.StatusNoContent) // 204 No Content on successful deletion
+	if user.Email != caller.Email || user.Name != caller.Name {
+		claims := auth.NewUserClaims(user, 1*time.Hour)
+
+		_, tokenString, err := h.TokenAuth.Encode(claims.ToMap())
+		if err != nil {
+			utils.WriteJSON(w, r, http.StatusInternalServerError, domain.ErrorResponse{Error: "failed to generate token"})
+			return
+		}
+
+		w.Header().Set(TokenHeader, tokenString)
+	}
+
+	respUser := domain.UserDTO{
+		ID:       user.ID,
+		Name:     user.Name,
+		Email:    user.Email,
+		Timezone: user.Timezone,
+	}
+
+	utils.WriteJSON(w, r, http.StatusOK, respUser)
 }
 
+// userIDFromPath reads the user id from the path and checks it is the caller's own id.
+// Another user's id answers 404 like an unknown one, so the endpoint does not reveal which ids exist.
+// On failure the response is already written and ok is false.
+func userIDFromPath(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	caller, ok := auth.UserFromContext(r.Context())
+	if !ok {
+		utils.WriteJSON(w, r, http.StatusForbidden, domain.ErrorResponse{Error: "missing user"})
+		return 0, false
+	}
+
+	idr := chi.URLParam(r, "id") // Get the "id" URL parameter
+
+	if idr == "" {
+		utils.WriteJSON(w, r, http.StatusBadRequest, domain.ErrorResponse{Error: "id is required"})
+		return 0, false
+	}
+
+	id, err := strconv.ParseInt(idr, 10, 64) // Convert id string to int
+	if err != nil {
+		utils.WriteJSON(w, r, http.StatusBadRequest, domain.ErrorResponse{Error: "id must be an integer"})
+		return 0, false
+	}
+
+	if id != caller.ID {
+		utils.WriteJSON(w, r, http.StatusNotFound, domain.ErrorResponse{Error: domain.ErrUserNotFound.Error()})
+		return 0, false
+	}
+
+	return id, true
+}
+
+// translateValidationError converts validator errors to user-friendly strings
 func translateValidationError(err error) string {
 	validationErrs, ok := err.(validator.ValidationErrors)
 	if !ok {
 		return "validation failed"
 	}
 
+	// The limits come from the validate tags of the DTO, so the messages can't drift from them
 	messages := []string{}
 	for _, fieldErr := range validationErrs {
-		switch fieldErr.Field() {
-		case "Name":
-			switch fieldErr.Tag() {
-			case "required":
-				messages = append(messages, "Name is required")
-			case "min":
-				messages = append(messages, "Name must be at least 5 characters")
-			case "max":
-				messages = append(messages, "Name must be at most 255 characters")
-			}
-		case "Email":
-			switch fieldErr.Tag() {
-			case "required":
-				messages = append(messages, "Email is required")
-			case "min":
-				messages = append(messages, "Email must be at least 5 characters")
-			case "max":
-				messages = append(messages, "Email must be at most 255 characters")
-			}
-		case "Password":
-			switch fieldErr.Tag() {
-			case "required":
-				messages = append(messages, "Password is required")
-			case "min":
-				messages = append(messages, "Password must be at least 5 characters")
-			case "max":
-				messages = append(messages, "Password must be at most 255 characters")
+		field := fieldErr.Field()
+
+		switch fieldErr.Tag() {
+		case "required":
+			messages = append(messages, field+" is required")
+		case "email":
+			messages = append(messages, field+" is invalid")
+		case "min":
+			messages = append(messages, field+" must be at least "+fieldErr.Param()+" characters")
+		case "max":
+			messages = append(messages, field+" must be at most "+fieldErr.Param()+" characters")
+		case "containsany":
+			if fieldErr.Param() == "0123456789" {
+				messages = append(messages, field+" must contain a number")
+			} else {
+				messages = append(messages, field+" must contain an uppercase letter")
 			}
 		default:
-			messages = append(messages, fieldErr.Field()+" is invalid")
-		}
-		if len(messages) == 0 {
-			return "validation failed"
+			messages = append(messages, field+" is invalid")
 		}
 	}
-	return strings.Join(messages, "; ") // Combine if multiple errors
 
+	if len(messages) == 0 {
+		return "validation failed"
+	}
+	return strings.Join(messages, "; ") // Combine if multiple errors
 }
 
 // Helper: Simple email format check (it can be in the to domain if you want)
@@ -13,6 +13,7 @@ import (
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/jwtauth/v5"
+	"github.com/macesz/todo-go/delivery/web/auth"
 	"github.com/macesz/todo-go/delivery/web/user/mocks"
 	"github.com/macesz/todo-go/domain"
 	"github.com/stretchr/testify/assert"
@@ -59,6 +60,16 @@ func TestLoginUser(t *testing.T) {
 				assert.Equal(t, "test@example.com", response.User.Email)
 
 				assert.True(t, strings.Count(response.Token, ".") == 2, "Token should have 3 parts separated by dots")
+
+				// The token carries the claims middlewares.UserContext reads back
+				token, err := jwtauth.VerifyToken(jwtauth.New("HS256", []byte("test-secret-key-for-testing"), nil), response.Token)
+				require.NoError(t, err)
+
+				claims, err := auth.ClaimsFromToken(token.PrivateClaims())
+				require.NoError(t, err)
+				assert.Equal(t, int64(1), claims.UserID)
+				assert.Equal(t, "Test User", claims.Name)
+				assert.Equal(t, "test@example.com", claims.Email)
 			},
 		},
 		{
@@ -78,7 +89,8 @@ func TestLoginUser(t *testing.T) {
 				assert.NoError(t, err)
 				assert.Equal(t, "invalid credentials", response.Error)
 			},
-		}, {
+		},
+		{
 			name:           "Invalid JSON",
 			inputBody:      `{"email":"test@example.com"`, // Malformed JSON
 			setupMock:      func(m *mocks.UserService) {}, // No service call
@@ -189,7 +201,7 @@ func TestCreateUser(t *testing.T) {
 			inputBody:      `{"email":"test@example.com","password":"Password123"}`, // Valid JSON, missing name
 			shouldCallMock: false,
 			mockError:      nil,
-			expectedStatus: http.StatusBadRequest,
+			expectedStatus: http.StatusUnprocessableEntity,
 			expectedBody:   `{"error":"Name is required"}`,
 		}, {
 			name:           "Missing Email",
@@ -197,7 +209,7 @@ func TestCreateUser(t *testing.T) {
 			shouldCallMock: false,
 			mockReturn:     nil,
 			mockError:      nil,
-			expectedStatus: http.StatusBadRequest,
+			expectedStatus: http.StatusUnprocessableEntity,
 			expectedBody:   `{"error":"Email is required"}`,
 		}, {
 			name:           "Missing Password",
@@ -205,7 +217,7 @@ func TestCreateUser(t *testing.T) {
 			shouldCallMock: false,
 			mockReturn:     nil,
 			mockError:      nil,
-			expectedStatus: http.StatusBadRequest,
+			expectedStatus: http.StatusUnprocessableEntity,
 			expectedBody:   `{"error":"Password is required"}`,
 		},
 	}
@@ -244,11 +256,74 @@ func TestCreateUser(t *testing.T) {
 
 }
 
+// TestCreateUserValidationMessages pins the exact messages of the CreateUserRequestDTO validate tags
+func TestCreateUserValidationMessages(t *testing.T) {
+	tests := []struct {
+		name         string
+		inputBody    string
+		expectedBody string
+	}{
+		{
+			name:         "Empty body",
+			inputBody:    `{}`,
+			expectedBody: `{"error":"Name is required; Email is required; Password is required"}`,
+		},
+		{
+			name:         "Short name",
+			inputBody:    `{"name":"T","email":"test@example.com","password":"Password123"}`,
+			expectedBody: `{"error":"Name must be at least 2 characters"}`,
+		},
+		{
+			name:         "Long name",
+			inputBody:    `{"name":"` + strings.Repeat("a", 256) + `","email":"test@example.com","password":"Password123"}`,
+			expectedBody: `{"error":"Name must be at most 255 characters"}`,
+		},
+		{
+			name:         "Invalid email",
+			inputBody:    `{"name":"Test User","email":"not-an-email","password":"Password123"}`,
+			expectedBody: `{"error":"Email is invalid"}`,
+		},
+		{
+			name:         "Short password",
+			inputBody:    `{"name":"Test User","email":"test@example.com","password":"Pa1"}`,
+			expectedBody: `{"error":"Password must be at least 6 characters"}`,
+		},
+		{
+			name:         "Password without a number",
+			inputBody:    `{"name":"Test User","email":"test@example.com","password":"Password"}`,
+			expectedBody: `{"error":"Password must contain a number"}`,
+		},
+		{
+			name:         "Password without an uppercase letter",
+			inputBody:    `{"name":"Test User","email":"test@example.com","password":"password123"}`,
+			expectedBody: `{"error":"Password must contain an uppercase letter"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Strict mock: invalid input must never reach the service
+			handlers := &UserHandlers{Service: mocks.NewUserService(t)}
+
+			req, err := http.NewRequest(http.MethodPost, "/users", strings.NewReader(tt.inputBody))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+
+			rr := httptest.NewRecorder()
+			handlers.CreateUser(rr, req)
+
+			require.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+			assert.JSONEq(t, tt.expectedBody, rr.Body.String())
+		})
+	}
+}
+
 func TestGetUser(t *testing.T) {
 	tests := []struct {
 		name           string
 		urlParam       string
 		shouldCallMock bool // Whether to expect service call
+		noUser         bool // Request without an authenticated user
 		mockReturn     *domain.User
 		mockError      error
 		expectedStatus int
@@ -272,12 +347,24 @@ func TestGetUser(t *testing.T) {
 			expectedBody:   `{"error":"id must be an integer"}`,
 		}, {
 			name:           "User not found",
-			urlParam:       "999",
+			urlParam:       "1",
 			shouldCallMock: true,
 			mockReturn:     nil,
 			mockError:      domain.ErrUserNotFound,
 			expectedStatus: http.StatusNotFound,
 			expectedBody:   `{"error":"user not found"}`,
+		}, {
+			name:           "Someone else's id",
+			urlParam:       "999",
+			shouldCallMock: false,
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"error":"user not found"}`,
+		}, {
+			name:           "Missing user",
+			urlParam:       "1",
+			noUser:         true,
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   `{"error":"missing user"}`,
 		}, {
 			name:           "Missing ID",
 			urlParam:       "",
@@ -326,6 +413,9 @@ func TestGetUser(t *testing.T) {
 			rctx := chi.NewRouteContext()
 			rctx.URLParams.Add("id", tt.urlParam)
 			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			if !tt.noUser {
+				req = withUserContext(req, 1)
+			}
 
 			handlers.GetUser(rr, req) // Assumes your handler method is named GetUser
 
@@ -347,6 +437,7 @@ func TestDeleteUser(t *testing.T) {
 		name           string
 		urlParam       string
 		shouldCallMock bool // Whether to expect service call
+		noUser         bool // Request without an authenticated user
 		mockError      error
 		expectedStatus int
 		expectedBody   string
@@ -367,11 +458,23 @@ func TestDeleteUser(t *testing.T) {
 			expectedBody:   `{"error":"id must be an integer"}`,
 		}, {
 			name:           "User not found",
-			urlParam:       "999",
+			urlParam:       "1",
 			shouldCallMock: true,
 			mockError:      domain.ErrUserNotFound,
 			expectedStatus: http.StatusNotFound,
 			expectedBody:   `{"error":"user not found"}`,
+		}, {
+			name:           "Someone else's id",
+			urlParam:       "999",
+			shouldCallMock: false,
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"error":"user not found"}`,
+		}, {
+			name:           "Missing user",
+			urlParam:       "1",
+			noUser:         true,
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   `{"error":"missing user"}`,
 		}, {
 			name:           "Missing ID",
 			urlParam:       "",
@@ -410,9 +513,20 @@ func TestDeleteUser(t *testing.T) {
 			rctx := chi.NewRouteContext()
 			rctx.URLParams.Add("id", tt.urlParam)
 			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			if !tt.noUser {
+				req = withUserContext(req, 1)
+			}
 
 			handlers.DeleteUser(rr, req)
 
+			require.Equal(t, tt.expectedStatus, rr.Code)
+
+			if tt.expectedStatus == http.StatusNoContent {
+				// 204 carries no body, not even an empty JSON object
+				assert.Zero(t, rr.Body.Len())
+				assert.Empty(t, rr.Header().Get("Content-Type"))
+			}
+
 			if tt.expectedBody == "" {
 				assert.Equal(t, tt.expectedBody, rr.Body.String())
 			} else {
@@ -423,3 +537,128 @@ func TestDeleteUser(t *testing.T) {
 		})
 	}
 }
+
+// withUserContext adds an authenticated user to the request context, like the UserContext middleware does.
+func withUserContext(req *http.Request, userID int64) *http.Request {
+	userCtx := &auth.UserContext{
+		ID:    userID,
+		Email: "test@example.com",
+		Name:  "Test User",
+	}
+	return req.WithContext(userCtx.AddToContext(req.Context()))
+}
+
+func TestUpdateProfile(t *testing.T) {
+	tests := []struct {
+		name           string
+		inputBody      string
+		noUser         bool
+		shouldCallMock bool
+		mockReturn     *domain.User
+		mockError      error
+		expectedStatus int
+		expectedBody   string
+		wantToken      bool
+	}{
+		{
+			name:           "New email issues a token",
+			inputBody:      `{"name":"Test User","email":"new@example.com"}`,
+			shouldCallMock: true,
+			mockReturn:     &domain.User{ID: 1, Name: "Test User", Email: "new@example.com"},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"id":1,"name":"Test User","email":"new@example.com"}`,
+			wantToken:      true,
+		},
+		{
+			name:           "Unchanged claims keep the token",
+			inputBody:      `{"name":"Test User","email":"test@example.com"}`,
+			shouldCallMock: true,
+			mockReturn:     &domain.User{ID: 1, Name: "Test User", Email: "test@example.com"},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"id":1,"name":"Test User","email":"test@example.com"}`,
+		},
+		{
+			name:           "New timezone",
+			inputBody:      `{"name":"Test User","email":"test@example.com","timezone":"Europe/Budapest"}`,
+			shouldCallMock: true,
+			mockReturn:     &domain.User{ID: 1, Name: "Test User", Email: "test@example.com", Timezone: "Europe/Budapest"},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"id":1,"name":"Test User","email":"test@example.com","timezone":"Europe/Budapest"}`,
+		},
+		{
+			name:           "Invalid timezone",
+			inputBody:      `{"name":"Test User","email":"test@example.com","timezone":"Mars/Olympus_Mons"}`,
+			shouldCallMock: true,
+			mockError:      domain.ErrInvalidTimezone,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   `{"error":"timezone must be an IANA name like Europe/Budapest"}`,
+		},
+		{
+			name:           "Duplicate email",
+			inputBody:      `{"name":"Test User","email":"taken@example.com"}`,
+			shouldCallMock: true,
+			mockError:      domain.ErrDuplicate,
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `{"error":"resource already exists"}`,
+		},
+		{
+			name:           "Invalid email",
+			inputBody:      `{"name":"Test User","email":"not-an-email"}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   `{"error":"Email is invalid"}`,
+		},
+		{
+			name:           "Missing name",
+			inputBody:      `{"email":"new@example.com"}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   `{"error":"Name is required"}`,
+		},
+		{
+			name:           "Invalid JSON",
+			inputBody:      `{"name":`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"unexpected EOF"}`,
+		},
+		{
+			name:           "Missing user",
+			inputBody:      `{"name":"Test User","email":"new@example.com"}`,
+			noUser:         true,
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   `{"error":"missing user"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := mocks.NewUserService(t)
+
+			if tt.shouldCallMock {
+				var reqProfile domain.UpdateProfileRequestDTO
+				require.NoError(t, json.Unmarshal([]byte(tt.inputBody), &reqProfile))
+
+				mockService.On("UpdateProfile", mock.Anything, int64(1), reqProfile.Name, reqProfile.Email, reqProfile.Timezone).
+					Return(tt.mockReturn, tt.mockError).Once()
+			}
+
+			handlers := &UserHandlers{
+				Service:   mockService,
+				TokenAuth: jwtauth.New("HS256", []byte("test-secret-key-for-testing"), nil),
+			}
+
+			req := httptest.NewRequest(http.MethodPut, "/users/me", strings.NewReader(tt.inputBody))
+			req.Header.Set("Content-Type", "application/json")
+			if !tt.noUser {
+				req = withUserContext(req, 1)
+			}
+
+			rr := httptest.NewRecorder()
+			handlers.UpdateProfile(rr, req)
+
+			require.Equal(t, tt.expectedStatus, rr.Code)
+			assert.JSONEq(t, tt.expectedBody, rr.Body.String())
+			assert.Equal(t, tt.wantToken, rr.Header().Get(TokenHeader) != "")
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
@@ -11,4 +11,5 @@ type UserService interface {
 	CreateUser(ctx context.Context, name, email, password string) (*domain.User, error)
 	Login(ctx context.Context, email, password string) (*domain.User, error)
 	DeleteUser(ctx context.Context, id int64) error
+	UpdateProfile(ctx context.Context, id int64, name, email, timezone string) (*domain.User, error)
 }
@@ -0,0 +1,50 @@
+package utils
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/macesz/todo-go/domain"
+)
+
+// ParseDryRun reads the dry_run query param of a destructive endpoint, missing means false.
+func ParseDryRun(r *http.Request) (bool, error) {
+	value := r.URL.Query().Get("dry_run")
+	if value == "" {
+		return false, nil
+	}
+
+	dryRun, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("%w: dry_run must be true or false", domain.ErrInvalidInput)
+	}
+
+	return dryRun, nil
+}
+
+// ParseReturnDeleted reads the return query param of a delete endpoint, true asks for the deleted resource
+// in the response instead of an empty 204. Missing means false.
+func ParseReturnDeleted(r *http.Request) (bool, error) {
+	value := r.URL.Query().Get("return")
+	if value == "" {
+		return false, nil
+	}
+
+	returnDeleted, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("%w: return must be true or false", domain.ErrInvalidInput)
+	}
+
+	return returnDeleted, nil
+}
+
+// DryRunResponse converts the result of a dry run to its response body.
+func DryRunResponse(result *domain.DryRunResult) domain.DryRunDTO {
+	ids := result.IDs
+	if ids == nil {
+		ids = []string{}
+	}
+
+	return domain.DryRunDTO{DryRun: true, Count: result.Count, IDs: ids}
+}
@@ -0,0 +1,34 @@
+package utils
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/macesz/todo-go/domain"
+)
+
+// internalServerError is the message of every 500, the cause stays in the logs
+const internalServerError = "internal server error"
+
+type debugErrorsKey struct{}
+
+// WithDebugErrors marks a request context, so WriteError sends the underlying error as well.
+func WithDebugErrors(ctx context.Context) context.Context {
+	return context.WithValue(ctx, debugErrorsKey{}, true)
+}
+
+func debugErrors(ctx context.Context) bool {
+	enabled, _ := ctx.Value(debugErrorsKey{}).(bool)
+	return enabled
+}
+
+// WriteError answers 500 Internal Server Error with a generic message.
+// With debug errors on the request, see WithDebugErrors, the error is sent in the detail field.
+func WriteError(w http.ResponseWriter, r *http.Request, err error) error {
+	response := domain.ErrorResponse{Error: internalServerError}
+	if err != nil && debugErrors(r.Context()) {
+		response.Detail = err.Error()
+	}
+
+	return WriteJSON(w, r, http.StatusInternalServerError, response)
+}
@@ -0,0 +1,42 @@
+package utils
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteError(t *testing.T) {
+	cause := errors.New("failed to list todos: pq: relation \"todos\" does not exist")
+
+	tests := []struct {
+		name  string
+		debug bool
+		err   error
+		want  string
+	}{
+		{name: "generic by default", err: cause, want: `{"error":"internal server error"}`},
+		{name: "debug sends the error", debug: true, err: cause, want: `{"error":"internal server error","detail":"failed to list todos: pq: relation \"todos\" does not exist"}`},
+		{name: "debug without an error", debug: true, want: `{"error":"internal server error"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/lists", nil)
+			if tt.debug {
+				req = req.WithContext(WithDebugErrors(req.Context()))
+			}
+
+			rr := httptest.NewRecorder()
+			err := WriteError(rr, req, tt.err)
+			require.NoError(t, err)
+
+			require.Equal(t, http.StatusInternalServerError, rr.Code)
+			assert.JSONEq(t, tt.want, rr.Body.String())
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/macesz/todo-go/domain"
+)
+
+// ErrMissingIfMatch is returned by ParseIfMatch when the request has no If-Match header.
+var ErrMissingIfMatch = errors.New("the If-Match header is required, send the ETag of the list you read")
+
+// ETag formats a version as a strong entity tag, like "3" with the quotes.
+func ETag(version int64) string {
+	return strconv.Quote(strconv.FormatInt(version, 10))
+}
+
+// ParseIfMatch reads the version from the If-Match header, the inverse of ETag.
+// A weak tag (W/"3") is accepted too.
+func ParseIfMatch(r *http.Request) (int64, error) {
+	value := strings.TrimSpace(r.Header.Get("If-Match"))
+	if value == "" {
+		return 0, ErrMissingIfMatch
+	}
+
+	unquoted, err := strconv.Unquote(strings.TrimPrefix(value, "W/"))
+	if err != nil {
+		return 0, fmt.Errorf("%w: If-Match must be an ETag like \"1\"", domain.ErrInvalidInput)
+	}
+
+	version, err := strconv.ParseInt(unquoted, 10, 64)
+	if err != nil || version < 1 {
+		return 0, fmt.Errorf("%w: If-Match must be an ETag like \"1\"", domain.ErrInvalidInput)
+	}
+
+	return version, nil
+}
@@ -2,25 +2,46 @@ package utils
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
+
+	"github.com/macesz/todo-go/domain"
+	"github.com/macesz/todo-go/pkg/logctx"
 )
 
-// writeJSON is a helper to write JSON responses.
-// type any = interface{} any is an alias for interface{} and is equivalent to interface{} in all ways.
-func WriteJSON(w http.ResponseWriter, status int, data any) error {
+// WriteJSON answers with data as JSON, an encoding failure becomes a logged 500.
+func WriteJSON(w http.ResponseWriter, r *http.Request, status int, data any) error {
+	body, err := json.Marshal(data)
+	if err != nil {
+		err = fmt.Errorf("failed to encode %T response: %w", data, err)
+		logctx.From(r.Context()).Error("failed to write response", "error", err)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(`{"error":"internal server error"}` + "\n"))
+
+		return err
+	}
 
 	w.Header().Set("Content-Type", "application/json") // Set content type header
-	w.WriteHeader(status)
+	w.WriteHeader(status)                              // Set the status code
 
-	// Set the status code
-	err := json.NewEncoder(w).Encode(data)
-	if err != nil {
+	// Headers are already sent at this point, the best we can do is log
+	if _, err := w.Write(append(body, '\n')); err != nil {
+		err = fmt.Errorf("failed to write %d response: %w", status, err)
+		logctx.From(r.Context()).Error("failed to write response", "error", err)
 		return err
 	}
 
 	return nil
 }
 
+// WriteValidationError answers 422 Unprocessable Entity, for a body that is well-formed JSON but fails validation.
+// A body that can't be decoded is a 400 Bad Request instead.
+func WriteValidationError(w http.ResponseWriter, r *http.Request, message string) error {
+	return WriteJSON(w, r, http.StatusUnprocessableEntity, domain.ErrorResponse{Error: message})
+}
+
 func JsonError(err error) string {
 	type response struct {
 		Error string `json:"error"`
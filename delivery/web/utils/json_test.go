@@ -0,0 +1,46 @@
+package utils
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/macesz/todo-go/pkg/logctx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteJSON(t *testing.T) {
+	rr := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/api/lists", nil)
+
+	err := WriteJSON(rr, req, http.StatusCreated, map[string]string{"title": "Buy milk"})
+	require.NoError(t, err)
+
+	require.Equal(t, http.StatusCreated, rr.Code)
+	require.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"title":"Buy milk"}`, rr.Body.String())
+}
+
+func TestWriteJSONUnencodable(t *testing.T) {
+	var logs bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&logs, nil)))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+
+	rr := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/api/lists", nil)
+	req = req.WithContext(logctx.WithRequestID(req.Context(), "host/abc-000001"))
+
+	// Channels cannot be encoded as JSON
+	err := WriteJSON(rr, req, http.StatusOK, map[string]any{"ch": make(chan int)})
+	require.Error(t, err)
+
+	require.Equal(t, http.StatusInternalServerError, rr.Code)
+	require.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"error":"internal server error"}`, rr.Body.String())
+	assert.Contains(t, logs.String(), "failed to encode")
+	assert.Contains(t, logs.String(), "request_id=host/abc-000001") // Logged with the request it belongs to
+}
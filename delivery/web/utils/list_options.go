@@ -0,0 +1,75 @@
+package utils
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/macesz/todo-go/domain"
+)
+
+// ParseListOptions reads the limit, offset, sort, order, done, q and updated_since query params.
+// Missing params keep their zero value, invalid ones return an ErrInvalidInput error.
+// The limit follows the pageSize policy.
+func ParseListOptions(r *http.Request, pageSize PageSize) (domain.ListOptions, error) {
+	query := r.URL.Query()
+
+	var opts domain.ListOptions
+
+	limit, err := pageSize.ParseLimit(query.Get("limit"), 0)
+	if err != nil {
+		return domain.ListOptions{}, err
+	}
+	opts.Limit = limit
+
+	if offset := query.Get("offset"); offset != "" {
+		n, err := strconv.Atoi(offset)
+		if err != nil || n < 0 {
+			return domain.ListOptions{}, fmt.Errorf("%w: offset must be a non-negative integer", domain.ErrInvalidInput)
+		}
+		opts.Offset = n
+	}
+
+	if sort := query.Get("sort"); sort != "" {
+		if !slices.Contains(domain.SortFields, sort) {
+			return domain.ListOptions{}, fmt.Errorf("%w: sort must be one of %v", domain.ErrInvalidInput, domain.SortFields)
+		}
+		opts.Sort = sort
+	}
+
+	if order := query.Get("order"); order != "" {
+		if order != domain.SortOrderAsc && order != domain.SortOrderDesc {
+			return domain.ListOptions{}, fmt.Errorf("%w: order must be asc or desc", domain.ErrInvalidInput)
+		}
+		opts.Order = order
+	}
+
+	if done := query.Get("done"); done != "" {
+		b, err := strconv.ParseBool(done)
+		if err != nil {
+			return domain.ListOptions{}, fmt.Errorf("%w: done must be true or false", domain.ErrInvalidInput)
+		}
+		opts.Done = &b
+	}
+
+	if q := strings.TrimSpace(query.Get("q")); q != "" {
+		if utf8.RuneCountInString(q) > domain.MaxTitleLength {
+			return domain.ListOptions{}, fmt.Errorf("%w: q must be at most %d characters", domain.ErrInvalidInput, domain.MaxTitleLength)
+		}
+		opts.Query = q
+	}
+
+	if updatedSince := query.Get("updated_since"); updatedSince != "" {
+		t, err := time.Parse(time.RFC3339, updatedSince)
+		if err != nil {
+			return domain.ListOptions{}, fmt.Errorf("%w: updated_since must be an RFC3339 timestamp", domain.ErrInvalidInput)
+		}
+		opts.UpdatedSince = &t
+	}
+
+	return opts, nil
+}
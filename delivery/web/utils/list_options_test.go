@@ -0,0 +1,78 @@
+package utils
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/macesz/todo-go/domain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseListOptions(t *testing.T) {
+	done := true
+	since := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		query   string
+		want    domain.ListOptions
+		wantErr bool
+	}{
+		{
+			name:  "no params",
+			query: "",
+			want:  domain.ListOptions{},
+		},
+		{
+			name:  "all params",
+			query: "?limit=10&offset=20&sort=title&order=desc&done=true",
+			want:  domain.ListOptions{Limit: 10, Offset: 20, Sort: "title", Order: "desc", Done: &done},
+		},
+		{
+			name:  "updated since",
+			query: "?updated_since=2024-01-02T03:04:05Z",
+			want:  domain.ListOptions{UpdatedSince: &since},
+		},
+		{
+			name:  "sort without order",
+			query: "?sort=created_at",
+			want:  domain.ListOptions{Sort: "created_at"},
+		},
+		{
+			name:  "search is trimmed",
+			query: "?q=%20milk%20",
+			want:  domain.ListOptions{Query: "milk"},
+		},
+		{
+			name:  "blank search is no search",
+			query: "?q=%20%20",
+			want:  domain.ListOptions{},
+		},
+		{name: "search too long", query: "?q=" + strings.Repeat("a", domain.MaxTitleLength+1), wantErr: true},
+		{name: "limit not a number", query: "?limit=ten", wantErr: true},
+		{name: "limit zero", query: "?limit=0", wantErr: true},
+		{name: "limit too large", query: "?limit=201", wantErr: true},
+		{name: "negative offset", query: "?offset=-1", wantErr: true},
+		{name: "unknown sort field", query: "?sort=password", wantErr: true},
+		{name: "invalid order", query: "?sort=id&order=up", wantErr: true},
+		{name: "invalid done", query: "?done=maybe", wantErr: true},
+		{name: "updated_since not a timestamp", query: "?updated_since=yesterday", wantErr: true},
+		{name: "updated_since without zone", query: "?updated_since=2024-01-02T03:04:05", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/api/lists"+tt.query, nil)
+
+			got, err := ParseListOptions(req, PageSize{})
+			if tt.wantErr {
+				require.ErrorIs(t, err, domain.ErrInvalidInput)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/macesz/todo-go/domain"
+)
+
+// DefaultMaxPageSize is the largest page size a client may ask for when MAX_PAGE_SIZE is not set.
+const DefaultMaxPageSize = 200
+
+// PageSize is the limit policy shared by every paginated endpoint.
+// The zero value allows up to DefaultMaxPageSize and rejects anything larger.
+type PageSize struct {
+	Max   int  // Largest limit, 0 means DefaultMaxPageSize
+	Clamp bool // Cut a limit over Max down to Max instead of rejecting it
+}
+
+// MaxLimit returns the largest limit the policy allows.
+func (p PageSize) MaxLimit() int {
+	if p.Max <= 0 {
+		return DefaultMaxPageSize
+	}
+
+	return p.Max
+}
+
+// ParseLimit reads a limit query param. An empty value gives fallback.
+// A limit below 1 is always an ErrInvalidInput error, one over the max is clamped or rejected by the policy.
+func (p PageSize) ParseLimit(value string, fallback int) (int, error) {
+	if value == "" {
+		return fallback, nil
+	}
+
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit < 1 || (limit > p.MaxLimit() && !p.Clamp) {
+		return 0, fmt.Errorf("%w: limit must be an integer between 1 and %d", domain.ErrInvalidInput, p.MaxLimit())
+	}
+
+	return min(limit, p.MaxLimit()), nil
+}
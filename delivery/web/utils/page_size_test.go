@@ -0,0 +1,41 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/macesz/todo-go/domain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPageSizeParseLimit(t *testing.T) {
+	tests := []struct {
+		name     string
+		pageSize PageSize
+		value    string
+		want     int
+		wantErr  bool
+	}{
+		{name: "empty gives the fallback", value: "", want: 10},
+		{name: "within the default max", value: "200", want: 200},
+		{name: "over the default max", value: "201", wantErr: true},
+		{name: "custom max", pageSize: PageSize{Max: 50}, value: "51", wantErr: true},
+		{name: "clamped to the max", pageSize: PageSize{Max: 50, Clamp: true}, value: "51", want: 50},
+		{name: "clamped to the default max", pageSize: PageSize{Clamp: true}, value: "1000", want: DefaultMaxPageSize},
+		{name: "zero", value: "0", wantErr: true},
+		{name: "negative", value: "-1", wantErr: true},
+		{name: "negative is not clamped", pageSize: PageSize{Clamp: true}, value: "-1", wantErr: true},
+		{name: "not a number", value: "ten", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.pageSize.ParseLimit(tt.value, 10)
+			if tt.wantErr {
+				require.ErrorIs(t, err, domain.ErrInvalidInput)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package utils
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrIDRequired = errors.New("id is required")
+	ErrIDNotUUID  = errors.New("id must be a UUID")
+)
+
+// ParsePublicID reads a public id (UUID) from the named URL param.
+// Lists and todos are addressed by their public id, the integer id never leaves the server.
+func ParsePublicID(r *http.Request, name string) (string, error) {
+	value := chi.URLParam(r, name)
+	if value == "" {
+		return "", ErrIDRequired
+	}
+
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return "", ErrIDNotUUID
+	}
+
+	return id.String(), nil
+}
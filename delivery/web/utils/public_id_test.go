@@ -0,0 +1,43 @@
+package utils
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePublicID(t *testing.T) {
+	tests := []struct {
+		name    string
+		param   string
+		want    string
+		wantErr error
+	}{
+		{name: "uuid", param: "6f1c2b9e-8a4d-4c1e-9b7a-3d2f1e0c5a11", want: "6f1c2b9e-8a4d-4c1e-9b7a-3d2f1e0c5a11"},
+		{name: "upper case uuid is normalized", param: "6F1C2B9E-8A4D-4C1E-9B7A-3D2F1E0C5A11", want: "6f1c2b9e-8a4d-4c1e-9b7a-3d2f1e0c5a11"},
+		{name: "missing", param: "", wantErr: ErrIDRequired},
+		{name: "integer id", param: "42", wantErr: ErrIDNotUUID},
+		{name: "garbage", param: "not-a-uuid", wantErr: ErrIDNotUUID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/api/lists/x", nil)
+
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", tt.param)
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+			got, err := ParsePublicID(req, "id")
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+)
+
+// Redacted replaces the value of a redacted field.
+const Redacted = "[REDACTED]"
+
+// redactedBody replaces a whole body that can't be parsed, it is still valid JSON
+var redactedBody = []byte(`"` + Redacted + `"`)
+
+// PasswordFields are the request fields that must never reach a log.
+var PasswordFields = []string{"password", "current_password", "new_password"}
+
+// RedactJSON returns a copy of a JSON body for logging, with the values of the given fields masked.
+// Fields match by name in any object of the body, ignoring case. A body that isn't valid JSON can't be
+// searched for the fields, so it is masked as a whole rather than logged as is.
+func RedactJSON(body []byte, fields ...string) []byte {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return body
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber() // Keep numbers as they were sent
+
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return redactedBody
+	}
+
+	redacted, err := json.Marshal(redactValue(value, fields))
+	if err != nil {
+		return redactedBody
+	}
+
+	return redacted
+}
+
+func redactValue(value any, fields []string) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, item := range v {
+			if isRedactedField(key, fields) {
+				v[key] = Redacted
+				continue
+			}
+			v[key] = redactValue(item, fields)
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = redactValue(item, fields)
+		}
+	}
+
+	return value
+}
+
+func isRedactedField(key string, fields []string) bool {
+	for _, field := range fields {
+		if strings.EqualFold(key, field) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,54 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedactJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{
+			name: "login body",
+			body: `{"email":"test@example.com","password":"Password123"}`,
+			want: `{"email":"test@example.com","password":"[REDACTED]"}`,
+		},
+		{
+			name: "password change",
+			body: `{"current_password":"old","new_password":"new","name":"Test"}`,
+			want: `{"current_password":"[REDACTED]","new_password":"[REDACTED]","name":"Test"}`,
+		},
+		{
+			name: "nested objects and arrays",
+			body: `{"users":[{"id":1,"Password":"secret"}],"meta":{"password":{"hash":"x"}}}`,
+			want: `{"users":[{"id":1,"Password":"[REDACTED]"}],"meta":{"password":"[REDACTED]"}}`,
+		},
+		{
+			name: "nothing to redact",
+			body: `{"title":"Buy milk","done":false,"count":12345678901234567890}`,
+			want: `{"title":"Buy milk","done":false,"count":12345678901234567890}`,
+		},
+		{
+			name: "invalid JSON is masked as a whole",
+			body: `{"password":"Password123"`,
+			want: `"[REDACTED]"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := RedactJSON([]byte(tt.body), PasswordFields...)
+
+			assert.JSONEq(t, tt.want, string(got))
+			assert.NotContains(t, string(got), "Password123")
+		})
+	}
+
+	t.Run("empty body", func(t *testing.T) {
+		assert.Empty(t, RedactJSON(nil, PasswordFields...))
+	})
+}
@@ -0,0 +1,22 @@
+package utils
+
+import "time"
+
+// FormatOptionalTime formats t as RFC3339, or returns "" when t is nil.
+func FormatOptionalTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+
+	return t.Format(time.RFC3339)
+}
+
+// FormatNullableTime formats t as RFC3339, or returns nil when t is nil, so it serializes as null.
+func FormatNullableTime(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+
+	formatted := t.Format(time.RFC3339)
+	return &formatted
+}
@@ -0,0 +1,38 @@
+package domain
+
+import "time"
+
+// Clock tells the services what time it is.
+// Inject a FixedClock in tests to get deterministic timestamps.
+type Clock interface {
+	Now() time.Time
+}
+
+// SystemClock is the real clock, backed by time.Now.
+type SystemClock struct{}
+
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
+
+// FixedClock always returns the same time.
+type FixedClock struct {
+	Time time.Time
+}
+
+func (c FixedClock) Now() time.Time {
+	return c.Time
+}
+
+// TruncatingClock cuts the times of Clock down to Precision.
+// Postgres keeps microseconds and rounds anything finer, so with microseconds a time
+// reads back from the database equal to the one that was written.
+type TruncatingClock struct {
+	Clock     Clock
+	Precision time.Duration
+}
+
+func (c TruncatingClock) Now() time.Time {
+	// Truncate also drops the monotonic reading, which a scanned time never has
+	return c.Clock.Now().Truncate(c.Precision)
+}
@@ -0,0 +1,24 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTruncatingClock(t *testing.T) {
+	t.Parallel()
+
+	fixed := time.Date(2024, 1, 2, 3, 4, 5, 123456789, time.UTC)
+
+	clock := TruncatingClock{Clock: FixedClock{Time: fixed}, Precision: time.Microsecond}
+	require.Equal(t, time.Date(2024, 1, 2, 3, 4, 5, 123456000, time.UTC), clock.Now())
+
+	clock.Precision = time.Millisecond
+	require.Equal(t, time.Date(2024, 1, 2, 3, 4, 5, 123000000, time.UTC), clock.Now())
+
+	// The system clock has a monotonic reading, a truncated time compares like a scanned one
+	now := TruncatingClock{Clock: SystemClock{}, Precision: time.Microsecond}.Now()
+	require.Equal(t, now.Round(0), now)
+}
@@ -0,0 +1,11 @@
+package domain
+
+import "regexp"
+
+// hexColorPattern is the hexcolor rule of the request validation: #RGB, #RGBA, #RRGGBB or #RRGGBBAA
+var hexColorPattern = regexp.MustCompile(`^#(?:[0-9a-fA-F]{3}|[0-9a-fA-F]{4}|[0-9a-fA-F]{6}|[0-9a-fA-F]{8})$`)
+
+// IsHexColor reports whether color is a hex color like #FF5733, for input that does not go through the request validation.
+func IsHexColor(color string) bool {
+	return hexColorPattern.MatchString(color)
+}
@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsHexColor(t *testing.T) {
+	tests := []struct {
+		color string
+		want  bool
+	}{
+		{color: "#FF5733", want: true},
+		{color: "#ff5733", want: true},
+		{color: "#FFF", want: true},
+		{color: "#FF573380", want: true},
+		{color: "FF5733", want: false},
+		{color: "#FF573", want: false},
+		{color: "#GG5733", want: false},
+		{color: "red", want: false},
+		{color: "", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.color, func(t *testing.T) {
+			require.Equal(t, tt.want, IsHexColor(tt.color))
+		})
+	}
+}
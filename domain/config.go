@@ -1,12 +1,134 @@
 package domain
 
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
 type Config struct {
-	DBAddr     string
-	DBUser     string
-	DBPassword string
-	DBName     string
-	ServerPort string
-	JWTSecret  string
-	DBPath     string
-	Port       string
+	DBDriver   string `yaml:"db_driver"` // Store backend, empty means postgres
+	DBAddr     string `yaml:"db_addr"`
+	DBUser     string `yaml:"db_user"`
+	DBPassword string `yaml:"db_pass"`
+	DBName     string `yaml:"db_name"`
+	ServerPort string `yaml:"server_port"`
+	JWTSecret  string `yaml:"jwt_secret"`
+	DBPath     string `yaml:"db_path"`
+	Port       string `yaml:"port"`
+
+	// Default sort of todos and lists when a request has no sort param, like "created_at:desc"
+	DefaultTodoSort string `yaml:"default_todo_sort"`
+	DefaultListSort string `yaml:"default_list_sort"`
+
+	// How long a trashed todo can be restored with undo-delete, like "5m", empty means 5 minutes
+	UndoDeleteTTL string `yaml:"undo_delete_ttl"`
+
+	// Redis address like "localhost:6379" for caching todo and list reads
+	RedisAddr string `yaml:"redis_addr"`
+
+	// Entries of the in-process cache used when RedisAddr is empty, empty or "0" turns caching off
+	CacheSize string `yaml:"cache_size"`
+
+	// How long a cached entry lives, like "5m", empty means DefaultCacheTTL
+	CacheTTL string `yaml:"cache_ttl"`
+
+	// Largest limit a paginated endpoint accepts, empty means 200
+	MaxPageSize string `yaml:"max_page_size"`
+
+	// Precision of the timestamps the services create, like "1ms", empty means DefaultTimestampPrecision
+	TimestampPrecision string `yaml:"timestamp_precision"`
+
+	// A database ping slower than this, like "200ms", reports degraded health, empty means DefaultHealthDegradedLatency
+	HealthDegradedLatency string `yaml:"health_degraded_latency"`
+
+	// On/off switches, at the top level of the file like the other settings
+	Features Features `yaml:",inline"`
+}
+
+const DefaultCacheTTL = "5m"
+
+// DefaultHealthDegradedLatency is the ping latency above which the database health is degraded.
+const DefaultHealthDegradedLatency = "500ms"
+
+// DefaultTimestampPrecision is the precision of a Postgres timestamptz.
+const DefaultTimestampPrecision = "1us"
+
+// ErrUnsupportedConfigFile is returned by LoadConfig for a file that is not YAML.
+var ErrUnsupportedConfigFile = errors.New("unsupported config file, use .yaml or .yml")
+
+// LoadConfig reads the config from a YAML file, then overlays the environment variables.
+// An env var that is set and not empty wins over the file. An empty path loads from env only.
+func LoadConfig(path string) (Config, error) {
+	cfg := Config{Features: DefaultFeatures()}
+
+	if path != "" {
+		if err := cfg.readFile(path); err != nil {
+			return Config{}, err
+		}
+	}
+
+	if err := cfg.overlayEnv(os.LookupEnv); err != nil {
+		return Config{}, err
+	}
+
+	return cfg, nil
+}
+
+func (c *Config) readFile(path string) error {
+	switch filepath.Ext(path) {
+	case ".yaml", ".yml":
+	default:
+		return fmt.Errorf("%w: %s", ErrUnsupportedConfigFile, path)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	// Unknown keys are an error, so a typo doesn't silently fall back to the default
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+
+	if err := decoder.Decode(c); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// overlayEnv replaces the fields whose env var is set and not empty.
+func (c *Config) overlayEnv(lookup func(string) (string, bool)) error {
+	stringVars := map[string]*string{
+		"DB_DRIVER":               &c.DBDriver,
+		"DB_ADDR":                 &c.DBAddr,
+		"DB_NAME":                 &c.DBName,
+		"DB_USER":                 &c.DBUser,
+		"DB_PASS":                 &c.DBPassword,
+		"JWT_SECRET":              &c.JWTSecret,
+		"SERVER_PORT":             &c.ServerPort,
+		"DEFAULT_TODO_SORT":       &c.DefaultTodoSort,
+		"DEFAULT_LIST_SORT":       &c.DefaultListSort,
+		"REDIS_ADDR":              &c.RedisAddr,
+		"CACHE_SIZE":              &c.CacheSize,
+		"CACHE_TTL":               &c.CacheTTL,
+		"MAX_PAGE_SIZE":           &c.MaxPageSize,
+		"TIMESTAMP_PRECISION":     &c.TimestampPrecision,
+		"UNDO_DELETE_TTL":         &c.UndoDeleteTTL,
+		"HEALTH_DEGRADED_LATENCY": &c.HealthDegradedLatency,
+	}
+
+	for name, field := range stringVars {
+		if value, ok := lookup(name); ok && value != "" {
+			*field = value
+		}
+	}
+
+	return c.Features.overlayEnv(lookup)
 }
@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// The tests set env vars, so they can't run in parallel
+func TestLoadConfig(t *testing.T) {
+	// Empty env vars are ignored, so this hides whatever the developer has set
+	for _, name := range []string{
+		"DB_DRIVER", "DB_ADDR", "DB_NAME", "DB_USER", "DB_PASS", "JWT_SECRET", "SERVER_PORT",
+		"DEFAULT_TODO_SORT", "DEFAULT_LIST_SORT", "WARN_DUPLICATE_TODO_TITLES", "SOFT_DELETE_TODOS",
+		"STRICT_TODO_LIST_ID", "REDIS_ADDR", "CACHE_SIZE", "CACHE_TTL", "MAX_PAGE_SIZE", "CLAMP_PAGE_SIZE",
+		"TIMESTAMP_PRECISION", "UNDO_DELETE_TTL", "DEBUG_ERRORS", "LOG_REQUEST_BODIES",
+		"HEALTH_DEGRADED_LATENCY",
+	} {
+		t.Setenv(name, "")
+	}
+
+	t.Run("file only", func(t *testing.T) {
+		cfg, err := LoadConfig("testdata/config.yaml")
+		require.NoError(t, err)
+
+		require.Equal(t, Config{
+			DBAddr:          "localhost:5432",
+			DBUser:          "todo",
+			DBPassword:      "secret",
+			DBName:          "todo",
+			ServerPort:      "8080",
+			JWTSecret:       "file-secret",
+			DefaultTodoSort: "created_at:desc",
+			CacheSize:       "1000",
+			CacheTTL:        "1m",
+			Features:        Features{SoftDeleteTodos: true},
+		}, cfg)
+	})
+
+	t.Run("env wins over the file", func(t *testing.T) {
+		t.Setenv("JWT_SECRET", "env-secret")
+		t.Setenv("SERVER_PORT", "9090")
+		t.Setenv("SOFT_DELETE_TODOS", "false")
+		t.Setenv("STRICT_TODO_LIST_ID", "true")
+		t.Setenv("DB_NAME", "") // Empty env vars keep the file value
+
+		cfg, err := LoadConfig("testdata/config.yaml")
+		require.NoError(t, err)
+
+		require.Equal(t, "env-secret", cfg.JWTSecret)
+		require.Equal(t, "9090", cfg.ServerPort)
+		require.False(t, cfg.Features.SoftDeleteTodos)
+		require.True(t, cfg.Features.StrictTodoListID)
+		require.Equal(t, "todo", cfg.DBName)
+		require.Equal(t, "localhost:5432", cfg.DBAddr)
+	})
+
+	t.Run("env only without a path", func(t *testing.T) {
+		t.Setenv("DB_ADDR", "db:5432")
+
+		cfg, err := LoadConfig("")
+		require.NoError(t, err)
+		require.Equal(t, "db:5432", cfg.DBAddr)
+	})
+
+	t.Run("unknown key", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		require.NoError(t, os.WriteFile(path, []byte("jwt_secrett: typo\n"), 0o600))
+
+		_, err := LoadConfig(path)
+		require.Error(t, err)
+	})
+
+	t.Run("unsupported format", func(t *testing.T) {
+		_, err := LoadConfig("testdata/config.toml")
+		require.ErrorIs(t, err, ErrUnsupportedConfigFile)
+	})
+
+	t.Run("invalid feature switch", func(t *testing.T) {
+		t.Setenv("SOFT_DELETE_TODOS", "yes")
+
+		_, err := LoadConfig("")
+		require.ErrorIs(t, err, ErrInvalidInput)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := LoadConfig("testdata/missing.yaml")
+		require.Error(t, err)
+	})
+}
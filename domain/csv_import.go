@@ -0,0 +1,17 @@
+package domain
+
+// CSVTodoRow is one todo of a CSV upload, as read from the file.
+// The service validates it, a bad row is reported and the other rows are still imported.
+type CSVTodoRow struct {
+	Line   int    // Line of the row in the file, the header is line 1
+	ListID string // Public id of the list the todo goes into
+	Title  string
+	Done   string // "true" or "false", empty means false
+}
+
+// CSVTodoResult reports what became of one CSV row.
+type CSVTodoResult struct {
+	Line int
+	ID   string // Public id of the created todo, empty when the row failed
+	Err  error  // Why the row was skipped, nil when the todo was created
+}
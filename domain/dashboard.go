@@ -0,0 +1,8 @@
+package domain
+
+// Dashboard is an overview of everything a user has.
+type Dashboard struct {
+	TotalLists int64
+	TotalTodos int64
+	DoneCount  int64
+}
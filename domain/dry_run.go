@@ -0,0 +1,7 @@
+package domain
+
+// DryRunResult reports what a destructive request would change, without changing anything.
+type DryRunResult struct {
+	Count int64    // Number of affected todos or lists
+	IDs   []string // Public ids of the affected todos or lists
+}
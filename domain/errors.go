@@ -26,6 +26,12 @@ var (
 
 	ErrListNotFound = errors.New("todo list not found")
 
+	// ErrUndoExpired is returned when a trashed todo is restored after its undo window
+	ErrUndoExpired = errors.New("the todo was deleted too long ago to restore")
+
+	// ErrVersionConflict is returned when a list update carries a stale version, someone else changed the list since it was read.
+	ErrVersionConflict = errors.New("the list was changed since it was read, reload it and try again")
+
 	// User-specific errors (add more as needed)
 	ErrUserNotFound       = errors.New("user not found")
 	ErrInvalidEmail       = errors.New("invalid email")
@@ -33,6 +39,25 @@ var (
 	ErrWeakPassword       = errors.New("password must be at least 8 characters")
 	ErrEmailExists        = errors.New("email already exists")
 	ErrInvalidCredentials = errors.New("invalid credentials")
+	ErrInvalidTimezone    = errors.New("timezone must be an IANA name like Europe/Budapest")
 
 	ErrInvalidToken = errors.New("invalid token claims")
+
+	// ErrWeakJWTSecret is returned at startup when JWT_SECRET is empty or too short to sign tokens safely.
+	ErrWeakJWTSecret = errors.New("jwt secret must be at least 16 characters")
+)
+
+// Warnings are returned next to a successful result, they don't fail the request.
+const (
+	// WarnDuplicateTitle is returned when a new todo has the same title as another todo in its list.
+	WarnDuplicateTitle = "a todo with this title already exists in the list"
+
+	// WarnLongTitle is returned when a todo title is close to the 255 character limit.
+	WarnLongTitle = "the title is close to the 255 character limit"
 )
+
+// MaxTitleLength is the longest todo title, in characters, the request validation accepts.
+const MaxTitleLength = 255
+
+// LongTitleWarnLength is the title length, in characters, from which a todo gets WarnLongTitle.
+const LongTitleWarnLength = 240
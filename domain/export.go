@@ -0,0 +1,44 @@
+package domain
+
+// ExportVersion is the format version of the backup document.
+const ExportVersion = 1
+
+// ExportDocument is the full backup of a user: the profile (never the password), every list and its todos.
+// GET /me/export writes it and POST /me/import reads it back, ids are the public ids and times are RFC3339.
+// The import ignores the ids and the user, the rows get new ids under the importing user.
+type ExportDocument struct {
+	Version    int          `json:"version"`
+	ExportedAt string       `json:"exported_at"`
+	User       UserDTO      `json:"user"`
+	Lists      []ExportList `json:"lists"`
+}
+
+type ExportList struct {
+	ID        string       `json:"id"`
+	Title     string       `json:"title"`
+	Color     string       `json:"color"`
+	Labels    []string     `json:"labels"`
+	CreatedAt string       `json:"created_at"`
+	UpdatedAt string       `json:"updated_at"`
+	Deleted   bool         `json:"deleted"`
+	Pinned    bool         `json:"pinned"`
+	Todos     []ExportTodo `json:"todos"`
+}
+
+type ExportTodo struct {
+	ID        string `json:"id"`
+	Title     string `json:"title"`
+	Done      bool   `json:"done"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+
+	CompletedAt string `json:"completed_at,omitempty"` // Only set on done todos
+	DueDate     string `json:"due_date,omitempty"`     // Only set on todos with a due date
+	Color       string `json:"color,omitempty"`        // Only set on todos with their own color, like #FF5733
+}
+
+// ImportResult counts what an import created.
+type ImportResult struct {
+	Lists int `json:"lists"`
+	Todos int `json:"todos"`
+}
@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Features are the on/off switches of the app, the single place to add a new one.
+// Each is set from its key in the config file or its env var, and starts from DefaultFeatures.
+type Features struct {
+	// Warn, but still create, when a new todo duplicates a title in its list
+	WarnDuplicateTodoTitles bool `yaml:"warn_duplicate_todo_titles"`
+
+	// Trash deleted todos (set deleted_at) instead of removing the row
+	SoftDeleteTodos bool `yaml:"soft_delete_todos"`
+
+	// Reject a todo create whose body list_id differs from the list in the path
+	StrictTodoListID bool `yaml:"strict_todo_list_id"`
+
+	// Cut a limit over MaxPageSize down to it, instead of answering 400
+	ClampPageSize bool `yaml:"clamp_page_size"`
+
+	// Send the underlying error in the body of a 500, for development only
+	DebugErrors bool `yaml:"debug_errors"`
+
+	// Log the JSON body of each request with the password fields masked, for debugging
+	LogRequestBodies bool `yaml:"log_request_bodies"`
+}
+
+// DefaultFeatures are the switches of a config that doesn't set them, every one is off.
+func DefaultFeatures() Features {
+	return Features{
+		WarnDuplicateTodoTitles: false,
+		SoftDeleteTodos:         false,
+		StrictTodoListID:        false,
+		ClampPageSize:           false,
+		DebugErrors:             false,
+		LogRequestBodies:        false,
+	}
+}
+
+// envVars maps the env var of each switch to its field
+func (f *Features) envVars() map[string]*bool {
+	return map[string]*bool{
+		"WARN_DUPLICATE_TODO_TITLES": &f.WarnDuplicateTodoTitles,
+		"SOFT_DELETE_TODOS":          &f.SoftDeleteTodos,
+		"STRICT_TODO_LIST_ID":        &f.StrictTodoListID,
+		"CLAMP_PAGE_SIZE":            &f.ClampPageSize,
+		"DEBUG_ERRORS":               &f.DebugErrors,
+		"LOG_REQUEST_BODIES":         &f.LogRequestBodies,
+	}
+}
+
+// overlayEnv sets the switches whose env var is set and not empty.
+// A value strconv.ParseBool doesn't know, like "yes", is an error rather than quietly off.
+func (f *Features) overlayEnv(lookup func(string) (string, bool)) error {
+	for name, field := range f.envVars() {
+		value, ok := lookup(name)
+		if !ok || value == "" {
+			continue
+		}
+
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("%s: %w: must be true or false, got %q", name, ErrInvalidInput, value)
+		}
+		*field = enabled
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeaturesOverlayEnv(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		want    Features
+		wantErr bool
+	}{
+		{name: "defaults", env: map[string]string{}, want: DefaultFeatures()},
+		{name: "empty keeps the default", env: map[string]string{"SOFT_DELETE_TODOS": ""}, want: DefaultFeatures()},
+		{
+			name: "overrides",
+			env: map[string]string{
+				"WARN_DUPLICATE_TODO_TITLES": "true",
+				"SOFT_DELETE_TODOS":          "1",
+				"STRICT_TODO_LIST_ID":        "TRUE",
+				"CLAMP_PAGE_SIZE":            "false",
+				"DEBUG_ERRORS":               "t",
+				"LOG_REQUEST_BODIES":         "true",
+			},
+			want: Features{WarnDuplicateTodoTitles: true, SoftDeleteTodos: true, StrictTodoListID: true, DebugErrors: true, LogRequestBodies: true},
+		},
+		{name: "not a bool", env: map[string]string{"DEBUG_ERRORS": "on"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lookup := func(name string) (string, bool) {
+				value, ok := tt.env[name]
+				return value, ok
+			}
+
+			features := DefaultFeatures()
+			err := features.overlayEnv(lookup)
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrInvalidInput)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, features)
+		})
+	}
+}
+
+func TestFeaturesOverlayEnvKeepsFileValues(t *testing.T) {
+	// A switch turned on in the config file stays on when its env var is not set
+	features := Features{SoftDeleteTodos: true}
+
+	err := features.overlayEnv(func(name string) (string, bool) {
+		if name == "STRICT_TODO_LIST_ID" {
+			return "true", true
+		}
+		return "", false
+	})
+	require.NoError(t, err)
+	require.Equal(t, Features{SoftDeleteTodos: true, StrictTodoListID: true}, features)
+}
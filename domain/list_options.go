@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ListOptions holds the paging, sorting and filtering of a list endpoint.
+// The zero value means no paging, the default order and no filters.
+// Filters combine with AND, the rows that pass are sorted (ties broken by id),
+// and only then Offset and Limit pick the page.
+type ListOptions struct {
+	Limit  int    // 0 means no limit
+	Offset int    // Number of rows to skip
+	Sort   string // Field to sort by, empty means the default sort
+	Order  string // "asc" or "desc", empty means ascending
+	Done   *bool  // Only todos with this done state, nil means all
+	Query  string // Only todos whose title contains it, ignoring case, empty means all
+
+	UpdatedSince *time.Time // Only rows updated at or after this time, nil means all
+}
+
+const (
+	SortOrderAsc  = "asc"
+	SortOrderDesc = "desc"
+)
+
+// SortFields are the fields both lists and todos can be sorted by.
+var SortFields = []string{"id", "title", "created_at"}
+
+// Sort is a sort field with its order, used as the default when a request has no sort.
+type Sort struct {
+	Field string
+	Order string
+}
+
+// ParseSort parses a sort setting like "title" or "created_at:desc".
+// An empty value is the zero Sort, which leaves the order to the store.
+func ParseSort(value string) (Sort, error) {
+	if value == "" {
+		return Sort{}, nil
+	}
+
+	field, order, _ := strings.Cut(value, ":")
+
+	if !slices.Contains(SortFields, field) {
+		return Sort{}, fmt.Errorf("%w: sort field %q must be one of %v", ErrInvalidInput, field, SortFields)
+	}
+
+	if order != "" && order != SortOrderAsc && order != SortOrderDesc {
+		return Sort{}, fmt.Errorf("%w: sort order %q must be asc or desc", ErrInvalidInput, order)
+	}
+
+	return Sort{Field: field, Order: order}, nil
+}
+
+// WithDefaultSort returns the options with the default sort applied when no sort was requested.
+// An explicitly requested order is kept.
+func (o ListOptions) WithDefaultSort(def Sort) ListOptions {
+	if o.Sort != "" {
+		return o
+	}
+
+	o.Sort = def.Field
+	if o.Order == "" {
+		o.Order = def.Order
+	}
+
+	return o
+}
@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSort(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    Sort
+		wantErr bool
+	}{
+		{value: "", want: Sort{}},
+		{value: "title", want: Sort{Field: "title"}},
+		{value: "created_at:desc", want: Sort{Field: "created_at", Order: "desc"}},
+		{value: "id:asc", want: Sort{Field: "id", Order: "asc"}},
+		{value: "password", wantErr: true},
+		{value: "title:sideways", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseSort(tt.value)
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrInvalidInput)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestWithDefaultSort(t *testing.T) {
+	def := Sort{Field: "created_at", Order: "desc"}
+
+	// No sort requested, the default is used
+	require.Equal(t, ListOptions{Sort: "created_at", Order: "desc"}, ListOptions{}.WithDefaultSort(def))
+
+	// Only the order requested, it is kept
+	require.Equal(t, ListOptions{Sort: "created_at", Order: "asc"}, ListOptions{Order: "asc"}.WithDefaultSort(def))
+
+	// Explicit sort wins
+	require.Equal(t, ListOptions{Sort: "title"}, ListOptions{Sort: "title"}.WithDefaultSort(def))
+}
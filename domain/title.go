@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"strings"
+	"unicode"
+)
+
+// ErrTitleControlChars is returned for a title with a control character other than a line break or a tab.
+// It is an ErrInvalidTitle, so errors.Is(err, ErrInvalidTitle) holds.
+var ErrTitleControlChars error = invalidTitleError("title must not contain control characters")
+
+// invalidTitleError is an ErrInvalidTitle with its own message
+type invalidTitleError string
+
+func (e invalidTitleError) Error() string { return string(e) }
+
+func (e invalidTitleError) Unwrap() error { return ErrInvalidTitle }
+
+// SanitizeTitle cleans a todo or list title before it is stored, titles end up in CSV and Markdown exports and in logs.
+// A run of line breaks and tabs, usually from pasting, becomes a single space, or nothing at either end.
+// Any other control character, like NUL or the escape of a terminal sequence, returns ErrTitleControlChars.
+// Printable unicode, like accents and emoji, is kept as is.
+func SanitizeTitle(title string) (string, error) {
+	var b strings.Builder
+	b.Grow(len(title))
+
+	pendingSpace := false
+	for _, r := range title {
+		switch {
+		case r == '\n' || r == '\r' || r == '\t':
+			pendingSpace = true
+			continue
+		case unicode.IsControl(r):
+			return "", ErrTitleControlChars
+		}
+
+		if pendingSpace && b.Len() > 0 {
+			b.WriteByte(' ')
+		}
+		pendingSpace = false
+		b.WriteRune(r)
+	}
+
+	return b.String(), nil
+}
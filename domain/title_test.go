@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSanitizeTitle(t *testing.T) {
+	tests := []struct {
+		name    string
+		title   string
+		want    string
+		wantErr bool
+	}{
+		{name: "plain", title: "Buy milk", want: "Buy milk"},
+		{name: "newline becomes a space", title: "Buy\nmilk", want: "Buy milk"},
+		{name: "a run of line breaks and tabs is one space", title: "Buy\r\n\tmilk", want: "Buy milk"},
+		{name: "line breaks at the ends are dropped", title: "\nBuy milk\r\n", want: "Buy milk"},
+		{name: "spaces are kept", title: "  Buy  milk ", want: "  Buy  milk "},
+		{name: "emoji", title: "Party 🎉 with 👩‍👩‍👧", want: "Party 🎉 with 👩‍👩‍👧"},
+		{name: "accents", title: "Bevásárlás: tej, kenyér", want: "Bevásárlás: tej, kenyér"},
+		{name: "only line breaks", title: "\n\n", want: ""},
+		{name: "nul", title: "Buy\x00milk", wantErr: true},
+		{name: "terminal escape", title: "\x1b[31mBuy milk", wantErr: true},
+		{name: "delete", title: "Buy milk\x7f", wantErr: true},
+		{name: "c1 control", title: "Buy\u0085milk", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SanitizeTitle(tt.title)
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrInvalidTitle)
+				require.EqualError(t, err, "title must not contain control characters")
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
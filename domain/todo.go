@@ -9,12 +9,26 @@ import (
 // It's like a Java class with fields, or a JS object.
 type Todo struct {
 	ID         int64
+	PublicID   string // UUID exposed in the API, ID stays internal
 	UserID     int64
 	TodoListID int64
 
 	Title     string
 	Done      bool
 	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	CompletedAt *time.Time // When the todo became done, nil while it is not done
+	DueDate     *time.Time // When the todo should be done by, nil when it has no deadline
+	DeletedAt   *time.Time // When the todo was moved to the trash, only set by the trash queries
+
+	Color     *string // Hex color like #FF5733, nil shows the color of the list
+	ListColor *string // Color of the list, set by the queries that read todos
+
+	Source TodoSource // How the todo was created
+
+	// TodoListPublicID is only set by queries that join the list, like Get and RecentlyCompleted
+	TodoListPublicID string
 }
 
 // Validate is a receiver method (attached to Todo).
@@ -26,3 +40,11 @@ func (t *Todo) Validate() error {
 	}
 	return nil
 }
+
+// DisplayColor is the color the todo is shown with, its own color or else the color of its list.
+func (t *Todo) DisplayColor() *string {
+	if t.Color != nil {
+		return t.Color
+	}
+	return t.ListColor
+}
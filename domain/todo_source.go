@@ -0,0 +1,28 @@
+package domain
+
+import "context"
+
+// TodoSource records how a todo was created, for analytics
+type TodoSource string
+
+const (
+	TodoSourceWeb       TodoSource = "web"       // Created in the web client
+	TodoSourceAPI       TodoSource = "api"       // Created through the API by any other client
+	TodoSourceImport    TodoSource = "import"    // Created by a CSV or backup import
+	TodoSourceRecurring TodoSource = "recurring" // Created from a recurring todo
+)
+
+type todoSourceKey struct{}
+
+// WithTodoSource returns a copy of ctx whose created todos get the given source
+func WithTodoSource(ctx context.Context, source TodoSource) context.Context {
+	return context.WithValue(ctx, todoSourceKey{}, source)
+}
+
+// TodoSourceFrom returns the source set by WithTodoSource, TodoSourceAPI when there is none
+func TodoSourceFrom(ctx context.Context) TodoSource {
+	if source, ok := ctx.Value(todoSourceKey{}).(TodoSource); ok {
+		return source
+	}
+	return TodoSourceAPI
+}
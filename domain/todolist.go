@@ -3,14 +3,41 @@ package domain
 import "time"
 
 type TodoList struct {
-	ID     int64
-	UserID int64
+	ID       int64
+	PublicID string // UUID exposed in the API, ID stays internal
+	UserID   int64
 
 	Title     string
 	Color     string
 	Labels    []string
 	CreatedAt time.Time
+	UpdatedAt time.Time
 	Deleted   bool
+	Pinned    bool
+
+	// Version is bumped by every change, an update must carry the version it was based on
+	Version int64
+
+	// NextDue is the earliest upcoming due date of the list's open todos, nil when none is due.
+	// Only set by List.
+	NextDue *time.Time
 
 	Items []Todo
 }
+
+// PercentComplete returns the share of done todos as a percentage (0-100).
+// An empty list is 0% complete.
+func PercentComplete(todos []*Todo) float64 {
+	if len(todos) == 0 {
+		return 0
+	}
+
+	done := 0
+	for _, todo := range todos {
+		if todo.Done {
+			done++
+		}
+	}
+
+	return float64(done) / float64(len(todos)) * 100
+}
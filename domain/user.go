@@ -1,10 +1,16 @@
 package domain
 
+import "time"
+
+// DefaultTimezone is the timezone of a user who hasn't set one
+const DefaultTimezone = "UTC"
+
 type User struct {
 	ID       int64
 	Name     string
 	Email    string
 	Password string
+	Timezone string // IANA name, like Europe/Budapest
 }
 
 // Custom errors for user validation, need to develop further...., its just a start
@@ -15,3 +21,23 @@ func (u *User) Validate() error {
 	// Add more checks (e.g., password strength)
 	return nil
 }
+
+// Location returns the timezone of the user, UTC when it is unset or unknown
+func (u *User) Location() *time.Location {
+	if loc, err := time.LoadLocation(u.Timezone); err == nil && u.Timezone != "" {
+		return loc
+	}
+	return time.UTC
+}
+
+// ValidateTimezone checks that name is an IANA timezone name.
+// "Local" is rejected, it means the zone of the server and not of the user.
+func ValidateTimezone(name string) error {
+	if name == "" || name == "Local" {
+		return ErrInvalidTimezone
+	}
+	if _, err := time.LoadLocation(name); err != nil {
+		return ErrInvalidTimezone
+	}
+	return nil
+}
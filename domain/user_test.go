@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateTimezone(t *testing.T) {
+	t.Parallel()
+
+	for _, name := range []string{"UTC", "Europe/Budapest", "America/New_York"} {
+		require.NoError(t, ValidateTimezone(name), name)
+	}
+
+	for _, name := range []string{"", "Local", "Mars/Olympus_Mons", "CEST"} {
+		require.ErrorIs(t, ValidateTimezone(name), ErrInvalidTimezone, name)
+	}
+}
+
+func TestUserLocation(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, time.UTC, (&User{}).Location())
+	require.Equal(t, time.UTC, (&User{Timezone: "Mars/Olympus_Mons"}).Location())
+
+	// Late evening UTC is already the next day in Budapest
+	at := time.Date(2024, 1, 1, 23, 30, 0, 0, time.UTC)
+	local := at.In((&User{Timezone: "Europe/Budapest"}).Location())
+	require.Equal(t, 2, local.Day())
+}
@@ -1,24 +1,40 @@
 package domain
 
+import "time"
+
 // TodoDTO is a Data Transfer Object for Todo.
 // It's used to transfer data in a format suitable for APIs (like JSON).
 // Similar to a Java DTO class or a JS object used in APIs.
 
 type ErrorResponse struct {
 	Error string `json:"error"`
+
+	// Detail is the underlying error of a 500, only sent with the DebugErrors config
+	Detail string `json:"detail,omitempty"`
 }
 
 // TodoList
 type TodoListDTO struct {
-	ID     int64 `json:"id"`
-	UserID int64 `json:"user_id"`
+	ID     string `json:"id"` // Public id (UUID), the integer id is never serialized
+	UserID int64  `json:"user_id"`
 
 	Title     string    `json:"title"`
 	Color     *string   `json:"color,omitempty"`
 	Labels    []string  `json:"labels,omitempty"`
 	CreatedAt string    `json:"created_at"`
+	UpdatedAt string    `json:"updated_at"`
 	Deleted   bool      `json:"deleted"`
+	Pinned    bool      `json:"pinned"`
 	Items     []TodoDTO `json:"items,omitempty"`
+
+	// Version is also sent as the ETag header, PUT /lists/{id} needs it back in If-Match.
+	Version int64 `json:"version"`
+
+	// PercentComplete is computed from Items, so it is only set when the items are loaded.
+	PercentComplete *float64 `json:"percent_complete,omitempty"`
+
+	// NextDue is RFC3339, the earliest upcoming due date of the open todos. Only sent by GET /lists.
+	NextDue *string `json:"next_due,omitempty"`
 }
 
 type CreateTodoListRequestDTO struct {
@@ -36,28 +52,128 @@ type UpdateTodoListRequestDTO struct {
 
 // TODO
 type TodoDTO struct {
-	ID         int64  `json:"id"`
+	ID         string `json:"id"` // Public id (UUID), the integer id is never serialized
 	UserID     int64  `json:"user_id"`
-	TodoListID int64  `json:"todolist_id"`
+	TodoListID string `json:"todolist_id"` // Public id of the list
 	Title      string `json:"title"`
 	Done       bool   `json:"done"`
 	CreatedAt  string `json:"created_at"`
+	UpdatedAt  string `json:"updated_at"`
+
+	// CompletedAt is when the todo became done, empty while it is not done.
+	CompletedAt string `json:"completed_at,omitempty"`
+
+	// DueDate is RFC3339, left out when the todo has no due date.
+	DueDate *string `json:"due_date,omitempty"`
+
+	// Color is the color of the todo, or the color of its list when the todo has none.
+	Color *string `json:"color,omitempty"`
+
+	// Source is how the todo was created: web, api, import or recurring.
+	Source string `json:"source,omitempty"`
+
+	// Warnings are non-fatal notes about the request, like a duplicate title on create or update.
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// NewTodoDTO maps a todo to its response, with the times as RFC3339.
+// The list id is todo.TodoListPublicID, callers that only know the list from the path set it themselves.
+func NewTodoDTO(todo *Todo) TodoDTO {
+	dto := TodoDTO{
+		ID:         todo.PublicID,
+		UserID:     todo.UserID,
+		TodoListID: todo.TodoListPublicID,
+		Title:      todo.Title,
+		Done:       todo.Done,
+		CreatedAt:  todo.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:  todo.UpdatedAt.Format(time.RFC3339),
+		Color:      todo.DisplayColor(),
+		Source:     string(todo.Source),
+	}
+
+	if todo.CompletedAt != nil {
+		dto.CompletedAt = todo.CompletedAt.Format(time.RFC3339)
+	}
+
+	if todo.DueDate != nil {
+		dueDate := todo.DueDate.Format(time.RFC3339)
+		dto.DueDate = &dueDate
+	}
+
+	return dto
 }
 
 type CreateTodoDTO struct {
-	Title string `json:"title" validate:"required,min=1,max=255"`
+	Title   string     `json:"title" validate:"required,min=1,max=255"`
+	DueDate *time.Time `json:"due_date,omitempty"`                            // RFC3339, optional
+	Color   *string    `json:"color,omitempty" validate:"omitempty,hexcolor"` // Like #FF5733, optional
+
+	// ListID is optional, the list in the path wins unless the strict list id check rejects a mismatch
+	ListID string `json:"list_id,omitempty"`
 }
 
 type UpdateTodoDTO struct {
-	Title string `json:"title" validate:"required,min=1,max=255"`
-	Done  bool   `json:"done" validate:"required"`
+	Title   string     `json:"title" validate:"required,min=1,max=255"`
+	Done    bool       `json:"done" validate:"required"`
+	DueDate *time.Time `json:"due_date,omitempty"`                            // RFC3339, leaving it out clears the due date
+	Color   *string    `json:"color,omitempty" validate:"omitempty,hexcolor"` // Like #FF5733, leaving it out clears the color
+}
+
+// CloneTodoDTO is the optional body of POST /todos/{id}/clone
+type CloneTodoDTO struct {
+	Title string `json:"title,omitempty" validate:"omitempty,max=255"` // Empty keeps the title of the original
+}
+
+// BatchGetTodosDTO is the body of POST /todos/batch-get
+type BatchGetTodosDTO struct {
+	IDs []string `json:"ids"` // Public ids of the todos
+}
+
+type EmptyDoneResponseDTO struct {
+	Count int64 `json:"count"`
+}
+
+type ToggleAllRequestDTO struct {
+	Done *bool `json:"done" validate:"required"` // A pointer, so a missing done is an error and not false
+}
+
+type ToggleAllResponseDTO struct {
+	Count int64 `json:"count"` // Todos that changed state
+}
+
+// CSVImportResponseDTO is the answer of POST /todos/import.csv, one result per data row of the file
+type CSVImportResponseDTO struct {
+	Created int                  `json:"created"`
+	Failed  int                  `json:"failed"`
+	Rows    []CSVImportRowResult `json:"rows"`
+}
+
+type CSVImportRowResult struct {
+	Line  int    `json:"line"`
+	ID    string `json:"id,omitempty"`    // Public id of the created todo
+	Error string `json:"error,omitempty"` // Why the row was skipped
+}
+
+// DryRunDTO is the answer of a destructive endpoint called with ?dry_run=true
+type DryRunDTO struct {
+	DryRun bool     `json:"dry_run"`
+	Count  int64    `json:"count"`
+	IDs    []string `json:"ids"` // Public ids of what would be deleted
+}
+
+// Dashboard
+type DashboardDTO struct {
+	TotalLists int64 `json:"total_lists"`
+	TotalTodos int64 `json:"total_todos"`
+	DoneCount  int64 `json:"done_count"`
 }
 
 // User
 type UserDTO struct {
-	ID    int64  `json:"id"`
-	Name  string `json:"name"`
-	Email string `json:"email"`
+	ID       int64  `json:"id"`
+	Name     string `json:"name"`
+	Email    string `json:"email"`
+	Timezone string `json:"timezone,omitempty"` // IANA name, UTC unless the user set one
 }
 
 type CreateUserRequestDTO struct {
@@ -66,6 +182,15 @@ type CreateUserRequestDTO struct {
 	Password string `json:"password" validate:"required,min=6,max=255,containsany=0123456789,containsany=ABCDEFGHIJKLMNOPQRSTUVWXYZ"`
 }
 
+// UpdateProfileRequestDTO is the body of PUT /users/me
+type UpdateProfileRequestDTO struct {
+	Name  string `json:"name" validate:"required,min=2,max=255"`
+	Email string `json:"email" validate:"required,email,max=255"`
+
+	// Timezone is an IANA name like Europe/Budapest, empty keeps the current one
+	Timezone string `json:"timezone,omitempty"`
+}
+
 type LoginRequest struct {
 	Email    string `json:"email"`
 	Password string `json:"password"`
@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTodoDTO(t *testing.T) {
+	created := time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC)
+	completed := created.Add(time.Hour)
+	due := created.Add(24 * time.Hour)
+	listColor := "#FF5733"
+
+	tests := []struct {
+		name string
+		todo *Todo
+		want TodoDTO
+	}{
+		{
+			name: "open todo without due date",
+			todo: &Todo{PublicID: "todo-1", UserID: 1, TodoListPublicID: "list-1", Title: "Milk", CreatedAt: created, UpdatedAt: created, Source: TodoSourceWeb},
+			want: TodoDTO{ID: "todo-1", UserID: 1, TodoListID: "list-1", Title: "Milk", CreatedAt: "2024-01-01T12:00:00Z", UpdatedAt: "2024-01-01T12:00:00Z", Source: "web"},
+		},
+		{
+			name: "done todo with due date and the list color",
+			todo: &Todo{PublicID: "todo-2", UserID: 1, TodoListPublicID: "list-1", Title: "Bread", Done: true, CreatedAt: created, UpdatedAt: completed, CompletedAt: &completed, DueDate: &due, ListColor: &listColor},
+			want: TodoDTO{ID: "todo-2", UserID: 1, TodoListID: "list-1", Title: "Bread", Done: true, CreatedAt: "2024-01-01T12:00:00Z", UpdatedAt: "2024-01-01T13:00:00Z", CompletedAt: "2024-01-01T13:00:00Z", DueDate: ptr("2024-01-02T12:00:00Z"), Color: &listColor},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, NewTodoDTO(tt.todo))
+		})
+	}
+}
+
+func ptr(s string) *string {
+	return &s
+}
@@ -0,0 +1,3 @@
+-- Remove pinned column
+ALTER TABLE todolists
+DROP COLUMN pinned;
@@ -0,0 +1,3 @@
+-- Add pinned flag, pinned lists are listed first
+ALTER TABLE todolists
+ADD COLUMN pinned BOOL NOT NULL DEFAULT false;
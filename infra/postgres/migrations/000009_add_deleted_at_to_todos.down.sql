@@ -0,0 +1,3 @@
+-- Remove deleted_at column
+ALTER TABLE todos
+DROP COLUMN deleted_at;
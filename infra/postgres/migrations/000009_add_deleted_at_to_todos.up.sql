@@ -0,0 +1,3 @@
+-- Add deleted_at for soft-deleted (trashed) todos
+ALTER TABLE todos
+ADD COLUMN deleted_at TIMESTAMP;
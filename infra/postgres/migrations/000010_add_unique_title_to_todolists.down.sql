@@ -0,0 +1,2 @@
+-- Remove the unique title index
+DROP INDEX IF EXISTS todolists_user_id_title_key;
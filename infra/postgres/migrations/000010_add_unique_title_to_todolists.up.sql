@@ -0,0 +1,17 @@
+-- A user can only have one open list with a given title, lists in the bin don't count.
+-- Existing duplicates are not renamed, the migration stops until they are resolved by hand.
+DO $$
+BEGIN
+    IF EXISTS (
+        SELECT 1
+        FROM todolists
+        WHERE NOT deleted
+        GROUP BY user_id, title
+        HAVING COUNT(*) > 1
+    ) THEN
+        RAISE EXCEPTION 'todolists has open lists with the same user_id and title, rename or delete them before migrating';
+    END IF;
+END
+$$;
+
+CREATE UNIQUE INDEX todolists_user_id_title_key ON todolists (user_id, title) WHERE NOT deleted;
@@ -0,0 +1,3 @@
+-- Remove updated_at column
+ALTER TABLE todos
+DROP COLUMN updated_at;
@@ -0,0 +1,6 @@
+-- Remove public id columns
+ALTER TABLE todos
+DROP COLUMN public_id;
+
+ALTER TABLE todolists
+DROP COLUMN public_id;
@@ -0,0 +1,12 @@
+-- Public ids are exposed in the API instead of the sequential integer ids
+ALTER TABLE todolists
+ADD COLUMN public_id UUID NOT NULL DEFAULT gen_random_uuid();
+
+ALTER TABLE todolists
+ADD CONSTRAINT todolists_public_id_key UNIQUE (public_id);
+
+ALTER TABLE todos
+ADD COLUMN public_id UUID NOT NULL DEFAULT gen_random_uuid();
+
+ALTER TABLE todos
+ADD CONSTRAINT todos_public_id_key UNIQUE (public_id);
@@ -0,0 +1,5 @@
+-- Add updated_at, existing lists start out as last updated when created
+ALTER TABLE todolists
+ADD COLUMN updated_at TIMESTAMP NOT NULL DEFAULT now();
+
+UPDATE todolists SET updated_at = created_at WHERE created_at IS NOT NULL;
@@ -0,0 +1,3 @@
+-- Remove completed_at column
+ALTER TABLE todos
+DROP COLUMN completed_at;
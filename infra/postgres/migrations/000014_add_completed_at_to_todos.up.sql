@@ -0,0 +1,5 @@
+-- Add completed_at, set while a todo is done. Done todos start out as completed when last updated
+ALTER TABLE todos
+ADD COLUMN completed_at TIMESTAMP NULL;
+
+UPDATE todos SET completed_at = updated_at WHERE done = true;
@@ -0,0 +1,3 @@
+-- Remove version column
+ALTER TABLE todolists
+DROP COLUMN version;
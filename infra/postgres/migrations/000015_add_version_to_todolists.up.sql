@@ -0,0 +1,3 @@
+-- Add version for optimistic concurrency, every change of a list bumps it
+ALTER TABLE todolists
+ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
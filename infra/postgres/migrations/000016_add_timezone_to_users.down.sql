@@ -0,0 +1,3 @@
+-- Remove timezone column
+ALTER TABLE users
+DROP COLUMN timezone;
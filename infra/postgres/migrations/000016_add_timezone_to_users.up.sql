@@ -0,0 +1,3 @@
+-- Add the IANA timezone of the user, the day boundaries of "today" are computed in it
+ALTER TABLE users
+ADD COLUMN timezone VARCHAR(64) NOT NULL DEFAULT 'UTC';
@@ -0,0 +1,3 @@
+-- Remove due_date column
+ALTER TABLE todos
+DROP COLUMN due_date;
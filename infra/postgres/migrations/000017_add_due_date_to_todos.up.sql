@@ -0,0 +1,3 @@
+-- Add an optional due date, overdue todos are the not done ones past it
+ALTER TABLE todos
+ADD COLUMN due_date TIMESTAMPTZ NULL;
@@ -0,0 +1,3 @@
+-- Remove color column
+ALTER TABLE todos
+DROP COLUMN color;
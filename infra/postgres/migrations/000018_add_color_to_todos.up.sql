@@ -0,0 +1,3 @@
+-- Add an optional color, a todo without one shows the color of its list
+ALTER TABLE todos
+ADD COLUMN color VARCHAR(7) NULL;
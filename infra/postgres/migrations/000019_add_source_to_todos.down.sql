@@ -0,0 +1,3 @@
+-- Remove source column
+ALTER TABLE todos
+DROP COLUMN source;
@@ -0,0 +1,3 @@
+-- Record how a todo was created: web, api, import or recurring
+ALTER TABLE todos
+ADD COLUMN source VARCHAR(16) NOT NULL DEFAULT 'api';
@@ -0,0 +1,3 @@
+-- Store deleted_at without time zone again
+ALTER TABLE todos
+ALTER COLUMN deleted_at TYPE TIMESTAMP;
@@ -0,0 +1,3 @@
+-- Store deleted_at with its time zone, the undo window compares it to the service clock
+ALTER TABLE todos
+ALTER COLUMN deleted_at TYPE TIMESTAMPTZ;
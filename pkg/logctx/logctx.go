@@ -0,0 +1,29 @@
+// Package logctx hands out loggers that carry the request scoped values of a context
+package logctx
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// RequestIDKey is the log attribute holding the request id
+const RequestIDKey = "request_id"
+
+// WithRequestID returns a copy of ctx carrying the given request id
+// The router sets it through middleware.RequestID, this is for callers outside of it
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, middleware.RequestIDKey, requestID)
+}
+
+// From returns the default logger, with the request id of ctx attached when there is one
+func From(ctx context.Context) *slog.Logger {
+	logger := slog.Default()
+
+	if requestID := middleware.GetReqID(ctx); requestID != "" {
+		return logger.With(slog.String(RequestIDKey, requestID))
+	}
+
+	return logger
+}
@@ -0,0 +1,54 @@
+package logctx
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFrom(t *testing.T) {
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		wantID    string
+		wantField bool
+	}{
+		{
+			name:      "with request id",
+			ctx:       WithRequestID(context.Background(), "host/abc-000001"),
+			wantID:    "host/abc-000001",
+			wantField: true,
+		},
+		{
+			name:      "without request id",
+			ctx:       context.Background(),
+			wantField: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			prev := slog.Default()
+			slog.SetDefault(slog.New(slog.NewJSONHandler(&buf, nil)))
+			t.Cleanup(func() { slog.SetDefault(prev) })
+
+			From(tt.ctx).Info("hello")
+
+			var line map[string]any
+			require.NoError(t, json.Unmarshal(buf.Bytes(), &line))
+
+			id, ok := line[RequestIDKey]
+			assert.Equal(t, tt.wantField, ok)
+			if tt.wantField {
+				assert.Equal(t, tt.wantID, id)
+			}
+		})
+	}
+}
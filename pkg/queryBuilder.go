@@ -2,12 +2,19 @@ package pkg
 
 import (
 	"bytes"
+	"errors"
+	"fmt"
 	"io/fs"
 	"path/filepath"
 	"strings"
 	"text/template"
 )
 
+// ErrNoQueryTemplate is returned for a query name that has no template, and by PrepareQuery for a nil template.
+var ErrNoQueryTemplate = errors.New("missing query template")
+
+// BuildQueries parses every template file of dir, keyed by the file name up to the first dot.
+// A file that doesn't parse is an error, so a broken query fails at startup and not on first use.
 func BuildQueries(files fs.ReadDirFS, dir string) (map[string]*template.Template, error) {
 	queries := make(map[string]*template.Template)
 
@@ -23,7 +30,7 @@ func BuildQueries(files fs.ReadDirFS, dir string) (map[string]*template.Template
 
 		pt, err := template.ParseFS(files, filepath.Join(dir, tmpf.Name()))
 		if err != nil {
-			continue
+			return nil, fmt.Errorf("failed to parse query template %s: %w", tmpf.Name(), err)
 		}
 
 		queries[strings.Split(tmpf.Name(), ".")[0]] = pt
@@ -32,7 +39,32 @@ func BuildQueries(files fs.ReadDirFS, dir string) (map[string]*template.Template
 	return queries, nil
 }
 
+// LookupQuery returns the template of the named query.
+// An unknown name, from a typo or a template file missing from the embed, is an error that names the query.
+func LookupQuery(queries map[string]*template.Template, name string) (*template.Template, error) {
+	queryTpl, ok := queries[name]
+	if !ok || queryTpl == nil {
+		return nil, fmt.Errorf("query template %q not found: %w", name, ErrNoQueryTemplate)
+	}
+
+	return queryTpl, nil
+}
+
+// PrepareNamedQuery looks up the named query and renders it with params.
+func PrepareNamedQuery(queries map[string]*template.Template, name string, params any) (string, error) {
+	queryTpl, err := LookupQuery(queries, name)
+	if err != nil {
+		return "", err
+	}
+
+	return PrepareQuery(queryTpl, params)
+}
+
 func PrepareQuery(queryTpl *template.Template, params any) (string, error) {
+	if queryTpl == nil {
+		return "", ErrNoQueryTemplate
+	}
+
 	var buff bytes.Buffer
 
 	err := queryTpl.Execute(&buff, params)
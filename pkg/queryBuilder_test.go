@@ -0,0 +1,110 @@
+package pkg
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildQueries(t *testing.T) {
+	tests := []struct {
+		name      string
+		files     fstest.MapFS
+		wantNames []string
+		wantErr   bool
+	}{
+		{
+			name: "keyed by the name before the first dot",
+			files: fstest.MapFS{
+				"queries/list_todo.sql.tpl":   {Data: []byte("SELECT * FROM todos")},
+				"queries/create_todo.sql.tpl": {Data: []byte("INSERT INTO todos")},
+			},
+			wantNames: []string{"list_todo", "create_todo"},
+		},
+		{
+			name: "directories are skipped",
+			files: fstest.MapFS{
+				"queries/get_todo.sql.tpl":       {Data: []byte("SELECT * FROM todos")},
+				"queries/nested/other.sql.tpl":   {Data: []byte("SELECT 1")},
+				"queries/nested/another.sql.tpl": {Data: []byte("SELECT 2")},
+			},
+			wantNames: []string{"get_todo"},
+		},
+		{
+			name: "a template that doesn't parse is an error",
+			files: fstest.MapFS{
+				"queries/broken.sql.tpl": {Data: []byte("SELECT {{ if .x }}")},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "missing directory",
+			files:   fstest.MapFS{},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queries, err := BuildQueries(tt.files, "queries")
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Len(t, queries, len(tt.wantNames))
+			for _, name := range tt.wantNames {
+				assert.Contains(t, queries, name)
+			}
+		})
+	}
+}
+
+func TestPrepareQuery(t *testing.T) {
+	queries, err := BuildQueries(fstest.MapFS{
+		"queries/list.sql.tpl": {Data: []byte("SELECT * FROM todos WHERE user_id = :user_id{{ if .limit }} LIMIT :limit{{ end }}")},
+	}, "queries")
+	require.NoError(t, err)
+
+	t.Run("renders the template params", func(t *testing.T) {
+		query, err := PrepareQuery(queries["list"], map[string]any{"limit": true})
+		require.NoError(t, err)
+		assert.Equal(t, "SELECT * FROM todos WHERE user_id = :user_id LIMIT :limit", query)
+	})
+
+	t.Run("nil params leave the optional parts out", func(t *testing.T) {
+		query, err := PrepareQuery(queries["list"], nil)
+		require.NoError(t, err)
+		assert.Equal(t, "SELECT * FROM todos WHERE user_id = :user_id", query)
+	})
+
+	t.Run("unknown query name is an error, not a panic", func(t *testing.T) {
+		_, err := PrepareQuery(queries["no_such_query"], nil)
+		require.ErrorIs(t, err, ErrNoQueryTemplate)
+	})
+}
+
+func TestLookupQuery(t *testing.T) {
+	queries, err := BuildQueries(fstest.MapFS{
+		"queries/list.sql.tpl": {Data: []byte("SELECT * FROM todos")},
+	}, "queries")
+	require.NoError(t, err)
+
+	t.Run("known name", func(t *testing.T) {
+		query, err := PrepareNamedQuery(queries, "list", nil)
+		require.NoError(t, err)
+		assert.Equal(t, "SELECT * FROM todos", query)
+	})
+
+	t.Run("missing name", func(t *testing.T) {
+		_, err := LookupQuery(queries, "lsit")
+		require.ErrorIs(t, err, ErrNoQueryTemplate)
+		assert.Contains(t, err.Error(), `query template "lsit" not found`)
+
+		_, err = PrepareNamedQuery(queries, "lsit", nil)
+		require.ErrorIs(t, err, ErrNoQueryTemplate)
+	})
+}
@@ -0,0 +1,11 @@
+package dashboard
+
+type DashboardService struct {
+	Store DashboardStore
+}
+
+func NewDashboardService(store DashboardStore) *DashboardService {
+	return &DashboardService{
+		Store: store,
+	}
+}
@@ -0,0 +1,11 @@
+package dashboard
+
+import (
+	"context"
+
+	"github.com/macesz/todo-go/domain"
+)
+
+type DashboardStore interface {
+	Get(ctx context.Context, userID int64) (*domain.Dashboard, error)
+}
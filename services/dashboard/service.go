@@ -0,0 +1,18 @@
+package dashboard
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/macesz/todo-go/domain"
+)
+
+// GetDashboard returns the overview counts of the user's lists and todos
+func (s *DashboardService) GetDashboard(ctx context.Context, userID int64) (*domain.Dashboard, error) {
+	dashboard, err := s.Store.Get(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dashboard: %w", err)
+	}
+
+	return dashboard, nil
+}
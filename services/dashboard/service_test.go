@@ -0,0 +1,73 @@
+package dashboard
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/macesz/todo-go/domain"
+	"github.com/macesz/todo-go/services/dashboard/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetDashboard(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		ctx    context.Context
+		userID int64
+	}
+
+	tests := []struct {
+		name      string
+		args      args
+		want      *domain.Dashboard
+		wantErr   bool
+		initMocks func(tt *testing.T, ta *args, s *DashboardService)
+	}{
+		{
+			name: "success",
+			args: args{ctx: context.Background(), userID: 1},
+			want: &domain.Dashboard{TotalLists: 2, TotalTodos: 5, DoneCount: 3},
+			initMocks: func(tt *testing.T, ta *args, s *DashboardService) {
+				store := mocks.NewDashboardStore(tt)
+				tt.Cleanup(func() { store.AssertExpectations(tt) })
+
+				store.On("Get", ta.ctx, ta.userID).Return(&domain.Dashboard{TotalLists: 2, TotalTodos: 5, DoneCount: 3}, nil).Once()
+
+				s.Store = store
+			},
+		},
+		{
+			name:    "store error",
+			args:    args{ctx: context.Background(), userID: 1},
+			wantErr: true,
+			initMocks: func(tt *testing.T, ta *args, s *DashboardService) {
+				store := mocks.NewDashboardStore(tt)
+				tt.Cleanup(func() { store.AssertExpectations(tt) })
+
+				store.On("Get", ta.ctx, ta.userID).Return(nil, errors.New("db error")).Once()
+
+				s.Store = store
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := &DashboardService{}
+
+			tc.initMocks(t, &tc.args, s)
+
+			got, err := s.GetDashboard(tc.args.ctx, tc.args.userID)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
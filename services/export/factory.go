@@ -0,0 +1,24 @@
+package export
+
+import "github.com/macesz/todo-go/domain"
+
+type ExportService struct {
+	Users UserStore
+	Lists TodoListStore
+	Todos TodoStore
+
+	Importer ImportStore
+
+	// Clock stamps exported_at, nil means the system clock
+	Clock domain.Clock
+}
+
+func NewExportService(users UserStore, lists TodoListStore, todos TodoStore, importer ImportStore, clock domain.Clock) *ExportService {
+	return &ExportService{
+		Users:    users,
+		Lists:    lists,
+		Todos:    todos,
+		Importer: importer,
+		Clock:    clock,
+	}
+}
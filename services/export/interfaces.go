@@ -0,0 +1,26 @@
+package export
+
+import (
+	"context"
+	"time"
+
+	"github.com/macesz/todo-go/domain"
+)
+
+// The export only reads, so it gets the read side of the existing stores, the import writes through ImportStore
+
+type UserStore interface {
+	GetUser(ctx context.Context, id int64) (*domain.User, error)
+}
+
+type TodoListStore interface {
+	List(ctx context.Context, userID int64, opts domain.ListOptions, now time.Time) ([]*domain.TodoList, error)
+}
+
+type TodoStore interface {
+	List(ctx context.Context, userID int64, todolistID int64, opts domain.ListOptions) ([]*domain.Todo, error)
+}
+
+type ImportStore interface {
+	Import(ctx context.Context, userID int64, lists []*domain.TodoList, replace bool) error
+}
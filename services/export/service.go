@@ -0,0 +1,293 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/macesz/todo-go/domain"
+	"github.com/macesz/todo-go/pkg/logctx"
+)
+
+// ExportUser writes the user's backup document (domain.ExportDocument) to w.
+// The profile and the lists are read up front, so a failure there returns before anything is written.
+// The todos are then read and written one list at a time, a large account is never held in memory at once.
+func (s *ExportService) ExportUser(ctx context.Context, userID int64, w io.Writer) error {
+	user, err := s.Users.GetUser(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	lists, err := s.Lists.List(ctx, userID, domain.ListOptions{}, s.now())
+	if err != nil {
+		return fmt.Errorf("failed to list todo lists: %w", err)
+	}
+
+	ew := &errWriter{w: w}
+
+	ew.write(`{"version":`)
+	ew.encode(domain.ExportVersion)
+	ew.write(`,"exported_at":`)
+	ew.encode(s.now().UTC().Format(time.RFC3339))
+	ew.write(`,"user":`)
+	ew.encode(domain.UserDTO{ID: user.ID, Name: user.Name, Email: user.Email})
+	ew.write(`,"lists":[`)
+
+	for i, list := range lists {
+		if ew.err != nil {
+			break
+		}
+
+		todos, err := s.Todos.List(ctx, userID, list.ID, domain.ListOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to list todos: %w", err)
+		}
+
+		if i > 0 {
+			ew.write(",")
+		}
+		ew.encode(toExportList(list, todos))
+	}
+
+	ew.write("]}")
+
+	if ew.err != nil {
+		return fmt.Errorf("failed to write export: %w", ew.err)
+	}
+
+	return nil
+}
+
+func (s *ExportService) now() time.Time {
+	if s.Clock == nil {
+		return time.Now()
+	}
+
+	return s.Clock.Now()
+}
+
+func toExportList(list *domain.TodoList, todos []*domain.Todo) domain.ExportList {
+	exportTodos := make([]domain.ExportTodo, 0, len(todos))
+	for _, todo := range todos {
+		exportTodo := domain.ExportTodo{
+			ID:        todo.PublicID,
+			Title:     todo.Title,
+			Done:      todo.Done,
+			CreatedAt: todo.CreatedAt.Format(time.RFC3339),
+			UpdatedAt: todo.UpdatedAt.Format(time.RFC3339),
+		}
+
+		if todo.CompletedAt != nil {
+			exportTodo.CompletedAt = todo.CompletedAt.Format(time.RFC3339)
+		}
+
+		if todo.DueDate != nil {
+			exportTodo.DueDate = todo.DueDate.Format(time.RFC3339)
+		}
+
+		// The own color only, a todo without one keeps showing the list color after the import
+		if todo.Color != nil {
+			exportTodo.Color = *todo.Color
+		}
+
+		exportTodos = append(exportTodos, exportTodo)
+	}
+
+	return domain.ExportList{
+		ID:        list.PublicID,
+		Title:     list.Title,
+		Color:     list.Color,
+		Labels:    list.Labels,
+		CreatedAt: list.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: list.UpdatedAt.Format(time.RFC3339),
+		Deleted:   list.Deleted,
+		Pinned:    list.Pinned,
+		Todos:     exportTodos,
+	}
+}
+
+// errWriter keeps the first write error, so the document can be written without checking every call
+type errWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (ew *errWriter) write(s string) {
+	if ew.err != nil {
+		return
+	}
+
+	_, ew.err = io.WriteString(ew.w, s)
+}
+
+func (ew *errWriter) encode(v any) {
+	if ew.err != nil {
+		return
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		ew.err = err
+		return
+	}
+
+	_, ew.err = ew.w.Write(data)
+}
+
+// ImportUser restores a backup document for the user, every list and todo is created anew.
+// With replace the user's current lists and todos are deleted first, all in one transaction.
+// An invalid document returns domain.ErrInvalidInput, a list title the user already has domain.ErrDuplicate.
+func (s *ExportService) ImportUser(ctx context.Context, userID int64, doc *domain.ExportDocument, replace bool) (*domain.ImportResult, error) {
+	lists, err := fromExportDocument(doc, s.now())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.Importer.Import(ctx, userID, lists, replace); err != nil {
+		if errors.Is(err, domain.ErrDuplicate) {
+			return nil, err
+		}
+		logctx.From(ctx).Error("failed to import backup", "user_id", userID, "error", err)
+		return nil, fmt.Errorf("failed to import backup: %w", err)
+	}
+
+	result := &domain.ImportResult{Lists: len(lists)}
+	for _, list := range lists {
+		result.Todos += len(list.Items)
+	}
+
+	return result, nil
+}
+
+// fromExportDocument validates the document and turns it into lists with their todos as Items.
+// Missing timestamps become now.
+func fromExportDocument(doc *domain.ExportDocument, now time.Time) ([]*domain.TodoList, error) {
+	if doc.Version != domain.ExportVersion {
+		return nil, fmt.Errorf("%w: version must be %d", domain.ErrInvalidInput, domain.ExportVersion)
+	}
+
+	lists := make([]*domain.TodoList, 0, len(doc.Lists))
+	titles := make(map[string]bool, len(doc.Lists))
+
+	for i, exportList := range doc.Lists {
+		listTitle, err := importTitle(exportList.Title)
+		if err != nil {
+			return nil, fmt.Errorf("%w: lists[%d]: %v", domain.ErrInvalidInput, i, err)
+		}
+		if titles[listTitle] {
+			return nil, fmt.Errorf("%w: lists[%d]: duplicate title %q", domain.ErrInvalidInput, i, listTitle)
+		}
+		titles[listTitle] = true
+
+		createdAt, err := parseExportTime(exportList.CreatedAt, now)
+		if err != nil {
+			return nil, fmt.Errorf("%w: lists[%d]: created_at %v", domain.ErrInvalidInput, i, err)
+		}
+		updatedAt, err := parseExportTime(exportList.UpdatedAt, createdAt)
+		if err != nil {
+			return nil, fmt.Errorf("%w: lists[%d]: updated_at %v", domain.ErrInvalidInput, i, err)
+		}
+
+		list := &domain.TodoList{
+			Title:     listTitle,
+			Color:     exportList.Color,
+			Labels:    exportList.Labels,
+			CreatedAt: createdAt,
+			UpdatedAt: updatedAt,
+			Deleted:   exportList.Deleted,
+			Pinned:    exportList.Pinned,
+			Items:     make([]domain.Todo, 0, len(exportList.Todos)),
+		}
+
+		for j, exportTodo := range exportList.Todos {
+			todoTitle, err := importTitle(exportTodo.Title)
+			if err != nil {
+				return nil, fmt.Errorf("%w: lists[%d].todos[%d]: %v", domain.ErrInvalidInput, i, j, err)
+			}
+
+			todoCreatedAt, err := parseExportTime(exportTodo.CreatedAt, now)
+			if err != nil {
+				return nil, fmt.Errorf("%w: lists[%d].todos[%d]: created_at %v", domain.ErrInvalidInput, i, j, err)
+			}
+			todoUpdatedAt, err := parseExportTime(exportTodo.UpdatedAt, todoCreatedAt)
+			if err != nil {
+				return nil, fmt.Errorf("%w: lists[%d].todos[%d]: updated_at %v", domain.ErrInvalidInput, i, j, err)
+			}
+
+			todo := domain.Todo{
+				Title:     todoTitle,
+				Done:      exportTodo.Done,
+				CreatedAt: todoCreatedAt,
+				UpdatedAt: todoUpdatedAt,
+			}
+
+			// Older backups have no completed_at, the last update is the best guess
+			if todo.Done {
+				completedAt, err := parseExportTime(exportTodo.CompletedAt, todoUpdatedAt)
+				if err != nil {
+					return nil, fmt.Errorf("%w: lists[%d].todos[%d]: completed_at %v", domain.ErrInvalidInput, i, j, err)
+				}
+				todo.CompletedAt = &completedAt
+			}
+
+			if exportTodo.DueDate != "" {
+				dueDate, err := parseExportTime(exportTodo.DueDate, time.Time{})
+				if err != nil {
+					return nil, fmt.Errorf("%w: lists[%d].todos[%d]: due_date %v", domain.ErrInvalidInput, i, j, err)
+				}
+				todo.DueDate = &dueDate
+			}
+
+			if exportTodo.Color != "" {
+				if !domain.IsHexColor(exportTodo.Color) {
+					return nil, fmt.Errorf("%w: lists[%d].todos[%d]: color must be a hex color like #FF5733", domain.ErrInvalidInput, i, j)
+				}
+				color := exportTodo.Color
+				todo.Color = &color
+			}
+
+			list.Items = append(list.Items, todo)
+		}
+
+		lists = append(lists, list)
+	}
+
+	return lists, nil
+}
+
+// importTitle cleans a list or todo title of the document the way a created one is, see domain.SanitizeTitle
+func importTitle(title string) (string, error) {
+	title, err := domain.SanitizeTitle(strings.TrimSpace(title))
+	if err != nil {
+		return "", err
+	}
+
+	if title == "" {
+		return "", errors.New("title is required")
+	}
+
+	if utf8.RuneCountInString(title) > domain.MaxTitleLength {
+		return "", fmt.Errorf("title must be at most %d characters", domain.MaxTitleLength)
+	}
+
+	return title, nil
+}
+
+// parseExportTime reads an RFC3339 time, an empty value gives fallback
+func parseExportTime(value string, fallback time.Time) (time.Time, error) {
+	if value == "" {
+		return fallback, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, errors.New("must be an RFC3339 timestamp")
+	}
+
+	return t, nil
+}
@@ -0,0 +1,327 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/macesz/todo-go/domain"
+	"github.com/macesz/todo-go/services/export/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+var fixedTime = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+func TestExportUser(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		ctx    context.Context
+		userID int64
+	}
+
+	user := &domain.User{ID: 1, Name: "User One", Email: "u1@example.com", Password: "hashed"}
+	todoColor := "#00AA00"
+	listColor := "#FFFFFF"
+	lists := []*domain.TodoList{
+		{ID: 10, PublicID: "list-10", UserID: 1, Title: "Groceries", Color: "#FFFFFF", Labels: []string{"home"}, CreatedAt: fixedTime, UpdatedAt: fixedTime, Pinned: true},
+		{ID: 11, PublicID: "list-11", UserID: 1, Title: "Empty", Color: "#000000", Labels: []string{""}, CreatedAt: fixedTime, UpdatedAt: fixedTime},
+	}
+	todos := []*domain.Todo{
+		{ID: 100, PublicID: "todo-100", UserID: 1, TodoListID: 10, Title: "Buy milk", Done: true, CreatedAt: fixedTime, UpdatedAt: fixedTime, CompletedAt: &fixedTime, DueDate: &fixedTime, Color: &todoColor, ListColor: &listColor},
+	}
+
+	tests := []struct {
+		name      string
+		args      args
+		want      string
+		wantErr   bool
+		wantEmpty bool // Nothing is written when the error comes before the todos
+		initMocks func(tt *testing.T, ta *args, s *ExportService)
+	}{
+		{
+			name: "success",
+			args: args{ctx: context.Background(), userID: 1},
+			want: `{"version":1,"exported_at":"2024-01-02T03:04:05Z",` +
+				`"user":{"id":1,"name":"User One","email":"u1@example.com"},` +
+				`"lists":[` +
+				`{"id":"list-10","title":"Groceries","color":"#FFFFFF","labels":["home"],"created_at":"2024-01-02T03:04:05Z","updated_at":"2024-01-02T03:04:05Z","deleted":false,"pinned":true,` +
+				`"todos":[{"id":"todo-100","title":"Buy milk","done":true,"created_at":"2024-01-02T03:04:05Z","updated_at":"2024-01-02T03:04:05Z","completed_at":"2024-01-02T03:04:05Z","due_date":"2024-01-02T03:04:05Z","color":"#00AA00"}]},` +
+				`{"id":"list-11","title":"Empty","color":"#000000","labels":[""],"created_at":"2024-01-02T03:04:05Z","updated_at":"2024-01-02T03:04:05Z","deleted":false,"pinned":false,"todos":[]}` +
+				`]}`,
+			initMocks: func(tt *testing.T, ta *args, s *ExportService) {
+				users := mocks.NewUserStore(tt)
+				listStore := mocks.NewTodoListStore(tt)
+				todoStore := mocks.NewTodoStore(tt)
+
+				users.On("GetUser", ta.ctx, ta.userID).Return(user, nil).Once()
+				listStore.On("List", ta.ctx, ta.userID, domain.ListOptions{}, fixedTime).Return(lists, nil).Once()
+				todoStore.On("List", ta.ctx, ta.userID, int64(10), domain.ListOptions{}).Return(todos, nil).Once()
+				todoStore.On("List", ta.ctx, ta.userID, int64(11), domain.ListOptions{}).Return([]*domain.Todo{}, nil).Once()
+
+				s.Users, s.Lists, s.Todos = users, listStore, todoStore
+			},
+		},
+		{
+			name: "no lists",
+			args: args{ctx: context.Background(), userID: 1},
+			want: `{"version":1,"exported_at":"2024-01-02T03:04:05Z","user":{"id":1,"name":"User One","email":"u1@example.com"},"lists":[]}`,
+			initMocks: func(tt *testing.T, ta *args, s *ExportService) {
+				users := mocks.NewUserStore(tt)
+				listStore := mocks.NewTodoListStore(tt)
+
+				users.On("GetUser", ta.ctx, ta.userID).Return(user, nil).Once()
+				listStore.On("List", ta.ctx, ta.userID, domain.ListOptions{}, fixedTime).Return([]*domain.TodoList{}, nil).Once()
+
+				s.Users, s.Lists = users, listStore
+			},
+		},
+		{
+			name:      "user store error",
+			args:      args{ctx: context.Background(), userID: 1},
+			wantErr:   true,
+			wantEmpty: true,
+			initMocks: func(tt *testing.T, ta *args, s *ExportService) {
+				users := mocks.NewUserStore(tt)
+				users.On("GetUser", ta.ctx, ta.userID).Return(nil, errors.New("db error")).Once()
+
+				s.Users = users
+			},
+		},
+		{
+			name:      "list store error",
+			args:      args{ctx: context.Background(), userID: 1},
+			wantErr:   true,
+			wantEmpty: true,
+			initMocks: func(tt *testing.T, ta *args, s *ExportService) {
+				users := mocks.NewUserStore(tt)
+				listStore := mocks.NewTodoListStore(tt)
+
+				users.On("GetUser", ta.ctx, ta.userID).Return(user, nil).Once()
+				listStore.On("List", ta.ctx, ta.userID, domain.ListOptions{}, fixedTime).Return(nil, errors.New("db error")).Once()
+
+				s.Users, s.Lists = users, listStore
+			},
+		},
+		{
+			name:    "todo store error",
+			args:    args{ctx: context.Background(), userID: 1},
+			wantErr: true,
+			initMocks: func(tt *testing.T, ta *args, s *ExportService) {
+				users := mocks.NewUserStore(tt)
+				listStore := mocks.NewTodoListStore(tt)
+				todoStore := mocks.NewTodoStore(tt)
+
+				users.On("GetUser", ta.ctx, ta.userID).Return(user, nil).Once()
+				listStore.On("List", ta.ctx, ta.userID, domain.ListOptions{}, fixedTime).Return(lists, nil).Once()
+				todoStore.On("List", ta.ctx, ta.userID, int64(10), domain.ListOptions{}).Return(nil, errors.New("db error")).Once()
+
+				s.Users, s.Lists, s.Todos = users, listStore, todoStore
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := &ExportService{Clock: domain.FixedClock{Time: fixedTime}}
+
+			tc.initMocks(t, &tc.args, s)
+
+			var buf bytes.Buffer
+			err := s.ExportUser(tc.args.ctx, tc.args.userID, &buf)
+			if tc.wantErr {
+				require.Error(t, err)
+				if tc.wantEmpty {
+					require.Empty(t, buf.String())
+				}
+				return
+			}
+			require.NoError(t, err)
+			require.JSONEq(t, tc.want, buf.String())
+			require.NotContains(t, buf.String(), "hashed")
+		})
+	}
+}
+
+func TestImportUser(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		ctx     context.Context
+		userID  int64
+		doc     *domain.ExportDocument
+		replace bool
+	}
+
+	validDoc := func() *domain.ExportDocument {
+		return &domain.ExportDocument{
+			Version: domain.ExportVersion,
+			User:    domain.UserDTO{ID: 99, Name: "Someone", Email: "someone@example.com"},
+			Lists: []domain.ExportList{
+				{
+					ID: "list-10", Title: "Groceries", Color: "#FFFFFF", Labels: []string{"home"},
+					CreatedAt: "2024-01-02T03:04:05Z", UpdatedAt: "2024-01-03T03:04:05Z", Pinned: true,
+					Todos: []domain.ExportTodo{
+						{ID: "todo-100", Title: "Buy milk", Done: true, CreatedAt: "2024-01-02T03:04:05Z", UpdatedAt: "2024-01-02T04:04:05Z", CompletedAt: "2024-01-02T03:34:05Z"},
+						{ID: "todo-101", Title: "Buy\r\nbread", DueDate: "2024-01-04T03:04:05Z", Color: "#00AA00"},
+					},
+				},
+				{ID: "list-11", Title: "Empty"},
+			},
+		}
+	}
+
+	// The titles are sanitized like created ones, "Buy\r\nbread" comes in as "Buy bread"
+	milkCompletedAt := fixedTime.Add(30 * time.Minute)
+	breadDueDate := fixedTime.Add(48 * time.Hour)
+	breadColor := "#00AA00"
+
+	wantLists := []*domain.TodoList{
+		{
+			Title: "Groceries", Color: "#FFFFFF", Labels: []string{"home"},
+			CreatedAt: fixedTime, UpdatedAt: fixedTime.Add(24 * time.Hour), Pinned: true,
+			Items: []domain.Todo{
+				{Title: "Buy milk", Done: true, CreatedAt: fixedTime, UpdatedAt: fixedTime.Add(time.Hour), CompletedAt: &milkCompletedAt},
+				{Title: "Buy bread", CreatedAt: fixedTime, UpdatedAt: fixedTime, DueDate: &breadDueDate, Color: &breadColor},
+			},
+		},
+		{Title: "Empty", CreatedAt: fixedTime, UpdatedAt: fixedTime, Items: []domain.Todo{}},
+	}
+
+	tests := []struct {
+		name      string
+		args      args
+		mutate    func(doc *domain.ExportDocument)
+		want      *domain.ImportResult
+		wantErrIs error
+		wantErr   bool
+		initMocks func(tt *testing.T, ta *args, s *ExportService)
+	}{
+		{
+			name: "success",
+			args: args{ctx: context.Background(), userID: 1, doc: validDoc(), replace: true},
+			want: &domain.ImportResult{Lists: 2, Todos: 2},
+			initMocks: func(tt *testing.T, ta *args, s *ExportService) {
+				importer := mocks.NewImportStore(tt)
+				importer.On("Import", ta.ctx, ta.userID, wantLists, true).Return(nil).Once()
+
+				s.Importer = importer
+			},
+		},
+		{
+			name:      "wrong version",
+			args:      args{ctx: context.Background(), userID: 1, doc: validDoc()},
+			mutate:    func(doc *domain.ExportDocument) { doc.Version = 2 },
+			wantErrIs: domain.ErrInvalidInput,
+			initMocks: func(tt *testing.T, ta *args, s *ExportService) {},
+		},
+		{
+			name:      "list without title",
+			args:      args{ctx: context.Background(), userID: 1, doc: validDoc()},
+			mutate:    func(doc *domain.ExportDocument) { doc.Lists[1].Title = "" },
+			wantErrIs: domain.ErrInvalidInput,
+			initMocks: func(tt *testing.T, ta *args, s *ExportService) {},
+		},
+		{
+			name:      "duplicate list title in the document",
+			args:      args{ctx: context.Background(), userID: 1, doc: validDoc()},
+			mutate:    func(doc *domain.ExportDocument) { doc.Lists[1].Title = "Groceries" },
+			wantErrIs: domain.ErrInvalidInput,
+			initMocks: func(tt *testing.T, ta *args, s *ExportService) {},
+		},
+		{
+			name:      "todo without title",
+			args:      args{ctx: context.Background(), userID: 1, doc: validDoc()},
+			mutate:    func(doc *domain.ExportDocument) { doc.Lists[0].Todos[1].Title = "" },
+			wantErrIs: domain.ErrInvalidInput,
+			initMocks: func(tt *testing.T, ta *args, s *ExportService) {},
+		},
+		{
+			name:      "todo title with a control character",
+			args:      args{ctx: context.Background(), userID: 1, doc: validDoc()},
+			mutate:    func(doc *domain.ExportDocument) { doc.Lists[0].Todos[1].Title = "Buy\x1b[31mbread" },
+			wantErrIs: domain.ErrInvalidInput,
+			initMocks: func(tt *testing.T, ta *args, s *ExportService) {},
+		},
+		{
+			name:      "list title too long",
+			args:      args{ctx: context.Background(), userID: 1, doc: validDoc()},
+			mutate:    func(doc *domain.ExportDocument) { doc.Lists[1].Title = strings.Repeat("a", domain.MaxTitleLength+1) },
+			wantErrIs: domain.ErrInvalidInput,
+			initMocks: func(tt *testing.T, ta *args, s *ExportService) {},
+		},
+		{
+			name:      "blank todo title",
+			args:      args{ctx: context.Background(), userID: 1, doc: validDoc()},
+			mutate:    func(doc *domain.ExportDocument) { doc.Lists[0].Todos[1].Title = " \n\t " },
+			wantErrIs: domain.ErrInvalidInput,
+			initMocks: func(tt *testing.T, ta *args, s *ExportService) {},
+		},
+		{
+			name:      "bad timestamp",
+			args:      args{ctx: context.Background(), userID: 1, doc: validDoc()},
+			mutate:    func(doc *domain.ExportDocument) { doc.Lists[0].Todos[0].CreatedAt = "yesterday" },
+			wantErrIs: domain.ErrInvalidInput,
+			initMocks: func(tt *testing.T, ta *args, s *ExportService) {},
+		},
+		{
+			name:      "todo color that is not a hex color",
+			args:      args{ctx: context.Background(), userID: 1, doc: validDoc()},
+			mutate:    func(doc *domain.ExportDocument) { doc.Lists[0].Todos[1].Color = "green" },
+			wantErrIs: domain.ErrInvalidInput,
+			initMocks: func(tt *testing.T, ta *args, s *ExportService) {},
+		},
+		{
+			name:      "title the user already has",
+			args:      args{ctx: context.Background(), userID: 1, doc: validDoc()},
+			wantErrIs: domain.ErrDuplicate,
+			initMocks: func(tt *testing.T, ta *args, s *ExportService) {
+				importer := mocks.NewImportStore(tt)
+				importer.On("Import", ta.ctx, ta.userID, wantLists, false).Return(domain.ErrDuplicate).Once()
+
+				s.Importer = importer
+			},
+		},
+		{
+			name:    "store error",
+			args:    args{ctx: context.Background(), userID: 1, doc: validDoc()},
+			wantErr: true,
+			initMocks: func(tt *testing.T, ta *args, s *ExportService) {
+				importer := mocks.NewImportStore(tt)
+				importer.On("Import", ta.ctx, ta.userID, wantLists, false).Return(errors.New("db error")).Once()
+
+				s.Importer = importer
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := &ExportService{Clock: domain.FixedClock{Time: fixedTime}}
+
+			if tc.mutate != nil {
+				tc.mutate(tc.args.doc)
+			}
+			tc.initMocks(t, &tc.args, s)
+
+			got, err := s.ImportUser(tc.args.ctx, tc.args.userID, tc.args.doc, tc.args.replace)
+			if tc.wantErrIs != nil {
+				require.ErrorIs(t, err, tc.wantErrIs)
+				return
+			}
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
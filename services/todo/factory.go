@@ -1,11 +1,30 @@
 package todo
 
+import (
+	"time"
+
+	"github.com/macesz/todo-go/domain"
+)
+
 // TodoService contains business logic for managing todos.
 // Like a service class in Java or JS
 type TodoService struct {
 	Store TodoStore // Dependency injection of the store (like a private field in Java)
+	Options
+}
+
+// Options are the per-deployment settings of the TodoService.
+type Options struct {
+	DefaultSort         domain.Sort   // Used when a list request has no sort
+	WarnDuplicateTitles bool          // Warn when a new todo has the same title as another in its list
+	SoftDelete          bool          // Move deleted todos to the trash instead of removing the row
+	UndoWindow          time.Duration // How long a trashed todo can be restored, DefaultUndoWindow when zero
+	Clock               domain.Clock  // Source of timestamps, the system clock when nil
 }
 
+// DefaultUndoWindow is how long a trashed todo can be restored when Options has no UndoWindow
+const DefaultUndoWindow = 5 * time.Minute
+
 // Factory function - Go's equivalent to a constructor in Java
 // Java: new TodoService(store)
 // Go:   NewTodoService(store)
@@ -13,8 +32,25 @@ type TodoService struct {
 // The "factory" name emphasizes that we're manufacturing instances rather than just initializing them.
 
 // Here we inject the store dependency (like constructor injection in Java)
-func NewTodoService(store TodoStore) *TodoService {
+func NewTodoService(store TodoStore, opts Options) *TodoService {
 	return &TodoService{
-		Store: store, // Assign the store to the service
+		Store:   store, // Assign the store to the service
+		Options: opts,
+	}
+}
+
+// now returns the current time from the configured clock
+func (s *TodoService) now() time.Time {
+	if s.Clock == nil {
+		return time.Now()
+	}
+	return s.Clock.Now()
+}
+
+// undoWindow returns the configured undo window, DefaultUndoWindow when none is set
+func (s *TodoService) undoWindow() time.Duration {
+	if s.UndoWindow <= 0 {
+		return DefaultUndoWindow
 	}
+	return s.UndoWindow
 }
@@ -2,17 +2,33 @@ package todo
 
 import (
 	"context"
+	"time"
 
 	"github.com/macesz/todo-go/domain"
 )
 
 // TodoStore defines the interface for a todo storage backend. Like a Java interface
 type TodoStore interface {
-	List(ctx context.Context, userID int64, todolistID int64) ([]*domain.Todo, error)
+	List(ctx context.Context, userID int64, todolistID int64, opts domain.ListOptions) ([]*domain.Todo, error)
 	Create(ctx context.Context, todolistID int64, todo *domain.Todo) error
 	Get(ctx context.Context, id int64) (*domain.Todo, error)
-	Update(ctx context.Context, id int64, title string, done bool) (*domain.Todo, error)
+	GetByIDs(ctx context.Context, userID int64, ids []int64) ([]*domain.Todo, error)
+	GetByPublicIDs(ctx context.Context, userID int64, publicIDs []string) ([]*domain.Todo, error)
+	IDByPublicID(ctx context.Context, userID int64, publicID string) (int64, error)
+	ListIDByPublicID(ctx context.Context, userID int64, publicID string) (int64, error)
+	TitleExists(ctx context.Context, todolistID int64, title string) (bool, error)
+	Update(ctx context.Context, id int64, title string, done bool, dueDate *time.Time, color *string, updatedAt time.Time) (*domain.Todo, error)
+	SetDone(ctx context.Context, userID int64, id int64, done bool, updatedAt time.Time) (*domain.Todo, error)
 	Delete(ctx context.Context, id int64) error
+	SoftDelete(ctx context.Context, id int64, deletedAt time.Time) error
+	TrashDone(ctx context.Context, userID int64, todolistID int64, deletedAt time.Time) (int64, error)
+	DeleteDone(ctx context.Context, userID int64, todolistID int64) (int64, error)
+	GetTrashed(ctx context.Context, userID int64, publicID string) (*domain.Todo, error)
+	Restore(ctx context.Context, todolistID int64, id int64) error
+	PurgeTrash(ctx context.Context, before time.Time) (int64, error)
+	SetAllDone(ctx context.Context, userID int64, todolistID int64, done bool, updatedAt time.Time) (int64, error)
+	RecentlyCompleted(ctx context.Context, userID int64, limit int) ([]*domain.Todo, error)
+	ListOverdue(ctx context.Context, userID int64, now time.Time) ([]*domain.Todo, error)
 }
 
 //********************************************************************************************
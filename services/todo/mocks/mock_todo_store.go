@@ -6,6 +6,7 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	"github.com/macesz/todo-go/domain"
 	mock "github.com/stretchr/testify/mock"
@@ -158,6 +159,78 @@ func (_c *TodoStore_Delete_Call) RunAndReturn(run func(ctx context.Context, id i
 	return _c
 }
 
+// DeleteDone provides a mock function for the type TodoStore
+func (_mock *TodoStore) DeleteDone(ctx context.Context, userID int64, todolistID int64) (int64, error) {
+	ret := _mock.Called(ctx, userID, todolistID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteDone")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int64) (int64, error)); ok {
+		return returnFunc(ctx, userID, todolistID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int64) int64); ok {
+		r0 = returnFunc(ctx, userID, todolistID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = returnFunc(ctx, userID, todolistID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// TodoStore_DeleteDone_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteDone'
+type TodoStore_DeleteDone_Call struct {
+	*mock.Call
+}
+
+// DeleteDone is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+//   - todolistID int64
+func (_e *TodoStore_Expecter) DeleteDone(ctx interface{}, userID interface{}, todolistID interface{}) *TodoStore_DeleteDone_Call {
+	return &TodoStore_DeleteDone_Call{Call: _e.mock.On("DeleteDone", ctx, userID, todolistID)}
+}
+
+func (_c *TodoStore_DeleteDone_Call) Run(run func(ctx context.Context, userID int64, todolistID int64)) *TodoStore_DeleteDone_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 int64
+		if args[2] != nil {
+			arg2 = args[2].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *TodoStore_DeleteDone_Call) Return(n int64, err error) *TodoStore_DeleteDone_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *TodoStore_DeleteDone_Call) RunAndReturn(run func(ctx context.Context, userID int64, todolistID int64) (int64, error)) *TodoStore_DeleteDone_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Get provides a mock function for the type TodoStore
 func (_mock *TodoStore) Get(ctx context.Context, id int64) (*domain.Todo, error) {
 	ret := _mock.Called(ctx, id)
@@ -226,48 +299,48 @@ func (_c *TodoStore_Get_Call) RunAndReturn(run func(ctx context.Context, id int6
 	return _c
 }
 
-// List provides a mock function for the type TodoStore
-func (_mock *TodoStore) List(ctx context.Context, userID int64, todolistID int64) ([]*domain.Todo, error) {
-	ret := _mock.Called(ctx, userID, todolistID)
+// GetByIDs provides a mock function for the type TodoStore
+func (_mock *TodoStore) GetByIDs(ctx context.Context, userID int64, ids []int64) ([]*domain.Todo, error) {
+	ret := _mock.Called(ctx, userID, ids)
 
 	if len(ret) == 0 {
-		panic("no return value specified for List")
+		panic("no return value specified for GetByIDs")
 	}
 
 	var r0 []*domain.Todo
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int64) ([]*domain.Todo, error)); ok {
-		return returnFunc(ctx, userID, todolistID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, []int64) ([]*domain.Todo, error)); ok {
+		return returnFunc(ctx, userID, ids)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int64) []*domain.Todo); ok {
-		r0 = returnFunc(ctx, userID, todolistID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, []int64) []*domain.Todo); ok {
+		r0 = returnFunc(ctx, userID, ids)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*domain.Todo)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
-		r1 = returnFunc(ctx, userID, todolistID)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, []int64) error); ok {
+		r1 = returnFunc(ctx, userID, ids)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// TodoStore_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
-type TodoStore_List_Call struct {
+// TodoStore_GetByIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByIDs'
+type TodoStore_GetByIDs_Call struct {
 	*mock.Call
 }
 
-// List is a helper method to define mock.On call
+// GetByIDs is a helper method to define mock.On call
 //   - ctx context.Context
 //   - userID int64
-//   - todolistID int64
-func (_e *TodoStore_Expecter) List(ctx interface{}, userID interface{}, todolistID interface{}) *TodoStore_List_Call {
-	return &TodoStore_List_Call{Call: _e.mock.On("List", ctx, userID, todolistID)}
+//   - ids []int64
+func (_e *TodoStore_Expecter) GetByIDs(ctx interface{}, userID interface{}, ids interface{}) *TodoStore_GetByIDs_Call {
+	return &TodoStore_GetByIDs_Call{Call: _e.mock.On("GetByIDs", ctx, userID, ids)}
 }
 
-func (_c *TodoStore_List_Call) Run(run func(ctx context.Context, userID int64, todolistID int64)) *TodoStore_List_Call {
+func (_c *TodoStore_GetByIDs_Call) Run(run func(ctx context.Context, userID int64, ids []int64)) *TodoStore_GetByIDs_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -277,9 +350,9 @@ func (_c *TodoStore_List_Call) Run(run func(ctx context.Context, userID int64, t
 		if args[1] != nil {
 			arg1 = args[1].(int64)
 		}
-		var arg2 int64
+		var arg2 []int64
 		if args[2] != nil {
-			arg2 = args[2].(int64)
+			arg2 = args[2].([]int64)
 		}
 		run(
 			arg0,
@@ -290,59 +363,132 @@ func (_c *TodoStore_List_Call) Run(run func(ctx context.Context, userID int64, t
 	return _c
 }
 
-func (_c *TodoStore_List_Call) Return(todos []*domain.Todo, err error) *TodoStore_List_Call {
+func (_c *TodoStore_GetByIDs_Call) Return(todos []*domain.Todo, err error) *TodoStore_GetByIDs_Call {
 	_c.Call.Return(todos, err)
 	return _c
 }
 
-func (_c *TodoStore_List_Call) RunAndReturn(run func(ctx context.Context, userID int64, todolistID int64) ([]*domain.Todo, error)) *TodoStore_List_Call {
+func (_c *TodoStore_GetByIDs_Call) RunAndReturn(run func(ctx context.Context, userID int64, ids []int64) ([]*domain.Todo, error)) *TodoStore_GetByIDs_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Update provides a mock function for the type TodoStore
-func (_mock *TodoStore) Update(ctx context.Context, id int64, title string, done bool) (*domain.Todo, error) {
-	ret := _mock.Called(ctx, id, title, done)
+// GetByPublicIDs provides a mock function for the type TodoStore
+func (_mock *TodoStore) GetByPublicIDs(ctx context.Context, userID int64, publicIDs []string) ([]*domain.Todo, error) {
+	ret := _mock.Called(ctx, userID, publicIDs)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Update")
+		panic("no return value specified for GetByPublicIDs")
+	}
+
+	var r0 []*domain.Todo
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, []string) ([]*domain.Todo, error)); ok {
+		return returnFunc(ctx, userID, publicIDs)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, []string) []*domain.Todo); ok {
+		r0 = returnFunc(ctx, userID, publicIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Todo)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, []string) error); ok {
+		r1 = returnFunc(ctx, userID, publicIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// TodoStore_GetByPublicIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByPublicIDs'
+type TodoStore_GetByPublicIDs_Call struct {
+	*mock.Call
+}
+
+// GetByPublicIDs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+//   - publicIDs []string
+func (_e *TodoStore_Expecter) GetByPublicIDs(ctx interface{}, userID interface{}, publicIDs interface{}) *TodoStore_GetByPublicIDs_Call {
+	return &TodoStore_GetByPublicIDs_Call{Call: _e.mock.On("GetByPublicIDs", ctx, userID, publicIDs)}
+}
+
+func (_c *TodoStore_GetByPublicIDs_Call) Run(run func(ctx context.Context, userID int64, publicIDs []string)) *TodoStore_GetByPublicIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 []string
+		if args[2] != nil {
+			arg2 = args[2].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *TodoStore_GetByPublicIDs_Call) Return(todos []*domain.Todo, err error) *TodoStore_GetByPublicIDs_Call {
+	_c.Call.Return(todos, err)
+	return _c
+}
+
+func (_c *TodoStore_GetByPublicIDs_Call) RunAndReturn(run func(ctx context.Context, userID int64, publicIDs []string) ([]*domain.Todo, error)) *TodoStore_GetByPublicIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTrashed provides a mock function for the type TodoStore
+func (_mock *TodoStore) GetTrashed(ctx context.Context, userID int64, publicID string) (*domain.Todo, error) {
+	ret := _mock.Called(ctx, userID, publicID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTrashed")
 	}
 
 	var r0 *domain.Todo
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, string, bool) (*domain.Todo, error)); ok {
-		return returnFunc(ctx, id, title, done)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, string) (*domain.Todo, error)); ok {
+		return returnFunc(ctx, userID, publicID)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, string, bool) *domain.Todo); ok {
-		r0 = returnFunc(ctx, id, title, done)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, string) *domain.Todo); ok {
+		r0 = returnFunc(ctx, userID, publicID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.Todo)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, string, bool) error); ok {
-		r1 = returnFunc(ctx, id, title, done)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, string) error); ok {
+		r1 = returnFunc(ctx, userID, publicID)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// TodoStore_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
-type TodoStore_Update_Call struct {
+// TodoStore_GetTrashed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTrashed'
+type TodoStore_GetTrashed_Call struct {
 	*mock.Call
 }
 
-// Update is a helper method to define mock.On call
+// GetTrashed is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id int64
-//   - title string
-//   - done bool
-func (_e *TodoStore_Expecter) Update(ctx interface{}, id interface{}, title interface{}, done interface{}) *TodoStore_Update_Call {
-	return &TodoStore_Update_Call{Call: _e.mock.On("Update", ctx, id, title, done)}
+//   - userID int64
+//   - publicID string
+func (_e *TodoStore_Expecter) GetTrashed(ctx interface{}, userID interface{}, publicID interface{}) *TodoStore_GetTrashed_Call {
+	return &TodoStore_GetTrashed_Call{Call: _e.mock.On("GetTrashed", ctx, userID, publicID)}
 }
 
-func (_c *TodoStore_Update_Call) Run(run func(ctx context.Context, id int64, title string, done bool)) *TodoStore_Update_Call {
+func (_c *TodoStore_GetTrashed_Call) Run(run func(ctx context.Context, userID int64, publicID string)) *TodoStore_GetTrashed_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -356,15 +502,992 @@ func (_c *TodoStore_Update_Call) Run(run func(ctx context.Context, id int64, tit
 		if args[2] != nil {
 			arg2 = args[2].(string)
 		}
-		var arg3 bool
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *TodoStore_GetTrashed_Call) Return(todo *domain.Todo, err error) *TodoStore_GetTrashed_Call {
+	_c.Call.Return(todo, err)
+	return _c
+}
+
+func (_c *TodoStore_GetTrashed_Call) RunAndReturn(run func(ctx context.Context, userID int64, publicID string) (*domain.Todo, error)) *TodoStore_GetTrashed_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IDByPublicID provides a mock function for the type TodoStore
+func (_mock *TodoStore) IDByPublicID(ctx context.Context, userID int64, publicID string) (int64, error) {
+	ret := _mock.Called(ctx, userID, publicID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IDByPublicID")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, string) (int64, error)); ok {
+		return returnFunc(ctx, userID, publicID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, string) int64); ok {
+		r0 = returnFunc(ctx, userID, publicID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, string) error); ok {
+		r1 = returnFunc(ctx, userID, publicID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// TodoStore_IDByPublicID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IDByPublicID'
+type TodoStore_IDByPublicID_Call struct {
+	*mock.Call
+}
+
+// IDByPublicID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+//   - publicID string
+func (_e *TodoStore_Expecter) IDByPublicID(ctx interface{}, userID interface{}, publicID interface{}) *TodoStore_IDByPublicID_Call {
+	return &TodoStore_IDByPublicID_Call{Call: _e.mock.On("IDByPublicID", ctx, userID, publicID)}
+}
+
+func (_c *TodoStore_IDByPublicID_Call) Run(run func(ctx context.Context, userID int64, publicID string)) *TodoStore_IDByPublicID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *TodoStore_IDByPublicID_Call) Return(n int64, err error) *TodoStore_IDByPublicID_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *TodoStore_IDByPublicID_Call) RunAndReturn(run func(ctx context.Context, userID int64, publicID string) (int64, error)) *TodoStore_IDByPublicID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function for the type TodoStore
+func (_mock *TodoStore) List(ctx context.Context, userID int64, todolistID int64, opts domain.ListOptions) ([]*domain.Todo, error) {
+	ret := _mock.Called(ctx, userID, todolistID, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*domain.Todo
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int64, domain.ListOptions) ([]*domain.Todo, error)); ok {
+		return returnFunc(ctx, userID, todolistID, opts)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int64, domain.ListOptions) []*domain.Todo); ok {
+		r0 = returnFunc(ctx, userID, todolistID, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Todo)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, int64, domain.ListOptions) error); ok {
+		r1 = returnFunc(ctx, userID, todolistID, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// TodoStore_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type TodoStore_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+//   - todolistID int64
+//   - opts domain.ListOptions
+func (_e *TodoStore_Expecter) List(ctx interface{}, userID interface{}, todolistID interface{}, opts interface{}) *TodoStore_List_Call {
+	return &TodoStore_List_Call{Call: _e.mock.On("List", ctx, userID, todolistID, opts)}
+}
+
+func (_c *TodoStore_List_Call) Run(run func(ctx context.Context, userID int64, todolistID int64, opts domain.ListOptions)) *TodoStore_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 int64
+		if args[2] != nil {
+			arg2 = args[2].(int64)
+		}
+		var arg3 domain.ListOptions
 		if args[3] != nil {
-			arg3 = args[3].(bool)
+			arg3 = args[3].(domain.ListOptions)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *TodoStore_List_Call) Return(todos []*domain.Todo, err error) *TodoStore_List_Call {
+	_c.Call.Return(todos, err)
+	return _c
+}
+
+func (_c *TodoStore_List_Call) RunAndReturn(run func(ctx context.Context, userID int64, todolistID int64, opts domain.ListOptions) ([]*domain.Todo, error)) *TodoStore_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListIDByPublicID provides a mock function for the type TodoStore
+func (_mock *TodoStore) ListIDByPublicID(ctx context.Context, userID int64, publicID string) (int64, error) {
+	ret := _mock.Called(ctx, userID, publicID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListIDByPublicID")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, string) (int64, error)); ok {
+		return returnFunc(ctx, userID, publicID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, string) int64); ok {
+		r0 = returnFunc(ctx, userID, publicID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, string) error); ok {
+		r1 = returnFunc(ctx, userID, publicID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// TodoStore_ListIDByPublicID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListIDByPublicID'
+type TodoStore_ListIDByPublicID_Call struct {
+	*mock.Call
+}
+
+// ListIDByPublicID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+//   - publicID string
+func (_e *TodoStore_Expecter) ListIDByPublicID(ctx interface{}, userID interface{}, publicID interface{}) *TodoStore_ListIDByPublicID_Call {
+	return &TodoStore_ListIDByPublicID_Call{Call: _e.mock.On("ListIDByPublicID", ctx, userID, publicID)}
+}
+
+func (_c *TodoStore_ListIDByPublicID_Call) Run(run func(ctx context.Context, userID int64, publicID string)) *TodoStore_ListIDByPublicID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *TodoStore_ListIDByPublicID_Call) Return(n int64, err error) *TodoStore_ListIDByPublicID_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *TodoStore_ListIDByPublicID_Call) RunAndReturn(run func(ctx context.Context, userID int64, publicID string) (int64, error)) *TodoStore_ListIDByPublicID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListOverdue provides a mock function for the type TodoStore
+func (_mock *TodoStore) ListOverdue(ctx context.Context, userID int64, now time.Time) ([]*domain.Todo, error) {
+	ret := _mock.Called(ctx, userID, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListOverdue")
+	}
+
+	var r0 []*domain.Todo
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, time.Time) ([]*domain.Todo, error)); ok {
+		return returnFunc(ctx, userID, now)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, time.Time) []*domain.Todo); ok {
+		r0 = returnFunc(ctx, userID, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Todo)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, time.Time) error); ok {
+		r1 = returnFunc(ctx, userID, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// TodoStore_ListOverdue_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListOverdue'
+type TodoStore_ListOverdue_Call struct {
+	*mock.Call
+}
+
+// ListOverdue is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+//   - now time.Time
+func (_e *TodoStore_Expecter) ListOverdue(ctx interface{}, userID interface{}, now interface{}) *TodoStore_ListOverdue_Call {
+	return &TodoStore_ListOverdue_Call{Call: _e.mock.On("ListOverdue", ctx, userID, now)}
+}
+
+func (_c *TodoStore_ListOverdue_Call) Run(run func(ctx context.Context, userID int64, now time.Time)) *TodoStore_ListOverdue_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *TodoStore_ListOverdue_Call) Return(todos []*domain.Todo, err error) *TodoStore_ListOverdue_Call {
+	_c.Call.Return(todos, err)
+	return _c
+}
+
+func (_c *TodoStore_ListOverdue_Call) RunAndReturn(run func(ctx context.Context, userID int64, now time.Time) ([]*domain.Todo, error)) *TodoStore_ListOverdue_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PurgeTrash provides a mock function for the type TodoStore
+func (_mock *TodoStore) PurgeTrash(ctx context.Context, before time.Time) (int64, error) {
+	ret := _mock.Called(ctx, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeTrash")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) (int64, error)); ok {
+		return returnFunc(ctx, before)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = returnFunc(ctx, before)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = returnFunc(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// TodoStore_PurgeTrash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgeTrash'
+type TodoStore_PurgeTrash_Call struct {
+	*mock.Call
+}
+
+// PurgeTrash is a helper method to define mock.On call
+//   - ctx context.Context
+//   - before time.Time
+func (_e *TodoStore_Expecter) PurgeTrash(ctx interface{}, before interface{}) *TodoStore_PurgeTrash_Call {
+	return &TodoStore_PurgeTrash_Call{Call: _e.mock.On("PurgeTrash", ctx, before)}
+}
+
+func (_c *TodoStore_PurgeTrash_Call) Run(run func(ctx context.Context, before time.Time)) *TodoStore_PurgeTrash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 time.Time
+		if args[1] != nil {
+			arg1 = args[1].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *TodoStore_PurgeTrash_Call) Return(n int64, err error) *TodoStore_PurgeTrash_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *TodoStore_PurgeTrash_Call) RunAndReturn(run func(ctx context.Context, before time.Time) (int64, error)) *TodoStore_PurgeTrash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecentlyCompleted provides a mock function for the type TodoStore
+func (_mock *TodoStore) RecentlyCompleted(ctx context.Context, userID int64, limit int) ([]*domain.Todo, error) {
+	ret := _mock.Called(ctx, userID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecentlyCompleted")
+	}
+
+	var r0 []*domain.Todo
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int) ([]*domain.Todo, error)); ok {
+		return returnFunc(ctx, userID, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int) []*domain.Todo); ok {
+		r0 = returnFunc(ctx, userID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Todo)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, int) error); ok {
+		r1 = returnFunc(ctx, userID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// TodoStore_RecentlyCompleted_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecentlyCompleted'
+type TodoStore_RecentlyCompleted_Call struct {
+	*mock.Call
+}
+
+// RecentlyCompleted is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+//   - limit int
+func (_e *TodoStore_Expecter) RecentlyCompleted(ctx interface{}, userID interface{}, limit interface{}) *TodoStore_RecentlyCompleted_Call {
+	return &TodoStore_RecentlyCompleted_Call{Call: _e.mock.On("RecentlyCompleted", ctx, userID, limit)}
+}
+
+func (_c *TodoStore_RecentlyCompleted_Call) Run(run func(ctx context.Context, userID int64, limit int)) *TodoStore_RecentlyCompleted_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *TodoStore_RecentlyCompleted_Call) Return(todos []*domain.Todo, err error) *TodoStore_RecentlyCompleted_Call {
+	_c.Call.Return(todos, err)
+	return _c
+}
+
+func (_c *TodoStore_RecentlyCompleted_Call) RunAndReturn(run func(ctx context.Context, userID int64, limit int) ([]*domain.Todo, error)) *TodoStore_RecentlyCompleted_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Restore provides a mock function for the type TodoStore
+func (_mock *TodoStore) Restore(ctx context.Context, todolistID int64, id int64) error {
+	ret := _mock.Called(ctx, todolistID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Restore")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = returnFunc(ctx, todolistID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// TodoStore_Restore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Restore'
+type TodoStore_Restore_Call struct {
+	*mock.Call
+}
+
+// Restore is a helper method to define mock.On call
+//   - ctx context.Context
+//   - todolistID int64
+//   - id int64
+func (_e *TodoStore_Expecter) Restore(ctx interface{}, todolistID interface{}, id interface{}) *TodoStore_Restore_Call {
+	return &TodoStore_Restore_Call{Call: _e.mock.On("Restore", ctx, todolistID, id)}
+}
+
+func (_c *TodoStore_Restore_Call) Run(run func(ctx context.Context, todolistID int64, id int64)) *TodoStore_Restore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 int64
+		if args[2] != nil {
+			arg2 = args[2].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *TodoStore_Restore_Call) Return(err error) *TodoStore_Restore_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *TodoStore_Restore_Call) RunAndReturn(run func(ctx context.Context, todolistID int64, id int64) error) *TodoStore_Restore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetAllDone provides a mock function for the type TodoStore
+func (_mock *TodoStore) SetAllDone(ctx context.Context, userID int64, todolistID int64, done bool, updatedAt time.Time) (int64, error) {
+	ret := _mock.Called(ctx, userID, todolistID, done, updatedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetAllDone")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int64, bool, time.Time) (int64, error)); ok {
+		return returnFunc(ctx, userID, todolistID, done, updatedAt)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int64, bool, time.Time) int64); ok {
+		r0 = returnFunc(ctx, userID, todolistID, done, updatedAt)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, int64, bool, time.Time) error); ok {
+		r1 = returnFunc(ctx, userID, todolistID, done, updatedAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// TodoStore_SetAllDone_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetAllDone'
+type TodoStore_SetAllDone_Call struct {
+	*mock.Call
+}
+
+// SetAllDone is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+//   - todolistID int64
+//   - done bool
+//   - updatedAt time.Time
+func (_e *TodoStore_Expecter) SetAllDone(ctx interface{}, userID interface{}, todolistID interface{}, done interface{}, updatedAt interface{}) *TodoStore_SetAllDone_Call {
+	return &TodoStore_SetAllDone_Call{Call: _e.mock.On("SetAllDone", ctx, userID, todolistID, done, updatedAt)}
+}
+
+func (_c *TodoStore_SetAllDone_Call) Run(run func(ctx context.Context, userID int64, todolistID int64, done bool, updatedAt time.Time)) *TodoStore_SetAllDone_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 int64
+		if args[2] != nil {
+			arg2 = args[2].(int64)
+		}
+		var arg3 bool
+		if args[3] != nil {
+			arg3 = args[3].(bool)
+		}
+		var arg4 time.Time
+		if args[4] != nil {
+			arg4 = args[4].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *TodoStore_SetAllDone_Call) Return(n int64, err error) *TodoStore_SetAllDone_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *TodoStore_SetAllDone_Call) RunAndReturn(run func(ctx context.Context, userID int64, todolistID int64, done bool, updatedAt time.Time) (int64, error)) *TodoStore_SetAllDone_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetDone provides a mock function for the type TodoStore
+func (_mock *TodoStore) SetDone(ctx context.Context, userID int64, id int64, done bool, updatedAt time.Time) (*domain.Todo, error) {
+	ret := _mock.Called(ctx, userID, id, done, updatedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetDone")
+	}
+
+	var r0 *domain.Todo
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int64, bool, time.Time) (*domain.Todo, error)); ok {
+		return returnFunc(ctx, userID, id, done, updatedAt)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int64, bool, time.Time) *domain.Todo); ok {
+		r0 = returnFunc(ctx, userID, id, done, updatedAt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Todo)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, int64, bool, time.Time) error); ok {
+		r1 = returnFunc(ctx, userID, id, done, updatedAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// TodoStore_SetDone_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetDone'
+type TodoStore_SetDone_Call struct {
+	*mock.Call
+}
+
+// SetDone is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+//   - id int64
+//   - done bool
+//   - updatedAt time.Time
+func (_e *TodoStore_Expecter) SetDone(ctx interface{}, userID interface{}, id interface{}, done interface{}, updatedAt interface{}) *TodoStore_SetDone_Call {
+	return &TodoStore_SetDone_Call{Call: _e.mock.On("SetDone", ctx, userID, id, done, updatedAt)}
+}
+
+func (_c *TodoStore_SetDone_Call) Run(run func(ctx context.Context, userID int64, id int64, done bool, updatedAt time.Time)) *TodoStore_SetDone_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 int64
+		if args[2] != nil {
+			arg2 = args[2].(int64)
+		}
+		var arg3 bool
+		if args[3] != nil {
+			arg3 = args[3].(bool)
+		}
+		var arg4 time.Time
+		if args[4] != nil {
+			arg4 = args[4].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *TodoStore_SetDone_Call) Return(todo *domain.Todo, err error) *TodoStore_SetDone_Call {
+	_c.Call.Return(todo, err)
+	return _c
+}
+
+func (_c *TodoStore_SetDone_Call) RunAndReturn(run func(ctx context.Context, userID int64, id int64, done bool, updatedAt time.Time) (*domain.Todo, error)) *TodoStore_SetDone_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SoftDelete provides a mock function for the type TodoStore
+func (_mock *TodoStore) SoftDelete(ctx context.Context, id int64, deletedAt time.Time) error {
+	ret := _mock.Called(ctx, id, deletedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SoftDelete")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, time.Time) error); ok {
+		r0 = returnFunc(ctx, id, deletedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// TodoStore_SoftDelete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SoftDelete'
+type TodoStore_SoftDelete_Call struct {
+	*mock.Call
+}
+
+// SoftDelete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - deletedAt time.Time
+func (_e *TodoStore_Expecter) SoftDelete(ctx interface{}, id interface{}, deletedAt interface{}) *TodoStore_SoftDelete_Call {
+	return &TodoStore_SoftDelete_Call{Call: _e.mock.On("SoftDelete", ctx, id, deletedAt)}
+}
+
+func (_c *TodoStore_SoftDelete_Call) Run(run func(ctx context.Context, id int64, deletedAt time.Time)) *TodoStore_SoftDelete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *TodoStore_SoftDelete_Call) Return(err error) *TodoStore_SoftDelete_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *TodoStore_SoftDelete_Call) RunAndReturn(run func(ctx context.Context, id int64, deletedAt time.Time) error) *TodoStore_SoftDelete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TitleExists provides a mock function for the type TodoStore
+func (_mock *TodoStore) TitleExists(ctx context.Context, todolistID int64, title string) (bool, error) {
+	ret := _mock.Called(ctx, todolistID, title)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TitleExists")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, string) (bool, error)); ok {
+		return returnFunc(ctx, todolistID, title)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, string) bool); ok {
+		r0 = returnFunc(ctx, todolistID, title)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, string) error); ok {
+		r1 = returnFunc(ctx, todolistID, title)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// TodoStore_TitleExists_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TitleExists'
+type TodoStore_TitleExists_Call struct {
+	*mock.Call
+}
+
+// TitleExists is a helper method to define mock.On call
+//   - ctx context.Context
+//   - todolistID int64
+//   - title string
+func (_e *TodoStore_Expecter) TitleExists(ctx interface{}, todolistID interface{}, title interface{}) *TodoStore_TitleExists_Call {
+	return &TodoStore_TitleExists_Call{Call: _e.mock.On("TitleExists", ctx, todolistID, title)}
+}
+
+func (_c *TodoStore_TitleExists_Call) Run(run func(ctx context.Context, todolistID int64, title string)) *TodoStore_TitleExists_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *TodoStore_TitleExists_Call) Return(b bool, err error) *TodoStore_TitleExists_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *TodoStore_TitleExists_Call) RunAndReturn(run func(ctx context.Context, todolistID int64, title string) (bool, error)) *TodoStore_TitleExists_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TrashDone provides a mock function for the type TodoStore
+func (_mock *TodoStore) TrashDone(ctx context.Context, userID int64, todolistID int64, deletedAt time.Time) (int64, error) {
+	ret := _mock.Called(ctx, userID, todolistID, deletedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TrashDone")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int64, time.Time) (int64, error)); ok {
+		return returnFunc(ctx, userID, todolistID, deletedAt)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int64, time.Time) int64); ok {
+		r0 = returnFunc(ctx, userID, todolistID, deletedAt)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, int64, time.Time) error); ok {
+		r1 = returnFunc(ctx, userID, todolistID, deletedAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// TodoStore_TrashDone_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TrashDone'
+type TodoStore_TrashDone_Call struct {
+	*mock.Call
+}
+
+// TrashDone is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+//   - todolistID int64
+//   - deletedAt time.Time
+func (_e *TodoStore_Expecter) TrashDone(ctx interface{}, userID interface{}, todolistID interface{}, deletedAt interface{}) *TodoStore_TrashDone_Call {
+	return &TodoStore_TrashDone_Call{Call: _e.mock.On("TrashDone", ctx, userID, todolistID, deletedAt)}
+}
+
+func (_c *TodoStore_TrashDone_Call) Run(run func(ctx context.Context, userID int64, todolistID int64, deletedAt time.Time)) *TodoStore_TrashDone_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 int64
+		if args[2] != nil {
+			arg2 = args[2].(int64)
+		}
+		var arg3 time.Time
+		if args[3] != nil {
+			arg3 = args[3].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *TodoStore_TrashDone_Call) Return(n int64, err error) *TodoStore_TrashDone_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *TodoStore_TrashDone_Call) RunAndReturn(run func(ctx context.Context, userID int64, todolistID int64, deletedAt time.Time) (int64, error)) *TodoStore_TrashDone_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function for the type TodoStore
+func (_mock *TodoStore) Update(ctx context.Context, id int64, title string, done bool, dueDate *time.Time, color *string, updatedAt time.Time) (*domain.Todo, error) {
+	ret := _mock.Called(ctx, id, title, done, dueDate, color, updatedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 *domain.Todo
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, string, bool, *time.Time, *string, time.Time) (*domain.Todo, error)); ok {
+		return returnFunc(ctx, id, title, done, dueDate, color, updatedAt)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, string, bool, *time.Time, *string, time.Time) *domain.Todo); ok {
+		r0 = returnFunc(ctx, id, title, done, dueDate, color, updatedAt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Todo)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, string, bool, *time.Time, *string, time.Time) error); ok {
+		r1 = returnFunc(ctx, id, title, done, dueDate, color, updatedAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// TodoStore_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type TodoStore_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - title string
+//   - done bool
+//   - dueDate *time.Time
+//   - color *string
+//   - updatedAt time.Time
+func (_e *TodoStore_Expecter) Update(ctx interface{}, id interface{}, title interface{}, done interface{}, dueDate interface{}, color interface{}, updatedAt interface{}) *TodoStore_Update_Call {
+	return &TodoStore_Update_Call{Call: _e.mock.On("Update", ctx, id, title, done, dueDate, color, updatedAt)}
+}
+
+func (_c *TodoStore_Update_Call) Run(run func(ctx context.Context, id int64, title string, done bool, dueDate *time.Time, color *string, updatedAt time.Time)) *TodoStore_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 bool
+		if args[3] != nil {
+			arg3 = args[3].(bool)
+		}
+		var arg4 *time.Time
+		if args[4] != nil {
+			arg4 = args[4].(*time.Time)
+		}
+		var arg5 *string
+		if args[5] != nil {
+			arg5 = args[5].(*string)
+		}
+		var arg6 time.Time
+		if args[6] != nil {
+			arg6 = args[6].(time.Time)
 		}
 		run(
 			arg0,
 			arg1,
 			arg2,
 			arg3,
+			arg4,
+			arg5,
+			arg6,
 		)
 	})
 	return _c
@@ -375,7 +1498,7 @@ func (_c *TodoStore_Update_Call) Return(todo *domain.Todo, err error) *TodoStore
 	return _c
 }
 
-func (_c *TodoStore_Update_Call) RunAndReturn(run func(ctx context.Context, id int64, title string, done bool) (*domain.Todo, error)) *TodoStore_Update_Call {
+func (_c *TodoStore_Update_Call) RunAndReturn(run func(ctx context.Context, id int64, title string, done bool, dueDate *time.Time, color *string, updatedAt time.Time) (*domain.Todo, error)) *TodoStore_Update_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -5,9 +5,14 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
+	"unicode/utf8"
 
+	"github.com/google/uuid"
 	"github.com/macesz/todo-go/domain"
+	"github.com/macesz/todo-go/pkg/logctx"
 )
 
 // ListTodos returns all todos
@@ -15,26 +20,35 @@ import (
 // Here we could add more business logic if needed
 // For example, filtering, sorting, etc.
 
-func (s *TodoService) ListTodos(ctx context.Context, userID int64, todolistID int64) ([]*domain.Todo, error) {
-	todos, err := s.Store.List(ctx, userID, todolistID)
+func (s *TodoService) ListTodos(ctx context.Context, userID int64, todolistID int64, opts domain.ListOptions) ([]*domain.Todo, error) {
+	todos, err := s.Store.List(ctx, userID, todolistID, opts.WithDefaultSort(s.DefaultSort))
 	if err != nil {
 		return nil, fmt.Errorf("failed to list todos: %w", err)
 	}
 	return todos, nil
 }
 
-// CreateTodo creates a new todo with the given title
-// Returns the created Todo or an error
+// CreateTodo creates a new todo with the given title, optional due date and optional color
+// Returns the created Todo, warnings for the client (like a duplicate title) or an error
 // Like a service method in Java or JS
 // Here we could add more business logic if needed
 // For example, checking for duplicates, logging, etc.
-func (s *TodoService) CreateTodo(ctx context.Context, userID int64, todolistID int64, title string) (*domain.Todo, error) {
+func (s *TodoService) CreateTodo(ctx context.Context, userID int64, todolistID int64, title string, dueDate *time.Time, color *string) (*domain.Todo, []string, error) {
 	// Validate title
+	title, err := domain.SanitizeTitle(title)
+	if err != nil {
+		return nil, nil, err
+	}
 	if title == "" {
-		return nil, domain.ErrInvalidTitle
+		return nil, nil, domain.ErrInvalidTitle
 	}
 
-	createdAt := time.Now()
+	warnings, err := s.titleWarnings(ctx, todolistID, title, true)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	createdAt := s.now()
 
 	todo := &domain.Todo{
 		UserID:     userID,
@@ -42,14 +56,19 @@ func (s *TodoService) CreateTodo(ctx context.Context, userID int64, todolistID i
 		Title:      title,
 		Done:       false,
 		CreatedAt:  createdAt,
+		UpdatedAt:  createdAt,
+		DueDate:    dueDate,
+		Color:      color,
+		Source:     domain.TodoSourceFrom(ctx),
 	}
 
-	err := s.Store.Create(ctx, todolistID, todo) // Delegate to the store
+	err = s.Store.Create(ctx, todolistID, todo) // Delegate to the store
 	if err != nil {
-		return nil, fmt.Errorf("failed to create todo: %w", err)
+		logctx.From(ctx).Error("failed to create todo", "user_id", userID, "list_id", todolistID, "error", err)
+		return nil, nil, fmt.Errorf("failed to create todo: %w", err)
 	}
 
-	return todo, nil
+	return todo, warnings, nil
 
 }
 
@@ -75,42 +94,425 @@ func (s *TodoService) GetTodo(ctx context.Context, userID int64, id int64) (*dom
 	return todo, nil
 }
 
-// UpdateTodo updates an existing todo by ID
+// ResolveTodoID returns the internal id of the user's todo with the given public id
+
+func (s *TodoService) ResolveTodoID(ctx context.Context, userID int64, publicID string) (int64, error) {
+	id, err := s.Store.IDByPublicID(ctx, userID, publicID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, domain.ErrNotFound
+		}
+		return 0, fmt.Errorf("failed to resolve todo id: %w", err)
+	}
+
+	return id, nil
+}
+
+// ResolveListID returns the internal id of the user's list with the given public id
 
-func (s *TodoService) UpdateTodo(ctx context.Context, userID int64, id int64, title string, done bool) (*domain.Todo, error) {
+func (s *TodoService) ResolveListID(ctx context.Context, userID int64, publicID string) (int64, error) {
+	id, err := s.Store.ListIDByPublicID(ctx, userID, publicID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, domain.ErrListNotFound
+		}
+		return 0, fmt.Errorf("failed to resolve list id: %w", err)
+	}
 
-	_, err := s.GetTodo(ctx, userID, id)
+	return id, nil
+}
+
+// GetTodoInList retrieves a todo by ID, but only if it belongs to the given list
+// A todo fetched through the wrong list is reported as not found
+
+func (s *TodoService) GetTodoInList(ctx context.Context, userID int64, todolistID int64, id int64) (*domain.Todo, error) {
+	todo, err := s.GetTodo(ctx, userID, id)
 	if err != nil {
-		// GetTodo already returns domain.ErrNotFound if not found or not owned
 		return nil, err
 	}
 
-	updated, err := s.Store.Update(ctx, id, title, done)
+	if todo.TodoListID != todolistID {
+		return nil, domain.ErrNotFound
+	}
+
+	return todo, nil
+}
+
+// UpdateTodo updates an existing todo by ID, a nil due date or color clears it
+// Returns the updated Todo and warnings for the client, like CreateTodo
+
+func (s *TodoService) UpdateTodo(ctx context.Context, userID int64, id int64, title string, done bool, dueDate *time.Time, color *string) (*domain.Todo, []string, error) {
+	title, err := domain.SanitizeTitle(title)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	existing, err := s.GetTodo(ctx, userID, id)
+	if err != nil {
+		// GetTodo already returns domain.ErrNotFound if not found or not owned
+		return nil, nil, err
+	}
+
+	// An unchanged title always matches the todo itself, so only a new title is checked for duplicates
+	warnings, err := s.titleWarnings(ctx, existing.TodoListID, title, title != existing.Title)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	updated, err := s.Store.Update(ctx, id, title, done, dueDate, color, s.now())
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, domain.ErrNotFound
+		}
+		return nil, nil, fmt.Errorf("failed to update todo: %w", err)
+	}
+
+	return updated, warnings, nil
+}
+
+// SetDone marks the user's todo done or not done, without touching its title
+// Returns ErrNotFound when the user has no such todo
+
+func (s *TodoService) SetDone(ctx context.Context, userID int64, id int64, done bool) (*domain.Todo, error) {
+	updated, err := s.Store.SetDone(ctx, userID, id, done, s.now())
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, domain.ErrNotFound
 		}
-		return nil, fmt.Errorf("failed to update todo: %w", err)
+		logctx.From(ctx).Error("failed to set todo done", "user_id", userID, "todo_id", id, "done", done, "error", err)
+		return nil, fmt.Errorf("failed to set todo done: %w", err)
 	}
 
 	return updated, nil
 }
 
-// DeleteTodo deletes a todo by ID
+// titleWarnings returns the warnings for a title that is valid but suspicious.
+// A warning never fails the request, the todo is still saved.
+func (s *TodoService) titleWarnings(ctx context.Context, todolistID int64, title string, checkDuplicate bool) ([]string, error) {
+	var warnings []string
+
+	// A duplicate title is allowed, the client only gets a warning
+	if s.WarnDuplicateTitles && checkDuplicate {
+		exists, err := s.Store.TitleExists(ctx, todolistID, title)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check todo title: %w", err)
+		}
 
-func (s *TodoService) DeleteTodo(ctx context.Context, userID int64, id int64) error {
-	if _, err := s.GetTodo(ctx, userID, id); err != nil {
-		return err
+		if exists {
+			warnings = append(warnings, domain.WarnDuplicateTitle)
+		}
 	}
 
-	err := s.Store.Delete(ctx, id)
+	if utf8.RuneCountInString(title) >= domain.LongTitleWarnLength {
+		warnings = append(warnings, domain.WarnLongTitle)
+	}
+
+	return warnings, nil
+}
+
+// DeleteTodo deletes a todo by ID and returns it as it was before the delete
+// With the SoftDelete option the todo is trashed instead of removed
+
+func (s *TodoService) DeleteTodo(ctx context.Context, userID int64, id int64) (*domain.Todo, error) {
+	todo, err := s.GetTodo(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.SoftDelete {
+		err = s.Store.SoftDelete(ctx, id, s.now())
+	} else {
+		err = s.Store.Delete(ctx, id)
+	}
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return domain.ErrNotFound
+			return nil, domain.ErrNotFound
 		}
-		return fmt.Errorf("failed to delete todo: %w", err)
+		logctx.From(ctx).Error("failed to delete todo", "user_id", userID, "todo_id", id, "error", err)
+		return nil, fmt.Errorf("failed to delete todo: %w", err)
+	}
+
+	return todo, nil
+
+}
+
+// DeleteTodoDryRun reports the todo DeleteTodo would delete, without deleting it
+
+func (s *TodoService) DeleteTodoDryRun(ctx context.Context, userID int64, id int64) (*domain.DryRunResult, error) {
+	todo, err := s.GetTodo(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.DryRunResult{Count: 1, IDs: []string{todo.PublicID}}, nil
+}
+
+// EmptyDone deletes every done todo of the list and returns how many were deleted
+// With the SoftDelete option they are trashed instead of removed, like in DeleteTodo
+
+func (s *TodoService) EmptyDone(ctx context.Context, userID int64, todolistID int64) (int64, error) {
+	var count int64
+	var err error
+	if s.SoftDelete {
+		count, err = s.Store.TrashDone(ctx, userID, todolistID, s.now())
+	} else {
+		count, err = s.Store.DeleteDone(ctx, userID, todolistID)
+	}
+	if err != nil {
+		logctx.From(ctx).Error("failed to empty done todos", "user_id", userID, "list_id", todolistID, "error", err)
+		return 0, fmt.Errorf("failed to empty done todos: %w", err)
 	}
 
-	return nil
+	logctx.From(ctx).Info("emptied done todos", "user_id", userID, "list_id", todolistID, "count", count)
 
+	return count, nil
+}
+
+// UndoDelete takes the user's todo of the list back out of the trash and returns it
+// Only within UndoWindow of the delete, after that it returns ErrUndoExpired and the todo waits for PurgeTrash
+
+func (s *TodoService) UndoDelete(ctx context.Context, userID int64, todolistID int64, publicID string) (*domain.Todo, error) {
+	todo, err := s.Store.GetTrashed(ctx, userID, publicID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get trashed todo: %w", err)
+	}
+
+	if todo.TodoListID != todolistID {
+		return nil, domain.ErrNotFound
+	}
+
+	if todo.DeletedAt == nil || s.now().Sub(*todo.DeletedAt) > s.undoWindow() {
+		return nil, domain.ErrUndoExpired
+	}
+
+	if err := s.Store.Restore(ctx, todolistID, todo.ID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		logctx.From(ctx).Error("failed to restore todo", "user_id", userID, "todo_id", todo.ID, "error", err)
+		return nil, fmt.Errorf("failed to restore todo: %w", err)
+	}
+
+	todo.DeletedAt = nil
+
+	return todo, nil
+}
+
+// PurgeTrash removes the todos whose undo window has passed, for every user
+// Returns the number of removed todos
+
+func (s *TodoService) PurgeTrash(ctx context.Context) (int64, error) {
+	count, err := s.Store.PurgeTrash(ctx, s.now().Add(-s.undoWindow()))
+	if err != nil {
+		logctx.From(ctx).Error("failed to purge trash", "error", err)
+		return 0, fmt.Errorf("failed to purge trash: %w", err)
+	}
+
+	return count, nil
+}
+
+// RunTrashPurge calls PurgeTrash every interval until the context is done
+
+func (s *TodoService) RunTrashPurge(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if count, err := s.PurgeTrash(ctx); err == nil && count > 0 {
+				logctx.From(ctx).Info("purged trash", "count", count)
+			}
+		}
+	}
+}
+
+// ToggleAll marks every todo of the list done or not done
+// Returns the number of todos that changed
+
+func (s *TodoService) ToggleAll(ctx context.Context, userID int64, todolistID int64, done bool) (int64, error) {
+	count, err := s.Store.SetAllDone(ctx, userID, todolistID, done, s.now())
+	if err != nil {
+		logctx.From(ctx).Error("failed to toggle todos", "user_id", userID, "list_id", todolistID, "done", done, "error", err)
+		return 0, fmt.Errorf("failed to toggle todos: %w", err)
+	}
+
+	return count, nil
+}
+
+// RecentlyCompleted returns the user's most recently completed todos across all lists
+// Newest completion first, at most limit todos
+
+func (s *TodoService) RecentlyCompleted(ctx context.Context, userID int64, limit int) ([]*domain.Todo, error) {
+	if limit <= 0 {
+		return nil, domain.ErrInvalidInput
+	}
+
+	todos, err := s.Store.RecentlyCompleted(ctx, userID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list recently completed todos: %w", err)
+	}
+
+	return todos, nil
+}
+
+// ListOverdue returns the user's not done todos whose due date has passed, across all lists
+// Earliest due first
+
+func (s *TodoService) ListOverdue(ctx context.Context, userID int64) ([]*domain.Todo, error) {
+	todos, err := s.Store.ListOverdue(ctx, userID, s.now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list overdue todos: %w", err)
+	}
+
+	return todos, nil
+}
+
+// EmptyDoneDryRun reports the todos EmptyDone would delete, without deleting them
+
+func (s *TodoService) EmptyDoneDryRun(ctx context.Context, userID int64, todolistID int64) (*domain.DryRunResult, error) {
+	done := true
+
+	todos, err := s.Store.List(ctx, userID, todolistID, domain.ListOptions{Done: &done})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list done todos: %w", err)
+	}
+
+	ids := make([]string, 0, len(todos))
+	for _, todo := range todos {
+		ids = append(ids, todo.PublicID)
+	}
+
+	return &domain.DryRunResult{Count: int64(len(ids)), IDs: ids}, nil
+}
+
+// ImportCSV creates a todo for every valid row of a CSV upload.
+// A row with a bad title, done value or list is skipped and reported in its result,
+// only an unexpected store error aborts the import.
+func (s *TodoService) ImportCSV(ctx context.Context, userID int64, rows []domain.CSVTodoRow) ([]domain.CSVTodoResult, error) {
+	results := make([]domain.CSVTodoResult, 0, len(rows))
+
+	for _, row := range rows {
+		todo, err := s.importCSVRow(ctx, userID, row)
+		if err != nil {
+			if errors.Is(err, domain.ErrInvalidTitle) || errors.Is(err, domain.ErrInvalidInput) || errors.Is(err, domain.ErrListNotFound) {
+				results = append(results, domain.CSVTodoResult{Line: row.Line, Err: err})
+				continue
+			}
+			logctx.From(ctx).Error("failed to import csv row", "user_id", userID, "line", row.Line, "error", err)
+			return nil, fmt.Errorf("failed to import csv line %d: %w", row.Line, err)
+		}
+
+		results = append(results, domain.CSVTodoResult{Line: row.Line, ID: todo.PublicID})
+	}
+
+	return results, nil
+}
+
+func (s *TodoService) importCSVRow(ctx context.Context, userID int64, row domain.CSVTodoRow) (*domain.Todo, error) {
+	title, err := domain.SanitizeTitle(strings.TrimSpace(row.Title))
+	if err != nil {
+		return nil, err
+	}
+	if title == "" {
+		return nil, domain.ErrInvalidTitle
+	}
+	if utf8.RuneCountInString(title) > domain.MaxTitleLength {
+		return nil, fmt.Errorf("%w: title must be at most %d characters", domain.ErrInvalidInput, domain.MaxTitleLength)
+	}
+
+	done := false
+	if value := strings.TrimSpace(row.Done); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: done must be true or false", domain.ErrInvalidInput)
+		}
+		done = parsed
+	}
+
+	// public_id is a UUID column, anything else would fail the query instead of not matching
+	listID, err := uuid.Parse(strings.TrimSpace(row.ListID))
+	if err != nil {
+		return nil, fmt.Errorf("%w: list_id must be a UUID", domain.ErrInvalidInput)
+	}
+
+	todolistID, err := s.ResolveListID(ctx, userID, listID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	createdAt := s.now()
+
+	todo := &domain.Todo{
+		UserID:     userID,
+		TodoListID: todolistID,
+		Title:      title,
+		Done:       done,
+		CreatedAt:  createdAt,
+		UpdatedAt:  createdAt,
+		Source:     domain.TodoSourceImport,
+	}
+
+	// The store sets completed_at of a todo created as done
+	if err := s.Store.Create(ctx, todolistID, todo); err != nil {
+		return nil, err
+	}
+
+	return todo, nil
+}
+
+// Clone copies the user's todo into its list as a new todo that is not done.
+// An empty title keeps the title of the original, the due date is copied as well.
+func (s *TodoService) Clone(ctx context.Context, userID int64, id int64, title string) (*domain.Todo, error) {
+	title, err := domain.SanitizeTitle(title)
+	if err != nil {
+		return nil, err
+	}
+
+	original, err := s.GetTodo(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if title == "" {
+		title = original.Title
+	}
+
+	createdAt := s.now()
+
+	clone := &domain.Todo{
+		UserID:           userID,
+		TodoListID:       original.TodoListID,
+		TodoListPublicID: original.TodoListPublicID,
+		Title:            title,
+		Done:             false,
+		CreatedAt:        createdAt,
+		UpdatedAt:        createdAt,
+		DueDate:          original.DueDate,
+		Color:            original.Color,
+		Source:           domain.TodoSourceFrom(ctx),
+	}
+
+	if err := s.Store.Create(ctx, original.TodoListID, clone); err != nil {
+		logctx.From(ctx).Error("failed to clone todo", "user_id", userID, "todo_id", id, "error", err)
+		return nil, fmt.Errorf("failed to clone todo: %w", err)
+	}
+
+	return clone, nil
+}
+
+// BatchGet returns the user's todos with the given public ids, ordered by id.
+// Ids of todos that don't exist or belong to another user are skipped, not reported.
+func (s *TodoService) BatchGet(ctx context.Context, userID int64, publicIDs []string) ([]*domain.Todo, error) {
+	todos, err := s.Store.GetByPublicIDs(ctx, userID, publicIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get todos: %w", err)
+	}
+
+	return todos, nil
 }
@@ -1,13 +1,19 @@
 package todo
 
 import (
+	"bytes"
 	"context"
+	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"log/slog"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/macesz/todo-go/domain"
+	"github.com/macesz/todo-go/pkg/logctx"
 	"github.com/macesz/todo-go/services/todo/mocks"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -36,6 +42,7 @@ func TestListTodos(t *testing.T) {
 		ctx    context.Context
 		userID int64
 		listID int64
+		opts   domain.ListOptions
 	}
 
 	// Define the test cases
@@ -65,7 +72,7 @@ func TestListTodos(t *testing.T) {
 					store.AssertExpectations(tt)
 				})
 
-				store.On("List", ta.ctx, ta.userID, ta.listID).Return([]*domain.Todo{
+				store.On("List", ta.ctx, ta.userID, ta.listID, ta.opts).Return([]*domain.Todo{
 					{ID: 1, UserID: 1, TodoListID: 1, Title: "Test Todo 1", Done: false, CreatedAt: fixedTime},
 					{ID: 2, UserID: 1, TodoListID: 1, Title: "Test Todo 2", Done: true, CreatedAt: fixedTime},
 				}, nil).Once()
@@ -84,7 +91,7 @@ func TestListTodos(t *testing.T) {
 				tt.Cleanup(func() {
 					store.AssertExpectations(tt)
 				})
-				store.On("List", ta.ctx, ta.userID, ta.listID).Return(nil, errors.New("could not list")).Once()
+				store.On("List", ta.ctx, ta.userID, ta.listID, ta.opts).Return(nil, errors.New("could not list")).Once()
 
 				s.Store = store
 			},
@@ -101,7 +108,7 @@ func TestListTodos(t *testing.T) {
 
 			tc.initMocks(t, &tc.args, s)
 
-			got, err := s.ListTodos(tc.args.ctx, tc.args.userID, tc.args.listID)
+			got, err := s.ListTodos(tc.args.ctx, tc.args.userID, tc.args.listID, tc.args.opts)
 			if tc.wantErr {
 				require.Error(t, err)
 				return
@@ -148,7 +155,8 @@ func TestCreateTodo(t *testing.T) {
 				require.Equal(t, ta.listID, todo.TodoListID)
 				require.Equal(t, ta.title, todo.Title)
 				require.False(t, todo.Done)
-				require.NotZero(t, todo.CreatedAt)
+				require.Equal(t, fixedTime, todo.CreatedAt)
+				require.Equal(t, fixedTime, todo.UpdatedAt)
 			},
 			initMocks: func(tt *testing.T, ta *args, s *TodoService) {
 				store := mocks.NewTodoStore(tt)
@@ -168,6 +176,7 @@ func TestCreateTodo(t *testing.T) {
 				}).Return(nil).Once()
 
 				s.Store = store
+				s.Clock = domain.FixedClock{Time: fixedTime}
 			},
 		},
 		{
@@ -214,7 +223,7 @@ func TestCreateTodo(t *testing.T) {
 
 			tc.initMocks(t, &tc.args, s)
 
-			got, err := s.CreateTodo(tc.args.ctx, tc.args.userId, tc.args.listID, tc.args.title)
+			got, _, err := s.CreateTodo(tc.args.ctx, tc.args.userId, tc.args.listID, tc.args.title, nil, nil)
 
 			if tc.wantErr {
 				require.Error(t, err)
@@ -330,6 +339,74 @@ func TestGetTodo(t *testing.T) {
 	}
 }
 
+func TestGetTodoInList(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		ctx    context.Context
+		userID int64
+		listID int64
+		id     int64
+	}
+
+	tests := []struct {
+		name      string
+		args      args
+		want      *domain.Todo
+		wantedErr error
+		initMocks func(tt *testing.T, ta *args, s *TodoService)
+	}{
+		{
+			name: "todo in list",
+			args: args{ctx: context.Background(), userID: 1, listID: 1, id: 1},
+			want: &domain.Todo{ID: 1, UserID: 1, TodoListID: 1, Title: "Test Todo", CreatedAt: fixedTime},
+			initMocks: func(tt *testing.T, ta *args, s *TodoService) {
+				store := mocks.NewTodoStore(tt)
+				tt.Cleanup(func() { store.AssertExpectations(tt) })
+
+				store.On("Get", ta.ctx, ta.id).Return(&domain.Todo{
+					ID: 1, UserID: 1, TodoListID: 1, Title: "Test Todo", CreatedAt: fixedTime,
+				}, nil).Once()
+
+				s.Store = store
+			},
+		},
+		{
+			name:      "todo in another list",
+			args:      args{ctx: context.Background(), userID: 1, listID: 2, id: 1},
+			wantedErr: domain.ErrNotFound,
+			initMocks: func(tt *testing.T, ta *args, s *TodoService) {
+				store := mocks.NewTodoStore(tt)
+				tt.Cleanup(func() { store.AssertExpectations(tt) })
+
+				store.On("Get", ta.ctx, ta.id).Return(&domain.Todo{
+					ID: 1, UserID: 1, TodoListID: 1, Title: "Test Todo", CreatedAt: fixedTime,
+				}, nil).Once()
+
+				s.Store = store
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := &TodoService{}
+
+			tc.initMocks(t, &tc.args, s)
+
+			got, err := s.GetTodoInList(tc.args.ctx, tc.args.userID, tc.args.listID, tc.args.id)
+			if tc.wantedErr != nil {
+				require.ErrorIs(t, err, tc.wantedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
+
 func TestUpdateTodo(t *testing.T) {
 	testListID := int64(1)
 
@@ -397,7 +474,7 @@ func TestUpdateTodo(t *testing.T) {
 				}, nil).Once()
 
 				// When Update is called with the given context, id, title, and done status, return a predefined todo
-				store.On("Update", ta.ctx, ta.id, ta.title, ta.done).Return(&domain.Todo{
+				store.On("Update", ta.ctx, ta.id, ta.title, ta.done, (*time.Time)(nil), (*string)(nil), fixedTime).Return(&domain.Todo{
 					UserID:     ta.userId,
 					ID:         ta.id,
 					TodoListID: ta.listID,
@@ -432,7 +509,7 @@ func TestUpdateTodo(t *testing.T) {
 					Done:   false,
 				}, nil).Once()
 
-				store.On("Update", ta.ctx, ta.id, ta.title, ta.done).Return((*domain.Todo)(nil), errors.New("not found")).Once()
+				store.On("Update", ta.ctx, ta.id, ta.title, ta.done, (*time.Time)(nil), (*string)(nil), fixedTime).Return((*domain.Todo)(nil), errors.New("not found")).Once()
 
 				s.Store = store
 			},
@@ -445,12 +522,13 @@ func TestUpdateTodo(t *testing.T) {
 			t.Parallel()
 
 			s := &TodoService{
-				Store: tc.fields.Store,
+				Store:   tc.fields.Store,
+				Options: Options{Clock: domain.FixedClock{Time: fixedTime}},
 			}
 
 			tc.initMocks(t, &tc.args, s)
 
-			got, err := s.UpdateTodo(tc.args.ctx, tc.args.userId, tc.args.id, tc.args.title, tc.args.done)
+			got, _, err := s.UpdateTodo(tc.args.ctx, tc.args.userId, tc.args.id, tc.args.title, tc.args.done, nil, nil)
 
 			require.Equal(t, tc.want, got)
 			require.Equal(t, tc.wantErr, err != nil)
@@ -508,6 +586,32 @@ func TestDeleteTodo(t *testing.T) {
 				s.Store = store
 			},
 		},
+		{
+			name:    "soft delete",
+			fields:  fields{},
+			wantErr: false,
+			args: args{
+				ctx:    context.Background(),
+				userId: 1,
+				id:     1,
+			},
+			initMocks: func(tt *testing.T, ta *args, s *TodoService) {
+				store := mocks.NewTodoStore(tt)
+				tt.Cleanup(func() { store.AssertExpectations(tt) })
+
+				store.On("Get", ta.ctx, ta.id).Return(&domain.Todo{
+					ID:     ta.id,
+					UserID: ta.userId,
+					Title:  "Test Todo",
+				}, nil).Once()
+
+				// Delete must not be called when soft delete is on
+				store.On("SoftDelete", ta.ctx, ta.id, fixedTime).Return(nil).Once()
+
+				s.Store = store
+				s.SoftDelete = true
+			},
+		},
 		{
 			name:    "not found",
 			fields:  fields{},
@@ -539,14 +643,1049 @@ func TestDeleteTodo(t *testing.T) {
 			t.Parallel()
 
 			s := &TodoService{
-				Store: tt.fields.Store,
+				Store:   tt.fields.Store,
+				Options: Options{Clock: domain.FixedClock{Time: fixedTime}},
 			}
 
 			tt.initMocks(t, &tt.args, s)
 
-			err := s.DeleteTodo(tt.args.ctx, tt.args.userId, tt.args.id)
+			got, err := s.DeleteTodo(tt.args.ctx, tt.args.userId, tt.args.id)
 
 			require.Equal(t, tt.wantErr, err != nil)
+			if !tt.wantErr {
+				// The todo comes back as it was read before the delete
+				require.Equal(t, &domain.Todo{ID: tt.args.id, UserID: tt.args.userId, Title: "Test Todo"}, got)
+			}
+		})
+	}
+}
+
+func TestEmptyDone(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		ctx    context.Context
+		userID int64
+		listID int64
+	}
+
+	tests := []struct {
+		name      string
+		args      args
+		want      int64
+		wantErr   bool
+		initMocks func(tt *testing.T, ta *args, s *TodoService)
+	}{
+		{
+			name: "success",
+			args: args{ctx: context.Background(), userID: 1, listID: 1},
+			want: 2,
+			initMocks: func(tt *testing.T, ta *args, s *TodoService) {
+				store := mocks.NewTodoStore(tt)
+				tt.Cleanup(func() { store.AssertExpectations(tt) })
+
+				// TrashDone must not be called when soft delete is off
+				store.On("DeleteDone", ta.ctx, ta.userID, ta.listID).Return(int64(2), nil).Once()
+
+				s.Store = store
+			},
+		},
+		{
+			name: "soft delete",
+			args: args{ctx: context.Background(), userID: 1, listID: 1},
+			want: 2,
+			initMocks: func(tt *testing.T, ta *args, s *TodoService) {
+				store := mocks.NewTodoStore(tt)
+				tt.Cleanup(func() { store.AssertExpectations(tt) })
+
+				// DeleteDone must not be called when soft delete is on
+				store.On("TrashDone", ta.ctx, ta.userID, ta.listID, fixedTime).Return(int64(2), nil).Once()
+
+				s.Store = store
+				s.SoftDelete = true
+			},
+		},
+		{
+			name:    "store error",
+			args:    args{ctx: context.Background(), userID: 1, listID: 1},
+			wantErr: true,
+			initMocks: func(tt *testing.T, ta *args, s *TodoService) {
+				store := mocks.NewTodoStore(tt)
+				tt.Cleanup(func() { store.AssertExpectations(tt) })
+
+				store.On("DeleteDone", ta.ctx, ta.userID, ta.listID).Return(int64(0), errors.New("db error")).Once()
+
+				s.Store = store
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := &TodoService{Options: Options{Clock: domain.FixedClock{Time: fixedTime}}}
+
+			tt.initMocks(t, &tt.args, s)
+
+			got, err := s.EmptyDone(tt.args.ctx, tt.args.userID, tt.args.listID)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRecentlyCompleted(t *testing.T) {
+	t.Parallel()
+
+	completedAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	type args struct {
+		ctx    context.Context
+		userID int64
+		limit  int
+	}
+
+	tests := []struct {
+		name      string
+		args      args
+		want      []*domain.Todo
+		wantErr   bool
+		wantErrIs error
+		initMocks func(tt *testing.T, ta *args, s *TodoService)
+	}{
+		{
+			name: "success",
+			args: args{ctx: context.Background(), userID: 1, limit: 10},
+			want: []*domain.Todo{{ID: 1, UserID: 1, Title: "done", Done: true, CompletedAt: &completedAt}},
+			initMocks: func(tt *testing.T, ta *args, s *TodoService) {
+				store := mocks.NewTodoStore(tt)
+				tt.Cleanup(func() { store.AssertExpectations(tt) })
+
+				store.On("RecentlyCompleted", ta.ctx, ta.userID, ta.limit).
+					Return([]*domain.Todo{{ID: 1, UserID: 1, Title: "done", Done: true, CompletedAt: &completedAt}}, nil).Once()
+
+				s.Store = store
+			},
+		},
+		{
+			name:      "invalid limit",
+			args:      args{ctx: context.Background(), userID: 1, limit: 0},
+			wantErr:   true,
+			wantErrIs: domain.ErrInvalidInput,
+			initMocks: func(tt *testing.T, ta *args, s *TodoService) {},
+		},
+		{
+			name:    "store error",
+			args:    args{ctx: context.Background(), userID: 1, limit: 10},
+			wantErr: true,
+			initMocks: func(tt *testing.T, ta *args, s *TodoService) {
+				store := mocks.NewTodoStore(tt)
+				tt.Cleanup(func() { store.AssertExpectations(tt) })
+
+				store.On("RecentlyCompleted", ta.ctx, ta.userID, ta.limit).Return(nil, errors.New("db error")).Once()
+
+				s.Store = store
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := &TodoService{}
+
+			tt.initMocks(t, &tt.args, s)
+
+			got, err := s.RecentlyCompleted(tt.args.ctx, tt.args.userID, tt.args.limit)
+			if tt.wantErr {
+				require.Error(t, err)
+				if tt.wantErrIs != nil {
+					require.ErrorIs(t, err, tt.wantErrIs)
+				}
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestEmptyDoneDryRun(t *testing.T) {
+	t.Parallel()
+
+	done := true
+
+	type args struct {
+		ctx    context.Context
+		userID int64
+		listID int64
+	}
+
+	tests := []struct {
+		name      string
+		args      args
+		want      *domain.DryRunResult
+		wantErr   bool
+		initMocks func(tt *testing.T, ta *args, s *TodoService)
+	}{
+		{
+			name: "reports the done todos without trashing them",
+			args: args{ctx: context.Background(), userID: 1, listID: 1},
+			want: &domain.DryRunResult{Count: 2, IDs: []string{"todo-1", "todo-2"}},
+			initMocks: func(tt *testing.T, ta *args, s *TodoService) {
+				store := mocks.NewTodoStore(tt)
+				tt.Cleanup(func() { store.AssertExpectations(tt) })
+
+				// No TrashDone expectation, the mock fails if the store is asked to trash
+				store.On("List", ta.ctx, ta.userID, ta.listID, domain.ListOptions{Done: &done}).
+					Return([]*domain.Todo{{ID: 1, PublicID: "todo-1", Done: true}, {ID: 2, PublicID: "todo-2", Done: true}}, nil).Once()
+
+				s.Store = store
+			},
+		},
+		{
+			name: "nothing done",
+			args: args{ctx: context.Background(), userID: 1, listID: 1},
+			want: &domain.DryRunResult{Count: 0, IDs: []string{}},
+			initMocks: func(tt *testing.T, ta *args, s *TodoService) {
+				store := mocks.NewTodoStore(tt)
+				tt.Cleanup(func() { store.AssertExpectations(tt) })
+
+				store.On("List", ta.ctx, ta.userID, ta.listID, domain.ListOptions{Done: &done}).Return([]*domain.Todo{}, nil).Once()
+
+				s.Store = store
+			},
+		},
+		{
+			name:    "store error",
+			args:    args{ctx: context.Background(), userID: 1, listID: 1},
+			wantErr: true,
+			initMocks: func(tt *testing.T, ta *args, s *TodoService) {
+				store := mocks.NewTodoStore(tt)
+				tt.Cleanup(func() { store.AssertExpectations(tt) })
+
+				store.On("List", ta.ctx, ta.userID, ta.listID, domain.ListOptions{Done: &done}).Return(nil, errors.New("db error")).Once()
+
+				s.Store = store
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := &TodoService{}
+
+			tt.initMocks(t, &tt.args, s)
+
+			got, err := s.EmptyDoneDryRun(tt.args.ctx, tt.args.userID, tt.args.listID)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDeleteTodoDryRun(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		ctx    context.Context
+		userID int64
+		id     int64
+	}
+
+	tests := []struct {
+		name      string
+		args      args
+		want      *domain.DryRunResult
+		wantErrIs error
+		initMocks func(tt *testing.T, ta *args, s *TodoService)
+	}{
+		{
+			name: "reports the todo without deleting it",
+			args: args{ctx: context.Background(), userID: 1, id: 1},
+			want: &domain.DryRunResult{Count: 1, IDs: []string{"todo-1"}},
+			initMocks: func(tt *testing.T, ta *args, s *TodoService) {
+				store := mocks.NewTodoStore(tt)
+				tt.Cleanup(func() { store.AssertExpectations(tt) })
+
+				store.On("Get", ta.ctx, ta.id).Return(&domain.Todo{ID: 1, PublicID: "todo-1", UserID: 1}, nil).Once()
+
+				s.Store = store
+				s.SoftDelete = true
+			},
+		},
+		{
+			name:      "not found",
+			args:      args{ctx: context.Background(), userID: 1, id: 2},
+			wantErrIs: domain.ErrNotFound,
+			initMocks: func(tt *testing.T, ta *args, s *TodoService) {
+				store := mocks.NewTodoStore(tt)
+				tt.Cleanup(func() { store.AssertExpectations(tt) })
+
+				store.On("Get", ta.ctx, ta.id).Return(nil, sql.ErrNoRows).Once()
+
+				s.Store = store
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := &TodoService{}
+
+			tt.initMocks(t, &tt.args, s)
+
+			got, err := s.DeleteTodoDryRun(tt.args.ctx, tt.args.userID, tt.args.id)
+			if tt.wantErrIs != nil {
+				require.ErrorIs(t, err, tt.wantErrIs)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestToggleAll(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		ctx    context.Context
+		userID int64
+		listID int64
+		done   bool
+	}
+
+	tests := []struct {
+		name      string
+		args      args
+		want      int64
+		wantErr   bool
+		initMocks func(tt *testing.T, ta *args, s *TodoService)
+	}{
+		{
+			name: "mark all done",
+			args: args{ctx: context.Background(), userID: 1, listID: 1, done: true},
+			want: 3,
+			initMocks: func(tt *testing.T, ta *args, s *TodoService) {
+				store := mocks.NewTodoStore(tt)
+				tt.Cleanup(func() { store.AssertExpectations(tt) })
+
+				store.On("SetAllDone", ta.ctx, ta.userID, ta.listID, true, fixedTime).Return(int64(3), nil).Once()
+
+				s.Store = store
+			},
+		},
+		{
+			name: "mark all not done",
+			args: args{ctx: context.Background(), userID: 1, listID: 1, done: false},
+			want: 1,
+			initMocks: func(tt *testing.T, ta *args, s *TodoService) {
+				store := mocks.NewTodoStore(tt)
+				tt.Cleanup(func() { store.AssertExpectations(tt) })
+
+				store.On("SetAllDone", ta.ctx, ta.userID, ta.listID, false, fixedTime).Return(int64(1), nil).Once()
+
+				s.Store = store
+			},
+		},
+		{
+			name:    "store error",
+			args:    args{ctx: context.Background(), userID: 1, listID: 1, done: true},
+			wantErr: true,
+			initMocks: func(tt *testing.T, ta *args, s *TodoService) {
+				store := mocks.NewTodoStore(tt)
+				tt.Cleanup(func() { store.AssertExpectations(tt) })
+
+				store.On("SetAllDone", ta.ctx, ta.userID, ta.listID, true, fixedTime).Return(int64(0), errors.New("db error")).Once()
+
+				s.Store = store
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := &TodoService{Options: Options{Clock: domain.FixedClock{Time: fixedTime}}}
+
+			tt.initMocks(t, &tt.args, s)
+
+			got, err := s.ToggleAll(tt.args.ctx, tt.args.userID, tt.args.listID, tt.args.done)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestListTodosDefaultSort(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		opts      domain.ListOptions
+		storeOpts domain.ListOptions
+	}{
+		{
+			name:      "no sort uses the default",
+			opts:      domain.ListOptions{Limit: 10},
+			storeOpts: domain.ListOptions{Limit: 10, Sort: "title", Order: "desc"},
+		},
+		{
+			name:      "explicit sort is kept",
+			opts:      domain.ListOptions{Sort: "id", Order: "asc"},
+			storeOpts: domain.ListOptions{Sort: "id", Order: "asc"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+
+			store := mocks.NewTodoStore(t)
+			store.On("List", ctx, int64(1), int64(1), tc.storeOpts).Return([]*domain.Todo{}, nil).Once()
+
+			s := NewTodoService(store, Options{DefaultSort: domain.Sort{Field: "title", Order: "desc"}})
+
+			_, err := s.ListTodos(ctx, 1, 1, tc.opts)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestCreateTodoSource(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want domain.TodoSource
+	}{
+		{name: "defaults to api", ctx: context.Background(), want: domain.TodoSourceAPI},
+		{name: "taken from the context", ctx: domain.WithTodoSource(context.Background(), domain.TodoSourceWeb), want: domain.TodoSourceWeb},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			store := mocks.NewTodoStore(t)
+			store.On("Create", tc.ctx, int64(1), mock.MatchedBy(func(todo *domain.Todo) bool {
+				return todo.Source == tc.want
+			})).Return(nil).Once()
+
+			s := NewTodoService(store, Options{})
+
+			todo, _, err := s.CreateTodo(tc.ctx, 1, 1, "Buy milk", nil, nil)
+			require.NoError(t, err)
+			require.Equal(t, tc.want, todo.Source)
+		})
+	}
+}
+
+func TestCreateTodoDuplicateTitleWarning(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		warn         bool
+		titleExists  bool
+		wantWarnings []string
+	}{
+		{
+			name:         "duplicate title gives a warning",
+			warn:         true,
+			titleExists:  true,
+			wantWarnings: []string{domain.WarnDuplicateTitle},
+		},
+		{
+			name:         "unique title gives no warnings",
+			warn:         true,
+			titleExists:  false,
+			wantWarnings: nil,
+		},
+		{
+			name:         "warning disabled",
+			warn:         false,
+			wantWarnings: nil,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+
+			store := mocks.NewTodoStore(t)
+			if tc.warn {
+				store.On("TitleExists", ctx, int64(1), "Buy milk").Return(tc.titleExists, nil).Once()
+			}
+			store.On("Create", ctx, int64(1), mock.AnythingOfType("*domain.Todo")).Return(nil).Once()
+
+			s := NewTodoService(store, Options{WarnDuplicateTitles: tc.warn})
+
+			todo, warnings, err := s.CreateTodo(ctx, 1, 1, "Buy milk", nil, nil)
+			require.NoError(t, err)
+			require.NotNil(t, todo)
+			require.Equal(t, tc.wantWarnings, warnings)
+		})
+	}
+}
+
+func TestTitleWarnings(t *testing.T) {
+	t.Parallel()
+
+	longTitle := strings.Repeat("é", domain.LongTitleWarnLength)
+
+	t.Run("long title on create", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+
+		store := mocks.NewTodoStore(t)
+		store.On("Create", ctx, int64(1), mock.AnythingOfType("*domain.Todo")).Return(nil).Once()
+
+		s := NewTodoService(store, Options{})
+
+		todo, warnings, err := s.CreateTodo(ctx, 1, 1, longTitle, nil, nil)
+		require.NoError(t, err)
+		require.NotNil(t, todo)
+		require.Equal(t, []string{domain.WarnLongTitle}, warnings)
+	})
+
+	t.Run("title just under the warning length", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+
+		store := mocks.NewTodoStore(t)
+		store.On("Create", ctx, int64(1), mock.AnythingOfType("*domain.Todo")).Return(nil).Once()
+
+		s := NewTodoService(store, Options{})
+
+		_, warnings, err := s.CreateTodo(ctx, 1, 1, longTitle[len("é"):], nil, nil)
+		require.NoError(t, err)
+		require.Nil(t, warnings)
+	})
+
+	t.Run("update to a duplicate long title", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+
+		store := mocks.NewTodoStore(t)
+		store.On("Get", ctx, int64(5)).Return(&domain.Todo{ID: 5, UserID: 1, TodoListID: 1, Title: "Old"}, nil).Once()
+		store.On("TitleExists", ctx, int64(1), longTitle).Return(true, nil).Once()
+		store.On("Update", ctx, int64(5), longTitle, false, (*time.Time)(nil), (*string)(nil), fixedTime).Return(&domain.Todo{ID: 5, UserID: 1, TodoListID: 1, Title: longTitle}, nil).Once()
+
+		s := NewTodoService(store, Options{WarnDuplicateTitles: true, Clock: domain.FixedClock{Time: fixedTime}})
+
+		updated, warnings, err := s.UpdateTodo(ctx, 1, 5, longTitle, false, nil, nil)
+		require.NoError(t, err)
+		require.Equal(t, longTitle, updated.Title)
+		require.Equal(t, []string{domain.WarnDuplicateTitle, domain.WarnLongTitle}, warnings)
+	})
+
+	t.Run("unchanged title is not a duplicate of itself", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+
+		// No TitleExists expectation, the mock fails if the title is checked
+		store := mocks.NewTodoStore(t)
+		store.On("Get", ctx, int64(5)).Return(&domain.Todo{ID: 5, UserID: 1, TodoListID: 1, Title: "Milk"}, nil).Once()
+		store.On("Update", ctx, int64(5), "Milk", true, (*time.Time)(nil), (*string)(nil), fixedTime).Return(&domain.Todo{ID: 5, UserID: 1, TodoListID: 1, Title: "Milk", Done: true}, nil).Once()
+
+		s := NewTodoService(store, Options{WarnDuplicateTitles: true, Clock: domain.FixedClock{Time: fixedTime}})
+
+		_, warnings, err := s.UpdateTodo(ctx, 1, 5, "Milk", true, nil, nil)
+		require.NoError(t, err)
+		require.Nil(t, warnings)
+	})
+}
+
+func TestResolvePublicIDs(t *testing.T) {
+	t.Parallel()
+
+	const publicID = "6f1c2b9e-8a4d-4c1e-9b7a-3d2f1e0c5a11"
+
+	tests := []struct {
+		name     string
+		storeID  int64
+		storeErr error
+		want     int64
+	}{
+		{name: "found", storeID: 7, want: 7},
+		{name: "not found", storeErr: sql.ErrNoRows},
+		{name: "store error", storeErr: errors.New("db error")},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+
+			store := mocks.NewTodoStore(t)
+			store.On("IDByPublicID", ctx, int64(1), publicID).Return(tc.storeID, tc.storeErr).Once()
+			store.On("ListIDByPublicID", ctx, int64(1), publicID).Return(tc.storeID, tc.storeErr).Once()
+
+			s := NewTodoService(store, Options{})
+
+			todoID, err := s.ResolveTodoID(ctx, 1, publicID)
+			listID, listErr := s.ResolveListID(ctx, 1, publicID)
+
+			switch {
+			case tc.storeErr == nil:
+				require.NoError(t, err)
+				require.NoError(t, listErr)
+				require.Equal(t, tc.want, todoID)
+				require.Equal(t, tc.want, listID)
+			case errors.Is(tc.storeErr, sql.ErrNoRows):
+				require.ErrorIs(t, err, domain.ErrNotFound)
+				require.ErrorIs(t, listErr, domain.ErrListNotFound)
+			default:
+				require.ErrorIs(t, err, tc.storeErr)
+				require.ErrorIs(t, listErr, tc.storeErr)
+			}
+		})
+	}
+}
+
+// TestCreateTodoLogsRequestID checks that a failing create logs the request id of the context.
+// It swaps the default logger, so it does not run in parallel.
+func TestCreateTodoLogsRequestID(t *testing.T) {
+	var buf bytes.Buffer
+
+	prev := slog.Default()
+	slog.SetDefault(slog.New(slog.NewJSONHandler(&buf, nil)))
+	t.Cleanup(func() { slog.SetDefault(prev) })
+
+	ctx := logctx.WithRequestID(context.Background(), "host/abc-000042")
+
+	store := mocks.NewTodoStore(t)
+	store.On("Create", ctx, int64(1), mock.AnythingOfType("*domain.Todo")).Return(errors.New("db down")).Once()
+
+	s := NewTodoService(store, Options{})
+
+	_, _, err := s.CreateTodo(ctx, 1, 1, "Buy milk", nil, nil)
+	require.Error(t, err)
+
+	var line map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &line))
+	require.Equal(t, "failed to create todo", line["msg"])
+	require.Equal(t, "host/abc-000042", line[logctx.RequestIDKey])
+}
+
+func TestImportCSV(t *testing.T) {
+	t.Parallel()
+
+	const (
+		listPublicID  = "6f1c2b9e-8a4d-4c1e-9b7a-3d2f1e0c5a11"
+		otherPublicID = "0b5e2d1c-3f4a-4b6c-8d7e-9f0a1b2c3d4e"
+	)
+
+	fixedTime := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	t.Run("valid and invalid rows", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+
+		store := mocks.NewTodoStore(t)
+		store.On("ListIDByPublicID", ctx, int64(1), listPublicID).Return(int64(7), nil).Twice()
+		store.On("ListIDByPublicID", ctx, int64(1), otherPublicID).Return(int64(0), sql.ErrNoRows).Once()
+		store.On("Create", ctx, int64(7), mock.MatchedBy(func(todo *domain.Todo) bool {
+			return todo.Title == "Milk" && !todo.Done && todo.UserID == 1 && todo.CreatedAt.Equal(fixedTime) && todo.Source == domain.TodoSourceImport
+		})).Run(func(args mock.Arguments) {
+			args.Get(2).(*domain.Todo).PublicID = "todo-milk"
+		}).Return(nil).Once()
+		store.On("Create", ctx, int64(7), mock.MatchedBy(func(todo *domain.Todo) bool {
+			return todo.Title == "Bread" && todo.Done
+		})).Run(func(args mock.Arguments) {
+			args.Get(2).(*domain.Todo).PublicID = "todo-bread"
+		}).Return(nil).Once()
+
+		s := NewTodoService(store, Options{Clock: domain.FixedClock{Time: fixedTime}})
+
+		results, err := s.ImportCSV(ctx, 1, []domain.CSVTodoRow{
+			{Line: 2, ListID: listPublicID, Title: " Milk "},
+			{Line: 3, ListID: listPublicID, Title: ""},
+			{Line: 4, ListID: listPublicID, Title: "Bread", Done: "true"},
+			{Line: 5, ListID: listPublicID, Title: "Eggs", Done: "maybe"},
+			{Line: 6, ListID: "not-a-uuid", Title: "Jam"},
+			{Line: 7, ListID: otherPublicID, Title: "Butter"},
+			{Line: 8, ListID: listPublicID, Title: strings.Repeat("a", domain.MaxTitleLength+1)},
+		})
+		require.NoError(t, err)
+		require.Len(t, results, 7)
+
+		require.Equal(t, domain.CSVTodoResult{Line: 2, ID: "todo-milk"}, results[0])
+		require.ErrorIs(t, results[1].Err, domain.ErrInvalidTitle)
+		require.Equal(t, domain.CSVTodoResult{Line: 4, ID: "todo-bread"}, results[2])
+		require.ErrorIs(t, results[3].Err, domain.ErrInvalidInput)
+		require.ErrorIs(t, results[4].Err, domain.ErrInvalidInput)
+		require.ErrorIs(t, results[5].Err, domain.ErrListNotFound)
+		require.ErrorIs(t, results[6].Err, domain.ErrInvalidInput)
+
+		require.Equal(t, 3, results[1].Line)
+		require.Empty(t, results[1].ID)
+	})
+
+	t.Run("store error aborts the import", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+
+		store := mocks.NewTodoStore(t)
+		store.On("ListIDByPublicID", ctx, int64(1), listPublicID).Return(int64(7), nil).Once()
+		store.On("Create", ctx, int64(7), mock.AnythingOfType("*domain.Todo")).Return(errors.New("db error")).Once()
+
+		s := NewTodoService(store, Options{})
+
+		results, err := s.ImportCSV(ctx, 1, []domain.CSVTodoRow{
+			{Line: 2, ListID: listPublicID, Title: "Milk"},
+			{Line: 3, ListID: listPublicID, Title: "Bread"},
+		})
+		require.Error(t, err)
+		require.Nil(t, results)
+	})
+}
+
+func TestClone(t *testing.T) {
+	t.Parallel()
+
+	fixedTime := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	color := "#00AA00"
+	original := &domain.Todo{ID: 5, PublicID: "todo-5", UserID: 1, TodoListID: 7, TodoListPublicID: "list-7", Title: "Milk", Done: true, Color: &color}
+
+	tests := []struct {
+		name      string
+		userID    int64
+		title     string
+		wantTitle string
+		wantedErr error
+		storeErr  error
+	}{
+		{name: "keeps the title", userID: 1, title: "", wantTitle: "Milk"},
+		{name: "overrides the title", userID: 1, title: "Oat milk", wantTitle: "Oat milk"},
+		{name: "someone else's todo", userID: 2, wantedErr: domain.ErrNotFound},
+		{name: "store error", userID: 1, storeErr: errors.New("db error")},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+
+			store := mocks.NewTodoStore(t)
+			store.On("Get", ctx, int64(5)).Return(original, nil).Once()
+			if tc.wantedErr == nil {
+				store.On("Create", ctx, int64(7), mock.AnythingOfType("*domain.Todo")).
+					Run(func(args mock.Arguments) {
+						args.Get(2).(*domain.Todo).ID = 6
+					}).
+					Return(tc.storeErr).
+					Once()
+			}
+
+			s := NewTodoService(store, Options{Clock: domain.FixedClock{Time: fixedTime}})
+
+			got, err := s.Clone(ctx, tc.userID, 5, tc.title)
+			if tc.wantedErr != nil {
+				require.ErrorIs(t, err, tc.wantedErr)
+				return
+			}
+			if tc.storeErr != nil {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			require.Equal(t, &domain.Todo{
+				ID:               6,
+				UserID:           1,
+				TodoListID:       7,
+				TodoListPublicID: "list-7",
+				Title:            tc.wantTitle,
+				Done:             false,
+				CreatedAt:        fixedTime,
+				UpdatedAt:        fixedTime,
+				Color:            &color,
+				Source:           domain.TodoSourceAPI,
+			}, got)
+		})
+	}
+}
+
+func TestBatchGet(t *testing.T) {
+	t.Parallel()
+
+	const (
+		ownedID   = "6f1c2b9e-8a4d-4c1e-9b7a-3d2f1e0c5a11"
+		foreignID = "6f1c2b9e-8a4d-4c1e-9b7a-3d2f1e0c5a12"
+	)
+
+	owned := &domain.Todo{ID: 5, PublicID: ownedID, UserID: 1, Title: "Milk"}
+
+	tests := []struct {
+		name     string
+		storeErr error
+		want     []*domain.Todo
+	}{
+		{name: "skips foreign ids", want: []*domain.Todo{owned}},
+		{name: "store error", storeErr: errors.New("db error")},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+
+			store := mocks.NewTodoStore(t)
+			store.On("GetByPublicIDs", ctx, int64(1), []string{ownedID, foreignID}).Return(tc.want, tc.storeErr).Once()
+
+			s := NewTodoService(store, Options{})
+
+			got, err := s.BatchGet(ctx, 1, []string{ownedID, foreignID})
+			if tc.storeErr != nil {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestUndoDelete(t *testing.T) {
+	t.Parallel()
+
+	const publicID = "6f1c2b9e-8a4d-4c1e-9b7a-3d2f1e0c5a11"
+
+	tests := []struct {
+		name        string
+		deletedAgo  time.Duration
+		window      time.Duration
+		listID      int64
+		getErr      error
+		restoreErr  error
+		wantRestore bool
+		wantErr     error
+	}{
+		{name: "within the default window", deletedAgo: time.Minute, listID: 7, wantRestore: true},
+		{name: "past the default window", deletedAgo: DefaultUndoWindow + time.Second, listID: 7, wantErr: domain.ErrUndoExpired},
+		{name: "within a configured window", deletedAgo: 10 * time.Minute, window: time.Hour, listID: 7, wantRestore: true},
+		{name: "not in the trash", getErr: sql.ErrNoRows, listID: 7, wantErr: domain.ErrNotFound},
+		{name: "in another list", deletedAgo: time.Minute, listID: 8, wantErr: domain.ErrNotFound},
+		{name: "restored meanwhile", deletedAgo: time.Minute, listID: 7, restoreErr: sql.ErrNoRows, wantRestore: true, wantErr: domain.ErrNotFound},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			deletedAt := fixedTime.Add(-tc.deletedAgo)
+			trashed := &domain.Todo{ID: 5, PublicID: publicID, UserID: 1, TodoListID: 7, Title: "Milk", DeletedAt: &deletedAt}
+
+			// Strict mock: Restore must not be called unless the case restores
+			store := mocks.NewTodoStore(t)
+			if tc.getErr != nil {
+				store.On("GetTrashed", ctx, int64(1), publicID).Return(nil, tc.getErr).Once()
+			} else {
+				store.On("GetTrashed", ctx, int64(1), publicID).Return(trashed, nil).Once()
+			}
+			if tc.wantRestore {
+				store.On("Restore", ctx, int64(7), int64(5)).Return(tc.restoreErr).Once()
+			}
+
+			s := NewTodoService(store, Options{UndoWindow: tc.window, Clock: domain.FixedClock{Time: fixedTime}})
+
+			got, err := s.UndoDelete(ctx, 1, tc.listID, publicID)
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+				require.Nil(t, got)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, publicID, got.PublicID)
+			require.Nil(t, got.DeletedAt)
+		})
+	}
+}
+
+func TestPurgeTrash(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("removes the todos trashed before the window", func(t *testing.T) {
+		t.Parallel()
+
+		store := mocks.NewTodoStore(t)
+		store.On("PurgeTrash", ctx, fixedTime.Add(-DefaultUndoWindow)).Return(int64(3), nil).Once()
+
+		s := NewTodoService(store, Options{Clock: domain.FixedClock{Time: fixedTime}})
+
+		count, err := s.PurgeTrash(ctx)
+		require.NoError(t, err)
+		require.Equal(t, int64(3), count)
+	})
+
+	t.Run("store error", func(t *testing.T) {
+		t.Parallel()
+
+		store := mocks.NewTodoStore(t)
+		store.On("PurgeTrash", ctx, fixedTime.Add(-time.Hour)).Return(int64(0), errors.New("db error")).Once()
+
+		s := NewTodoService(store, Options{UndoWindow: time.Hour, Clock: domain.FixedClock{Time: fixedTime}})
+
+		_, err := s.PurgeTrash(ctx)
+		require.Error(t, err)
+	})
+}
+
+func TestSetDone(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		storeErr error
+		wantErr  error
+	}{
+		{name: "success"},
+		{name: "not found", storeErr: sql.ErrNoRows, wantErr: domain.ErrNotFound},
+		{name: "store error", storeErr: errors.New("db error")},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			done := &domain.Todo{ID: 5, UserID: 1, Title: "Milk", Done: true, CompletedAt: &fixedTime}
+
+			// Only SetDone: no read of the todo and no full Update
+			store := mocks.NewTodoStore(t)
+			if tc.storeErr != nil {
+				store.On("SetDone", ctx, int64(1), int64(5), true, fixedTime).Return(nil, tc.storeErr).Once()
+			} else {
+				store.On("SetDone", ctx, int64(1), int64(5), true, fixedTime).Return(done, nil).Once()
+			}
+
+			s := NewTodoService(store, Options{Clock: domain.FixedClock{Time: fixedTime}})
+
+			got, err := s.SetDone(ctx, 1, 5, true)
+			if tc.storeErr != nil {
+				require.Error(t, err)
+				if tc.wantErr != nil {
+					require.ErrorIs(t, err, tc.wantErr)
+				}
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, done, got)
+		})
+	}
+}
+
+func TestListOverdue(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("passes the clock time to the store", func(t *testing.T) {
+		t.Parallel()
+
+		due := fixedTime.Add(-time.Hour)
+		overdue := []*domain.Todo{{ID: 5, UserID: 1, Title: "Milk", DueDate: &due}}
+
+		store := mocks.NewTodoStore(t)
+		store.On("ListOverdue", ctx, int64(1), fixedTime).Return(overdue, nil).Once()
+
+		s := NewTodoService(store, Options{Clock: domain.FixedClock{Time: fixedTime}})
+
+		got, err := s.ListOverdue(ctx, 1)
+		require.NoError(t, err)
+		require.Equal(t, overdue, got)
+	})
+
+	t.Run("store error", func(t *testing.T) {
+		t.Parallel()
+
+		store := mocks.NewTodoStore(t)
+		store.On("ListOverdue", ctx, int64(1), fixedTime).Return(nil, errors.New("db error")).Once()
+
+		s := NewTodoService(store, Options{Clock: domain.FixedClock{Time: fixedTime}})
+
+		_, err := s.ListOverdue(ctx, 1)
+		require.Error(t, err)
+	})
+}
+
+func TestCreateTodoSanitizesTitle(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		title   string
+		want    string
+		wantErr error
+	}{
+		{name: "newline", title: "Buy milk\nand bread", want: "Buy milk and bread"},
+		{name: "emoji is kept", title: "Birthday 🎂 party", want: "Birthday 🎂 party"},
+		{name: "control character", title: "Buy\x1bmilk", wantErr: domain.ErrInvalidTitle},
+		{name: "nothing but line breaks", title: "\r\n", wantErr: domain.ErrInvalidTitle},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+
+			// Strict mock, the store is only called with the sanitized title
+			store := mocks.NewTodoStore(t)
+			if tc.wantErr == nil {
+				store.On("Create", ctx, int64(1), mock.MatchedBy(func(todo *domain.Todo) bool {
+					return todo.Title == tc.want
+				})).Return(nil).Once()
+			}
+
+			s := NewTodoService(store, Options{})
+
+			todo, _, err := s.CreateTodo(ctx, 1, 1, tc.title, nil, nil)
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.want, todo.Title)
 		})
 	}
 }
@@ -1,11 +1,29 @@
 package todolist
 
+import (
+	"time"
+
+	"github.com/macesz/todo-go/domain"
+)
+
 type TodoListService struct {
-	Store TodoListStore
+	Store       TodoListStore
+	DefaultSort domain.Sort  // Used when a list request has no sort
+	Clock       domain.Clock // Source of timestamps, the system clock when nil
 }
 
-func NewTodoListService(store TodoListStore) *TodoListService {
+func NewTodoListService(store TodoListStore, defaultSort domain.Sort, clock domain.Clock) *TodoListService {
 	return &TodoListService{
-		Store: store, // Assign the store to the service
+		Store:       store, // Assign the store to the service
+		DefaultSort: defaultSort,
+		Clock:       clock,
+	}
+}
+
+// now returns the current time from the configured clock
+func (s *TodoListService) now() time.Time {
+	if s.Clock == nil {
+		return time.Now()
 	}
+	return s.Clock.Now()
 }
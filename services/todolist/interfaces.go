@@ -2,14 +2,18 @@ package todolist
 
 import (
 	"context"
+	"time"
 
 	"github.com/macesz/todo-go/domain"
 )
 
 type TodoListStore interface {
-	List(ctx context.Context, userId int64) ([]*domain.TodoList, error)
+	List(ctx context.Context, userId int64, opts domain.ListOptions, now time.Time) ([]*domain.TodoList, error)
 	GetListByID(ctx context.Context, id int64) (*domain.TodoList, error)
+	IDByPublicID(ctx context.Context, userID int64, publicID string) (int64, error)
 	Create(ctx context.Context, todoList *domain.TodoList) error
-	Update(ctx context.Context, id int64, title string, color string, labels []string, deleted bool) (*domain.TodoList, error)
+	GetOrCreate(ctx context.Context, todoList *domain.TodoList) (*domain.TodoList, bool, error)
+	Update(ctx context.Context, id int64, version int64, title string, color string, labels []string, deleted bool, updatedAt time.Time) (*domain.TodoList, error)
+	SetPinned(ctx context.Context, id int64, pinned bool, updatedAt time.Time) (*domain.TodoList, error)
 	Delete(ctx context.Context, id int64) error
 }
@@ -6,6 +6,7 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	"github.com/macesz/todo-go/domain"
 	mock "github.com/stretchr/testify/mock"
@@ -220,9 +221,155 @@ func (_c *TodoListStore_GetListByID_Call) RunAndReturn(run func(ctx context.Cont
 	return _c
 }
 
+// GetOrCreate provides a mock function for the type TodoListStore
+func (_mock *TodoListStore) GetOrCreate(ctx context.Context, todoList *domain.TodoList) (*domain.TodoList, bool, error) {
+	ret := _mock.Called(ctx, todoList)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOrCreate")
+	}
+
+	var r0 *domain.TodoList
+	var r1 bool
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.TodoList) (*domain.TodoList, bool, error)); ok {
+		return returnFunc(ctx, todoList)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.TodoList) *domain.TodoList); ok {
+		r0 = returnFunc(ctx, todoList)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.TodoList)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *domain.TodoList) bool); ok {
+		r1 = returnFunc(ctx, todoList)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, *domain.TodoList) error); ok {
+		r2 = returnFunc(ctx, todoList)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// TodoListStore_GetOrCreate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOrCreate'
+type TodoListStore_GetOrCreate_Call struct {
+	*mock.Call
+}
+
+// GetOrCreate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - todoList *domain.TodoList
+func (_e *TodoListStore_Expecter) GetOrCreate(ctx interface{}, todoList interface{}) *TodoListStore_GetOrCreate_Call {
+	return &TodoListStore_GetOrCreate_Call{Call: _e.mock.On("GetOrCreate", ctx, todoList)}
+}
+
+func (_c *TodoListStore_GetOrCreate_Call) Run(run func(ctx context.Context, todoList *domain.TodoList)) *TodoListStore_GetOrCreate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *domain.TodoList
+		if args[1] != nil {
+			arg1 = args[1].(*domain.TodoList)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *TodoListStore_GetOrCreate_Call) Return(todoList1 *domain.TodoList, b bool, err error) *TodoListStore_GetOrCreate_Call {
+	_c.Call.Return(todoList1, b, err)
+	return _c
+}
+
+func (_c *TodoListStore_GetOrCreate_Call) RunAndReturn(run func(ctx context.Context, todoList *domain.TodoList) (*domain.TodoList, bool, error)) *TodoListStore_GetOrCreate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IDByPublicID provides a mock function for the type TodoListStore
+func (_mock *TodoListStore) IDByPublicID(ctx context.Context, userID int64, publicID string) (int64, error) {
+	ret := _mock.Called(ctx, userID, publicID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IDByPublicID")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, string) (int64, error)); ok {
+		return returnFunc(ctx, userID, publicID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, string) int64); ok {
+		r0 = returnFunc(ctx, userID, publicID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, string) error); ok {
+		r1 = returnFunc(ctx, userID, publicID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// TodoListStore_IDByPublicID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IDByPublicID'
+type TodoListStore_IDByPublicID_Call struct {
+	*mock.Call
+}
+
+// IDByPublicID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+//   - publicID string
+func (_e *TodoListStore_Expecter) IDByPublicID(ctx interface{}, userID interface{}, publicID interface{}) *TodoListStore_IDByPublicID_Call {
+	return &TodoListStore_IDByPublicID_Call{Call: _e.mock.On("IDByPublicID", ctx, userID, publicID)}
+}
+
+func (_c *TodoListStore_IDByPublicID_Call) Run(run func(ctx context.Context, userID int64, publicID string)) *TodoListStore_IDByPublicID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *TodoListStore_IDByPublicID_Call) Return(n int64, err error) *TodoListStore_IDByPublicID_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *TodoListStore_IDByPublicID_Call) RunAndReturn(run func(ctx context.Context, userID int64, publicID string) (int64, error)) *TodoListStore_IDByPublicID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // List provides a mock function for the type TodoListStore
-func (_mock *TodoListStore) List(ctx context.Context, userId int64) ([]*domain.TodoList, error) {
-	ret := _mock.Called(ctx, userId)
+func (_mock *TodoListStore) List(ctx context.Context, userId int64, opts domain.ListOptions, now time.Time) ([]*domain.TodoList, error) {
+	ret := _mock.Called(ctx, userId, opts, now)
 
 	if len(ret) == 0 {
 		panic("no return value specified for List")
@@ -230,18 +377,18 @@ func (_mock *TodoListStore) List(ctx context.Context, userId int64) ([]*domain.T
 
 	var r0 []*domain.TodoList
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) ([]*domain.TodoList, error)); ok {
-		return returnFunc(ctx, userId)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, domain.ListOptions, time.Time) ([]*domain.TodoList, error)); ok {
+		return returnFunc(ctx, userId, opts, now)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) []*domain.TodoList); ok {
-		r0 = returnFunc(ctx, userId)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, domain.ListOptions, time.Time) []*domain.TodoList); ok {
+		r0 = returnFunc(ctx, userId, opts, now)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*domain.TodoList)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, int64) error); ok {
-		r1 = returnFunc(ctx, userId)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, domain.ListOptions, time.Time) error); ok {
+		r1 = returnFunc(ctx, userId, opts, now)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -256,11 +403,13 @@ type TodoListStore_List_Call struct {
 // List is a helper method to define mock.On call
 //   - ctx context.Context
 //   - userId int64
-func (_e *TodoListStore_Expecter) List(ctx interface{}, userId interface{}) *TodoListStore_List_Call {
-	return &TodoListStore_List_Call{Call: _e.mock.On("List", ctx, userId)}
+//   - opts domain.ListOptions
+//   - now time.Time
+func (_e *TodoListStore_Expecter) List(ctx interface{}, userId interface{}, opts interface{}, now interface{}) *TodoListStore_List_Call {
+	return &TodoListStore_List_Call{Call: _e.mock.On("List", ctx, userId, opts, now)}
 }
 
-func (_c *TodoListStore_List_Call) Run(run func(ctx context.Context, userId int64)) *TodoListStore_List_Call {
+func (_c *TodoListStore_List_Call) Run(run func(ctx context.Context, userId int64, opts domain.ListOptions, now time.Time)) *TodoListStore_List_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -270,9 +419,19 @@ func (_c *TodoListStore_List_Call) Run(run func(ctx context.Context, userId int6
 		if args[1] != nil {
 			arg1 = args[1].(int64)
 		}
+		var arg2 domain.ListOptions
+		if args[2] != nil {
+			arg2 = args[2].(domain.ListOptions)
+		}
+		var arg3 time.Time
+		if args[3] != nil {
+			arg3 = args[3].(time.Time)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
+			arg3,
 		)
 	})
 	return _c
@@ -283,14 +442,94 @@ func (_c *TodoListStore_List_Call) Return(todoLists []*domain.TodoList, err erro
 	return _c
 }
 
-func (_c *TodoListStore_List_Call) RunAndReturn(run func(ctx context.Context, userId int64) ([]*domain.TodoList, error)) *TodoListStore_List_Call {
+func (_c *TodoListStore_List_Call) RunAndReturn(run func(ctx context.Context, userId int64, opts domain.ListOptions, now time.Time) ([]*domain.TodoList, error)) *TodoListStore_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetPinned provides a mock function for the type TodoListStore
+func (_mock *TodoListStore) SetPinned(ctx context.Context, id int64, pinned bool, updatedAt time.Time) (*domain.TodoList, error) {
+	ret := _mock.Called(ctx, id, pinned, updatedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetPinned")
+	}
+
+	var r0 *domain.TodoList
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, bool, time.Time) (*domain.TodoList, error)); ok {
+		return returnFunc(ctx, id, pinned, updatedAt)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, bool, time.Time) *domain.TodoList); ok {
+		r0 = returnFunc(ctx, id, pinned, updatedAt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.TodoList)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, bool, time.Time) error); ok {
+		r1 = returnFunc(ctx, id, pinned, updatedAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// TodoListStore_SetPinned_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetPinned'
+type TodoListStore_SetPinned_Call struct {
+	*mock.Call
+}
+
+// SetPinned is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - pinned bool
+//   - updatedAt time.Time
+func (_e *TodoListStore_Expecter) SetPinned(ctx interface{}, id interface{}, pinned interface{}, updatedAt interface{}) *TodoListStore_SetPinned_Call {
+	return &TodoListStore_SetPinned_Call{Call: _e.mock.On("SetPinned", ctx, id, pinned, updatedAt)}
+}
+
+func (_c *TodoListStore_SetPinned_Call) Run(run func(ctx context.Context, id int64, pinned bool, updatedAt time.Time)) *TodoListStore_SetPinned_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 bool
+		if args[2] != nil {
+			arg2 = args[2].(bool)
+		}
+		var arg3 time.Time
+		if args[3] != nil {
+			arg3 = args[3].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *TodoListStore_SetPinned_Call) Return(todoList *domain.TodoList, err error) *TodoListStore_SetPinned_Call {
+	_c.Call.Return(todoList, err)
+	return _c
+}
+
+func (_c *TodoListStore_SetPinned_Call) RunAndReturn(run func(ctx context.Context, id int64, pinned bool, updatedAt time.Time) (*domain.TodoList, error)) *TodoListStore_SetPinned_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // Update provides a mock function for the type TodoListStore
-func (_mock *TodoListStore) Update(ctx context.Context, id int64, title string, color string, labels []string, deleted bool) (*domain.TodoList, error) {
-	ret := _mock.Called(ctx, id, title, color, labels, deleted)
+func (_mock *TodoListStore) Update(ctx context.Context, id int64, version int64, title string, color string, labels []string, deleted bool, updatedAt time.Time) (*domain.TodoList, error) {
+	ret := _mock.Called(ctx, id, version, title, color, labels, deleted, updatedAt)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Update")
@@ -298,18 +537,18 @@ func (_mock *TodoListStore) Update(ctx context.Context, id int64, title string,
 
 	var r0 *domain.TodoList
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, string, string, []string, bool) (*domain.TodoList, error)); ok {
-		return returnFunc(ctx, id, title, color, labels, deleted)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int64, string, string, []string, bool, time.Time) (*domain.TodoList, error)); ok {
+		return returnFunc(ctx, id, version, title, color, labels, deleted, updatedAt)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, string, string, []string, bool) *domain.TodoList); ok {
-		r0 = returnFunc(ctx, id, title, color, labels, deleted)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int64, string, string, []string, bool, time.Time) *domain.TodoList); ok {
+		r0 = returnFunc(ctx, id, version, title, color, labels, deleted, updatedAt)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.TodoList)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, string, string, []string, bool) error); ok {
-		r1 = returnFunc(ctx, id, title, color, labels, deleted)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, int64, string, string, []string, bool, time.Time) error); ok {
+		r1 = returnFunc(ctx, id, version, title, color, labels, deleted, updatedAt)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -324,15 +563,17 @@ type TodoListStore_Update_Call struct {
 // Update is a helper method to define mock.On call
 //   - ctx context.Context
 //   - id int64
+//   - version int64
 //   - title string
 //   - color string
 //   - labels []string
 //   - deleted bool
-func (_e *TodoListStore_Expecter) Update(ctx interface{}, id interface{}, title interface{}, color interface{}, labels interface{}, deleted interface{}) *TodoListStore_Update_Call {
-	return &TodoListStore_Update_Call{Call: _e.mock.On("Update", ctx, id, title, color, labels, deleted)}
+//   - updatedAt time.Time
+func (_e *TodoListStore_Expecter) Update(ctx interface{}, id interface{}, version interface{}, title interface{}, color interface{}, labels interface{}, deleted interface{}, updatedAt interface{}) *TodoListStore_Update_Call {
+	return &TodoListStore_Update_Call{Call: _e.mock.On("Update", ctx, id, version, title, color, labels, deleted, updatedAt)}
 }
 
-func (_c *TodoListStore_Update_Call) Run(run func(ctx context.Context, id int64, title string, color string, labels []string, deleted bool)) *TodoListStore_Update_Call {
+func (_c *TodoListStore_Update_Call) Run(run func(ctx context.Context, id int64, version int64, title string, color string, labels []string, deleted bool, updatedAt time.Time)) *TodoListStore_Update_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -342,21 +583,29 @@ func (_c *TodoListStore_Update_Call) Run(run func(ctx context.Context, id int64,
 		if args[1] != nil {
 			arg1 = args[1].(int64)
 		}
-		var arg2 string
+		var arg2 int64
 		if args[2] != nil {
-			arg2 = args[2].(string)
+			arg2 = args[2].(int64)
 		}
 		var arg3 string
 		if args[3] != nil {
 			arg3 = args[3].(string)
 		}
-		var arg4 []string
+		var arg4 string
 		if args[4] != nil {
-			arg4 = args[4].([]string)
+			arg4 = args[4].(string)
 		}
-		var arg5 bool
+		var arg5 []string
 		if args[5] != nil {
-			arg5 = args[5].(bool)
+			arg5 = args[5].([]string)
+		}
+		var arg6 bool
+		if args[6] != nil {
+			arg6 = args[6].(bool)
+		}
+		var arg7 time.Time
+		if args[7] != nil {
+			arg7 = args[7].(time.Time)
 		}
 		run(
 			arg0,
@@ -365,6 +614,8 @@ func (_c *TodoListStore_Update_Call) Run(run func(ctx context.Context, id int64,
 			arg3,
 			arg4,
 			arg5,
+			arg6,
+			arg7,
 		)
 	})
 	return _c
@@ -375,7 +626,7 @@ func (_c *TodoListStore_Update_Call) Return(todoList *domain.TodoList, err error
 	return _c
 }
 
-func (_c *TodoListStore_Update_Call) RunAndReturn(run func(ctx context.Context, id int64, title string, color string, labels []string, deleted bool) (*domain.TodoList, error)) *TodoListStore_Update_Call {
+func (_c *TodoListStore_Update_Call) RunAndReturn(run func(ctx context.Context, id int64, version int64, title string, color string, labels []string, deleted bool, updatedAt time.Time) (*domain.TodoList, error)) *TodoListStore_Update_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -5,17 +5,29 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
-	"time"
+	"slices"
 
 	"github.com/macesz/todo-go/domain"
+	"github.com/macesz/todo-go/pkg/logctx"
 )
 
-func (s *TodoListService) List(ctx context.Context, userID int64) ([]*domain.TodoList, error) {
-	todoLists, err := s.Store.List(ctx, userID)
+func (s *TodoListService) List(ctx context.Context, userID int64, opts domain.ListOptions) ([]*domain.TodoList, error) {
+	todoLists, err := s.Store.List(ctx, userID, opts.WithDefaultSort(s.DefaultSort), s.now())
 	if err != nil {
 		return nil, fmt.Errorf("failed to list todo lists: %w", err)
 	}
 
+	// Pinned lists always come first, the stable sort keeps the store order within each group
+	slices.SortStableFunc(todoLists, func(a, b *domain.TodoList) int {
+		switch {
+		case a.Pinned && !b.Pinned:
+			return -1
+		case !a.Pinned && b.Pinned:
+			return 1
+		}
+		return 0
+	})
+
 	return todoLists, nil
 }
 
@@ -35,12 +47,29 @@ func (s *TodoListService) GetListByID(ctx context.Context, userID int64, id int6
 	return todoList, nil
 }
 
+// ResolveID returns the internal id of the user's list with the given public id
+func (s *TodoListService) ResolveID(ctx context.Context, userID int64, publicID string) (int64, error) {
+	id, err := s.Store.IDByPublicID(ctx, userID, publicID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, domain.ErrListNotFound
+		}
+		return 0, fmt.Errorf("failed to resolve list id: %w", err)
+	}
+
+	return id, nil
+}
+
 func (s *TodoListService) Create(ctx context.Context, userID int64, title string, color string, labels []string) (*domain.TodoList, error) {
+	title, err := domain.SanitizeTitle(title)
+	if err != nil {
+		return nil, err
+	}
 	if title == "" {
 		title = "Title"
 	}
 
-	createdAt := time.Now()
+	createdAt := s.now()
 
 	todolist := &domain.TodoList{
 		UserID:    userID,
@@ -48,28 +77,96 @@ func (s *TodoListService) Create(ctx context.Context, userID int64, title string
 		Color:     color,
 		Labels:    labels,
 		CreatedAt: createdAt,
+		UpdatedAt: createdAt,
 	}
 
-	err := s.Store.Create(ctx, todolist)
+	err = s.Store.Create(ctx, todolist)
 	if err != nil {
+		logctx.From(ctx).Error("failed to create todo list", "user_id", userID, "error", err)
 		return nil, fmt.Errorf("failed to create todo list: %w", err)
 	}
 
 	return todolist, err
 }
 
-func (s *TodoListService) Update(ctx context.Context, userID int64, id int64, title string, color string, labels []string, deleted bool) (*domain.TodoList, error) {
+// GetOrCreate returns the user's list with the given title, creating it if absent.
+// The bool reports whether the list was created by this call.
+func (s *TodoListService) GetOrCreate(ctx context.Context, userID int64, title string, color string, labels []string) (*domain.TodoList, bool, error) {
+	title, err := domain.SanitizeTitle(title)
+	if err != nil {
+		return nil, false, err
+	}
+	if title == "" {
+		return nil, false, domain.ErrInvalidTitle
+	}
+
+	now := s.now()
+
+	todolist := &domain.TodoList{
+		UserID:    userID,
+		Title:     title,
+		Color:     color,
+		Labels:    labels,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	stored, created, err := s.Store.GetOrCreate(ctx, todolist)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get or create todo list: %w", err)
+	}
+
+	return stored, created, nil
+}
+
+// Update changes a list owned by the user, version is the version the change is based on
+// Returns ErrVersionConflict when the list was changed in the meantime
+// A nil color keeps the current color of the list
+func (s *TodoListService) Update(ctx context.Context, userID int64, id int64, version int64, title string, color *string, labels []string, deleted bool) (*domain.TodoList, error) {
+	title, err := domain.SanitizeTitle(title)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := s.GetListByID(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if current.Version != version {
+		return nil, domain.ErrVersionConflict
+	}
+
+	newColor := current.Color
+	if color != nil {
+		newColor = *color
+	}
+
+	updated, err := s.Store.Update(ctx, id, version, title, newColor, labels, deleted, s.now())
+	if err != nil {
+		// The list was there a moment ago, so no row means it changed in between
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrVersionConflict
+		}
+		return nil, fmt.Errorf("failed to update list: %w", err)
+	}
+
+	return updated, nil
+}
+
+// SetPinned pins or unpins a list owned by the user
+func (s *TodoListService) SetPinned(ctx context.Context, userID int64, id int64, pinned bool) (*domain.TodoList, error) {
 	_, err := s.GetListByID(ctx, userID, id)
 	if err != nil {
 		return nil, err
 	}
 
-	updated, err := s.Store.Update(ctx, id, title, color, labels, deleted)
+	updated, err := s.Store.SetPinned(ctx, id, pinned, s.now())
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, domain.ErrListNotFound
 		}
-		return nil, fmt.Errorf("failed to update list: %w", err)
+		return nil, fmt.Errorf("failed to pin list: %w", err)
 	}
 
 	return updated, nil
@@ -85,7 +182,19 @@ func (s *TodoListService) Delete(ctx context.Context, userID int64, id int64) er
 		if errors.Is(err, sql.ErrNoRows) {
 			return domain.ErrListNotFound
 		}
+		logctx.From(ctx).Error("failed to delete list", "user_id", userID, "list_id", id, "error", err)
 		return fmt.Errorf("failed to delete list: %w", err)
 	}
 	return nil
 }
+
+// DeleteDryRun reports the list Delete would delete, without deleting it.
+// The todos of the list go with it.
+func (s *TodoListService) DeleteDryRun(ctx context.Context, userID int64, id int64) (*domain.DryRunResult, error) {
+	todoList, err := s.GetListByID(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.DryRunResult{Count: 1, IDs: []string{todoList.PublicID}}, nil
+}
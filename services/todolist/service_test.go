@@ -26,6 +26,7 @@ func TestListTodos(t *testing.T) {
 	type args struct {
 		ctx    context.Context
 		userID int64
+		opts   domain.ListOptions
 	}
 
 	tests := []struct {
@@ -50,10 +51,38 @@ func TestListTodos(t *testing.T) {
 					store.AssertExpectations(tt)
 				})
 
-				store.On("List", ta.ctx, ta.userID).Return([]*domain.TodoList{
+				// The next due date is computed against the service clock
+				store.On("List", ta.ctx, ta.userID, ta.opts, fixedTime).Return([]*domain.TodoList{
 					{ID: 1, UserID: 1, Title: "Shopping", Color: "white", Labels: nil, CreatedAt: fixedTime, Deleted: false, Items: nil},
 				}, nil).Once()
 
+				s.Store = store
+				s.Clock = domain.FixedClock{Time: fixedTime}
+			},
+		}, {
+			name:   "pinned lists first",
+			fields: fields{},
+			args:   args{ctx: context.Background()},
+			want: []*domain.TodoList{
+				{ID: 3, UserID: 1, Title: "Urgent", CreatedAt: fixedTime, Pinned: true},
+				{ID: 4, UserID: 1, Title: "Favorites", CreatedAt: fixedTime, Pinned: true},
+				{ID: 1, UserID: 1, Title: "Shopping", CreatedAt: fixedTime},
+				{ID: 2, UserID: 1, Title: "Work", CreatedAt: fixedTime},
+			},
+			initMocks: func(tt *testing.T, ta *args, s *TodoListService) {
+				store := mocks.NewTodoListStore(tt)
+
+				tt.Cleanup(func() {
+					store.AssertExpectations(tt)
+				})
+
+				store.On("List", ta.ctx, ta.userID, ta.opts, mock.Anything).Return([]*domain.TodoList{
+					{ID: 1, UserID: 1, Title: "Shopping", CreatedAt: fixedTime},
+					{ID: 2, UserID: 1, Title: "Work", CreatedAt: fixedTime},
+					{ID: 3, UserID: 1, Title: "Urgent", CreatedAt: fixedTime, Pinned: true},
+					{ID: 4, UserID: 1, Title: "Favorites", CreatedAt: fixedTime, Pinned: true},
+				}, nil).Once()
+
 				s.Store = store
 			},
 		}, {
@@ -67,7 +96,7 @@ func TestListTodos(t *testing.T) {
 				tt.Cleanup(func() {
 					store.AssertExpectations(tt)
 				})
-				store.On("List", ta.ctx, ta.userID).Return(nil, errors.New("could not list")).Once()
+				store.On("List", ta.ctx, ta.userID, ta.opts, mock.Anything).Return(nil, errors.New("could not list")).Once()
 
 				s.Store = store
 			},
@@ -84,7 +113,7 @@ func TestListTodos(t *testing.T) {
 
 			tc.initMocks(t, &tc.args, s)
 
-			got, err := s.List(tc.args.ctx, tc.args.userID)
+			got, err := s.List(tc.args.ctx, tc.args.userID, tc.args.opts)
 			if tc.wantErr {
 				require.Error(t, err)
 				return
@@ -127,7 +156,7 @@ func TestCreateList(t *testing.T) {
 				require.Equal(t, ta.title, todoList.Title)
 				require.Equal(t, ta.color, todoList.Color)
 				require.Equal(t, ta.labels, todoList.Labels)
-				require.NotZero(t, todoList.CreatedAt)
+				require.Equal(t, fixedTime, todoList.CreatedAt)
 			},
 			initMocks: func(tt *testing.T, ta *args, s *TodoListService) {
 				store := mocks.NewTodoListStore(tt)
@@ -147,6 +176,7 @@ func TestCreateList(t *testing.T) {
 				}).Return(nil).Once()
 
 				s.Store = store
+				s.Clock = domain.FixedClock{Time: fixedTime}
 			},
 		}, {
 			name:    "store error",
@@ -203,6 +233,105 @@ func TestCreateList(t *testing.T) {
 
 }
 
+func TestGetOrCreate(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		ctx    context.Context
+		userID int64
+		title  string
+		color  string
+	}
+
+	tests := []struct {
+		name        string
+		args        args
+		want        *domain.TodoList
+		wantCreated bool
+		wantErr     bool
+		wantedErr   error
+		initMocks   func(tt *testing.T, ta *args, s *TodoListService)
+	}{
+		{
+			name:        "first call creates",
+			args:        args{ctx: context.Background(), userID: 1, title: "Shopping", color: "white"},
+			want:        &domain.TodoList{ID: 1, UserID: 1, Title: "Shopping", Color: "white"},
+			wantCreated: true,
+			initMocks: func(tt *testing.T, ta *args, s *TodoListService) {
+				store := mocks.NewTodoListStore(tt)
+				tt.Cleanup(func() { store.AssertExpectations(tt) })
+
+				store.On("GetOrCreate", ta.ctx, mock.MatchedBy(
+					func(todoList *domain.TodoList) bool {
+						return todoList.UserID == ta.userID && todoList.Title == ta.title && todoList.Color == ta.color
+					})).Return(&domain.TodoList{ID: 1, UserID: 1, Title: "Shopping", Color: "white"}, true, nil).Once()
+
+				s.Store = store
+			},
+		},
+		{
+			name:        "second call returns existing",
+			args:        args{ctx: context.Background(), userID: 1, title: "Shopping", color: "red"},
+			want:        &domain.TodoList{ID: 1, UserID: 1, Title: "Shopping", Color: "white", CreatedAt: fixedTime},
+			wantCreated: false,
+			initMocks: func(tt *testing.T, ta *args, s *TodoListService) {
+				store := mocks.NewTodoListStore(tt)
+				tt.Cleanup(func() { store.AssertExpectations(tt) })
+
+				store.On("GetOrCreate", ta.ctx, mock.AnythingOfType("*domain.TodoList")).
+					Return(&domain.TodoList{ID: 1, UserID: 1, Title: "Shopping", Color: "white", CreatedAt: fixedTime}, false, nil).Once()
+
+				s.Store = store
+			},
+		},
+		{
+			name:      "empty title",
+			args:      args{ctx: context.Background(), userID: 1, title: ""},
+			wantErr:   true,
+			wantedErr: domain.ErrInvalidTitle,
+			initMocks: func(tt *testing.T, ta *args, s *TodoListService) {
+				s.Store = mocks.NewTodoListStore(tt)
+			},
+		},
+		{
+			name:    "store error",
+			args:    args{ctx: context.Background(), userID: 1, title: "Shopping"},
+			wantErr: true,
+			initMocks: func(tt *testing.T, ta *args, s *TodoListService) {
+				store := mocks.NewTodoListStore(tt)
+				tt.Cleanup(func() { store.AssertExpectations(tt) })
+
+				store.On("GetOrCreate", ta.ctx, mock.AnythingOfType("*domain.TodoList")).
+					Return(nil, false, errors.New("db error")).Once()
+
+				s.Store = store
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := &TodoListService{}
+
+			tc.initMocks(t, &tc.args, s)
+
+			got, created, err := s.GetOrCreate(tc.args.ctx, tc.args.userID, tc.args.title, tc.args.color, nil)
+			if tc.wantErr {
+				require.Error(t, err)
+				if tc.wantedErr != nil {
+					require.ErrorIs(t, err, tc.wantedErr)
+				}
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got)
+			require.Equal(t, tc.wantCreated, created)
+		})
+	}
+}
+
 func TestGetListByID(t *testing.T) {
 	t.Parallel()
 
@@ -357,6 +486,7 @@ func TestUpdate(t *testing.T) {
 		ctx     context.Context
 		userID  int64
 		id      int64
+		version int64
 		title   string
 		color   string
 		labels  []string
@@ -379,6 +509,7 @@ func TestUpdate(t *testing.T) {
 				ctx:     context.Background(),
 				userID:  1,
 				id:      1,
+				version: 1,
 				title:   "Updated Shopping",
 				color:   "blue",
 				labels:  []string{"urgent", "groceries"},
@@ -404,6 +535,7 @@ func TestUpdate(t *testing.T) {
 				// Mock GetListByID - verify list exists and belongs to user
 				store.On("GetListByID", ta.ctx, ta.id).Return(&domain.TodoList{
 					ID:        1,
+					Version:   1,
 					UserID:    1,
 					Title:     "Shopping",
 					Color:     "white",
@@ -414,7 +546,7 @@ func TestUpdate(t *testing.T) {
 				}, nil).Once()
 
 				// Mock Update
-				store.On("Update", ta.ctx, ta.id, ta.title, ta.color, ta.labels, ta.deleted).Return(&domain.TodoList{
+				store.On("Update", ta.ctx, ta.id, ta.version, ta.title, ta.color, ta.labels, ta.deleted, fixedTime).Return(&domain.TodoList{
 					ID:        1,
 					UserID:    1,
 					Title:     "Updated Shopping",
@@ -425,6 +557,7 @@ func TestUpdate(t *testing.T) {
 				}, nil).Once()
 
 				s.Store = store
+				s.Clock = domain.FixedClock{Time: fixedTime}
 			},
 		},
 		{
@@ -476,9 +609,9 @@ func TestUpdate(t *testing.T) {
 		{
 			name:      "store update returns sql.ErrNoRows",
 			fields:    fields{},
-			args:      args{ctx: context.Background(), userID: 1, id: 1, title: "Test", color: "red", labels: nil, deleted: false},
+			args:      args{ctx: context.Background(), userID: 1, id: 1, version: 1, title: "Test", color: "red", labels: nil, deleted: false},
 			wantErr:   true,
-			wantedErr: domain.ErrListNotFound,
+			wantedErr: domain.ErrVersionConflict,
 			initMocks: func(tt *testing.T, ta *args, s *TodoListService) {
 				store := mocks.NewTodoListStore(tt)
 
@@ -489,6 +622,7 @@ func TestUpdate(t *testing.T) {
 				// GetListByID succeeds
 				store.On("GetListByID", ta.ctx, ta.id).Return(&domain.TodoList{
 					ID:        1,
+					Version:   1,
 					UserID:    1,
 					Title:     "Shopping",
 					Color:     "white",
@@ -498,8 +632,28 @@ func TestUpdate(t *testing.T) {
 					CreatedAt: fixedTime,
 				}, nil).Once()
 
-				// But Update fails with ErrNoRows (race condition scenario)
-				store.On("Update", ta.ctx, ta.id, ta.title, ta.color, ta.labels, ta.deleted).Return(nil, sql.ErrNoRows).Once()
+				// But Update fails with ErrNoRows, the version changed in between
+				store.On("Update", ta.ctx, ta.id, ta.version, ta.title, ta.color, ta.labels, ta.deleted, fixedTime).Return(nil, sql.ErrNoRows).Once()
+
+				s.Store = store
+				s.Clock = domain.FixedClock{Time: fixedTime}
+			},
+		},
+		{
+			name:      "stale version",
+			fields:    fields{},
+			args:      args{ctx: context.Background(), userID: 1, id: 1, version: 1, title: "Test", color: "red", labels: nil, deleted: false},
+			wantErr:   true,
+			wantedErr: domain.ErrVersionConflict,
+			initMocks: func(tt *testing.T, ta *args, s *TodoListService) {
+				store := mocks.NewTodoListStore(tt)
+
+				tt.Cleanup(func() {
+					store.AssertExpectations(tt)
+				})
+
+				// Someone else updated the list after the client read version 1, Update is never called
+				store.On("GetListByID", ta.ctx, ta.id).Return(&domain.TodoList{ID: 1, Version: 2, UserID: 1, Title: "Shopping", CreatedAt: fixedTime}, nil).Once()
 
 				s.Store = store
 			},
@@ -507,7 +661,7 @@ func TestUpdate(t *testing.T) {
 		{
 			name:    "store update error",
 			fields:  fields{},
-			args:    args{ctx: context.Background(), userID: 1, id: 1, title: "Test", color: "red", labels: nil, deleted: false},
+			args:    args{ctx: context.Background(), userID: 1, id: 1, version: 1, title: "Test", color: "red", labels: nil, deleted: false},
 			wantErr: true,
 			initMocks: func(tt *testing.T, ta *args, s *TodoListService) {
 				store := mocks.NewTodoListStore(tt)
@@ -519,6 +673,7 @@ func TestUpdate(t *testing.T) {
 				// GetListByID succeeds
 				store.On("GetListByID", ta.ctx, ta.id).Return(&domain.TodoList{
 					ID:        1,
+					Version:   1,
 					UserID:    1,
 					Title:     "Shopping",
 					Color:     "white",
@@ -529,9 +684,10 @@ func TestUpdate(t *testing.T) {
 				}, nil).Once()
 
 				// Update fails with generic error
-				store.On("Update", ta.ctx, ta.id, ta.title, ta.color, ta.labels, ta.deleted).Return(nil, errors.New("database error")).Once()
+				store.On("Update", ta.ctx, ta.id, ta.version, ta.title, ta.color, ta.labels, ta.deleted, fixedTime).Return(nil, errors.New("database error")).Once()
 
 				s.Store = store
+				s.Clock = domain.FixedClock{Time: fixedTime}
 			},
 		},
 	}
@@ -546,7 +702,7 @@ func TestUpdate(t *testing.T) {
 
 			tc.initMocks(t, &tc.args, s)
 
-			got, err := s.Update(tc.args.ctx, tc.args.userID, tc.args.id, tc.args.title, tc.args.color, tc.args.labels, tc.args.deleted)
+			got, err := s.Update(tc.args.ctx, tc.args.userID, tc.args.id, tc.args.version, tc.args.title, &tc.args.color, tc.args.labels, tc.args.deleted)
 			if tc.wantErr {
 				require.Error(t, err)
 				if tc.wantedErr != nil {
@@ -560,6 +716,25 @@ func TestUpdate(t *testing.T) {
 	}
 }
 
+func TestUpdateWithoutColor(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	store := mocks.NewTodoListStore(t)
+	store.On("GetListByID", ctx, int64(1)).Return(&domain.TodoList{ID: 1, UserID: 1, Version: 2, Title: "Shopping", Color: "white"}, nil).Once()
+
+	// A nil color sends the current color to the store, so it stays the same
+	store.On("Update", ctx, int64(1), int64(2), "Groceries", "white", []string(nil), false, fixedTime).
+		Return(&domain.TodoList{ID: 1, UserID: 1, Version: 3, Title: "Groceries", Color: "white"}, nil).Once()
+
+	s := &TodoListService{Store: store, Clock: domain.FixedClock{Time: fixedTime}}
+
+	got, err := s.Update(ctx, 1, 1, 2, "Groceries", nil, nil, false)
+	require.NoError(t, err)
+	require.Equal(t, "white", got.Color)
+}
+
 func TestDelete(t *testing.T) {
 	t.Parallel()
 
@@ -652,3 +827,238 @@ func TestDelete(t *testing.T) {
 		})
 	}
 }
+
+func TestDeleteDryRun(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		ctx    context.Context
+		userID int64
+		id     int64
+	}
+
+	tests := []struct {
+		name      string
+		args      args
+		want      *domain.DryRunResult
+		wantedErr error
+		initMocks func(tt *testing.T, ta *args, s *TodoListService)
+	}{
+		{
+			name: "reports the list without deleting it",
+			args: args{ctx: context.Background(), userID: 1, id: 1},
+			want: &domain.DryRunResult{Count: 1, IDs: []string{"list-1"}},
+			initMocks: func(tt *testing.T, ta *args, s *TodoListService) {
+				store := mocks.NewTodoListStore(tt)
+				tt.Cleanup(func() { store.AssertExpectations(tt) })
+
+				// No Delete expectation, the mock fails if the store is asked to delete
+				store.On("GetListByID", ta.ctx, ta.id).Return(&domain.TodoList{ID: 1, PublicID: "list-1", UserID: 1, Title: "Shopping"}, nil).Once()
+
+				s.Store = store
+			},
+		},
+		{
+			name:      "someone else's list",
+			args:      args{ctx: context.Background(), userID: 1, id: 2},
+			wantedErr: domain.ErrListNotFound,
+			initMocks: func(tt *testing.T, ta *args, s *TodoListService) {
+				store := mocks.NewTodoListStore(tt)
+				tt.Cleanup(func() { store.AssertExpectations(tt) })
+
+				store.On("GetListByID", ta.ctx, ta.id).Return(&domain.TodoList{ID: 2, PublicID: "list-2", UserID: 2}, nil).Once()
+
+				s.Store = store
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := &TodoListService{}
+
+			tc.initMocks(t, &tc.args, s)
+
+			got, err := s.DeleteDryRun(tc.args.ctx, tc.args.userID, tc.args.id)
+			if tc.wantedErr != nil {
+				require.ErrorIs(t, err, tc.wantedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestSetPinned(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		ctx    context.Context
+		userID int64
+		id     int64
+		pinned bool
+	}
+
+	tests := []struct {
+		name      string
+		args      args
+		want      *domain.TodoList
+		wantErr   bool
+		wantedErr error
+		initMocks func(tt *testing.T, ta *args, s *TodoListService)
+	}{
+		{
+			name: "success",
+			args: args{ctx: context.Background(), userID: 1, id: 1, pinned: true},
+			want: &domain.TodoList{ID: 1, UserID: 1, Title: "Shopping", CreatedAt: fixedTime, Pinned: true},
+			initMocks: func(tt *testing.T, ta *args, s *TodoListService) {
+				store := mocks.NewTodoListStore(tt)
+
+				tt.Cleanup(func() {
+					store.AssertExpectations(tt)
+				})
+
+				store.On("GetListByID", ta.ctx, ta.id).Return(&domain.TodoList{
+					ID: 1, UserID: 1, Title: "Shopping", CreatedAt: fixedTime,
+				}, nil).Once()
+
+				store.On("SetPinned", ta.ctx, ta.id, ta.pinned, fixedTime).Return(&domain.TodoList{
+					ID: 1, UserID: 1, Title: "Shopping", CreatedAt: fixedTime, Pinned: true,
+				}, nil).Once()
+
+				s.Store = store
+				s.Clock = domain.FixedClock{Time: fixedTime}
+			},
+		},
+		{
+			name:      "not owner",
+			args:      args{ctx: context.Background(), userID: 2, id: 1, pinned: true},
+			wantErr:   true,
+			wantedErr: domain.ErrListNotFound,
+			initMocks: func(tt *testing.T, ta *args, s *TodoListService) {
+				store := mocks.NewTodoListStore(tt)
+
+				tt.Cleanup(func() {
+					store.AssertExpectations(tt)
+				})
+
+				store.On("GetListByID", ta.ctx, ta.id).Return(&domain.TodoList{
+					ID: 1, UserID: 1, Title: "Shopping", CreatedAt: fixedTime,
+				}, nil).Once()
+
+				s.Store = store
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := &TodoListService{}
+
+			tc.initMocks(t, &tc.args, s)
+
+			got, err := s.SetPinned(tc.args.ctx, tc.args.userID, tc.args.id, tc.args.pinned)
+			if tc.wantErr {
+				require.Error(t, err)
+				if tc.wantedErr != nil {
+					require.ErrorIs(t, err, tc.wantedErr)
+				}
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestListDefaultSort(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	store := mocks.NewTodoListStore(t)
+	store.On("List", ctx, int64(1), domain.ListOptions{Sort: "created_at", Order: "desc"}, mock.Anything).Return([]*domain.TodoList{}, nil).Once()
+
+	s := NewTodoListService(store, domain.Sort{Field: "created_at", Order: "desc"}, nil)
+
+	_, err := s.List(ctx, 1, domain.ListOptions{})
+	require.NoError(t, err)
+}
+
+func TestResolveID(t *testing.T) {
+	t.Parallel()
+
+	const publicID = "6f1c2b9e-8a4d-4c1e-9b7a-3d2f1e0c5a11"
+
+	tests := []struct {
+		name     string
+		storeID  int64
+		storeErr error
+		want     int64
+		wantErr  error
+	}{
+		{name: "found", storeID: 7, want: 7},
+		{name: "not found", storeErr: sql.ErrNoRows, wantErr: domain.ErrListNotFound},
+		{name: "store error", storeErr: errors.New("db error")},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+
+			store := mocks.NewTodoListStore(t)
+			store.On("IDByPublicID", ctx, int64(1), publicID).Return(tc.storeID, tc.storeErr).Once()
+
+			s := &TodoListService{Store: store}
+
+			got, err := s.ResolveID(ctx, 1, publicID)
+			if tc.storeErr != nil {
+				require.Error(t, err)
+				if tc.wantErr != nil {
+					require.ErrorIs(t, err, tc.wantErr)
+				}
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestCreateListSanitizesTitle(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("newline", func(t *testing.T) {
+		t.Parallel()
+
+		store := mocks.NewTodoListStore(t)
+		store.On("Create", ctx, mock.MatchedBy(func(list *domain.TodoList) bool {
+			return list.Title == "Weekend 🏕️ trip"
+		})).Return(nil).Once()
+
+		s := NewTodoListService(store, domain.Sort{}, nil)
+
+		list, err := s.Create(ctx, 1, "Weekend 🏕️\ntrip", "default", nil)
+		require.NoError(t, err)
+		require.Equal(t, "Weekend 🏕️ trip", list.Title)
+	})
+
+	t.Run("control character", func(t *testing.T) {
+		t.Parallel()
+
+		// Strict mock, the store is not called
+		s := NewTodoListService(mocks.NewTodoListStore(t), domain.Sort{}, nil)
+
+		_, err := s.Create(ctx, 1, "Weekend\x00trip", "default", nil)
+		require.ErrorIs(t, err, domain.ErrInvalidTitle)
+	})
+}
@@ -12,4 +12,5 @@ type UserStore interface {
 	GetUserByEmail(ctx context.Context, email string) (*domain.User, error)
 	Login(ctx context.Context, email, password string) (*domain.User, error)
 	DeleteUser(ctx context.Context, id int64) error
+	UpdateProfile(ctx context.Context, id int64, name, email, timezone string) (*domain.User, error)
 }
@@ -372,3 +372,89 @@ func (_c *UserStore_Login_Call) RunAndReturn(run func(ctx context.Context, email
 	_c.Call.Return(run)
 	return _c
 }
+
+// UpdateProfile provides a mock function for the type UserStore
+func (_mock *UserStore) UpdateProfile(ctx context.Context, id int64, name string, email string, timezone string) (*domain.User, error) {
+	ret := _mock.Called(ctx, id, name, email, timezone)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateProfile")
+	}
+
+	var r0 *domain.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, string, string, string) (*domain.User, error)); ok {
+		return returnFunc(ctx, id, name, email, timezone)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, string, string, string) *domain.User); ok {
+		r0 = returnFunc(ctx, id, name, email, timezone)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, string, string, string) error); ok {
+		r1 = returnFunc(ctx, id, name, email, timezone)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// UserStore_UpdateProfile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateProfile'
+type UserStore_UpdateProfile_Call struct {
+	*mock.Call
+}
+
+// UpdateProfile is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - name string
+//   - email string
+//   - timezone string
+func (_e *UserStore_Expecter) UpdateProfile(ctx interface{}, id interface{}, name interface{}, email interface{}, timezone interface{}) *UserStore_UpdateProfile_Call {
+	return &UserStore_UpdateProfile_Call{Call: _e.mock.On("UpdateProfile", ctx, id, name, email, timezone)}
+}
+
+func (_c *UserStore_UpdateProfile_Call) Run(run func(ctx context.Context, id int64, name string, email string, timezone string)) *UserStore_UpdateProfile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *UserStore_UpdateProfile_Call) Return(user *domain.User, err error) *UserStore_UpdateProfile_Call {
+	_c.Call.Return(user, err)
+	return _c
+}
+
+func (_c *UserStore_UpdateProfile_Call) RunAndReturn(run func(ctx context.Context, id int64, name string, email string, timezone string) (*domain.User, error)) *UserStore_UpdateProfile_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -2,6 +2,7 @@ package user
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/macesz/todo-go/domain"
@@ -45,11 +46,54 @@ func (u *UserService) GetUser(ctx context.Context, id int64) (*domain.User, erro
 }
 
 // user login
+// An unknown email and a wrong password both return domain.ErrInvalidCredentials, so callers can't probe for accounts
 func (u *UserService) Login(ctx context.Context, email, password string) (*domain.User, error) {
-	return u.UserStore.Login(ctx, email, password)
+	user, err := u.UserStore.Login(ctx, email, password)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) || errors.Is(err, domain.ErrInvalidCredentials) {
+			return nil, domain.ErrInvalidCredentials
+		}
+		return nil, fmt.Errorf("failed to login: %w", err)
+	}
+
+	return user, nil
 }
 
 // delete user by id
 func (u *UserService) DeleteUser(ctx context.Context, id int64) error {
 	return u.UserStore.DeleteUser(ctx, id)
 }
+
+// update the name, email and timezone of a user, the email must not belong to another user
+// An empty timezone keeps the one the user has
+func (u *UserService) UpdateProfile(ctx context.Context, id int64, name, email, timezone string) (*domain.User, error) {
+	if name == "" || email == "" {
+		return nil, fmt.Errorf("missing required fields: %w", domain.ErrInvalidInput)
+	}
+
+	if timezone != "" {
+		if err := domain.ValidateTimezone(timezone); err != nil {
+			return nil, err
+		}
+	}
+
+	existingUser, err := u.UserStore.GetUserByEmail(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+
+	if existingUser != nil && existingUser.ID != id {
+		return nil, fmt.Errorf("email already in use: %w", domain.ErrDuplicate)
+	}
+
+	// The store still reports ErrDuplicate if another user takes the email in the meantime
+	user, err := u.UserStore.UpdateProfile(ctx, id, name, email, timezone)
+	if err != nil {
+		if errors.Is(err, domain.ErrDuplicate) || errors.Is(err, domain.ErrUserNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to update profile in store: %w", err)
+	}
+
+	return user, nil
+}
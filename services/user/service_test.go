@@ -283,3 +283,105 @@ func TestDeleteUser(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdateProfile(t *testing.T) {
+	t.Parallel()
+
+	updated := &domain.User{ID: 1, Name: "New Name", Email: "new@example.com", Timezone: "Europe/Budapest"}
+
+	tests := []struct {
+		name         string
+		email        string
+		timezone     string
+		existing     *domain.User
+		storeErr     error
+		callStore    bool
+		wantTimezone string
+		want         *domain.User
+		wantedErr    error
+	}{
+		{name: "success", email: "new@example.com", timezone: "Europe/Budapest", callStore: true, wantTimezone: "Europe/Budapest", want: updated},
+		{name: "keeps own email", email: "new@example.com", timezone: "Europe/Budapest", existing: &domain.User{ID: 1}, callStore: true, wantTimezone: "Europe/Budapest", want: updated},
+		{name: "empty timezone is passed on", email: "new@example.com", callStore: true, wantTimezone: "", want: updated},
+		{name: "email of another user", email: "new@example.com", timezone: "UTC", existing: &domain.User{ID: 2}, wantedErr: domain.ErrDuplicate},
+		{name: "email taken meanwhile", email: "new@example.com", timezone: "UTC", callStore: true, wantTimezone: "UTC", storeErr: domain.ErrDuplicate, wantedErr: domain.ErrDuplicate},
+		{name: "unknown timezone", email: "new@example.com", timezone: "Mars/Olympus_Mons", wantedErr: domain.ErrInvalidTimezone},
+		{name: "server local timezone", email: "new@example.com", timezone: "Local", wantedErr: domain.ErrInvalidTimezone},
+		{name: "missing email", email: "", wantedErr: domain.ErrInvalidInput},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+
+			store := mocks.NewUserStore(t)
+			if tc.callStore || tc.existing != nil {
+				store.On("GetUserByEmail", ctx, tc.email).Return(tc.existing, nil).Once()
+			}
+			if tc.callStore {
+				store.On("UpdateProfile", ctx, int64(1), "New Name", tc.email, tc.wantTimezone).Return(tc.want, tc.storeErr).Once()
+			}
+
+			s := &UserService{UserStore: store}
+
+			got, err := s.UpdateProfile(ctx, 1, "New Name", tc.email, tc.timezone)
+			if tc.wantedErr != nil {
+				require.ErrorIs(t, err, tc.wantedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestLogin(t *testing.T) {
+	t.Parallel()
+
+	user := &domain.User{ID: 1, Name: "User One", Email: "u1@example.com"}
+
+	tests := []struct {
+		name     string
+		storeErr error
+		wantErr  error
+		wantUser *domain.User
+	}{
+		{name: "success", wantUser: user},
+		{name: "wrong password", storeErr: domain.ErrInvalidCredentials, wantErr: domain.ErrInvalidCredentials},
+		{name: "unknown email looks like a wrong password", storeErr: domain.ErrUserNotFound, wantErr: domain.ErrInvalidCredentials},
+		{name: "store error", storeErr: errors.New("db down")},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+
+			store := mocks.NewUserStore(t)
+			if tc.storeErr != nil {
+				store.On("Login", ctx, "u1@example.com", "Password123").Return(nil, tc.storeErr).Once()
+			} else {
+				store.On("Login", ctx, "u1@example.com", "Password123").Return(user, nil).Once()
+			}
+
+			s := &UserService{UserStore: store}
+
+			got, err := s.Login(ctx, "u1@example.com", "Password123")
+			if tc.storeErr != nil {
+				require.Error(t, err)
+				if tc.wantErr != nil {
+					require.ErrorIs(t, err, tc.wantErr)
+					require.NotErrorIs(t, err, domain.ErrUserNotFound)
+				} else {
+					require.NotErrorIs(t, err, domain.ErrInvalidCredentials)
+				}
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wantUser, got)
+		})
+	}
+}
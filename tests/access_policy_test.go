@@ -0,0 +1,76 @@
+package tests
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/macesz/todo-go/domain"
+	"github.com/macesz/todo-go/tests/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+// Test_AccessPolicy checks the access policy of the protected routes on every resource:
+// no token is 401, another user's resource is 404 and the caller's own resource is 200.
+func Test_AccessPolicy(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	tc, server, services := testutils.ComposeServer(t)
+
+	owner := domain.User{
+		Name:     "User One",
+		Email:    "u1@example.com",
+		Password: "pass",
+	}
+	ownerHeader, err := testutils.GivenUser(t, services.TokenAuth, tc.DB, &owner)
+	require.NoError(t, err)
+
+	other := domain.User{
+		Name:     "User Two",
+		Email:    "u2@example.com",
+		Password: "pass",
+	}
+	otherHeader, err := testutils.GivenUser(t, services.TokenAuth, tc.DB, &other)
+	require.NoError(t, err)
+
+	listID, err := testutils.GivenTodoLists(t, tc.DB, domain.TodoList{UserID: owner.ID, Title: "Groceries"})
+	require.NoError(t, err)
+	todoID, err := testutils.GivenTodo(t, tc.DB, domain.Todo{UserID: owner.ID, TodoListID: listID, Title: "Milk"})
+	require.NoError(t, err)
+
+	list := testutils.ListPublicID(t, tc.DB, listID)
+	todo := testutils.TodoPublicID(t, tc.DB, todoID)
+
+	routes := []struct {
+		resource string
+		path     string
+	}{
+		{"list", "/api/lists/" + list},
+		{"todos of a list", "/api/lists/" + list + "/todos"},
+		{"todo", "/api/lists/" + list + "/todos/" + todo},
+		{"list of a todo", "/api/todos/" + todo + "/list"},
+		{"user", fmt.Sprintf("/api/users/%d", owner.ID)},
+	}
+
+	callers := []struct {
+		name   string
+		header map[string]string
+		want   int
+	}{
+		{"no token", nil, http.StatusUnauthorized},
+		{"another user", otherHeader, http.StatusNotFound},
+		{"owner", ownerHeader, http.StatusOK},
+	}
+
+	for _, route := range routes {
+		for _, caller := range callers {
+			t.Run(route.resource+"/"+caller.name, func(t *testing.T) {
+				resp, body := testutils.TestRequest(t, server, http.MethodGet, route.path, caller.header, nil)
+
+				require.Equal(t, caller.want, resp.StatusCode, string(body))
+			})
+		}
+	}
+}
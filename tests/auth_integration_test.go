@@ -64,7 +64,7 @@ func Test_Auth_Integration2(t *testing.T) {
 	t.Run("Authorization (Access Control)", func(t *testing.T) {
 
 		t.Run("User 1 can see their own list", func(t *testing.T) {
-			url := fmt.Sprintf("/api/lists/%d", listID)
+			url := fmt.Sprintf("/api/lists/%s", testutils.ListPublicID(t, tc.DB, listID))
 			resp, _ := testutils.TestRequest(t, server, http.MethodGet, url, header1, nil)
 
 			require.Equal(t, http.StatusOK, resp.StatusCode)
@@ -72,7 +72,7 @@ func Test_Auth_Integration2(t *testing.T) {
 
 		t.Run("User 2 CANNOT see User 1's list -> 404 or 403", func(t *testing.T) {
 			// User 2 has a valid token, but requests User 1's data
-			url := fmt.Sprintf("/api/lists/%d", listID)
+			url := fmt.Sprintf("/api/lists/%s", testutils.ListPublicID(t, tc.DB, listID))
 			resp, _ := testutils.TestRequest(t, server, http.MethodGet, url, header2, nil)
 
 			// Should be Not Found (secure) or Forbidden
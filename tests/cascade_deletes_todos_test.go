@@ -60,7 +60,7 @@ func Test_CascadeDeleteDeletesTodos(t *testing.T) {
 	require.Equal(t, 2, beforeCount)
 
 	// 3. Delete the list via HTTP
-	url := fmt.Sprintf("/api/lists/%d", todolistID)
+	url := fmt.Sprintf("/api/lists/%s", testutils.ListPublicID(t, tc.DB, todolistID))
 	resp, _ := testutils.TestRequest(t, server, http.MethodDelete, url, header, nil)
 
 	require.Equal(t, http.StatusNoContent, resp.StatusCode)
@@ -0,0 +1,40 @@
+package tests
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/macesz/todo-go/tests/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+// Test_ComposeServer_RequiresAuth checks that the composed server runs the real
+// router middleware, so protected routes reject requests without a token.
+func Test_ComposeServer_RequiresAuth(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	_, server, _ := testutils.ComposeServer(t)
+
+	routes := []struct {
+		method string
+		path   string
+	}{
+		{http.MethodGet, "/api/lists"},
+		{http.MethodPost, "/api/lists"},
+		{http.MethodGet, "/api/lists/1"},
+		{http.MethodPut, "/api/lists/1/pin"},
+		{http.MethodGet, "/api/lists/1/todos"},
+		{http.MethodPost, "/api/lists/1/todos/empty-done"},
+		{http.MethodGet, "/api/users/1"},
+	}
+
+	for _, route := range routes {
+		t.Run(route.method+" "+route.path, func(t *testing.T) {
+			resp, _ := testutils.TestRequest(t, server, route.method, route.path, nil, nil)
+
+			require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package tests
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/macesz/todo-go/cmd/composition"
+	"github.com/macesz/todo-go/delivery/web"
+	"github.com/macesz/todo-go/domain"
+	"github.com/macesz/todo-go/tests/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+// Test_ComposeWiring builds the whole graph on a real database and checks that no service or handler is left nil.
+// The fields are walked by reflection, so a field added to ServerServices or Handlers is covered without changing the test.
+func Test_ComposeWiring(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	tc := testutils.SetupTestDB(t)
+
+	cfg := domain.Config{
+		JWTSecret: "my-super-secret-test-key-12345",
+	}
+
+	services, err := composition.ComposeServices(cfg, tc.DB)
+	require.NoError(t, err)
+	requireAllFieldsSet(t, *services)
+
+	handlers, err := web.CreateHandlers(t.Context(), cfg, services)
+	require.NoError(t, err)
+	requireAllFieldsSet(t, *handlers)
+
+	router, err := web.CreateRouter(t.Context(), cfg, services, handlers)
+	require.NoError(t, err)
+	require.NotNil(t, router)
+}
+
+// requireAllFieldsSet fails for every nil pointer or interface field of a struct
+func requireAllFieldsSet(t *testing.T, v any) {
+	t.Helper()
+
+	value := reflect.ValueOf(v)
+	for i := range value.NumField() {
+		field := value.Field(i)
+		name := value.Type().Name() + "." + value.Type().Field(i).Name
+
+		switch field.Kind() {
+		case reflect.Pointer, reflect.Interface:
+			require.False(t, field.IsNil(), "%s is not wired", name)
+		}
+	}
+}
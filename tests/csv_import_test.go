@@ -0,0 +1,113 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"maps"
+	"mime/multipart"
+	"net/http"
+	"testing"
+
+	"github.com/macesz/todo-go/domain"
+	"github.com/macesz/todo-go/tests/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ImportTodosCSV(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	tc, server, services := testutils.ComposeServer(t)
+
+	user := domain.User{
+		Name:     "User One",
+		Email:    "u1@example.com",
+		Password: "pass",
+	}
+	header, err := testutils.GivenUser(t, services.TokenAuth, tc.DB, &user)
+	require.NoError(t, err)
+
+	other := domain.User{
+		Name:     "User Two",
+		Email:    "u2@example.com",
+		Password: "pass",
+	}
+	_, err = testutils.GivenUser(t, services.TokenAuth, tc.DB, &other)
+	require.NoError(t, err)
+
+	listID, err := testutils.GivenTodoLists(t, tc.DB, domain.TodoList{UserID: user.ID, Title: "Groceries"})
+	require.NoError(t, err)
+	otherListID, err := testutils.GivenTodoLists(t, tc.DB, domain.TodoList{UserID: other.ID, Title: "Other"})
+	require.NoError(t, err)
+
+	list := testutils.ListPublicID(t, tc.DB, listID)
+	otherList := testutils.ListPublicID(t, tc.DB, otherListID)
+
+	upload := func(t *testing.T, content string) (*http.Response, []byte) {
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		part, err := writer.CreateFormFile("file", "todos.csv")
+		require.NoError(t, err)
+		_, err = part.Write([]byte(content))
+		require.NoError(t, err)
+		require.NoError(t, writer.Close())
+
+		headers := maps.Clone(header)
+		headers["Content-Type"] = writer.FormDataContentType()
+
+		return testutils.TestRequest(t, server, http.MethodPost, "/api/todos/import.csv", headers, body)
+	}
+
+	t.Run("valid rows are created, bad rows are reported", func(t *testing.T) {
+		resp, body := upload(t, "list_id,title,done\n"+
+			list+",Milk,false\n"+
+			list+",,false\n"+
+			list+",Bread,true\n"+
+			list+",Eggs,maybe\n"+
+			otherList+",Not mine,false\n")
+		require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+
+		var result domain.CSVImportResponseDTO
+		require.NoError(t, json.Unmarshal(body, &result))
+
+		require.Equal(t, 2, result.Created)
+		require.Equal(t, 3, result.Failed)
+		require.Len(t, result.Rows, 5)
+
+		require.NotEmpty(t, result.Rows[0].ID)
+		require.Equal(t, "title is required", result.Rows[1].Error)
+		require.NotEmpty(t, result.Rows[2].ID)
+		require.Equal(t, "invalid input: done must be true or false", result.Rows[3].Error)
+		require.Equal(t, "todo list not found", result.Rows[4].Error)
+
+		for i, row := range result.Rows {
+			require.Equal(t, i+2, row.Line)
+		}
+
+		resp, body = testutils.TestRequest(t, server, http.MethodGet, "/api/lists/"+list+"/todos?sort=title", header, nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var todos []domain.TodoDTO
+		require.NoError(t, json.Unmarshal(body, &todos))
+		require.Len(t, todos, 2)
+
+		require.Equal(t, result.Rows[2].ID, todos[0].ID)
+		require.Equal(t, "Bread", todos[0].Title)
+		require.True(t, todos[0].Done)
+		require.NotEmpty(t, todos[0].CompletedAt)
+
+		require.Equal(t, result.Rows[0].ID, todos[1].ID)
+		require.Equal(t, "Milk", todos[1].Title)
+		require.False(t, todos[1].Done)
+
+		var otherCount int
+		require.NoError(t, tc.DB.Get(&otherCount, "SELECT COUNT(*) FROM todos WHERE todolist_id = $1", otherListID))
+		require.Zero(t, otherCount)
+	})
+
+	t.Run("json is not accepted", func(t *testing.T) {
+		resp, _ := testutils.TestRequest(t, server, http.MethodPost, "/api/todos/import.csv", header, bytes.NewReader([]byte(`{}`)))
+		require.Equal(t, http.StatusUnsupportedMediaType, resp.StatusCode)
+	})
+}
@@ -0,0 +1,64 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/macesz/todo-go/domain"
+	"github.com/macesz/todo-go/tests/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Dashboard_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	tc, server, services := testutils.ComposeServer(t)
+
+	user := domain.User{
+		Name:     "User One",
+		Email:    "u1@example.com",
+		Password: "pass",
+	}
+	header, err := testutils.GivenUser(t, services.TokenAuth, tc.DB, &user)
+	require.NoError(t, err)
+
+	other := domain.User{
+		Name:     "User Two",
+		Email:    "u2@example.com",
+		Password: "pass2",
+	}
+	_, err = testutils.GivenUser(t, services.TokenAuth, tc.DB, &other)
+	require.NoError(t, err)
+
+	// Seed two lists with three todos, two of them done
+	workID, err := testutils.GivenTodoLists(t, tc.DB, domain.TodoList{UserID: user.ID, Title: "Work"})
+	require.NoError(t, err)
+	homeID, err := testutils.GivenTodoLists(t, tc.DB, domain.TodoList{UserID: user.ID, Title: "Home"})
+	require.NoError(t, err)
+
+	for _, todo := range []domain.Todo{
+		{UserID: user.ID, TodoListID: workID, Title: "Report", Done: true},
+		{UserID: user.ID, TodoListID: workID, Title: "Meeting", Done: false},
+		{UserID: user.ID, TodoListID: homeID, Title: "Dishes", Done: true},
+	} {
+		_, err = testutils.GivenTodo(t, tc.DB, todo)
+		require.NoError(t, err)
+	}
+
+	// Another user's data must not be counted
+	otherListID, err := testutils.GivenTodoLists(t, tc.DB, domain.TodoList{UserID: other.ID, Title: "Other"})
+	require.NoError(t, err)
+	_, err = testutils.GivenTodo(t, tc.DB, domain.Todo{UserID: other.ID, TodoListID: otherListID, Title: "Not mine", Done: true})
+	require.NoError(t, err)
+
+	resp, body := testutils.TestRequest(t, server, http.MethodGet, "/api/dashboard", header, nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var dashboard domain.DashboardDTO
+	require.NoError(t, json.Unmarshal(body, &dashboard))
+
+	require.Equal(t, domain.DashboardDTO{TotalLists: 2, TotalTodos: 3, DoneCount: 2}, dashboard)
+}
@@ -0,0 +1,56 @@
+package tests
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/macesz/todo-go/domain"
+	"github.com/macesz/todo-go/tests/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+// Test_DeleteNoContent checks that every successful delete answers a bare 204, with no body through the full middleware stack
+func Test_DeleteNoContent(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	tc, server, services := testutils.ComposeServer(t)
+
+	user := domain.User{
+		Name:     "User One",
+		Email:    "u1@example.com",
+		Password: "pass",
+	}
+	header, err := testutils.GivenUser(t, services.TokenAuth, tc.DB, &user)
+	require.NoError(t, err)
+
+	listID, err := testutils.GivenTodoLists(t, tc.DB, domain.TodoList{UserID: user.ID, Title: "Groceries"})
+	require.NoError(t, err)
+	todoID, err := testutils.GivenTodo(t, tc.DB, domain.Todo{UserID: user.ID, TodoListID: listID, Title: "Milk"})
+	require.NoError(t, err)
+
+	listPath := "/api/lists/" + testutils.ListPublicID(t, tc.DB, listID)
+
+	// In order, so each delete still finds its resource
+	paths := []struct {
+		name string
+		path string
+	}{
+		{name: "todo", path: listPath + "/todos/" + testutils.TodoPublicID(t, tc.DB, todoID)},
+		{name: "list", path: listPath},
+		{name: "user", path: fmt.Sprintf("/api/users/%d", user.ID)},
+	}
+
+	for _, p := range paths {
+		t.Run(p.name, func(t *testing.T) {
+			resp, body := testutils.TestRequest(t, server, http.MethodDelete, p.path, header, nil)
+			require.Equal(t, http.StatusNoContent, resp.StatusCode, string(body))
+
+			require.Empty(t, body)
+			require.Zero(t, resp.ContentLength)
+			require.Empty(t, resp.Header.Get("Content-Type"))
+		})
+	}
+}
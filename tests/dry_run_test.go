@@ -0,0 +1,81 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/macesz/todo-go/domain"
+	"github.com/macesz/todo-go/tests/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_DryRunLeavesDataUnchanged(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	tc, server, services := testutils.ComposeServer(t)
+
+	user := domain.User{
+		Name:     "User One",
+		Email:    "u1@example.com",
+		Password: "pass",
+	}
+	header, err := testutils.GivenUser(t, services.TokenAuth, tc.DB, &user)
+	require.NoError(t, err)
+
+	listID, err := testutils.GivenTodoLists(t, tc.DB, domain.TodoList{UserID: user.ID, Title: "Chores"})
+	require.NoError(t, err)
+	doneID, err := testutils.GivenTodo(t, tc.DB, domain.Todo{UserID: user.ID, TodoListID: listID, Title: "Dishes", Done: true})
+	require.NoError(t, err)
+	_, err = testutils.GivenTodo(t, tc.DB, domain.Todo{UserID: user.ID, TodoListID: listID, Title: "Laundry"})
+	require.NoError(t, err)
+
+	listPath := "/api/lists/" + testutils.ListPublicID(t, tc.DB, listID)
+	todoPath := listPath + "/todos/" + testutils.TodoPublicID(t, tc.DB, doneID)
+
+	countTodos := func(t *testing.T) int {
+		resp, body := testutils.TestRequest(t, server, http.MethodGet, listPath+"/todos", header, nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var todos []domain.TodoDTO
+		require.NoError(t, json.Unmarshal(body, &todos))
+		return len(todos)
+	}
+
+	dryRun := func(t *testing.T, method string, path string) domain.DryRunDTO {
+		resp, body := testutils.TestRequest(t, server, method, path+"?dry_run=true", header, nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var result domain.DryRunDTO
+		require.NoError(t, json.Unmarshal(body, &result))
+		require.True(t, result.DryRun)
+		return result
+	}
+
+	t.Run("empty done", func(t *testing.T) {
+		result := dryRun(t, http.MethodPost, listPath+"/todos/empty-done")
+		require.Equal(t, int64(1), result.Count)
+		require.Equal(t, []string{testutils.TodoPublicID(t, tc.DB, doneID)}, result.IDs)
+
+		require.Equal(t, 2, countTodos(t))
+	})
+
+	t.Run("delete todo", func(t *testing.T) {
+		result := dryRun(t, http.MethodDelete, todoPath)
+		require.Equal(t, int64(1), result.Count)
+
+		resp, _ := testutils.TestRequest(t, server, http.MethodGet, todoPath, header, nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("delete list", func(t *testing.T) {
+		result := dryRun(t, http.MethodDelete, listPath)
+		require.Equal(t, []string{testutils.ListPublicID(t, tc.DB, listID)}, result.IDs)
+
+		resp, _ := testutils.TestRequest(t, server, http.MethodGet, listPath, header, nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Equal(t, 2, countTodos(t))
+	})
+}
@@ -0,0 +1,67 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/macesz/todo-go/domain"
+	"github.com/macesz/todo-go/tests/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_EmptyDoneTrashesOnlyDoneTodos(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	tc, server, services := testutils.ComposeServer(t)
+
+	user := domain.User{
+		Name:     "User One",
+		Email:    "u1@example.com",
+		Password: "pass",
+	}
+	header, err := testutils.GivenUser(t, services.TokenAuth, tc.DB, &user)
+	require.NoError(t, err)
+
+	todolistID, err := testutils.GivenTodoLists(t, tc.DB, domain.TodoList{UserID: user.ID, Title: "Chores"})
+	require.NoError(t, err)
+
+	// 1. Two done and one pending todo in the list
+	for _, todo := range []domain.Todo{
+		{UserID: user.ID, TodoListID: todolistID, Title: "Done 1", Done: true},
+		{UserID: user.ID, TodoListID: todolistID, Title: "Done 2", Done: true},
+		{UserID: user.ID, TodoListID: todolistID, Title: "Pending", Done: false},
+	} {
+		_, err = testutils.GivenTodo(t, tc.DB, todo)
+		require.NoError(t, err)
+	}
+
+	// 2. Empty the done todos via HTTP
+	listPublicID := testutils.ListPublicID(t, tc.DB, todolistID)
+	url := fmt.Sprintf("/api/lists/%s/todos/empty-done", listPublicID)
+	resp, body := testutils.TestRequest(t, server, http.MethodPost, url, header, nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var result domain.EmptyDoneResponseDTO
+	require.NoError(t, json.Unmarshal(body, &result))
+	require.Equal(t, int64(2), result.Count)
+
+	// 3. Only the pending todo is still listed
+	url = fmt.Sprintf("/api/lists/%s/todos", listPublicID)
+	resp, body = testutils.TestRequest(t, server, http.MethodGet, url, header, nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var todos []domain.TodoDTO
+	require.NoError(t, json.Unmarshal(body, &todos))
+	require.Len(t, todos, 1)
+	require.Equal(t, "Pending", todos[0].Title)
+
+	// 4. Soft delete is off, the done todos are removed rather than trashed
+	var doneCount int
+	err = tc.DB.Get(&doneCount, "SELECT COUNT(*) FROM todos WHERE todolist_id = $1 AND done = true", todolistID)
+	require.NoError(t, err)
+	require.Equal(t, 0, doneCount)
+}
@@ -0,0 +1,86 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/macesz/todo-go/domain"
+	"github.com/macesz/todo-go/tests/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Export_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	tc, server, services := testutils.ComposeServer(t)
+
+	user := domain.User{
+		Name:     "User One",
+		Email:    "u1@example.com",
+		Password: "pass",
+	}
+	header, err := testutils.GivenUser(t, services.TokenAuth, tc.DB, &user)
+	require.NoError(t, err)
+
+	other := domain.User{
+		Name:     "User Two",
+		Email:    "u2@example.com",
+		Password: "pass2",
+	}
+	_, err = testutils.GivenUser(t, services.TokenAuth, tc.DB, &other)
+	require.NoError(t, err)
+
+	workID, err := testutils.GivenTodoLists(t, tc.DB, domain.TodoList{UserID: user.ID, Title: "Work", Labels: []string{"office"}})
+	require.NoError(t, err)
+	homeID, err := testutils.GivenTodoLists(t, tc.DB, domain.TodoList{UserID: user.ID, Title: "Home"})
+	require.NoError(t, err)
+
+	for _, todo := range []domain.Todo{
+		{UserID: user.ID, TodoListID: workID, Title: "Report", Done: true},
+		{UserID: user.ID, TodoListID: workID, Title: "Meeting"},
+		{UserID: user.ID, TodoListID: homeID, Title: "Dishes"},
+	} {
+		_, err = testutils.GivenTodo(t, tc.DB, todo)
+		require.NoError(t, err)
+	}
+
+	// Another user's data must not be exported
+	otherListID, err := testutils.GivenTodoLists(t, tc.DB, domain.TodoList{UserID: other.ID, Title: "Other"})
+	require.NoError(t, err)
+	_, err = testutils.GivenTodo(t, tc.DB, domain.Todo{UserID: other.ID, TodoListID: otherListID, Title: "Not mine"})
+	require.NoError(t, err)
+
+	resp, body := testutils.TestRequest(t, server, http.MethodGet, "/api/me/export", header, nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Contains(t, resp.Header.Get("Content-Disposition"), "attachment")
+	require.NotContains(t, string(body), "password")
+
+	var export domain.ExportDocument
+	require.NoError(t, json.Unmarshal(body, &export))
+
+	require.Equal(t, domain.ExportVersion, export.Version)
+	require.NotEmpty(t, export.ExportedAt)
+	require.Equal(t, domain.UserDTO{ID: user.ID, Name: "User One", Email: "u1@example.com"}, export.User)
+
+	require.Len(t, export.Lists, 2)
+
+	byTitle := make(map[string]domain.ExportList)
+	for _, list := range export.Lists {
+		byTitle[list.Title] = list
+	}
+
+	work := byTitle["Work"]
+	require.Equal(t, testutils.ListPublicID(t, tc.DB, workID), work.ID)
+	require.Equal(t, []string{"office"}, work.Labels)
+	require.Len(t, work.Todos, 2)
+	require.Equal(t, "Report", work.Todos[0].Title)
+	require.True(t, work.Todos[0].Done)
+	require.Equal(t, "Meeting", work.Todos[1].Title)
+
+	home := byTitle["Home"]
+	require.Len(t, home.Todos, 1)
+	require.Equal(t, "Dishes", home.Todos[0].Title)
+}
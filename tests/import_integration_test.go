@@ -0,0 +1,129 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/macesz/todo-go/domain"
+	"github.com/macesz/todo-go/tests/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Import_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	tc, server, services := testutils.ComposeServer(t)
+
+	source := domain.User{Name: "Source", Email: "source@example.com", Password: "pass"}
+	sourceHeader, err := testutils.GivenUser(t, services.TokenAuth, tc.DB, &source)
+	require.NoError(t, err)
+
+	target := domain.User{Name: "Target", Email: "target@example.com", Password: "pass2"}
+	targetHeader, err := testutils.GivenUser(t, services.TokenAuth, tc.DB, &target)
+	require.NoError(t, err)
+
+	workID, err := testutils.GivenTodoLists(t, tc.DB, domain.TodoList{UserID: source.ID, Title: "Work", Color: "#FF0000", Labels: []string{"office", "q3"}})
+	require.NoError(t, err)
+	homeID, err := testutils.GivenTodoLists(t, tc.DB, domain.TodoList{UserID: source.ID, Title: "Home"})
+	require.NoError(t, err)
+
+	meetingColor := "#00AA00"
+
+	for _, todo := range []domain.Todo{
+		{UserID: source.ID, TodoListID: workID, Title: "Report", Done: true},
+		{UserID: source.ID, TodoListID: workID, Title: "Meeting", Color: &meetingColor},
+		{UserID: source.ID, TodoListID: homeID, Title: "Dishes"},
+	} {
+		_, err = testutils.GivenTodo(t, tc.DB, todo)
+		require.NoError(t, err)
+	}
+
+	exportDoc := func(header map[string]string) domain.ExportDocument {
+		t.Helper()
+
+		resp, body := testutils.TestRequest(t, server, http.MethodGet, "/api/me/export", header, nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var doc domain.ExportDocument
+		require.NoError(t, json.Unmarshal(body, &doc))
+		return doc
+	}
+
+	importDoc := func(header map[string]string, query string, doc domain.ExportDocument) (*http.Response, []byte) {
+		t.Helper()
+
+		body, err := json.Marshal(doc)
+		require.NoError(t, err)
+
+		return testutils.TestRequest(t, server, http.MethodPost, "/api/me/import"+query, header, bytes.NewReader(body))
+	}
+
+	// withoutIDs drops what an import does not keep, so two exports can be compared
+	withoutIDs := func(doc domain.ExportDocument) []domain.ExportList {
+		lists := make([]domain.ExportList, len(doc.Lists))
+		for i, list := range doc.Lists {
+			list.ID = ""
+			todos := make([]domain.ExportTodo, len(list.Todos))
+			for j, todo := range list.Todos {
+				todo.ID = ""
+				todos[j] = todo
+			}
+			list.Todos = todos
+			lists[i] = list
+		}
+		return lists
+	}
+
+	backup := exportDoc(sourceHeader)
+	require.Len(t, backup.Lists, 2)
+
+	t.Run("import into a fresh user recreates the data", func(t *testing.T) {
+		resp, body := importDoc(targetHeader, "", backup)
+		require.Equal(t, http.StatusCreated, resp.StatusCode, string(body))
+
+		var result domain.ImportResult
+		require.NoError(t, json.Unmarshal(body, &result))
+		require.Equal(t, domain.ImportResult{Lists: 2, Todos: 3}, result)
+
+		restored := exportDoc(targetHeader)
+		require.Equal(t, target.ID, restored.User.ID)
+		require.Equal(t, withoutIDs(backup), withoutIDs(restored))
+
+		// New rows, new public ids
+		for i := range backup.Lists {
+			require.NotEqual(t, backup.Lists[i].ID, restored.Lists[i].ID)
+		}
+	})
+
+	t.Run("importing again conflicts on the list titles", func(t *testing.T) {
+		resp, _ := importDoc(targetHeader, "", backup)
+		require.Equal(t, http.StatusConflict, resp.StatusCode)
+
+		// Nothing of the failed import was kept
+		restored := exportDoc(targetHeader)
+		require.Len(t, restored.Lists, 2)
+	})
+
+	t.Run("replace wipes the existing data first", func(t *testing.T) {
+		partial := backup
+		partial.Lists = backup.Lists[:1]
+
+		resp, body := importDoc(targetHeader, "?replace=true", partial)
+		require.Equal(t, http.StatusCreated, resp.StatusCode, string(body))
+
+		restored := exportDoc(targetHeader)
+		require.Equal(t, withoutIDs(partial), withoutIDs(restored))
+	})
+
+	t.Run("invalid document", func(t *testing.T) {
+		invalid := backup
+		invalid.Version = 99
+
+		resp, _ := importDoc(targetHeader, "", invalid)
+		require.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	})
+}
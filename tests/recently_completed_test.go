@@ -0,0 +1,94 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/macesz/todo-go/domain"
+	"github.com/macesz/todo-go/tests/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_RecentlyCompletedTodos(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	tc, server, services := testutils.ComposeServer(t)
+
+	user := domain.User{
+		Name:     "User One",
+		Email:    "u1@example.com",
+		Password: "pass",
+	}
+	header, err := testutils.GivenUser(t, services.TokenAuth, tc.DB, &user)
+	require.NoError(t, err)
+
+	other := domain.User{
+		Name:     "User Two",
+		Email:    "u2@example.com",
+		Password: "pass",
+	}
+	_, err = testutils.GivenUser(t, services.TokenAuth, tc.DB, &other)
+	require.NoError(t, err)
+
+	now := time.Now().UTC().Truncate(time.Second)
+	at := func(hoursAgo int) *time.Time {
+		completedAt := now.Add(-time.Duration(hoursAgo) * time.Hour)
+		return &completedAt
+	}
+
+	work, err := testutils.GivenTodoLists(t, tc.DB, domain.TodoList{UserID: user.ID, Title: "Work"})
+	require.NoError(t, err)
+	home, err := testutils.GivenTodoLists(t, tc.DB, domain.TodoList{UserID: user.ID, Title: "Home"})
+	require.NoError(t, err)
+	otherList, err := testutils.GivenTodoLists(t, tc.DB, domain.TodoList{UserID: other.ID, Title: "Other"})
+	require.NoError(t, err)
+
+	oldest, err := testutils.GivenTodo(t, tc.DB, domain.Todo{UserID: user.ID, TodoListID: work, Title: "Oldest", Done: true, CompletedAt: at(3)})
+	require.NoError(t, err)
+	newest, err := testutils.GivenTodo(t, tc.DB, domain.Todo{UserID: user.ID, TodoListID: home, Title: "Newest", Done: true, CompletedAt: at(1)})
+	require.NoError(t, err)
+	middle, err := testutils.GivenTodo(t, tc.DB, domain.Todo{UserID: user.ID, TodoListID: work, Title: "Middle", Done: true, CompletedAt: at(2)})
+	require.NoError(t, err)
+	_, err = testutils.GivenTodo(t, tc.DB, domain.Todo{UserID: user.ID, TodoListID: work, Title: "Open"})
+	require.NoError(t, err)
+	_, err = testutils.GivenTodo(t, tc.DB, domain.Todo{UserID: other.ID, TodoListID: otherList, Title: "Not mine", Done: true, CompletedAt: at(0)})
+	require.NoError(t, err)
+
+	get := func(t *testing.T, path string) []domain.TodoDTO {
+		resp, body := testutils.TestRequest(t, server, http.MethodGet, path, header, nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var todos []domain.TodoDTO
+		require.NoError(t, json.Unmarshal(body, &todos))
+		return todos
+	}
+
+	t.Run("newest completion first", func(t *testing.T) {
+		todos := get(t, "/api/me/todos/recently-completed")
+		require.Len(t, todos, 3)
+
+		require.Equal(t, testutils.TodoPublicID(t, tc.DB, newest), todos[0].ID)
+		require.Equal(t, testutils.TodoPublicID(t, tc.DB, middle), todos[1].ID)
+		require.Equal(t, testutils.TodoPublicID(t, tc.DB, oldest), todos[2].ID)
+
+		require.Equal(t, testutils.ListPublicID(t, tc.DB, home), todos[0].TodoListID)
+		require.Equal(t, at(1).Format(time.RFC3339), todos[0].CompletedAt)
+	})
+
+	t.Run("limit", func(t *testing.T) {
+		todos := get(t, "/api/me/todos/recently-completed?limit=2")
+		require.Len(t, todos, 2)
+
+		require.Equal(t, testutils.TodoPublicID(t, tc.DB, newest), todos[0].ID)
+		require.Equal(t, testutils.TodoPublicID(t, tc.DB, middle), todos[1].ID)
+	})
+
+	t.Run("invalid limit", func(t *testing.T) {
+		resp, _ := testutils.TestRequest(t, server, http.MethodGet, "/api/me/todos/recently-completed?limit=abc", header, nil)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
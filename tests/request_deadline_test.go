@@ -0,0 +1,82 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/macesz/todo-go/domain"
+	"github.com/macesz/todo-go/tests/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+// Test_RequestDeadline checks that the request context reaches the database: when the client gives up on a
+// request whose query is blocked, the query is canceled instead of waiting for the lock.
+// There is no server-side timeout middleware yet, so this asserts the cancellation and not a 504.
+func Test_RequestDeadline(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	tc, server, services := testutils.ComposeServer(t)
+
+	user := domain.User{
+		Name:     "User One",
+		Email:    "u1@example.com",
+		Password: "pass",
+	}
+	header, err := testutils.GivenUser(t, services.TokenAuth, tc.DB, &user)
+	require.NoError(t, err)
+
+	listID, err := testutils.GivenTodoLists(t, tc.DB, domain.TodoList{UserID: user.ID, Title: "Groceries"})
+	require.NoError(t, err)
+	todoID, err := testutils.GivenTodo(t, tc.DB, domain.Todo{UserID: user.ID, TodoListID: listID, Title: "Milk"})
+	require.NoError(t, err)
+
+	// Hold a lock on the todo, so the UPDATE of the request blocks until it is canceled
+	tx, err := tc.DB.BeginTxx(t.Context(), nil)
+	require.NoError(t, err)
+	defer tx.Rollback()
+
+	_, err = tx.Exec("SELECT id FROM todos WHERE id = $1 FOR UPDATE", todoID)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(t.Context(), 300*time.Millisecond)
+	defer cancel()
+
+	path := "/api/lists/" + testutils.ListPublicID(t, tc.DB, listID) + "/todos/" + testutils.TodoPublicID(t, tc.DB, todoID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, server.URL+path, strings.NewReader(`{"title":"Oat milk","done":true}`))
+	require.NoError(t, err)
+
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range header {
+		req.Header.Set(key, value)
+	}
+
+	start := time.Now()
+	resp, err := server.Client().Do(req)
+	if resp != nil {
+		resp.Body.Close()
+	}
+
+	require.True(t, errors.Is(err, context.DeadlineExceeded), "expected the client deadline, got %v", err)
+	require.Less(t, time.Since(start), 2*time.Second)
+
+	// The server cancels the request context when the client goes away, and the blocked query with it
+	require.Eventually(t, func() bool {
+		var waiting int
+		err := tc.DB.Get(&waiting, `SELECT COUNT(*) FROM pg_stat_activity
+			WHERE wait_event_type = 'Lock' AND query LIKE 'UPDATE todos%'`)
+		return err == nil && waiting == 0
+	}, 5*time.Second, 50*time.Millisecond)
+
+	// Once the lock is gone the canceled update must not land
+	require.NoError(t, tx.Rollback())
+
+	var title string
+	require.NoError(t, tc.DB.Get(&title, "SELECT title FROM todos WHERE id = $1", todoID))
+	require.Equal(t, "Milk", title)
+}
@@ -0,0 +1,73 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/macesz/todo-go/domain"
+	"github.com/macesz/todo-go/tests/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+// Test_StableOrdering seeds todos that tie on every sortable field except id,
+// and checks that repeated calls and pages always come back in the same order.
+func Test_StableOrdering(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	tc, server, services := testutils.ComposeServer(t)
+
+	user := domain.User{Name: "User One", Email: "u1@example.com", Password: "pass"}
+	header, err := testutils.GivenUser(t, services.TokenAuth, tc.DB, &user)
+	require.NoError(t, err)
+
+	listID, err := testutils.GivenTodoLists(t, tc.DB, domain.TodoList{UserID: user.ID, Title: "Ties"})
+	require.NoError(t, err)
+
+	createdAt := time.Now().Truncate(time.Second)
+	for i := 0; i < 6; i++ {
+		_, err := testutils.GivenTodo(t, tc.DB, domain.Todo{UserID: user.ID, TodoListID: listID, Title: "Same", CreatedAt: createdAt})
+		require.NoError(t, err)
+	}
+
+	listPublicID := testutils.ListPublicID(t, tc.DB, listID)
+
+	listIDs := func(url string) []string {
+		resp, body := testutils.TestRequest(t, server, http.MethodGet, url, header, nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var todos []domain.TodoDTO
+		require.NoError(t, json.Unmarshal(body, &todos))
+
+		ids := make([]string, 0, len(todos))
+		for _, todo := range todos {
+			ids = append(ids, todo.ID)
+		}
+		return ids
+	}
+
+	for _, sort := range []string{"", "&sort=title", "&sort=created_at&order=desc"} {
+		t.Run("sort"+sort, func(t *testing.T) {
+			base := fmt.Sprintf("/api/lists/%s/todos?limit=100%s", listPublicID, sort)
+
+			all := listIDs(base)
+			require.Len(t, all, 6)
+
+			// Repeated calls return the same order
+			for i := 0; i < 3; i++ {
+				require.Equal(t, all, listIDs(base))
+			}
+
+			// Pages line up with the full result, no duplicates or gaps
+			var paged []string
+			for offset := 0; offset < 6; offset += 2 {
+				paged = append(paged, listIDs(fmt.Sprintf("/api/lists/%s/todos?limit=2&offset=%d%s", listPublicID, offset, sort))...)
+			}
+			require.Equal(t, all, paged)
+		})
+	}
+}
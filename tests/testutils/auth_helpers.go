@@ -25,7 +25,11 @@ func GenerateTestToken(tokenAuth *jwtauth.JWTAuth, user *domain.User) (string, e
 
 // SetupTestAuth creates the JWT for testing
 func SetupTestAuth() *jwtauth.JWTAuth {
-	return auth.CreateTokenAuth("my-super-secret-test-key-12345")
+	tokenAuth, err := auth.CreateTokenAuth("my-super-secret-test-key-12345")
+	if err != nil {
+		panic(err)
+	}
+	return tokenAuth
 }
 
 func AddBerrierTokenToHeader(token string, header map[string]string) map[string]string {
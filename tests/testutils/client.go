@@ -0,0 +1,65 @@
+package testutils
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// Client sends requests to the test server with the same headers every time, like the auth header GivenUser returns
+type Client struct {
+	Server *httptest.Server
+	Header map[string]string
+}
+
+func (c *Client) Get(t *testing.T, path string) (*http.Response, []byte) {
+	t.Helper()
+	return c.Do(t, http.MethodGet, path, nil)
+}
+
+func (c *Client) Post(t *testing.T, path string, body any) (*http.Response, []byte) {
+	t.Helper()
+	return c.Do(t, http.MethodPost, path, body)
+}
+
+func (c *Client) Put(t *testing.T, path string, body any) (*http.Response, []byte) {
+	t.Helper()
+	return c.Do(t, http.MethodPut, path, body)
+}
+
+func (c *Client) Delete(t *testing.T, path string) (*http.Response, []byte) {
+	t.Helper()
+	return c.Do(t, http.MethodDelete, path, nil)
+}
+
+// Do sends the body as JSON, a nil body sends none, and returns the response with its body read
+func (c *Client) Do(t *testing.T, method, path string, body any) (*http.Response, []byte) {
+	t.Helper()
+
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			t.Fatal(err)
+			return nil, nil
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	return TestRequest(t, c.Server, method, path, c.Header, reader)
+}
+
+// Decode unmarshals a JSON response body, failing the test when it doesn't fit T
+func Decode[T any](t *testing.T, body []byte) T {
+	t.Helper()
+
+	var value T
+	if err := json.Unmarshal(body, &value); err != nil {
+		t.Fatalf("failed to decode %s: %v", body, err)
+	}
+
+	return value
+}
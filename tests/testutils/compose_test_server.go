@@ -7,9 +7,15 @@ import (
 	"github.com/macesz/todo-go/cmd/composition"
 	"github.com/macesz/todo-go/delivery/web"
 	"github.com/macesz/todo-go/domain"
+	"github.com/stretchr/testify/require"
 )
 
+// ComposeServer starts a test database and serves the real router on top of it,
+// with the same services, routes and middleware as cmd/main.go.
+// Every integration test should build its server through here.
 func ComposeServer(t *testing.T) (*TestContainer, *httptest.Server, *web.ServerServices) {
+	t.Helper()
+
 	ctx := t.Context()
 	cfg := domain.Config{
 		JWTSecret: "my-super-secret-test-key-12345",
@@ -18,19 +24,19 @@ func ComposeServer(t *testing.T) (*TestContainer, *httptest.Server, *web.ServerS
 	// Setup database
 	tc := SetupTestDB(t)
 
-	services := composition.ComposeServices(cfg, tc.DB)
+	services, err := composition.ComposeServices(cfg, tc.DB)
+	require.NoError(t, err, "failed to compose services")
 
-	handlers, err := web.CreateHandlers(ctx, services)
-	if err != nil {
-		t.Error(err)
-	}
+	handlers, err := web.CreateHandlers(ctx, cfg, services)
+	require.NoError(t, err, "failed to create handlers")
 
 	router, err := web.CreateRouter(ctx, cfg, services, handlers)
-	if err != nil {
-		t.Error(err)
-	}
+	require.NoError(t, err, "failed to create router")
 
 	server := httptest.NewServer(router)
 
+	// Stop the server when test completes
+	t.Cleanup(server.Close)
+
 	return tc, server, services
 }
@@ -182,8 +182,13 @@ func CleanupDB(t *testing.T, db *sqlx.DB) {
 }
 
 func GivenTodoLists(t *testing.T, db *sqlx.DB, todoList domain.TodoList) (int64, error) {
-	sql := `INSERT INTO todolists (user_id, title, color, labels, created_at)
-			VALUES (:user_id, :title, :color, :labels, :created_at)
+	// A list without UpdatedAt was last updated when created
+	if todoList.UpdatedAt.IsZero() {
+		todoList.UpdatedAt = todoList.CreatedAt
+	}
+
+	sql := `INSERT INTO todolists (user_id, title, color, labels, created_at, updated_at)
+			VALUES (:user_id, :title, :color, :labels, :created_at, :updated_at)
 			RETURNING id;`
 
 	queryParams := map[string]any{
@@ -192,6 +197,7 @@ func GivenTodoLists(t *testing.T, db *sqlx.DB, todoList domain.TodoList) (int64,
 		"color":      todoList.Color,
 		"labels":     strings.Join(todoList.Labels, ","),
 		"created_at": todoList.CreatedAt,
+		"updated_at": todoList.UpdatedAt,
 	}
 
 	result, err := db.NamedQueryContext(t.Context(), sql, queryParams)
@@ -223,16 +229,19 @@ func GivenTodo(t *testing.T, db *sqlx.DB, todo domain.Todo) (int64, error) {
 		todo.CreatedAt = time.Now()
 	}
 
-	sql := `INSERT INTO todos (user_id, todolist_id, title, done, created_at)
-			VALUES (:user_id, :todolist_id, :title, :done, :created_at)
+	sql := `INSERT INTO todos (user_id, todolist_id, title, done, created_at, updated_at, completed_at, due_date, color)
+			VALUES (:user_id, :todolist_id, :title, :done, :created_at, :created_at, :completed_at, :due_date, :color)
 			RETURNING id;`
 
 	params := map[string]any{
-		"user_id":     todo.UserID,
-		"todolist_id": todo.TodoListID,
-		"title":       todo.Title,
-		"done":        todo.Done,
-		"created_at":  todo.CreatedAt,
+		"user_id":      todo.UserID,
+		"todolist_id":  todo.TodoListID,
+		"title":        todo.Title,
+		"done":         todo.Done,
+		"created_at":   todo.CreatedAt,
+		"completed_at": todo.CompletedAt,
+		"due_date":     todo.DueDate,
+		"color":        todo.Color,
 	}
 
 	rows, err := db.NamedQueryContext(t.Context(), sql, params)
@@ -293,3 +302,25 @@ func GivenUser(t *testing.T, tokenAuth *jwtauth.JWTAuth, db *sqlx.DB, user *doma
 
 	return header, nil
 }
+
+// ListPublicID returns the public id of a list, the API addresses lists by it
+func ListPublicID(t *testing.T, db *sqlx.DB, id int64) string {
+	t.Helper()
+
+	var publicID string
+	err := db.GetContext(t.Context(), &publicID, "SELECT public_id FROM todolists WHERE id = $1", id)
+	require.NoError(t, err)
+
+	return publicID
+}
+
+// TodoPublicID returns the public id of a todo, the API addresses todos by it
+func TodoPublicID(t *testing.T, db *sqlx.DB, id int64) string {
+	t.Helper()
+
+	var publicID string
+	err := db.GetContext(t.Context(), &publicID, "SELECT public_id FROM todos WHERE id = $1", id)
+	require.NoError(t, err)
+
+	return publicID
+}
@@ -0,0 +1,44 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/macesz/todo-go/dal/pgtodo"
+	"github.com/macesz/todo-go/domain"
+	"github.com/macesz/todo-go/services/todo"
+	"github.com/macesz/todo-go/tests/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_TimestampRoundTrip(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	tc := testutils.SetupTestDB(t)
+	tokenAuth := testutils.SetupTestAuth()
+
+	user := domain.User{Name: "User One", Email: "u1@example.com", Password: "pass"}
+	_, err := testutils.GivenUser(t, tokenAuth, tc.DB, &user)
+	require.NoError(t, err)
+
+	listID, err := testutils.GivenTodoLists(t, tc.DB, domain.TodoList{UserID: user.ID, Title: "Chores"})
+	require.NoError(t, err)
+
+	// Nanoseconds that Postgres would round up, the clock has to cut them before the write
+	written := time.Date(2024, 1, 2, 3, 4, 5, 123456789, time.UTC)
+	clock := domain.TruncatingClock{Clock: domain.FixedClock{Time: written}, Precision: time.Microsecond}
+
+	svc := todo.NewTodoService(pgtodo.CreateStore(tc.DB), todo.Options{Clock: clock})
+
+	created, _, err := svc.CreateTodo(t.Context(), user.ID, listID, "Dishes", nil, nil)
+	require.NoError(t, err)
+
+	read, err := svc.GetTodo(t.Context(), user.ID, created.ID)
+	require.NoError(t, err)
+
+	require.Equal(t, created.CreatedAt.UnixNano(), read.CreatedAt.UnixNano())
+	require.True(t, created.CreatedAt.Equal(read.CreatedAt))
+	require.Equal(t, 123456000, read.CreatedAt.Nanosecond())
+}
@@ -0,0 +1,80 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/macesz/todo-go/domain"
+	"github.com/macesz/todo-go/tests/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_BatchGetTodos(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	tc, server, services := testutils.ComposeServer(t)
+
+	user := domain.User{
+		Name:     "User One",
+		Email:    "u1@example.com",
+		Password: "pass",
+	}
+	header, err := testutils.GivenUser(t, services.TokenAuth, tc.DB, &user)
+	require.NoError(t, err)
+
+	other := domain.User{
+		Name:     "User Two",
+		Email:    "u2@example.com",
+		Password: "pass",
+	}
+	_, err = testutils.GivenUser(t, services.TokenAuth, tc.DB, &other)
+	require.NoError(t, err)
+
+	listID, err := testutils.GivenTodoLists(t, tc.DB, domain.TodoList{UserID: user.ID, Title: "Groceries"})
+	require.NoError(t, err)
+	milkID, err := testutils.GivenTodo(t, tc.DB, domain.Todo{UserID: user.ID, TodoListID: listID, Title: "Milk"})
+	require.NoError(t, err)
+	breadID, err := testutils.GivenTodo(t, tc.DB, domain.Todo{UserID: user.ID, TodoListID: listID, Title: "Bread"})
+	require.NoError(t, err)
+
+	otherListID, err := testutils.GivenTodoLists(t, tc.DB, domain.TodoList{UserID: other.ID, Title: "Theirs"})
+	require.NoError(t, err)
+	foreignID, err := testutils.GivenTodo(t, tc.DB, domain.Todo{UserID: other.ID, TodoListID: otherListID, Title: "Secret"})
+	require.NoError(t, err)
+
+	milk := testutils.TodoPublicID(t, tc.DB, milkID)
+	bread := testutils.TodoPublicID(t, tc.DB, breadID)
+	foreign := testutils.TodoPublicID(t, tc.DB, foreignID)
+
+	batchGet := func(t *testing.T, ids ...string) []domain.TodoDTO {
+		t.Helper()
+
+		reqBody, err := json.Marshal(domain.BatchGetTodosDTO{IDs: ids})
+		require.NoError(t, err)
+
+		resp, body := testutils.TestRequest(t, server, http.MethodPost, "/api/todos/batch-get", header, bytes.NewReader(reqBody))
+		require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+
+		var todos []domain.TodoDTO
+		require.NoError(t, json.Unmarshal(body, &todos))
+
+		return todos
+	}
+
+	t.Run("returns only owned todos", func(t *testing.T) {
+		todos := batchGet(t, bread, foreign, milk)
+
+		require.Len(t, todos, 2)
+		require.Equal(t, milk, todos[0].ID)
+		require.Equal(t, bread, todos[1].ID)
+		require.Equal(t, testutils.ListPublicID(t, tc.DB, listID), todos[0].TodoListID)
+	})
+
+	t.Run("only foreign ids give an empty result", func(t *testing.T) {
+		require.Empty(t, batchGet(t, foreign))
+	})
+}
@@ -0,0 +1,76 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/macesz/todo-go/domain"
+	"github.com/macesz/todo-go/tests/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_CloneTodo(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	tc, server, services := testutils.ComposeServer(t)
+
+	user := domain.User{
+		Name:     "User One",
+		Email:    "u1@example.com",
+		Password: "pass",
+	}
+	header, err := testutils.GivenUser(t, services.TokenAuth, tc.DB, &user)
+	require.NoError(t, err)
+
+	other := domain.User{
+		Name:     "User Two",
+		Email:    "u2@example.com",
+		Password: "pass",
+	}
+	otherHeader, err := testutils.GivenUser(t, services.TokenAuth, tc.DB, &other)
+	require.NoError(t, err)
+
+	listID, err := testutils.GivenTodoLists(t, tc.DB, domain.TodoList{UserID: user.ID, Title: "Groceries"})
+	require.NoError(t, err)
+	todoID, err := testutils.GivenTodo(t, tc.DB, domain.Todo{UserID: user.ID, TodoListID: listID, Title: "Milk", Done: true})
+	require.NoError(t, err)
+
+	original := testutils.TodoPublicID(t, tc.DB, todoID)
+	clonePath := "/api/todos/" + original + "/clone"
+
+	t.Run("clone is a new todo in the same list, not done", func(t *testing.T) {
+		resp, body := testutils.TestRequest(t, server, http.MethodPost, clonePath, header, nil)
+		require.Equal(t, http.StatusCreated, resp.StatusCode, string(body))
+
+		var clone domain.TodoDTO
+		require.NoError(t, json.Unmarshal(body, &clone))
+
+		require.NotEqual(t, original, clone.ID)
+		require.Equal(t, testutils.ListPublicID(t, tc.DB, listID), clone.TodoListID)
+		require.Equal(t, "Milk", clone.Title)
+		require.False(t, clone.Done)
+		require.Empty(t, clone.CompletedAt)
+	})
+
+	t.Run("clone with a new title", func(t *testing.T) {
+		resp, body := testutils.TestRequest(t, server, http.MethodPost, clonePath, header, bytes.NewReader([]byte(`{"title":"Oat milk"}`)))
+		require.Equal(t, http.StatusCreated, resp.StatusCode, string(body))
+
+		var clone domain.TodoDTO
+		require.NoError(t, json.Unmarshal(body, &clone))
+		require.Equal(t, "Oat milk", clone.Title)
+
+		var count int
+		require.NoError(t, tc.DB.Get(&count, "SELECT COUNT(*) FROM todos WHERE todolist_id = $1", listID))
+		require.Equal(t, 3, count)
+	})
+
+	t.Run("someone else's todo is not found", func(t *testing.T) {
+		resp, _ := testutils.TestRequest(t, server, http.MethodPost, clonePath, otherHeader, nil)
+		require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
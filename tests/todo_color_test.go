@@ -0,0 +1,68 @@
+package tests
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/macesz/todo-go/domain"
+	"github.com/macesz/todo-go/tests/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_TodoColor(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	tc, server, services := testutils.ComposeServer(t)
+
+	user := domain.User{
+		Name:     "User One",
+		Email:    "u1@example.com",
+		Password: "pass",
+	}
+	header, err := testutils.GivenUser(t, services.TokenAuth, tc.DB, &user)
+	require.NoError(t, err)
+
+	listID, err := testutils.GivenTodoLists(t, tc.DB, domain.TodoList{UserID: user.ID, Title: "Groceries", Color: "#FF5733"})
+	require.NoError(t, err)
+
+	client := &testutils.Client{Server: server, Header: header}
+	todosPath := "/api/lists/" + testutils.ListPublicID(t, tc.DB, listID) + "/todos"
+
+	color := func(s string) *string { return &s }
+
+	t.Run("a todo without a color shows the list color", func(t *testing.T) {
+		resp, body := client.Post(t, todosPath, domain.CreateTodoDTO{Title: "Milk"})
+		require.Equal(t, http.StatusCreated, resp.StatusCode, string(body))
+
+		got := testutils.Decode[domain.TodoDTO](t, body)
+		require.Equal(t, color("#FF5733"), got.Color)
+	})
+
+	t.Run("set and clear the color", func(t *testing.T) {
+		resp, body := client.Post(t, todosPath, domain.CreateTodoDTO{Title: "Bread", Color: color("#00AA00")})
+		require.Equal(t, http.StatusCreated, resp.StatusCode, string(body))
+
+		created := testutils.Decode[domain.TodoDTO](t, body)
+		require.Equal(t, color("#00AA00"), created.Color)
+
+		resp, body = client.Get(t, todosPath+"/"+created.ID)
+		require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+		require.Equal(t, color("#00AA00"), testutils.Decode[domain.TodoDTO](t, body).Color)
+
+		// Leaving the color out of the update clears it, the todo falls back to the list color
+		resp, body = client.Put(t, todosPath+"/"+created.ID, domain.UpdateTodoDTO{Title: "Bread", Done: true})
+		require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+		require.Equal(t, color("#FF5733"), testutils.Decode[domain.TodoDTO](t, body).Color)
+
+		var stored *string
+		require.NoError(t, tc.DB.Get(&stored, "SELECT color FROM todos WHERE public_id = $1", created.ID))
+		require.Nil(t, stored)
+	})
+
+	t.Run("an invalid color is rejected", func(t *testing.T) {
+		resp, body := client.Post(t, todosPath, domain.CreateTodoDTO{Title: "Eggs", Color: color("red")})
+		require.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode, string(body))
+	})
+}
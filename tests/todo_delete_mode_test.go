@@ -0,0 +1,95 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/macesz/todo-go/dal/pgtodo"
+	"github.com/macesz/todo-go/domain"
+	"github.com/macesz/todo-go/services/todo"
+	"github.com/macesz/todo-go/tests/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_DeleteTodo_SoftAndHardDelete(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	tc := testutils.SetupTestDB(t)
+	tokenAuth := testutils.SetupTestAuth()
+
+	user := domain.User{Name: "User One", Email: "u1@example.com", Password: "pass"}
+	_, err := testutils.GivenUser(t, tokenAuth, tc.DB, &user)
+	require.NoError(t, err)
+
+	listID, err := testutils.GivenTodoLists(t, tc.DB, domain.TodoList{UserID: user.ID, Title: "Chores"})
+	require.NoError(t, err)
+
+	store := pgtodo.CreateStore(tc.DB)
+
+	t.Run("hard delete removes the row", func(t *testing.T) {
+		id, err := testutils.GivenTodo(t, tc.DB, domain.Todo{UserID: user.ID, TodoListID: listID, Title: "Hard"})
+		require.NoError(t, err)
+
+		svc := todo.NewTodoService(store, todo.Options{SoftDelete: false})
+		_, err = svc.DeleteTodo(t.Context(), user.ID, id)
+		require.NoError(t, err)
+
+		var count int
+		err = tc.DB.Get(&count, "SELECT COUNT(*) FROM todos WHERE id = $1", id)
+		require.NoError(t, err)
+		require.Equal(t, 0, count)
+	})
+
+	t.Run("soft delete keeps the row in the trash", func(t *testing.T) {
+		id, err := testutils.GivenTodo(t, tc.DB, domain.Todo{UserID: user.ID, TodoListID: listID, Title: "Soft"})
+		require.NoError(t, err)
+
+		svc := todo.NewTodoService(store, todo.Options{SoftDelete: true})
+		_, err = svc.DeleteTodo(t.Context(), user.ID, id)
+		require.NoError(t, err)
+
+		var count int
+		err = tc.DB.Get(&count, "SELECT COUNT(*) FROM todos WHERE id = $1 AND deleted_at IS NOT NULL", id)
+		require.NoError(t, err)
+		require.Equal(t, 1, count)
+
+		// A trashed todo is gone for the user
+		_, err = svc.GetTodo(t.Context(), user.ID, id)
+		require.ErrorIs(t, err, domain.ErrNotFound)
+
+		_, err = svc.DeleteTodo(t.Context(), user.ID, id)
+		require.ErrorIs(t, err, domain.ErrNotFound)
+	})
+
+	// emptyDone gives the list one done todo and empties the done todos with the delete mode
+	emptyDone := func(t *testing.T, softDelete bool) int64 {
+		t.Helper()
+
+		id, err := testutils.GivenTodo(t, tc.DB, domain.Todo{UserID: user.ID, TodoListID: listID, Title: "Done", Done: true})
+		require.NoError(t, err)
+
+		svc := todo.NewTodoService(store, todo.Options{SoftDelete: softDelete})
+		count, err := svc.EmptyDone(t.Context(), user.ID, listID)
+		require.NoError(t, err)
+		require.Equal(t, int64(1), count)
+
+		return id
+	}
+
+	t.Run("hard empty done removes the rows", func(t *testing.T) {
+		id := emptyDone(t, false)
+
+		var count int
+		require.NoError(t, tc.DB.Get(&count, "SELECT COUNT(*) FROM todos WHERE id = $1", id))
+		require.Equal(t, 0, count)
+	})
+
+	t.Run("soft empty done keeps the rows in the trash", func(t *testing.T) {
+		id := emptyDone(t, true)
+
+		var count int
+		require.NoError(t, tc.DB.Get(&count, "SELECT COUNT(*) FROM todos WHERE id = $1 AND deleted_at IS NOT NULL", id))
+		require.Equal(t, 1, count)
+	})
+}
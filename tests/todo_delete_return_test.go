@@ -0,0 +1,47 @@
+package tests
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/macesz/todo-go/domain"
+	"github.com/macesz/todo-go/tests/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_DeleteTodoReturn(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	tc, server, services := testutils.ComposeServer(t)
+
+	user := domain.User{
+		Name:     "User One",
+		Email:    "u1@example.com",
+		Password: "pass",
+	}
+	header, err := testutils.GivenUser(t, services.TokenAuth, tc.DB, &user)
+	require.NoError(t, err)
+
+	listID, err := testutils.GivenTodoLists(t, tc.DB, domain.TodoList{UserID: user.ID, Title: "Groceries"})
+	require.NoError(t, err)
+	todoID, err := testutils.GivenTodo(t, tc.DB, domain.Todo{UserID: user.ID, TodoListID: listID, Title: "Milk", Done: true})
+	require.NoError(t, err)
+
+	path := "/api/lists/" + testutils.ListPublicID(t, tc.DB, listID) + "/todos/" + testutils.TodoPublicID(t, tc.DB, todoID)
+
+	client := &testutils.Client{Server: server, Header: header}
+
+	resp, before := client.Get(t, path)
+	require.Equal(t, http.StatusOK, resp.StatusCode, string(before))
+
+	resp, deleted := client.Delete(t, path+"?return=true")
+	require.Equal(t, http.StatusOK, resp.StatusCode, string(deleted))
+
+	// The body is the todo as it was before the delete
+	require.JSONEq(t, string(before), string(deleted))
+
+	resp, _ = client.Get(t, path)
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
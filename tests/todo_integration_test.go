@@ -7,13 +7,12 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/google/uuid"
 	"github.com/macesz/todo-go/domain"
 	"github.com/macesz/todo-go/tests/testutils"
 	"github.com/stretchr/testify/require"
 )
 
-// setupTestServer creates a real server with all dependencies
-
 func Test_Todo_Integration(t *testing.T) {
 	if testing.Short() {
 		t.Skip("Skipping integration test in short mode")
@@ -58,11 +57,16 @@ func Test_Todo_Integration(t *testing.T) {
 	todoID, err := testutils.GivenTodo(t, tc.DB, domain.Todo{UserID: user.ID, TodoListID: listID2, Title: "Todo2", Done: false})
 	require.NoError(t, err)
 
+	// The API addresses lists and todos by their public ids
+	listPublicID := testutils.ListPublicID(t, tc.DB, listID)
+	listPublicID2 := testutils.ListPublicID(t, tc.DB, listID2)
+	todoPublicID := testutils.TodoPublicID(t, tc.DB, todoID)
+
 	t.Run("Full CRUD Lifecycle", func(t *testing.T) {
 
 		// 1. List todos (should be empty)
 		t.Run("List empty todos", func(t *testing.T) {
-			url := fmt.Sprintf("/api/lists/%d/todos", listID)
+			url := fmt.Sprintf("/api/lists/%s/todos", listPublicID)
 			resp, respbody := testutils.TestRequest(t, server, http.MethodGet, url, header, nil)
 
 			require.Equal(t, http.StatusOK, resp.StatusCode)
@@ -82,7 +86,7 @@ func Test_Todo_Integration(t *testing.T) {
 			}
 			body, _ := json.Marshal(payload)
 
-			url := fmt.Sprintf("/api/lists/%d/todos", listID)
+			url := fmt.Sprintf("/api/lists/%s/todos", listPublicID)
 			resp, respbody := testutils.TestRequest(t, server, http.MethodPost, url, header, bytes.NewReader(body))
 
 			require.Equal(t, http.StatusCreated, resp.StatusCode)
@@ -90,13 +94,23 @@ func Test_Todo_Integration(t *testing.T) {
 			err := json.Unmarshal(respbody, &createdTodo)
 			require.NoError(t, err)
 			require.NotZero(t, createdTodo.ID)
+			require.Equal(t, listPublicID, createdTodo.TodoListID)
+
+			// The ids are public UUIDs, the integer ids are never serialized
+			_, err = uuid.Parse(createdTodo.ID)
+			require.NoError(t, err)
+
+			var raw map[string]any
+			require.NoError(t, json.Unmarshal(respbody, &raw))
+			require.IsType(t, "", raw["id"])
+			require.IsType(t, "", raw["todolist_id"])
 			require.Equal(t, "Integration Test Todo", createdTodo.Title)
 			require.False(t, createdTodo.Done)
 		})
 
 		// 3. Get the created todo
 		t.Run("Get todo by ID", func(t *testing.T) {
-			url := fmt.Sprintf("/api/lists/%d/todos/%d", listID, createdTodo.ID)
+			url := fmt.Sprintf("/api/lists/%s/todos/%s", listPublicID, createdTodo.ID)
 			resp, respbody := testutils.TestRequest(t, server, http.MethodGet, url, header, nil)
 
 			require.Equal(t, http.StatusOK, resp.StatusCode)
@@ -110,6 +124,21 @@ func Test_Todo_Integration(t *testing.T) {
 			require.Equal(t, createdTodo.Title, fetchedTodo.Title)
 		})
 
+		t.Run("Get todo by integer ID returns 400", func(t *testing.T) {
+			url := fmt.Sprintf("/api/lists/%s/todos/%d", listPublicID, todoID)
+			resp, _ := testutils.TestRequest(t, server, http.MethodGet, url, header, nil)
+
+			require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		})
+
+		// The todo exists, but not in listID2
+		t.Run("Get todo via wrong list returns 404", func(t *testing.T) {
+			url := fmt.Sprintf("/api/lists/%s/todos/%s", listPublicID2, createdTodo.ID)
+			resp, _ := testutils.TestRequest(t, server, http.MethodGet, url, header, nil)
+
+			require.Equal(t, http.StatusNotFound, resp.StatusCode)
+		})
+
 		// 4. Update the todo
 		t.Run("Update todo", func(t *testing.T) {
 			payload := domain.UpdateTodoDTO{
@@ -118,7 +147,7 @@ func Test_Todo_Integration(t *testing.T) {
 			}
 			body, _ := json.Marshal(payload)
 
-			url := fmt.Sprintf("/api/lists/%d/todos/%d", listID, createdTodo.ID)
+			url := fmt.Sprintf("/api/lists/%s/todos/%s", listPublicID, createdTodo.ID)
 			resp, respbody := testutils.TestRequest(t, server, http.MethodPut, url, header, bytes.NewReader(body))
 
 			require.Equal(t, http.StatusOK, resp.StatusCode)
@@ -133,7 +162,7 @@ func Test_Todo_Integration(t *testing.T) {
 
 		// 5. List todos (should have one)
 		t.Run("List todos after create", func(t *testing.T) {
-			url := fmt.Sprintf("/api/lists/%d/todos", listID)
+			url := fmt.Sprintf("/api/lists/%s/todos", listPublicID)
 			resp, respbody := testutils.TestRequest(t, server, http.MethodGet, url, header, nil)
 
 			require.Equal(t, http.StatusOK, resp.StatusCode)
@@ -148,7 +177,7 @@ func Test_Todo_Integration(t *testing.T) {
 
 		// 6. Delete the todo
 		t.Run("Delete todo", func(t *testing.T) {
-			url := fmt.Sprintf("/api/lists/%d/todos/%d", listID, todoID)
+			url := fmt.Sprintf("/api/lists/%s/todos/%s", listPublicID, todoPublicID)
 			resp, _ := testutils.TestRequest(t, server, http.MethodDelete, url, header, nil)
 
 			require.Equal(t, http.StatusNoContent, resp.StatusCode)
@@ -156,7 +185,7 @@ func Test_Todo_Integration(t *testing.T) {
 
 		// 7. Verify deletion
 		t.Run("Get deleted todo returns 404", func(t *testing.T) {
-			url := fmt.Sprintf("/api/lists/%d/todos/%d", listID, todoID)
+			url := fmt.Sprintf("/api/lists/%s/todos/%s", listPublicID, todoPublicID)
 			resp, _ := testutils.TestRequest(t, server, http.MethodDelete, url, header, nil)
 
 			require.Equal(t, http.StatusNotFound, resp.StatusCode)
@@ -167,22 +196,18 @@ func Test_Todo_Integration(t *testing.T) {
 
 		// User 2 tries to access User 1's todo - should fail
 		t.Run("User 2 cannot access User 1 todo", func(t *testing.T) {
-			url := fmt.Sprintf("/api/lists/%d/todos/%d", listID, todoID)
+			url := fmt.Sprintf("/api/lists/%s/todos/%s", listPublicID, todoPublicID)
 			resp, _ := testutils.TestRequest(t, server, http.MethodGet, url, header2, nil)
 
 			require.Equal(t, http.StatusNotFound, resp.StatusCode)
 		})
 
-		// User 2 lists todos - should be empty
-		t.Run("User 2 sees only their todos", func(t *testing.T) {
-			url := fmt.Sprintf("/api/lists/%d/todos", listID2)
-			resp, respbody := testutils.TestRequest(t, server, http.MethodGet, url, header2, nil)
-
-			require.Equal(t, http.StatusOK, resp.StatusCode)
+		// User 2 lists todos of User 1's list - the list is not found for them
+		t.Run("User 2 cannot list User 1 todos", func(t *testing.T) {
+			url := fmt.Sprintf("/api/lists/%s/todos", listPublicID2)
+			resp, _ := testutils.TestRequest(t, server, http.MethodGet, url, header2, nil)
 
-			var todos []domain.TodoDTO
-			json.Unmarshal(respbody, &todos)
-			require.Empty(t, todos)
+			require.Equal(t, http.StatusNotFound, resp.StatusCode)
 		})
 	})
 
@@ -192,9 +217,16 @@ func Test_Todo_Integration(t *testing.T) {
 			payload := domain.CreateTodoDTO{Title: ""}
 			body, _ := json.Marshal(payload)
 
-			url := fmt.Sprintf("/api/lists/%d/todos", listID)
+			url := fmt.Sprintf("/api/lists/%s/todos", listPublicID)
 			resp, _ := testutils.TestRequest(t, server, http.MethodPost, url, header, bytes.NewReader(body))
 
+			require.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+		})
+
+		t.Run("Create with broken JSON", func(t *testing.T) {
+			url := fmt.Sprintf("/api/lists/%s/todos", listPublicID)
+			resp, _ := testutils.TestRequest(t, server, http.MethodPost, url, header, bytes.NewReader([]byte(`{"title":`)))
+
 			require.Equal(t, http.StatusBadRequest, resp.StatusCode)
 		})
 	})
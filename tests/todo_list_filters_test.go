@@ -0,0 +1,87 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/macesz/todo-go/domain"
+	"github.com/macesz/todo-go/tests/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+// Test_ListTodosFilters exercises search, status, sort and pagination of GET /lists/{id}/todos together
+func Test_ListTodosFilters(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	tc, server, services := testutils.ComposeServer(t)
+
+	user := domain.User{
+		Name:     "User One",
+		Email:    "u1@example.com",
+		Password: "pass",
+	}
+	header, err := testutils.GivenUser(t, services.TokenAuth, tc.DB, &user)
+	require.NoError(t, err)
+
+	listID, err := testutils.GivenTodoLists(t, tc.DB, domain.TodoList{UserID: user.ID, Title: "Groceries"})
+	require.NoError(t, err)
+
+	for _, todo := range []domain.Todo{
+		{Title: "Buy milk", Done: true},
+		{Title: "Buy oat milk", Done: true},
+		{Title: "MILK shake", Done: true},
+		{Title: "Milk chocolate", Done: false},
+		{Title: "Bread", Done: true},
+		{Title: "Juice 100%", Done: true},
+	} {
+		todo.UserID = user.ID
+		todo.TodoListID = listID
+		_, err := testutils.GivenTodo(t, tc.DB, todo)
+		require.NoError(t, err)
+	}
+
+	listTodos := func(t *testing.T, query url.Values) []string {
+		t.Helper()
+
+		path := "/api/lists/" + testutils.ListPublicID(t, tc.DB, listID) + "/todos?" + query.Encode()
+		resp, body := testutils.TestRequest(t, server, http.MethodGet, path, header, nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+
+		var todos []domain.TodoDTO
+		require.NoError(t, json.Unmarshal(body, &todos))
+
+		titles := make([]string, len(todos))
+		for i, todo := range todos {
+			titles[i] = todo.Title
+		}
+		return titles
+	}
+
+	t.Run("all filters together", func(t *testing.T) {
+		titles := listTodos(t, url.Values{
+			"q":      {"milk"},
+			"done":   {"true"},
+			"sort":   {"title"},
+			"order":  {"desc"},
+			"limit":  {"2"},
+			"offset": {"1"},
+		})
+
+		// Done todos with milk in the title are MILK shake, Buy oat milk and Buy milk; the page skips the first
+		require.Equal(t, []string{"Buy oat milk", "Buy milk"}, titles)
+	})
+
+	t.Run("search ignores case", func(t *testing.T) {
+		titles := listTodos(t, url.Values{"q": {"MiLk"}})
+		require.ElementsMatch(t, []string{"Buy milk", "Buy oat milk", "Milk chocolate", "MILK shake"}, titles)
+	})
+
+	t.Run("wildcards in the search match literally", func(t *testing.T) {
+		require.Equal(t, []string{"Juice 100%"}, listTodos(t, url.Values{"q": {"%"}}))
+		require.Empty(t, listTodos(t, url.Values{"q": {"_"}}))
+	})
+}
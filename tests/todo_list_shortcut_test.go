@@ -0,0 +1,60 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/macesz/todo-go/domain"
+	"github.com/macesz/todo-go/tests/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetTodoList_ByTodo(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	tc, server, services := testutils.ComposeServer(t)
+
+	user := domain.User{
+		Name:     "User One",
+		Email:    "u1@example.com",
+		Password: "pass",
+	}
+	header, err := testutils.GivenUser(t, services.TokenAuth, tc.DB, &user)
+	require.NoError(t, err)
+
+	other := domain.User{
+		Name:     "User Two",
+		Email:    "u2@example.com",
+		Password: "pass",
+	}
+	otherHeader, err := testutils.GivenUser(t, services.TokenAuth, tc.DB, &other)
+	require.NoError(t, err)
+
+	_, err = testutils.GivenTodoLists(t, tc.DB, domain.TodoList{UserID: user.ID, Title: "Work"})
+	require.NoError(t, err)
+	home, err := testutils.GivenTodoLists(t, tc.DB, domain.TodoList{UserID: user.ID, Title: "Home"})
+	require.NoError(t, err)
+
+	todoID, err := testutils.GivenTodo(t, tc.DB, domain.Todo{UserID: user.ID, TodoListID: home, Title: "Dishes"})
+	require.NoError(t, err)
+
+	path := "/api/todos/" + testutils.TodoPublicID(t, tc.DB, todoID) + "/list"
+
+	t.Run("returns the owning list", func(t *testing.T) {
+		resp, body := testutils.TestRequest(t, server, http.MethodGet, path, header, nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var list domain.TodoListDTO
+		require.NoError(t, json.Unmarshal(body, &list))
+		require.Equal(t, testutils.ListPublicID(t, tc.DB, home), list.ID)
+		require.Equal(t, "Home", list.Title)
+	})
+
+	t.Run("someone else's todo is not found", func(t *testing.T) {
+		resp, _ := testutils.TestRequest(t, server, http.MethodGet, path, otherHeader, nil)
+		require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
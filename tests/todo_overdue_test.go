@@ -0,0 +1,94 @@
+package tests
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/macesz/todo-go/domain"
+	"github.com/macesz/todo-go/tests/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListOverdueTodos(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	tc, server, services := testutils.ComposeServer(t)
+
+	user := domain.User{
+		Name:     "User One",
+		Email:    "u1@example.com",
+		Password: "pass",
+	}
+	header, err := testutils.GivenUser(t, services.TokenAuth, tc.DB, &user)
+	require.NoError(t, err)
+
+	groceries, err := testutils.GivenTodoLists(t, tc.DB, domain.TodoList{UserID: user.ID, Title: "Groceries"})
+	require.NoError(t, err)
+	chores, err := testutils.GivenTodoLists(t, tc.DB, domain.TodoList{UserID: user.ID, Title: "Chores"})
+	require.NoError(t, err)
+
+	now := time.Now()
+	lastWeek := now.Add(-7 * 24 * time.Hour)
+	yesterday := now.Add(-24 * time.Hour)
+	tomorrow := now.Add(24 * time.Hour)
+
+	givenTodo := func(todo domain.Todo) int64 {
+		id, err := testutils.GivenTodo(t, tc.DB, todo)
+		require.NoError(t, err)
+		return id
+	}
+
+	milk := givenTodo(domain.Todo{UserID: user.ID, TodoListID: groceries, Title: "Milk", DueDate: &yesterday})
+	rent := givenTodo(domain.Todo{UserID: user.ID, TodoListID: chores, Title: "Pay rent", DueDate: &lastWeek})
+	givenTodo(domain.Todo{UserID: user.ID, TodoListID: groceries, Title: "Bread", DueDate: &tomorrow})
+	givenTodo(domain.Todo{UserID: user.ID, TodoListID: groceries, Title: "Eggs"})
+	givenTodo(domain.Todo{UserID: user.ID, TodoListID: chores, Title: "Dishes", Done: true, DueDate: &yesterday})
+
+	client := &testutils.Client{Server: server, Header: header}
+
+	t.Run("lists not done todos past their due date across lists, earliest first", func(t *testing.T) {
+		resp, body := client.Get(t, "/api/todos/overdue")
+		require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+
+		got := testutils.Decode[[]domain.TodoDTO](t, body)
+		require.Len(t, got, 2)
+		require.Equal(t, testutils.TodoPublicID(t, tc.DB, rent), got[0].ID)
+		require.Equal(t, testutils.TodoPublicID(t, tc.DB, milk), got[1].ID)
+		require.NotNil(t, got[0].DueDate)
+	})
+
+	t.Run("other users see none of them", func(t *testing.T) {
+		other := domain.User{Name: "User Two", Email: "u2@example.com", Password: "pass"}
+		otherHeader, err := testutils.GivenUser(t, services.TokenAuth, tc.DB, &other)
+		require.NoError(t, err)
+
+		otherClient := &testutils.Client{Server: server, Header: otherHeader}
+
+		resp, body := otherClient.Get(t, "/api/todos/overdue")
+		require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+		require.Empty(t, testutils.Decode[[]domain.TodoDTO](t, body))
+	})
+
+	t.Run("clearing the due date on update drops the todo", func(t *testing.T) {
+		milkID := testutils.TodoPublicID(t, tc.DB, milk)
+		path := "/api/lists/" + testutils.ListPublicID(t, tc.DB, groceries) + "/todos/" + milkID
+
+		resp, body := client.Put(t, path, domain.UpdateTodoDTO{Title: "Milk", Done: true})
+		require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+		require.Nil(t, testutils.Decode[domain.TodoDTO](t, body).DueDate)
+
+		// Not done again, but without a due date it is no longer overdue
+		resp, body = client.Post(t, "/api/todos/"+milkID+"/undone", nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+
+		resp, body = client.Get(t, "/api/todos/overdue")
+		require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+
+		got := testutils.Decode[[]domain.TodoDTO](t, body)
+		require.Len(t, got, 1)
+		require.Equal(t, testutils.TodoPublicID(t, tc.DB, rent), got[0].ID)
+	})
+}
@@ -0,0 +1,78 @@
+package tests
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/macesz/todo-go/domain"
+	"github.com/macesz/todo-go/tests/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_SetTodoDone(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	tc, server, services := testutils.ComposeServer(t)
+
+	user := domain.User{
+		Name:     "User One",
+		Email:    "u1@example.com",
+		Password: "pass",
+	}
+	header, err := testutils.GivenUser(t, services.TokenAuth, tc.DB, &user)
+	require.NoError(t, err)
+
+	listID, err := testutils.GivenTodoLists(t, tc.DB, domain.TodoList{UserID: user.ID, Title: "Groceries"})
+	require.NoError(t, err)
+	todoID, err := testutils.GivenTodo(t, tc.DB, domain.Todo{UserID: user.ID, TodoListID: listID, Title: "Milk"})
+	require.NoError(t, err)
+
+	client := &testutils.Client{Server: server, Header: header}
+
+	todoPublicID := testutils.TodoPublicID(t, tc.DB, todoID)
+	todoPath := "/api/lists/" + testutils.ListPublicID(t, tc.DB, listID) + "/todos/" + todoPublicID
+
+	resp, body := client.Get(t, todoPath)
+	require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+	before := testutils.Decode[domain.TodoDTO](t, body)
+
+	t.Run("done sets completed_at and keeps the rest", func(t *testing.T) {
+		resp, body := client.Post(t, "/api/todos/"+todoPublicID+"/done", nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+
+		got := testutils.Decode[domain.TodoDTO](t, body)
+		require.True(t, got.Done)
+		require.NotEmpty(t, got.CompletedAt)
+		require.Equal(t, before.Title, got.Title)
+		require.Equal(t, before.TodoListID, got.TodoListID)
+		require.Equal(t, before.CreatedAt, got.CreatedAt)
+	})
+
+	t.Run("undone clears completed_at and keeps the rest", func(t *testing.T) {
+		resp, body := client.Post(t, "/api/todos/"+todoPublicID+"/undone", nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+
+		got := testutils.Decode[domain.TodoDTO](t, body)
+		require.False(t, got.Done)
+		require.Empty(t, got.CompletedAt)
+		require.Equal(t, before.Title, got.Title)
+		require.Equal(t, before.CreatedAt, got.CreatedAt)
+	})
+
+	t.Run("another user's todo is not found", func(t *testing.T) {
+		other := domain.User{Name: "User Two", Email: "u2@example.com", Password: "pass"}
+		otherHeader, err := testutils.GivenUser(t, services.TokenAuth, tc.DB, &other)
+		require.NoError(t, err)
+
+		otherClient := &testutils.Client{Server: server, Header: otherHeader}
+
+		resp, _ := otherClient.Post(t, "/api/todos/"+todoPublicID+"/done", nil)
+		require.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+		var done bool
+		require.NoError(t, tc.DB.Get(&done, "SELECT done FROM todos WHERE id = $1", todoID))
+		require.False(t, done)
+	})
+}
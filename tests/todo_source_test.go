@@ -0,0 +1,89 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"maps"
+	"mime/multipart"
+	"net/http"
+	"testing"
+
+	"github.com/macesz/todo-go/delivery/web/middlewares"
+	"github.com/macesz/todo-go/domain"
+	"github.com/macesz/todo-go/tests/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_TodoSource(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	tc, server, services := testutils.ComposeServer(t)
+
+	user := domain.User{
+		Name:     "User One",
+		Email:    "u1@example.com",
+		Password: "pass",
+	}
+	header, err := testutils.GivenUser(t, services.TokenAuth, tc.DB, &user)
+	require.NoError(t, err)
+
+	listID, err := testutils.GivenTodoLists(t, tc.DB, domain.TodoList{UserID: user.ID, Title: "Groceries"})
+	require.NoError(t, err)
+
+	list := testutils.ListPublicID(t, tc.DB, listID)
+	todosPath := "/api/lists/" + list + "/todos"
+
+	storedSource := func(t *testing.T, publicID string) string {
+		var source string
+		require.NoError(t, tc.DB.Get(&source, "SELECT source FROM todos WHERE public_id = $1", publicID))
+		return source
+	}
+
+	t.Run("a normal create is api", func(t *testing.T) {
+		client := &testutils.Client{Server: server, Header: header}
+
+		resp, body := client.Post(t, todosPath, domain.CreateTodoDTO{Title: "Milk"})
+		require.Equal(t, http.StatusCreated, resp.StatusCode, string(body))
+
+		created := testutils.Decode[domain.TodoDTO](t, body)
+		require.Equal(t, "api", created.Source)
+		require.Equal(t, "api", storedSource(t, created.ID))
+
+		resp, body = client.Get(t, todosPath+"/"+created.ID)
+		require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+		require.Equal(t, "api", testutils.Decode[domain.TodoDTO](t, body).Source)
+	})
+
+	t.Run("a create from the web client is web", func(t *testing.T) {
+		webHeader := maps.Clone(header)
+		webHeader[middlewares.ClientHeader] = "web"
+		client := &testutils.Client{Server: server, Header: webHeader}
+
+		resp, body := client.Post(t, todosPath, domain.CreateTodoDTO{Title: "Bread"})
+		require.Equal(t, http.StatusCreated, resp.StatusCode, string(body))
+		require.Equal(t, "web", storedSource(t, testutils.Decode[domain.TodoDTO](t, body).ID))
+	})
+
+	t.Run("an imported todo is import", func(t *testing.T) {
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		part, err := writer.CreateFormFile("file", "todos.csv")
+		require.NoError(t, err)
+		_, err = part.Write([]byte("list_id,title\n" + list + ",Eggs\n"))
+		require.NoError(t, err)
+		require.NoError(t, writer.Close())
+
+		headers := maps.Clone(header)
+		headers["Content-Type"] = writer.FormDataContentType()
+
+		resp, respBody := testutils.TestRequest(t, server, http.MethodPost, "/api/todos/import.csv", headers, body)
+		require.Equal(t, http.StatusOK, resp.StatusCode, string(respBody))
+
+		var result domain.CSVImportResponseDTO
+		require.NoError(t, json.Unmarshal(respBody, &result))
+		require.Len(t, result.Rows, 1)
+		require.Equal(t, "import", storedSource(t, result.Rows[0].ID))
+	})
+}
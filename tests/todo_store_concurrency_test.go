@@ -0,0 +1,91 @@
+package tests
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/macesz/todo-go/dal/pgtodo"
+	"github.com/macesz/todo-go/domain"
+	"github.com/macesz/todo-go/tests/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+// Test_TodoStore_ConcurrentCreate fires many Create calls for one list at once.
+// Every todo has to get its own id and public id, and none may be lost,
+// also when there are more goroutines than pooled connections.
+func Test_TodoStore_ConcurrentCreate(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	const workers = 100
+
+	tc := testutils.SetupTestDB(t)
+	tokenAuth := testutils.SetupTestAuth()
+
+	user := domain.User{Name: "User One", Email: "u1@example.com", Password: "pass"}
+	_, err := testutils.GivenUser(t, tokenAuth, tc.DB, &user)
+	require.NoError(t, err)
+
+	listID, err := testutils.GivenTodoLists(t, tc.DB, domain.TodoList{UserID: user.ID, Title: "Busy"})
+	require.NoError(t, err)
+
+	// A pool smaller than the number of goroutines makes them queue for connections,
+	// and stays below the max_connections of the test Postgres
+	tc.DB.SetMaxOpenConns(10)
+
+	store := pgtodo.CreateStore(tc.DB)
+
+	var (
+		wg    sync.WaitGroup
+		start = make(chan struct{})
+		todos = make([]*domain.Todo, workers)
+		errs  = make([]error, workers)
+	)
+
+	for i := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			todo := &domain.Todo{
+				UserID:     user.ID,
+				TodoListID: listID,
+				Title:      fmt.Sprintf("Todo %d", i),
+				CreatedAt:  time.Now(),
+			}
+
+			<-start // Release every goroutine at once
+			errs[i] = store.Create(t.Context(), listID, todo)
+			todos[i] = todo
+		}()
+	}
+
+	close(start)
+	wg.Wait()
+
+	ids := make(map[int64]bool, workers)
+	publicIDs := make(map[string]bool, workers)
+
+	for i, todo := range todos {
+		require.NoError(t, errs[i], "create %d", i)
+		require.NotZero(t, todo.ID)
+		require.NotEmpty(t, todo.PublicID)
+
+		ids[todo.ID] = true
+		publicIDs[todo.PublicID] = true
+	}
+
+	require.Len(t, ids, workers, "ids must be unique")
+	require.Len(t, publicIDs, workers, "public ids must be unique")
+
+	var count int
+	require.NoError(t, tc.DB.Get(&count, "SELECT COUNT(*) FROM todos WHERE todolist_id = $1", listID))
+	require.Equal(t, workers, count)
+
+	listed, err := store.List(t.Context(), user.ID, listID, domain.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, listed, workers)
+}
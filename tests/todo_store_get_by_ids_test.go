@@ -0,0 +1,115 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/macesz/todo-go/dal/pgtodo"
+	"github.com/macesz/todo-go/domain"
+	"github.com/macesz/todo-go/tests/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_TodoStore_GetByIDs(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	tc := testutils.SetupTestDB(t)
+	tokenAuth := testutils.SetupTestAuth()
+
+	user := domain.User{Name: "User One", Email: "u1@example.com", Password: "pass"}
+	_, err := testutils.GivenUser(t, tokenAuth, tc.DB, &user)
+	require.NoError(t, err)
+
+	other := domain.User{Name: "User Two", Email: "u2@example.com", Password: "pass2"}
+	_, err = testutils.GivenUser(t, tokenAuth, tc.DB, &other)
+	require.NoError(t, err)
+
+	listID, err := testutils.GivenTodoLists(t, tc.DB, domain.TodoList{UserID: user.ID, Title: "Mine"})
+	require.NoError(t, err)
+	otherListID, err := testutils.GivenTodoLists(t, tc.DB, domain.TodoList{UserID: other.ID, Title: "Theirs"})
+	require.NoError(t, err)
+
+	first, err := testutils.GivenTodo(t, tc.DB, domain.Todo{UserID: user.ID, TodoListID: listID, Title: "First"})
+	require.NoError(t, err)
+	second, err := testutils.GivenTodo(t, tc.DB, domain.Todo{UserID: user.ID, TodoListID: listID, Title: "Second"})
+	require.NoError(t, err)
+	_, err = testutils.GivenTodo(t, tc.DB, domain.Todo{UserID: user.ID, TodoListID: listID, Title: "Not asked for"})
+	require.NoError(t, err)
+	foreign, err := testutils.GivenTodo(t, tc.DB, domain.Todo{UserID: other.ID, TodoListID: otherListID, Title: "Foreign"})
+	require.NoError(t, err)
+
+	store := pgtodo.CreateStore(tc.DB)
+
+	t.Run("returns only the user's matching todos", func(t *testing.T) {
+		todos, err := store.GetByIDs(t.Context(), user.ID, []int64{second, foreign, 999999, first})
+		require.NoError(t, err)
+
+		require.Len(t, todos, 2)
+		require.Equal(t, first, todos[0].ID)
+		require.Equal(t, "First", todos[0].Title)
+		require.Equal(t, second, todos[1].ID)
+		require.Equal(t, "Second", todos[1].Title)
+	})
+
+	t.Run("no ids", func(t *testing.T) {
+		todos, err := store.GetByIDs(t.Context(), user.ID, nil)
+		require.NoError(t, err)
+		require.Empty(t, todos)
+	})
+}
+
+func Test_TodoStore_GetByPublicIDs(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	tc := testutils.SetupTestDB(t)
+	tokenAuth := testutils.SetupTestAuth()
+
+	user := domain.User{Name: "User One", Email: "u1@example.com", Password: "pass"}
+	_, err := testutils.GivenUser(t, tokenAuth, tc.DB, &user)
+	require.NoError(t, err)
+
+	other := domain.User{Name: "User Two", Email: "u2@example.com", Password: "pass2"}
+	_, err = testutils.GivenUser(t, tokenAuth, tc.DB, &other)
+	require.NoError(t, err)
+
+	listID, err := testutils.GivenTodoLists(t, tc.DB, domain.TodoList{UserID: user.ID, Title: "Mine"})
+	require.NoError(t, err)
+	otherListID, err := testutils.GivenTodoLists(t, tc.DB, domain.TodoList{UserID: other.ID, Title: "Theirs"})
+	require.NoError(t, err)
+
+	first, err := testutils.GivenTodo(t, tc.DB, domain.Todo{UserID: user.ID, TodoListID: listID, Title: "First"})
+	require.NoError(t, err)
+	second, err := testutils.GivenTodo(t, tc.DB, domain.Todo{UserID: user.ID, TodoListID: listID, Title: "Second"})
+	require.NoError(t, err)
+	_, err = testutils.GivenTodo(t, tc.DB, domain.Todo{UserID: user.ID, TodoListID: listID, Title: "Not asked for"})
+	require.NoError(t, err)
+	foreign, err := testutils.GivenTodo(t, tc.DB, domain.Todo{UserID: other.ID, TodoListID: otherListID, Title: "Foreign"})
+	require.NoError(t, err)
+
+	store := pgtodo.CreateStore(tc.DB)
+
+	t.Run("returns only the user's matching todos", func(t *testing.T) {
+		todos, err := store.GetByPublicIDs(t.Context(), user.ID, []string{
+			testutils.TodoPublicID(t, tc.DB, second),
+			testutils.TodoPublicID(t, tc.DB, foreign),
+			"6f1c2b9e-8a4d-4c1e-9b7a-3d2f1e0c5a11",
+			testutils.TodoPublicID(t, tc.DB, first),
+		})
+		require.NoError(t, err)
+
+		require.Len(t, todos, 2)
+		require.Equal(t, first, todos[0].ID)
+		require.Equal(t, "First", todos[0].Title)
+		require.Equal(t, second, todos[1].ID)
+		require.Equal(t, "Second", todos[1].Title)
+	})
+
+	t.Run("no ids", func(t *testing.T) {
+		todos, err := store.GetByPublicIDs(t.Context(), user.ID, nil)
+		require.NoError(t, err)
+		require.Empty(t, todos)
+	})
+}
@@ -0,0 +1,115 @@
+package tests
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/macesz/todo-go/dal/pgtodo"
+	"github.com/macesz/todo-go/domain"
+	"github.com/macesz/todo-go/services/todo"
+	"github.com/macesz/todo-go/tests/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_UndoDeleteTodo(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	tc, server, services := testutils.ComposeServer(t)
+
+	user := domain.User{
+		Name:     "User One",
+		Email:    "u1@example.com",
+		Password: "pass",
+	}
+	header, err := testutils.GivenUser(t, services.TokenAuth, tc.DB, &user)
+	require.NoError(t, err)
+
+	listID, err := testutils.GivenTodoLists(t, tc.DB, domain.TodoList{UserID: user.ID, Title: "Groceries"})
+	require.NoError(t, err)
+	client := &testutils.Client{Server: server, Header: header}
+
+	listPath := "/api/lists/" + testutils.ListPublicID(t, tc.DB, listID) + "/todos/"
+
+	// trashed puts a new todo in the trash as if it was soft-deleted the given interval ago
+	trashed := func(t *testing.T, title string, ago string) (int64, string) {
+		t.Helper()
+
+		id, err := testutils.GivenTodo(t, tc.DB, domain.Todo{UserID: user.ID, TodoListID: listID, Title: title})
+		require.NoError(t, err)
+
+		path := listPath + testutils.TodoPublicID(t, tc.DB, id)
+
+		_, err = tc.DB.Exec("UPDATE todos SET deleted_at = now() - $2::interval WHERE id = $1", id, ago)
+		require.NoError(t, err)
+
+		return id, path
+	}
+
+	t.Run("restore within the window", func(t *testing.T) {
+		_, path := trashed(t, "Milk", "1 minute")
+
+		resp, _ := client.Get(t, path)
+		require.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+		resp, body := client.Post(t, path+"/undo-delete", nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+		require.Equal(t, "Milk", testutils.Decode[domain.TodoDTO](t, body).Title)
+
+		resp, _ = client.Get(t, path)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		// It is out of the trash now, a second undo finds nothing
+		resp, _ = client.Post(t, path+"/undo-delete", nil)
+		require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("restore after the window is gone", func(t *testing.T) {
+		id, path := trashed(t, "Bread", "10 minutes")
+
+		resp, body := client.Post(t, path+"/undo-delete", nil)
+		require.Equal(t, http.StatusGone, resp.StatusCode, string(body))
+		require.JSONEq(t, `{"error":"the todo was deleted too long ago to restore"}`, string(body))
+
+		// The cleanup job removes it for good
+		svc := todo.NewTodoService(pgtodo.CreateStore(tc.DB), todo.Options{})
+		_, err := svc.PurgeTrash(t.Context())
+		require.NoError(t, err)
+
+		var count int
+		require.NoError(t, tc.DB.Get(&count, "SELECT COUNT(*) FROM todos WHERE id = $1", id))
+		require.Equal(t, 0, count)
+
+		resp, _ = client.Post(t, path+"/undo-delete", nil)
+		require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("restore a todo deleted by a clock west of UTC", func(t *testing.T) {
+		id, err := testutils.GivenTodo(t, tc.DB, domain.Todo{UserID: user.ID, TodoListID: listID, Title: "Butter"})
+		require.NoError(t, err)
+
+		path := listPath + testutils.TodoPublicID(t, tc.DB, id)
+
+		// The same instant in another zone, it must not read back hours in the past
+		deletedAt := time.Now().In(time.FixedZone("UTC-5", -5*60*60))
+		require.NoError(t, pgtodo.CreateStore(tc.DB).SoftDelete(t.Context(), id, deletedAt))
+
+		resp, body := client.Post(t, path+"/undo-delete", nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+	})
+
+	t.Run("another user's todo is not found", func(t *testing.T) {
+		_, path := trashed(t, "Eggs", "1 minute")
+
+		other := domain.User{Name: "User Two", Email: "u2@example.com", Password: "pass"}
+		otherHeader, err := testutils.GivenUser(t, services.TokenAuth, tc.DB, &other)
+		require.NoError(t, err)
+
+		otherClient := &testutils.Client{Server: server, Header: otherHeader}
+
+		resp, _ := otherClient.Post(t, path+"/undo-delete", nil)
+		require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
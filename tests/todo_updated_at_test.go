@@ -0,0 +1,67 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/macesz/todo-go/domain"
+	"github.com/macesz/todo-go/tests/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_UpdateTodoSetsUpdatedAt(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	tc, server, services := testutils.ComposeServer(t)
+
+	user := domain.User{
+		Name:     "User One",
+		Email:    "u1@example.com",
+		Password: "pass",
+	}
+	header, err := testutils.GivenUser(t, services.TokenAuth, tc.DB, &user)
+	require.NoError(t, err)
+
+	listID, err := testutils.GivenTodoLists(t, tc.DB, domain.TodoList{UserID: user.ID, Title: "List"})
+	require.NoError(t, err)
+
+	// Created an hour ago, so the update is clearly later even with second precision
+	todoID, err := testutils.GivenTodo(t, tc.DB, domain.Todo{
+		UserID:     user.ID,
+		TodoListID: listID,
+		Title:      "Old title",
+		CreatedAt:  time.Now().Add(-time.Hour),
+	})
+	require.NoError(t, err)
+
+	body, _ := json.Marshal(domain.UpdateTodoDTO{Title: "New title", Done: true})
+
+	url := fmt.Sprintf("/api/lists/%s/todos/%s", testutils.ListPublicID(t, tc.DB, listID), testutils.TodoPublicID(t, tc.DB, todoID))
+	resp, respbody := testutils.TestRequest(t, server, http.MethodPut, url, header, bytes.NewReader(body))
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var updated domain.TodoDTO
+	require.NoError(t, json.Unmarshal(respbody, &updated))
+
+	createdAt, err := time.Parse(time.RFC3339, updated.CreatedAt)
+	require.NoError(t, err)
+	updatedAt, err := time.Parse(time.RFC3339, updated.UpdatedAt)
+	require.NoError(t, err)
+
+	require.True(t, updatedAt.After(createdAt), "updated_at %s should be after created_at %s", updatedAt, createdAt)
+
+	// The response is the persisted row, a fresh GET returns the same timestamps
+	resp, respbody = testutils.TestRequest(t, server, http.MethodGet, url, header, nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var fetched domain.TodoDTO
+	require.NoError(t, json.Unmarshal(respbody, &fetched))
+	require.Equal(t, updated.CreatedAt, fetched.CreatedAt)
+	require.Equal(t, updated.UpdatedAt, fetched.UpdatedAt)
+}
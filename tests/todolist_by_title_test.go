@@ -0,0 +1,64 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/macesz/todo-go/domain"
+	"github.com/macesz/todo-go/tests/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_TodoList_GetOrCreateByTitle(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	tc, server, services := testutils.ComposeServer(t)
+
+	user := domain.User{
+		Name:     "User One",
+		Email:    "u1@example.com",
+		Password: "pass",
+	}
+	header, err := testutils.GivenUser(t, services.TokenAuth, tc.DB, &user)
+	require.NoError(t, err)
+
+	var first domain.TodoListDTO
+
+	t.Run("first call creates", func(t *testing.T) {
+		resp, body := testutils.TestRequest(t, server, http.MethodPut, "/api/lists/by-title/Groceries", header, nil)
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		require.NoError(t, json.Unmarshal(body, &first))
+		require.Equal(t, "Groceries", first.Title)
+		require.NotZero(t, first.ID)
+	})
+
+	t.Run("second call returns existing", func(t *testing.T) {
+		resp, body := testutils.TestRequest(t, server, http.MethodPut, "/api/lists/by-title/Groceries", header, nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var second domain.TodoListDTO
+		require.NoError(t, json.Unmarshal(body, &second))
+		require.Equal(t, first.ID, second.ID)
+
+		var count int
+		err := tc.DB.Get(&count, "SELECT COUNT(*) FROM todolists WHERE user_id = $1 AND title = $2", user.ID, "Groceries")
+		require.NoError(t, err)
+		require.Equal(t, 1, count)
+	})
+
+	t.Run("a list in the bin frees its title", func(t *testing.T) {
+		_, err := tc.DB.Exec("UPDATE todolists SET deleted = true WHERE user_id = $1 AND title = $2", user.ID, "Groceries")
+		require.NoError(t, err)
+
+		resp, body := testutils.TestRequest(t, server, http.MethodPut, "/api/lists/by-title/Groceries", header, nil)
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		var third domain.TodoListDTO
+		require.NoError(t, json.Unmarshal(body, &third))
+		require.NotEqual(t, first.ID, third.ID)
+	})
+}
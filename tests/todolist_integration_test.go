@@ -4,16 +4,16 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"maps"
 	"net/http"
 	"testing"
 
+	"github.com/google/uuid"
 	"github.com/macesz/todo-go/domain"
 	"github.com/macesz/todo-go/tests/testutils"
 	"github.com/stretchr/testify/require"
 )
 
-// setupTodoListTestServer creates a real server with all dependencies for todo list testing
-
 func Test_TodoList_Integration(t *testing.T) {
 	if testing.Short() {
 		t.Skip("Skipping integration test in short mode")
@@ -88,13 +88,17 @@ func Test_TodoList_Integration(t *testing.T) {
 
 			require.NoError(t, err)
 			require.NotZero(t, createdList.ID, "list should have an ID")
+
+			// The id is a public UUID, the integer id is never serialized
+			_, err = uuid.Parse(createdList.ID)
+			require.NoError(t, err)
 			require.Equal(t, "My Shopping List", createdList.Title)
 			require.Equal(t, "#FF5733", *createdList.Color)
 			require.Equal(t, []string{"shopping", "groceries"}, createdList.Labels)
 		})
 
 		t.Run("Get todoList by ID", func(t *testing.T) {
-			url := fmt.Sprintf("/api/lists/%d", createdList.ID)
+			url := fmt.Sprintf("/api/lists/%s", createdList.ID)
 
 			resp, respbody := testutils.TestRequest(t, server, http.MethodGet, url, header1, nil)
 
@@ -110,16 +114,24 @@ func Test_TodoList_Integration(t *testing.T) {
 		})
 
 		t.Run("id valid but not found -> 404", func(t *testing.T) {
-			url := fmt.Sprintf("/api/lists/%d", int64(999999))
+			url := fmt.Sprintf("/api/lists/%s", uuid.NewString())
 
 			resp, _ := testutils.TestRequest(t, server, http.MethodGet, url, header1, nil)
 
 			require.Equal(t, http.StatusNotFound, resp.StatusCode)
 		})
 
+		t.Run("integer id -> 400", func(t *testing.T) {
+			url := fmt.Sprintf("/api/lists/%d", int64(1))
+
+			resp, _ := testutils.TestRequest(t, server, http.MethodGet, url, header1, nil)
+
+			require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		})
+
 		t.Run("no user context -> 401", func(t *testing.T) {
 
-			url := fmt.Sprintf("/api/lists/%d", int64(1))
+			url := fmt.Sprintf("/api/lists/%s", createdList.ID)
 
 			resp, _ := testutils.TestRequest(t, server, http.MethodGet, url, nil, nil)
 
@@ -137,8 +149,12 @@ func Test_TodoList_Integration(t *testing.T) {
 			}
 			body, _ := json.Marshal(payload)
 
-			url := fmt.Sprintf("/api/lists/%d", createdList.ID)
-			resp, respbody := testutils.TestRequest(t, server, http.MethodPut, url, header1, bytes.NewReader(body))
+			// The update is based on the version that came back from the create
+			header := maps.Clone(header1)
+			header["If-Match"] = fmt.Sprintf(`"%d"`, createdList.Version)
+
+			url := fmt.Sprintf("/api/lists/%s", createdList.ID)
+			resp, respbody := testutils.TestRequest(t, server, http.MethodPut, url, header, bytes.NewReader(body))
 
 			require.Equal(t, http.StatusOK, resp.StatusCode)
 
@@ -154,7 +170,7 @@ func Test_TodoList_Integration(t *testing.T) {
 
 		// 6. Delete the todo
 		t.Run("Delete todo list", func(t *testing.T) {
-			url := fmt.Sprintf("/api/lists/%d", createdList.ID)
+			url := fmt.Sprintf("/api/lists/%s", createdList.ID)
 
 			resp, _ := testutils.TestRequest(t, server, http.MethodDelete, url, header1, nil)
 
@@ -172,7 +188,7 @@ func Test_TodoList_Integration(t *testing.T) {
 
 			// User 2 tries to access User 1's todo - should fail
 			t.Run("User 2 cannot access User 1 todo", func(t *testing.T) {
-				url := fmt.Sprintf("/api/lists/%d", createdID)
+				url := fmt.Sprintf("/api/lists/%s", testutils.ListPublicID(t, tc.DB, createdID))
 
 				resp, _ := testutils.TestRequest(t, server, http.MethodGet, url, header2, nil)
 
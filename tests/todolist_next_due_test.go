@@ -0,0 +1,75 @@
+package tests
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/macesz/todo-go/domain"
+	"github.com/macesz/todo-go/tests/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListTodoListsNextDue(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	tc, server, services := testutils.ComposeServer(t)
+
+	user := domain.User{
+		Name:     "User One",
+		Email:    "u1@example.com",
+		Password: "pass",
+	}
+	header, err := testutils.GivenUser(t, services.TokenAuth, tc.DB, &user)
+	require.NoError(t, err)
+
+	groceries, err := testutils.GivenTodoLists(t, tc.DB, domain.TodoList{UserID: user.ID, Title: "Groceries"})
+	require.NoError(t, err)
+	chores, err := testutils.GivenTodoLists(t, tc.DB, domain.TodoList{UserID: user.ID, Title: "Chores"})
+	require.NoError(t, err)
+
+	// Postgres keeps microseconds, the response is RFC3339 in seconds
+	now := time.Now().UTC().Truncate(time.Second)
+	yesterday := now.Add(-24 * time.Hour)
+	tomorrow := now.Add(24 * time.Hour)
+	nextWeek := now.Add(7 * 24 * time.Hour)
+
+	givenTodo := func(todo domain.Todo) {
+		_, err := testutils.GivenTodo(t, tc.DB, todo)
+		require.NoError(t, err)
+	}
+
+	// Groceries: the overdue and the done todos are skipped, tomorrow is the earliest upcoming
+	givenTodo(domain.Todo{UserID: user.ID, TodoListID: groceries, Title: "Milk", DueDate: &yesterday})
+	givenTodo(domain.Todo{UserID: user.ID, TodoListID: groceries, Title: "Bread", DueDate: &nextWeek})
+	givenTodo(domain.Todo{UserID: user.ID, TodoListID: groceries, Title: "Eggs", DueDate: &tomorrow})
+	givenTodo(domain.Todo{UserID: user.ID, TodoListID: groceries, Title: "Butter", Done: true, DueDate: &now})
+
+	// Chores: nothing upcoming
+	givenTodo(domain.Todo{UserID: user.ID, TodoListID: chores, Title: "Dishes", DueDate: &yesterday})
+	givenTodo(domain.Todo{UserID: user.ID, TodoListID: chores, Title: "Laundry"})
+
+	client := &testutils.Client{Server: server, Header: header}
+
+	resp, body := client.Get(t, "/api/lists")
+	require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+
+	got := testutils.Decode[[]domain.TodoListDTO](t, body)
+	nextDue := make(map[string]*string, len(got))
+	for _, list := range got {
+		nextDue[list.ID] = list.NextDue
+	}
+	require.Len(t, nextDue, 2)
+
+	t.Run("earliest upcoming due date of the open todos", func(t *testing.T) {
+		got := nextDue[testutils.ListPublicID(t, tc.DB, groceries)]
+		require.NotNil(t, got)
+		require.Equal(t, tomorrow.Format(time.RFC3339), *got)
+	})
+
+	t.Run("no upcoming due date is null", func(t *testing.T) {
+		require.Nil(t, nextDue[testutils.ListPublicID(t, tc.DB, chores)])
+	})
+}
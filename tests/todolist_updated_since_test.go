@@ -0,0 +1,73 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/macesz/todo-go/domain"
+	"github.com/macesz/todo-go/tests/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListTodoListsUpdatedSince(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	tc, server, services := testutils.ComposeServer(t)
+
+	user := domain.User{
+		Name:     "User One",
+		Email:    "u1@example.com",
+		Password: "pass",
+	}
+	header, err := testutils.GivenUser(t, services.TokenAuth, tc.DB, &user)
+	require.NoError(t, err)
+
+	other := domain.User{
+		Name:     "User Two",
+		Email:    "u2@example.com",
+		Password: "pass",
+	}
+	_, err = testutils.GivenUser(t, services.TokenAuth, tc.DB, &other)
+	require.NoError(t, err)
+
+	now := time.Now().UTC().Truncate(time.Second)
+	since := now.Add(-time.Hour)
+
+	_, err = testutils.GivenTodoLists(t, tc.DB, domain.TodoList{UserID: user.ID, Title: "Stale", CreatedAt: now.Add(-48 * time.Hour)})
+	require.NoError(t, err)
+	recentID, err := testutils.GivenTodoLists(t, tc.DB, domain.TodoList{UserID: user.ID, Title: "Recent", CreatedAt: now.Add(-48 * time.Hour), UpdatedAt: now})
+	require.NoError(t, err)
+	_, err = testutils.GivenTodoLists(t, tc.DB, domain.TodoList{UserID: other.ID, Title: "Someone else's", CreatedAt: now, UpdatedAt: now})
+	require.NoError(t, err)
+
+	t.Run("only lists updated since the timestamp", func(t *testing.T) {
+		path := "/api/lists?updated_since=" + url.QueryEscape(since.Format(time.RFC3339))
+		resp, body := testutils.TestRequest(t, server, http.MethodGet, path, header, nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var lists []domain.TodoListDTO
+		require.NoError(t, json.Unmarshal(body, &lists))
+		require.Len(t, lists, 1)
+		require.Equal(t, testutils.ListPublicID(t, tc.DB, recentID), lists[0].ID)
+		require.Equal(t, now.Format(time.RFC3339), lists[0].UpdatedAt)
+	})
+
+	t.Run("without the param every list is returned", func(t *testing.T) {
+		resp, body := testutils.TestRequest(t, server, http.MethodGet, "/api/lists", header, nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var lists []domain.TodoListDTO
+		require.NoError(t, json.Unmarshal(body, &lists))
+		require.Len(t, lists, 2)
+	})
+
+	t.Run("invalid timestamp", func(t *testing.T) {
+		resp, _ := testutils.TestRequest(t, server, http.MethodGet, "/api/lists?updated_since=yesterday", header, nil)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
@@ -0,0 +1,91 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"maps"
+	"net/http"
+	"testing"
+
+	"github.com/macesz/todo-go/domain"
+	"github.com/macesz/todo-go/tests/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_UpdateTodoListIfMatch(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	tc, server, services := testutils.ComposeServer(t)
+
+	user := domain.User{
+		Name:     "User One",
+		Email:    "u1@example.com",
+		Password: "pass",
+	}
+	header, err := testutils.GivenUser(t, services.TokenAuth, tc.DB, &user)
+	require.NoError(t, err)
+
+	listID, err := testutils.GivenTodoLists(t, tc.DB, domain.TodoList{UserID: user.ID, Title: "Groceries"})
+	require.NoError(t, err)
+
+	url := "/api/lists/" + testutils.ListPublicID(t, tc.DB, listID)
+
+	// GET returns the version as the ETag
+	resp, original := testutils.TestRequest(t, server, http.MethodGet, url, header, nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	etag := resp.Header.Get("ETag")
+	require.Equal(t, `"1"`, etag)
+	createdAt := testutils.Decode[domain.TodoListDTO](t, original).CreatedAt
+
+	update := func(t *testing.T, ifMatch string, title string) (*http.Response, []byte) {
+		color := "#FFFFFF"
+		body, err := json.Marshal(domain.UpdateTodoListRequestDTO{Title: title, Color: &color})
+		require.NoError(t, err)
+
+		h := maps.Clone(header)
+		if ifMatch != "" {
+			h["If-Match"] = ifMatch
+		}
+
+		return testutils.TestRequest(t, server, http.MethodPut, url, h, bytes.NewReader(body))
+	}
+
+	t.Run("clean update bumps the version", func(t *testing.T) {
+		resp, body := update(t, etag, "Shopping")
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Equal(t, `"2"`, resp.Header.Get("ETag"))
+
+		var list domain.TodoListDTO
+		require.NoError(t, json.Unmarshal(body, &list))
+		require.Equal(t, "Shopping", list.Title)
+		require.Equal(t, int64(2), list.Version)
+
+		// The update keeps the list's created_at
+		require.Equal(t, createdAt, list.CreatedAt)
+	})
+
+	t.Run("stale version is a conflict", func(t *testing.T) {
+		resp, _ := update(t, etag, "Clobbered")
+		require.Equal(t, http.StatusConflict, resp.StatusCode)
+
+		resp, body := testutils.TestRequest(t, server, http.MethodGet, url, header, nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var list domain.TodoListDTO
+		require.NoError(t, json.Unmarshal(body, &list))
+		require.Equal(t, "Shopping", list.Title)
+	})
+
+	t.Run("missing If-Match", func(t *testing.T) {
+		resp, _ := update(t, "", "No precondition")
+		require.Equal(t, http.StatusPreconditionRequired, resp.StatusCode)
+	})
+
+	t.Run("pinning bumps the version", func(t *testing.T) {
+		resp, _ := testutils.TestRequest(t, server, http.MethodPut, url+"/pin", header, nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Equal(t, `"3"`, resp.Header.Get("ETag"))
+	})
+}
@@ -0,0 +1,120 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/macesz/todo-go/domain"
+	"github.com/macesz/todo-go/tests/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ToggleAllTodos(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	tc, server, services := testutils.ComposeServer(t)
+
+	user := domain.User{
+		Name:     "User One",
+		Email:    "u1@example.com",
+		Password: "pass",
+	}
+	header, err := testutils.GivenUser(t, services.TokenAuth, tc.DB, &user)
+	require.NoError(t, err)
+
+	other := domain.User{
+		Name:     "User Two",
+		Email:    "u2@example.com",
+		Password: "pass",
+	}
+	otherHeader, err := testutils.GivenUser(t, services.TokenAuth, tc.DB, &other)
+	require.NoError(t, err)
+
+	listID, err := testutils.GivenTodoLists(t, tc.DB, domain.TodoList{UserID: user.ID, Title: "Chores"})
+	require.NoError(t, err)
+	for _, todo := range []domain.Todo{
+		{UserID: user.ID, TodoListID: listID, Title: "Dishes", Done: true},
+		{UserID: user.ID, TodoListID: listID, Title: "Laundry"},
+		{UserID: user.ID, TodoListID: listID, Title: "Vacuum"},
+	} {
+		_, err := testutils.GivenTodo(t, tc.DB, todo)
+		require.NoError(t, err)
+	}
+
+	listPath := "/api/lists/" + testutils.ListPublicID(t, tc.DB, listID) + "/todos"
+
+	toggle := func(t *testing.T, header map[string]string, done bool) (*http.Response, domain.ToggleAllResponseDTO) {
+		body, err := json.Marshal(domain.ToggleAllRequestDTO{Done: &done})
+		require.NoError(t, err)
+
+		resp, respBody := testutils.TestRequest(t, server, http.MethodPost, listPath+"/toggle-all", header, bytes.NewReader(body))
+
+		var result domain.ToggleAllResponseDTO
+		if resp.StatusCode == http.StatusOK {
+			require.NoError(t, json.Unmarshal(respBody, &result))
+		}
+		return resp, result
+	}
+
+	// completedAt reads the column directly, so a NULL can not hide behind the DTO formatting
+	completedAt := func(t *testing.T) []*time.Time {
+		var got []*time.Time
+		err := tc.DB.Select(&got, "SELECT completed_at FROM todos WHERE todolist_id = $1 ORDER BY id", listID)
+		require.NoError(t, err)
+		require.Len(t, got, 3)
+		return got
+	}
+
+	listTodos := func(t *testing.T) []domain.TodoDTO {
+		resp, body := testutils.TestRequest(t, server, http.MethodGet, listPath, header, nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var todos []domain.TodoDTO
+		require.NoError(t, json.Unmarshal(body, &todos))
+		return todos
+	}
+
+	t.Run("mark all done", func(t *testing.T) {
+		resp, result := toggle(t, header, true)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Equal(t, int64(2), result.Count) // Dishes was done already
+
+		todos := listTodos(t)
+		require.Len(t, todos, 3)
+		for _, todo := range todos {
+			require.True(t, todo.Done)
+			require.NotEmpty(t, todo.CompletedAt)
+		}
+		for _, at := range completedAt(t) {
+			require.NotNil(t, at)
+		}
+	})
+
+	t.Run("mark all undone", func(t *testing.T) {
+		resp, result := toggle(t, header, false)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Equal(t, int64(3), result.Count)
+
+		for _, todo := range listTodos(t) {
+			require.False(t, todo.Done)
+			require.Empty(t, todo.CompletedAt)
+		}
+		for _, at := range completedAt(t) {
+			require.Nil(t, at)
+		}
+	})
+
+	t.Run("someone else's list", func(t *testing.T) {
+		resp, _ := toggle(t, otherHeader, true)
+		require.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+		for _, todo := range listTodos(t) {
+			require.False(t, todo.Done)
+		}
+	})
+}
@@ -0,0 +1,94 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/macesz/todo-go/delivery/web/user"
+	"github.com/macesz/todo-go/domain"
+	"github.com/macesz/todo-go/tests/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_UpdateProfile(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	tc, server, services := testutils.ComposeServer(t)
+
+	owner := domain.User{
+		Name:     "User One",
+		Email:    "u1@example.com",
+		Password: "pass",
+	}
+	header, err := testutils.GivenUser(t, services.TokenAuth, tc.DB, &owner)
+	require.NoError(t, err)
+
+	other := domain.User{
+		Name:     "User Two",
+		Email:    "u2@example.com",
+		Password: "pass",
+	}
+	_, err = testutils.GivenUser(t, services.TokenAuth, tc.DB, &other)
+	require.NoError(t, err)
+
+	updateProfile := func(t *testing.T, header map[string]string, name, email string) (*http.Response, []byte) {
+		t.Helper()
+
+		reqBody, err := json.Marshal(domain.UpdateProfileRequestDTO{Name: name, Email: email})
+		require.NoError(t, err)
+
+		return testutils.TestRequest(t, server, http.MethodPut, "/api/users/me", header, bytes.NewReader(reqBody))
+	}
+
+	t.Run("email of another user is a conflict", func(t *testing.T) {
+		resp, body := updateProfile(t, header, "User One", other.Email)
+		require.Equal(t, http.StatusConflict, resp.StatusCode, string(body))
+	})
+
+	t.Run("invalid email", func(t *testing.T) {
+		resp, body := updateProfile(t, header, "User One", "not-an-email")
+		require.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode, string(body))
+	})
+
+	t.Run("timezone", func(t *testing.T) {
+		reqBody := []byte(`{"name":"User One","email":"u1@example.com","timezone":"Europe/Budapest"}`)
+		resp, body := testutils.TestRequest(t, server, http.MethodPut, "/api/users/me", header, bytes.NewReader(reqBody))
+		require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+
+		var got domain.UserDTO
+		require.NoError(t, json.Unmarshal(body, &got))
+		require.Equal(t, "Europe/Budapest", got.Timezone)
+
+		// Leaving it out keeps the timezone
+		resp, body = updateProfile(t, header, "User One", "u1@example.com")
+		require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+		require.NoError(t, json.Unmarshal(body, &got))
+		require.Equal(t, "Europe/Budapest", got.Timezone)
+
+		reqBody = []byte(`{"name":"User One","email":"u1@example.com","timezone":"Mars/Olympus_Mons"}`)
+		resp, body = testutils.TestRequest(t, server, http.MethodPut, "/api/users/me", header, bytes.NewReader(reqBody))
+		require.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode, string(body))
+	})
+
+	t.Run("new name and email, with a fresh token", func(t *testing.T) {
+		resp, body := updateProfile(t, header, "Renamed", "renamed@example.com")
+		require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+
+		var got domain.UserDTO
+		require.NoError(t, json.Unmarshal(body, &got))
+		require.Equal(t, domain.UserDTO{ID: owner.ID, Name: "Renamed", Email: "renamed@example.com", Timezone: "Europe/Budapest"}, got)
+
+		token := resp.Header.Get(user.TokenHeader)
+		require.NotEmpty(t, token)
+
+		// The fresh token works, and so does the old one until it expires
+		resp, _ = testutils.TestRequest(t, server, http.MethodGet, "/api/lists", testutils.AddBerrierTokenToHeader(token, nil), nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		resp, _ = testutils.TestRequest(t, server, http.MethodGet, "/api/lists", header, nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+}